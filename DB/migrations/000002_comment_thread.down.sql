@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS comment_parent_id_idx;
+DROP INDEX IF EXISTS comment_post_id_idx;
+
+ALTER TABLE comment
+    DROP COLUMN IF EXISTS is_deleted,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,6 @@
+ALTER TABLE comment
+    ADD COLUMN IF NOT EXISTS parent_id INT REFERENCES comment(id) ON DELETE CASCADE DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS is_deleted BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS comment_post_id_idx ON comment (post_id);
+CREATE INDEX IF NOT EXISTS comment_parent_id_idx ON comment (parent_id);
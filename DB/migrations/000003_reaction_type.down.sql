@@ -0,0 +1,4 @@
+ALTER TABLE reaction
+    DROP CONSTRAINT IF EXISTS unique_user_message,
+    DROP COLUMN IF EXISTS message_id,
+    DROP COLUMN IF EXISTS type;
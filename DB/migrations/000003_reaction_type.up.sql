@@ -0,0 +1,5 @@
+ALTER TABLE reaction
+    ADD COLUMN IF NOT EXISTS type TEXT NOT NULL DEFAULT 'like'
+        CONSTRAINT reaction_type_check CHECK (type IN ('like', 'love', 'laugh', 'sad', 'angry')),
+    ADD COLUMN IF NOT EXISTS message_id INT REFERENCES message(id) ON DELETE CASCADE DEFAULT NULL,
+    ADD CONSTRAINT unique_user_message UNIQUE (message_id, user_id);
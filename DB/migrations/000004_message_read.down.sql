@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS message_unread_idx;
+ALTER TABLE message ALTER COLUMN is_read DROP NOT NULL;
@@ -0,0 +1,3 @@
+UPDATE message SET is_read = FALSE WHERE is_read IS NULL;
+ALTER TABLE message ALTER COLUMN is_read SET NOT NULL;
+CREATE INDEX IF NOT EXISTS message_unread_idx ON message (receiver, sender) WHERE is_read = FALSE;
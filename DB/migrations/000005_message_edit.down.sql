@@ -0,0 +1,3 @@
+ALTER TABLE message
+    DROP COLUMN IF EXISTS reply_to,
+    DROP COLUMN IF EXISTS is_edited;
@@ -0,0 +1,3 @@
+ALTER TABLE message
+    ADD COLUMN IF NOT EXISTS is_edited BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS reply_to INT REFERENCES message(id) ON DELETE SET NULL DEFAULT NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS message_group_idx;
+DELETE FROM message WHERE group_id IS NOT NULL;
+ALTER TABLE message DROP COLUMN IF EXISTS group_id;
+DROP TABLE IF EXISTS group_member;
+DROP TABLE IF EXISTS group_chat;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS group_chat (
+                                          id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+                                          title TEXT NOT NULL CONSTRAINT group_title_length CHECK (CHAR_LENGTH(title) <= 50),
+                                          avatar TEXT CONSTRAINT avatar_group_length CHECK (CHAR_LENGTH(avatar) <= 100) DEFAULT '/image/default_group',
+                                          owner INT REFERENCES profile(id) ON DELETE SET NULL,
+                                          created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+                                          updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS group_member (
+                                            group_id INT REFERENCES group_chat(id) ON DELETE CASCADE,
+                                            profile_id INT REFERENCES profile(id) ON DELETE CASCADE,
+                                            is_admin BOOLEAN NOT NULL DEFAULT FALSE,
+                                            last_read_id INT NOT NULL DEFAULT 0,
+                                            created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+                                            updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+                                            PRIMARY KEY (group_id, profile_id)
+);
+
+CREATE INDEX IF NOT EXISTS group_member_profile_idx ON group_member (profile_id);
+
+ALTER TABLE message
+    ADD COLUMN IF NOT EXISTS group_id INT REFERENCES group_chat(id) ON DELETE CASCADE DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS message_group_idx ON message (group_id, created_at DESC) WHERE group_id IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE message
+    ADD COLUMN IF NOT EXISTS file_path TEXT NOT NULL CONSTRAINT message_file_path_length CHECK (CHAR_LENGTH(file_path) <= 100) DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS message_client_id_idx;
+ALTER TABLE message DROP COLUMN IF EXISTS client_id;
@@ -0,0 +1,4 @@
+ALTER TABLE message
+    ADD COLUMN IF NOT EXISTS client_id TEXT CONSTRAINT client_id_length CHECK (CHAR_LENGTH(client_id) <= 64) DEFAULT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS message_client_id_idx ON message (sender, client_id) WHERE client_id IS NOT NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE post
+    ADD COLUMN IF NOT EXISTS search TSVECTOR GENERATED ALWAYS AS (
+        to_tsvector('russian', COALESCE(content, '')) || to_tsvector('english', COALESCE(content, ''))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS post_search_idx ON post USING GIN (search);
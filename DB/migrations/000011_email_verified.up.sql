@@ -0,0 +1,6 @@
+-- the users registered before the verification keep their accounts working
+ALTER TABLE profile
+    ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE profile
+    ALTER COLUMN email_verified SET DEFAULT FALSE;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS two_factor (
+    profile_id INT PRIMARY KEY REFERENCES profile(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- the codes are stored hashed, a used code is deleted
+CREATE TABLE IF NOT EXISTS recovery_code (
+    profile_id INT NOT NULL REFERENCES two_factor(profile_id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    PRIMARY KEY (profile_id, code_hash)
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS profile_name_trgm_idx;
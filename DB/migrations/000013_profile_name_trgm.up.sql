@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS profile_name_trgm_idx ON profile USING GIN ((first_name || ' ' || last_name) gin_trgm_ops);
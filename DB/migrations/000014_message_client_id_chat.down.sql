@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS message_client_id_group_idx;
+DROP INDEX IF EXISTS message_client_id_direct_idx;
+
+CREATE UNIQUE INDEX IF NOT EXISTS message_client_id_idx ON message (sender, client_id) WHERE client_id IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS message_client_id_idx;
+
+-- the client ids are unique within a chat: the direct messages have no group and the group ones have no receiver
+CREATE UNIQUE INDEX IF NOT EXISTS message_client_id_direct_idx ON message (sender, receiver, client_id) WHERE client_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS message_client_id_group_idx ON message (sender, group_id, client_id) WHERE client_id IS NOT NULL;
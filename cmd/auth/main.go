@@ -22,7 +22,11 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	httpServer, err := auth.GetHTTPServer(cfg, authMetrics)
+	limitMetrics, err := metrics.NewLimitMetrics("auth")
+	if err != nil {
+		panic(err)
+	}
+	httpServer, err := auth.GetHTTPServer(cfg, authMetrics, limitMetrics)
 	if err != nil {
 		panic(err)
 	}
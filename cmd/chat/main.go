@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/2024_2_BetterCallFirewall/internal/app/chat"
 	"github.com/2024_2_BetterCallFirewall/internal/config"
 	"github.com/2024_2_BetterCallFirewall/internal/metrics"
 )
 
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	confPath := flag.String("c", ".env", "path to config file")
 	flag.Parse()
@@ -23,13 +32,33 @@ func main() {
 		panic(err)
 	}
 
-	server, err := chat.GetServer(cfg, chatMetrics)
+	connMetrics, err := metrics.NewChatMetrics("chat")
 	if err != nil {
 		panic(err)
 	}
 
+	server, err := chat.GetServer(cfg, chatMetrics, connMetrics)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	idle := make(chan struct{})
+	go func() {
+		defer close(idle)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("shutdown chat server: %v", err)
+		}
+	}()
+
 	log.Printf("Starting server on port %s", cfg.CHAT.Port)
-	if err := server.ListenAndServe(); err != nil {
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		panic(err)
 	}
+	<-idle
 }
@@ -22,7 +22,12 @@ func main() {
 		panic(err)
 	}
 
-	server, err := post.GetHTTPServer(cfg, postMetric)
+	cacheMetrics, err := metrics.NewCacheMetrics("post")
+	if err != nil {
+		panic(err)
+	}
+
+	server, err := post.GetHTTPServer(cfg, postMetric, cacheMetrics)
 	if err != nil {
 		panic(err)
 	}
@@ -2,18 +2,25 @@ package auth_api
 
 import (
 	"context"
+	"errors"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
 //go:generate mockgen -destination=mock.go -source=$GOFILE -package=${GOPACKAGE}
 type SessionManager interface {
 	Check(string) (*models.Session, error)
-	Create(userID uint32) (*models.Session, error)
+	Create(user *models.User, device models.Device) (*models.Session, error)
+	Rotate(sess *models.Session) (*models.Session, error)
 	Destroy(sess *models.Session) error
+
+	ListSessions(sess *models.Session) ([]*models.SessionInfo, error)
+	RevokeSession(sess *models.Session, publicID string) error
+	RevokeOtherSessions(sess *models.Session) error
 }
 
 type Adapter struct {
@@ -27,6 +34,36 @@ func New(authServer SessionManager) *Adapter {
 	}
 }
 
+func newSession(sess *models.Session) *Session {
+	return &Session{
+		ID:        sess.ID,
+		UserID:    sess.UserID,
+		CreatedAt: sess.CreatedAt,
+		UserAgent: sess.UserAgent,
+		IP:        sess.IP,
+		LoginAt:   sess.LoginAt,
+		LastSeen:  sess.LastSeen,
+		Verified:  !sess.Unverified,
+	}
+}
+
+func fromSession(sess *Session) *models.Session {
+	if sess == nil {
+		return nil
+	}
+
+	return &models.Session{
+		ID:         sess.ID,
+		UserID:     sess.UserID,
+		CreatedAt:  sess.CreatedAt,
+		UserAgent:  sess.UserAgent,
+		IP:         sess.IP,
+		LoginAt:    sess.LoginAt,
+		LastSeen:   sess.LastSeen,
+		Unverified: !sess.Verified,
+	}
+}
+
 func (a *Adapter) Check(ctx context.Context, reqGRPC *CheckRequest) (*CheckResponse, error) {
 	req := reqGRPC.Cookie
 	sess, err := a.authServer.Check(req)
@@ -35,33 +72,41 @@ func (a *Adapter) Check(ctx context.Context, reqGRPC *CheckRequest) (*CheckRespo
 	}
 
 	res := &CheckResponse{
-		Sess: &Session{
-			ID:        sess.ID,
-			UserID:    sess.UserID,
-			CreatedAt: sess.CreatedAt,
-		},
+		Sess: newSession(sess),
 	}
 
 	return res, nil
 }
 
 func (a *Adapter) Create(ctx context.Context, reqGRPC *CreateRequest) (*CreateResponse, error) {
-	req := reqGRPC.UserID
-	sess, err := a.authServer.Create(req)
+	device := models.Device{
+		UserAgent: reqGRPC.UserAgent,
+		IP:        reqGRPC.IP,
+	}
+	user := &models.User{
+		ID:            reqGRPC.UserID,
+		EmailVerified: reqGRPC.Verified,
+	}
+	sess, err := a.authServer.Create(user, device)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	res := &CreateResponse{
-		Sess: &Session{
-			ID:        sess.ID,
-			UserID:    sess.UserID,
-			CreatedAt: sess.CreatedAt,
-		},
+		Sess: newSession(sess),
 	}
 
 	return res, nil
 }
 
+func (a *Adapter) Rotate(ctx context.Context, reqGRPC *RotateRequest) (*RotateResponse, error) {
+	sess, err := a.authServer.Rotate(fromSession(reqGRPC.Sess))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &RotateResponse{Sess: newSession(sess)}, nil
+}
+
 func (a *Adapter) Destroy(ctx context.Context, reqGRPC *DestroyRequest) (*EmptyResponse, error) {
 	req := &models.Session{
 		ID:        reqGRPC.Sess.ID,
@@ -75,3 +120,46 @@ func (a *Adapter) Destroy(ctx context.Context, reqGRPC *DestroyRequest) (*EmptyR
 
 	return nil, nil
 }
+
+func (a *Adapter) ListSessions(ctx context.Context, reqGRPC *ListSessionsRequest) (*ListSessionsResponse, error) {
+	sessions, err := a.authServer.ListSessions(fromSession(reqGRPC.Sess))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &ListSessionsResponse{Sessions: make([]*SessionInfo, 0, len(sessions))}
+	for _, sess := range sessions {
+		res.Sessions = append(res.Sessions, &SessionInfo{
+			ID:        sess.ID,
+			UserAgent: sess.UserAgent,
+			IP:        sess.IP,
+			CreatedAt: sess.CreatedAt,
+			LastSeen:  sess.LastSeen,
+			Current:   sess.Current,
+		})
+	}
+
+	return res, nil
+}
+
+func (a *Adapter) RevokeSession(ctx context.Context, reqGRPC *RevokeSessionRequest) (*EmptyResponse, error) {
+	err := a.authServer.RevokeSession(fromSession(reqGRPC.Sess), reqGRPC.SessionID)
+	if errors.Is(err, my_err.ErrSessionNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &EmptyResponse{}, nil
+}
+
+func (a *Adapter) RevokeOtherSessions(
+	ctx context.Context, reqGRPC *RevokeOtherSessionsRequest,
+) (*EmptyResponse, error) {
+	if err := a.authServer.RevokeOtherSessions(fromSession(reqGRPC.Sess)); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &EmptyResponse{}, nil
+}
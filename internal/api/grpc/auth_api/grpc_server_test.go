@@ -12,6 +12,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
 type mocks struct {
@@ -52,7 +53,7 @@ func TestCreate(t *testing.T) {
 			},
 			ExpectedErrCode: codes.Internal,
 			SetupMock: func(request *CreateRequest, m *mocks) {
-				m.sessionManager.EXPECT().Create(gomock.Any()).Return(nil, errMock)
+				m.sessionManager.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errMock)
 			},
 		},
 		{
@@ -65,11 +66,11 @@ func TestCreate(t *testing.T) {
 				return implementation.Create(ctx, request)
 			},
 			ExpectedResult: func() (*CreateResponse, error) {
-				return &CreateResponse{Sess: &Session{ID: "1", UserID: 1, CreatedAt: createTime}}, nil
+				return &CreateResponse{Sess: &Session{ID: "1", UserID: 1, CreatedAt: createTime, Verified: true}}, nil
 			},
 			ExpectedErrCode: codes.OK,
 			SetupMock: func(request *CreateRequest, m *mocks) {
-				m.sessionManager.EXPECT().Create(gomock.Any()).Return(&models.Session{ID: "1", UserID: request.UserID, CreatedAt: createTime}, nil)
+				m.sessionManager.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&models.Session{ID: "1", UserID: request.UserID, CreatedAt: createTime}, nil)
 			},
 		},
 	}
@@ -131,7 +132,7 @@ func TestCheck(t *testing.T) {
 				return implementation.Check(ctx, request)
 			},
 			ExpectedResult: func() (*CheckResponse, error) {
-				return &CheckResponse{Sess: &Session{ID: "1", UserID: 1, CreatedAt: createTime}}, nil
+				return &CheckResponse{Sess: &Session{ID: "1", UserID: 1, CreatedAt: createTime, Verified: true}}, nil
 			},
 			ExpectedErrCode: codes.OK,
 			SetupMock: func(request *CheckRequest, m *mocks) {
@@ -173,7 +174,7 @@ func TestDestroy(t *testing.T) {
 		{
 			name: "1",
 			SetupInput: func() (*DestroyRequest, error) {
-				res := &DestroyRequest{Sess: &Session{ID: "1", UserID: 0, CreatedAt: createTime}}
+				res := &DestroyRequest{Sess: &Session{ID: "1", UserID: 0, CreatedAt: createTime, Verified: true}}
 				return res, nil
 			},
 			Run: func(ctx context.Context, implementation *Adapter, request *DestroyRequest) (*EmptyResponse, error) {
@@ -190,7 +191,7 @@ func TestDestroy(t *testing.T) {
 		{
 			name: "2",
 			SetupInput: func() (*DestroyRequest, error) {
-				res := &DestroyRequest{Sess: &Session{ID: "1", UserID: 1, CreatedAt: createTime}}
+				res := &DestroyRequest{Sess: &Session{ID: "1", UserID: 1, CreatedAt: createTime, Verified: true}}
 				return res, nil
 			},
 			Run: func(ctx context.Context, implementation *Adapter, request *DestroyRequest) (*EmptyResponse, error) {
@@ -233,6 +234,284 @@ func TestDestroy(t *testing.T) {
 	}
 }
 
+func TestRotate(t *testing.T) {
+	tests := []TableTest[RotateResponse, RotateRequest]{
+		{
+			name: "1",
+			SetupInput: func() (*RotateRequest, error) {
+				return &RotateRequest{}, nil
+			},
+			Run: func(ctx context.Context, implementation *Adapter, request *RotateRequest) (*RotateResponse, error) {
+				return implementation.Rotate(ctx, request)
+			},
+			ExpectedResult: func() (*RotateResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.Internal,
+			SetupMock: func(request *RotateRequest, m *mocks) {
+				m.sessionManager.EXPECT().Rotate(gomock.Any()).Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*RotateRequest, error) {
+				return &RotateRequest{Sess: &Session{ID: "1", UserID: 1, Verified: true}}, nil
+			},
+			Run: func(ctx context.Context, implementation *Adapter, request *RotateRequest) (*RotateResponse, error) {
+				return implementation.Rotate(ctx, request)
+			},
+			ExpectedResult: func() (*RotateResponse, error) {
+				return &RotateResponse{Sess: &Session{ID: "2", UserID: 1, UserAgent: "Firefox", IP: "127.0.0.1", LoginAt: 10, Verified: true}}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *RotateRequest, m *mocks) {
+				m.sessionManager.EXPECT().Rotate(&models.Session{ID: "1", UserID: 1}).Return(
+					&models.Session{ID: "2", UserID: 1, UserAgent: "Firefox", IP: "127.0.0.1", LoginAt: 10}, nil,
+				)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			assert.Equal(t, status.Code(err), v.ExpectedErrCode)
+		})
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	tests := []TableTest[ListSessionsResponse, ListSessionsRequest]{
+		{
+			name: "1",
+			SetupInput: func() (*ListSessionsRequest, error) {
+				return &ListSessionsRequest{}, nil
+			},
+			Run: func(ctx context.Context, implementation *Adapter, request *ListSessionsRequest) (*ListSessionsResponse, error) {
+				return implementation.ListSessions(ctx, request)
+			},
+			ExpectedResult: func() (*ListSessionsResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.Internal,
+			SetupMock: func(request *ListSessionsRequest, m *mocks) {
+				m.sessionManager.EXPECT().ListSessions(gomock.Any()).Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*ListSessionsRequest, error) {
+				return &ListSessionsRequest{Sess: &Session{ID: "1", UserID: 1, Verified: true}}, nil
+			},
+			Run: func(ctx context.Context, implementation *Adapter, request *ListSessionsRequest) (*ListSessionsResponse, error) {
+				return implementation.ListSessions(ctx, request)
+			},
+			ExpectedResult: func() (*ListSessionsResponse, error) {
+				return &ListSessionsResponse{Sessions: []*SessionInfo{
+					{ID: "a", UserAgent: "Firefox", IP: "127.0.0.1", CreatedAt: 10, LastSeen: 20, Current: true},
+					{ID: "b", UserAgent: "Chrome", CreatedAt: 5, LastSeen: 6},
+				}}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *ListSessionsRequest, m *mocks) {
+				m.sessionManager.EXPECT().ListSessions(&models.Session{ID: "1", UserID: 1}).Return(
+					[]*models.SessionInfo{
+						{ID: "a", UserAgent: "Firefox", IP: "127.0.0.1", CreatedAt: 10, LastSeen: 20, Current: true},
+						{ID: "b", UserAgent: "Chrome", CreatedAt: 5, LastSeen: 6},
+					}, nil,
+				)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			assert.Equal(t, status.Code(err), v.ExpectedErrCode)
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	tests := []TableTest[EmptyResponse, RevokeSessionRequest]{
+		{
+			name: "1",
+			SetupInput: func() (*RevokeSessionRequest, error) {
+				return &RevokeSessionRequest{Sess: &Session{ID: "1", UserID: 1, Verified: true}, SessionID: "a"}, nil
+			},
+			Run: func(ctx context.Context, implementation *Adapter, request *RevokeSessionRequest) (*EmptyResponse, error) {
+				return implementation.RevokeSession(ctx, request)
+			},
+			ExpectedResult: func() (*EmptyResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.Internal,
+			SetupMock: func(request *RevokeSessionRequest, m *mocks) {
+				m.sessionManager.EXPECT().RevokeSession(gomock.Any(), "a").Return(errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*RevokeSessionRequest, error) {
+				return &RevokeSessionRequest{Sess: &Session{ID: "1", UserID: 1, Verified: true}, SessionID: "a"}, nil
+			},
+			Run: func(ctx context.Context, implementation *Adapter, request *RevokeSessionRequest) (*EmptyResponse, error) {
+				return implementation.RevokeSession(ctx, request)
+			},
+			ExpectedResult: func() (*EmptyResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.NotFound,
+			SetupMock: func(request *RevokeSessionRequest, m *mocks) {
+				m.sessionManager.EXPECT().RevokeSession(gomock.Any(), "a").Return(my_err.ErrSessionNotFound)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*RevokeSessionRequest, error) {
+				return &RevokeSessionRequest{Sess: &Session{ID: "1", UserID: 1, Verified: true}, SessionID: "a"}, nil
+			},
+			Run: func(ctx context.Context, implementation *Adapter, request *RevokeSessionRequest) (*EmptyResponse, error) {
+				return implementation.RevokeSession(ctx, request)
+			},
+			ExpectedResult: func() (*EmptyResponse, error) {
+				return &EmptyResponse{}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *RevokeSessionRequest, m *mocks) {
+				m.sessionManager.EXPECT().RevokeSession(&models.Session{ID: "1", UserID: 1}, "a").Return(nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			assert.Equal(t, status.Code(err), v.ExpectedErrCode)
+		})
+	}
+}
+
+func TestRevokeOtherSessions(t *testing.T) {
+	tests := []TableTest[EmptyResponse, RevokeOtherSessionsRequest]{
+		{
+			name: "1",
+			SetupInput: func() (*RevokeOtherSessionsRequest, error) {
+				return &RevokeOtherSessionsRequest{}, nil
+			},
+			Run: func(ctx context.Context, implementation *Adapter, request *RevokeOtherSessionsRequest) (*EmptyResponse, error) {
+				return implementation.RevokeOtherSessions(ctx, request)
+			},
+			ExpectedResult: func() (*EmptyResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.Internal,
+			SetupMock: func(request *RevokeOtherSessionsRequest, m *mocks) {
+				m.sessionManager.EXPECT().RevokeOtherSessions(gomock.Any()).Return(errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*RevokeOtherSessionsRequest, error) {
+				return &RevokeOtherSessionsRequest{Sess: &Session{ID: "1", UserID: 1, Verified: true}}, nil
+			},
+			Run: func(ctx context.Context, implementation *Adapter, request *RevokeOtherSessionsRequest) (*EmptyResponse, error) {
+				return implementation.RevokeOtherSessions(ctx, request)
+			},
+			ExpectedResult: func() (*EmptyResponse, error) {
+				return &EmptyResponse{}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *RevokeOtherSessionsRequest, m *mocks) {
+				m.sessionManager.EXPECT().RevokeOtherSessions(&models.Session{ID: "1", UserID: 1}).Return(nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			assert.Equal(t, status.Code(err), v.ExpectedErrCode)
+		})
+	}
+}
+
 type TableTest[T, In any] struct {
 	name            string
 	SetupInput      func() (*In, error)
@@ -50,18 +50,18 @@ func (mr *MockSessionManagerMockRecorder) Check(arg0 interface{}) *gomock.Call {
 }
 
 // Create mocks base method.
-func (m *MockSessionManager) Create(userID uint32) (*models.Session, error) {
+func (m *MockSessionManager) Create(user *models.User, device models.Device) (*models.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", userID)
+	ret := m.ctrl.Call(m, "Create", user, device)
 	ret0, _ := ret[0].(*models.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockSessionManagerMockRecorder) Create(userID interface{}) *gomock.Call {
+func (mr *MockSessionManagerMockRecorder) Create(user, device interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessionManager)(nil).Create), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessionManager)(nil).Create), user, device)
 }
 
 // Destroy mocks base method.
@@ -77,3 +77,61 @@ func (mr *MockSessionManagerMockRecorder) Destroy(sess interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockSessionManager)(nil).Destroy), sess)
 }
+
+// ListSessions mocks base method.
+func (m *MockSessionManager) ListSessions(sess *models.Session) ([]*models.SessionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", sess)
+	ret0, _ := ret[0].([]*models.SessionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockSessionManagerMockRecorder) ListSessions(sess interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockSessionManager)(nil).ListSessions), sess)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockSessionManager) RevokeOtherSessions(sess *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", sess)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockSessionManagerMockRecorder) RevokeOtherSessions(sess interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockSessionManager)(nil).RevokeOtherSessions), sess)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionManager) RevokeSession(sess *models.Session, publicID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", sess, publicID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionManagerMockRecorder) RevokeSession(sess, publicID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionManager)(nil).RevokeSession), sess, publicID)
+}
+
+// Rotate mocks base method.
+func (m *MockSessionManager) Rotate(sess *models.Session) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", sess)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockSessionManagerMockRecorder) Rotate(sess interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockSessionManager)(nil).Rotate), sess)
+}
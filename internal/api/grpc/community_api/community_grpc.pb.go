@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CommunityService_CheckAccess_FullMethodName = "/community_api.CommunityService/CheckAccess"
-	CommunityService_GetHeader_FullMethodName   = "/community_api.CommunityService/GetHeader"
+	CommunityService_CheckAccess_FullMethodName       = "/community_api.CommunityService/CheckAccess"
+	CommunityService_GetHeader_FullMethodName         = "/community_api.CommunityService/GetHeader"
+	CommunityService_GetHeaders_FullMethodName        = "/community_api.CommunityService/GetHeaders"
+	CommunityService_SearchCommunities_FullMethodName = "/community_api.CommunityService/SearchCommunities"
 )
 
 // CommunityServiceClient is the client API for CommunityService service.
@@ -29,6 +31,8 @@ const (
 type CommunityServiceClient interface {
 	CheckAccess(ctx context.Context, in *CheckAccessRequest, opts ...grpc.CallOption) (*CheckAccessResponse, error)
 	GetHeader(ctx context.Context, in *GetHeaderRequest, opts ...grpc.CallOption) (*GetHeaderResponse, error)
+	GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (*GetHeadersResponse, error)
+	SearchCommunities(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchCommunitiesResponse, error)
 }
 
 type communityServiceClient struct {
@@ -59,12 +63,34 @@ func (c *communityServiceClient) GetHeader(ctx context.Context, in *GetHeaderReq
 	return out, nil
 }
 
+func (c *communityServiceClient) GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (*GetHeadersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHeadersResponse)
+	err := c.cc.Invoke(ctx, CommunityService_GetHeaders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *communityServiceClient) SearchCommunities(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchCommunitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchCommunitiesResponse)
+	err := c.cc.Invoke(ctx, CommunityService_SearchCommunities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommunityServiceServer is the server API for CommunityService service.
 // All implementations must embed UnimplementedCommunityServiceServer
 // for forward compatibility.
 type CommunityServiceServer interface {
 	CheckAccess(context.Context, *CheckAccessRequest) (*CheckAccessResponse, error)
 	GetHeader(context.Context, *GetHeaderRequest) (*GetHeaderResponse, error)
+	GetHeaders(context.Context, *GetHeadersRequest) (*GetHeadersResponse, error)
+	SearchCommunities(context.Context, *SearchRequest) (*SearchCommunitiesResponse, error)
 	mustEmbedUnimplementedCommunityServiceServer()
 }
 
@@ -81,6 +107,12 @@ func (UnimplementedCommunityServiceServer) CheckAccess(context.Context, *CheckAc
 func (UnimplementedCommunityServiceServer) GetHeader(context.Context, *GetHeaderRequest) (*GetHeaderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetHeader not implemented")
 }
+func (UnimplementedCommunityServiceServer) GetHeaders(context.Context, *GetHeadersRequest) (*GetHeadersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeaders not implemented")
+}
+func (UnimplementedCommunityServiceServer) SearchCommunities(context.Context, *SearchRequest) (*SearchCommunitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchCommunities not implemented")
+}
 func (UnimplementedCommunityServiceServer) mustEmbedUnimplementedCommunityServiceServer() {}
 func (UnimplementedCommunityServiceServer) testEmbeddedByValue()                          {}
 
@@ -138,6 +170,42 @@ func _CommunityService_GetHeader_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommunityService_GetHeaders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHeadersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommunityServiceServer).GetHeaders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommunityService_GetHeaders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommunityServiceServer).GetHeaders(ctx, req.(*GetHeadersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommunityService_SearchCommunities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommunityServiceServer).SearchCommunities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommunityService_SearchCommunities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommunityServiceServer).SearchCommunities(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommunityService_ServiceDesc is the grpc.ServiceDesc for CommunityService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +221,14 @@ var CommunityService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetHeader",
 			Handler:    _CommunityService_GetHeader_Handler,
 		},
+		{
+			MethodName: "GetHeaders",
+			Handler:    _CommunityService_GetHeaders_Handler,
+		},
+		{
+			MethodName: "SearchCommunities",
+			Handler:    _CommunityService_SearchCommunities_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/community.proto",
@@ -15,6 +15,8 @@ import (
 type CommunityService interface {
 	CheckAccess(ctx context.Context, communityID, userID uint32) bool
 	GetHeader(ctx context.Context, communityID uint32) (*models.Header, error)
+	GetHeaders(ctx context.Context, communityIDs []uint32) ([]*models.Header, error)
+	Search(ctx context.Context, query string, userID, lastID uint32) ([]*models.CommunityCard, error)
 }
 
 type Adapter struct {
@@ -54,3 +56,49 @@ func (a *Adapter) GetHeader(ctx context.Context, req *GetHeaderRequest) (*GetHea
 	}
 	return resp, nil
 }
+
+func (a *Adapter) GetHeaders(ctx context.Context, req *GetHeadersRequest) (*GetHeadersResponse, error) {
+	res, err := a.serv.GetHeaders(ctx, req.CommunityIDs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &GetHeadersResponse{
+		Heads: make([]*Header, 0, len(res)),
+	}
+	for _, header := range res {
+		resp.Heads = append(resp.Heads, &Header{
+			AuthorID:    header.AuthorID,
+			CommunityID: header.CommunityID,
+			Author:      header.Author,
+			Avatar:      string(header.Avatar),
+		})
+	}
+
+	return resp, nil
+}
+
+func (a *Adapter) SearchCommunities(ctx context.Context, req *SearchRequest) (*SearchCommunitiesResponse, error) {
+	res, err := a.serv.Search(ctx, req.Query, req.UserID, req.LastID)
+	if errors.Is(err, my_err.ErrNoMoreContent) {
+		return &SearchCommunitiesResponse{}, nil
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &SearchCommunitiesResponse{
+		Communities: make([]*CommunityCard, 0, len(res)),
+	}
+	for _, card := range res {
+		resp.Communities = append(resp.Communities, &CommunityCard{
+			ID:         card.ID,
+			Name:       card.Name,
+			Avatar:     string(card.Avatar),
+			About:      card.About,
+			IsFollowed: card.IsFollowed,
+		})
+	}
+
+	return resp, nil
+}
@@ -196,6 +196,159 @@ func TestGetHeader(t *testing.T) {
 	}
 }
 
+func TestGetHeaders(t *testing.T) {
+	run := func(ctx context.Context, implementation *Adapter, request *GetHeadersRequest) (*GetHeadersResponse, error) {
+		return implementation.GetHeaders(ctx, request)
+	}
+	tests := []TableTest[GetHeadersResponse, GetHeadersRequest]{
+		{
+			name: "1",
+			SetupInput: func() (*GetHeadersRequest, error) {
+				return &GetHeadersRequest{CommunityIDs: []uint32{1, 2}}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*GetHeadersResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.Internal,
+			SetupMock: func(request *GetHeadersRequest, m *mocks) {
+				m.communityService.EXPECT().GetHeaders(gomock.Any(), []uint32{1, 2}).Return(nil, errors.New("error"))
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*GetHeadersRequest, error) {
+				return &GetHeadersRequest{CommunityIDs: []uint32{1, 2}}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*GetHeadersResponse, error) {
+				return &GetHeadersResponse{
+					Heads: []*Header{
+						{CommunityID: 1, Author: "first", Avatar: "/image"},
+						{CommunityID: 2, Author: "second"},
+					},
+				}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *GetHeadersRequest, m *mocks) {
+				m.communityService.EXPECT().GetHeaders(gomock.Any(), []uint32{1, 2}).Return([]*models.Header{
+					{CommunityID: 1, Author: "first", Avatar: "/image"},
+					{CommunityID: 2, Author: "second"},
+				}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			assert.Equal(t, status.Code(err), v.ExpectedErrCode)
+		})
+	}
+}
+
+func TestSearchCommunities(t *testing.T) {
+	run := func(ctx context.Context, implementation *Adapter, request *SearchRequest) (*SearchCommunitiesResponse, error) {
+		return implementation.SearchCommunities(ctx, request)
+	}
+	tests := []TableTest[SearchCommunitiesResponse, SearchRequest]{
+		{
+			name: "1",
+			SetupInput: func() (*SearchRequest, error) {
+				return &SearchRequest{UserID: 1, Query: "cats", LastID: 10}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*SearchCommunitiesResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.Internal,
+			SetupMock: func(request *SearchRequest, m *mocks) {
+				m.communityService.EXPECT().Search(gomock.Any(), "cats", uint32(1), uint32(10)).
+					Return(nil, errors.New("error"))
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*SearchRequest, error) {
+				return &SearchRequest{UserID: 1, Query: "cats", LastID: 10}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*SearchCommunitiesResponse, error) {
+				return &SearchCommunitiesResponse{}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *SearchRequest, m *mocks) {
+				m.communityService.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, my_err.ErrNoMoreContent)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*SearchRequest, error) {
+				return &SearchRequest{UserID: 1, Query: "cats", LastID: 10}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*SearchCommunitiesResponse, error) {
+				return &SearchCommunitiesResponse{
+					Communities: []*CommunityCard{{ID: 3, Name: "cats", Avatar: "/image", About: "about", IsFollowed: true}},
+				}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *SearchRequest, m *mocks) {
+				m.communityService.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*models.CommunityCard{
+						{ID: 3, Name: "cats", Avatar: "/image", About: "about", IsFollowed: true},
+					}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			assert.Equal(t, status.Code(err), v.ExpectedErrCode)
+		})
+	}
+}
+
 type TableTest[T, In any] struct {
 	name            string
 	SetupInput      func() (*In, error)
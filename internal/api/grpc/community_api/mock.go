@@ -63,3 +63,33 @@ func (mr *MockCommunityServiceMockRecorder) GetHeader(ctx, communityID interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockCommunityService)(nil).GetHeader), ctx, communityID)
 }
+
+// GetHeaders mocks base method.
+func (m *MockCommunityService) GetHeaders(ctx context.Context, communityIDs []uint32) ([]*models.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeaders", ctx, communityIDs)
+	ret0, _ := ret[0].([]*models.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeaders indicates an expected call of GetHeaders.
+func (mr *MockCommunityServiceMockRecorder) GetHeaders(ctx, communityIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeaders", reflect.TypeOf((*MockCommunityService)(nil).GetHeaders), ctx, communityIDs)
+}
+
+// Search mocks base method.
+func (m *MockCommunityService) Search(ctx context.Context, query string, userID, lastID uint32) ([]*models.CommunityCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, userID, lastID)
+	ret0, _ := ret[0].([]*models.CommunityCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockCommunityServiceMockRecorder) Search(ctx, query, userID, lastID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockCommunityService)(nil).Search), ctx, query, userID, lastID)
+}
@@ -2,16 +2,19 @@ package post_api
 
 import (
 	"context"
+	"errors"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
 //go:generate mockgen -destination=mock.go -source=$GOFILE -package=${GOPACKAGE}
 type PostService interface {
 	GetAuthorsPosts(ctx context.Context, header *models.Header, userID uint32) ([]*models.Post, error)
+	Search(ctx context.Context, userID uint32, search *models.PostSearch) (*models.PostPage, error)
 }
 
 type Adapter struct {
@@ -41,24 +44,64 @@ func (a *Adapter) GetAuthorsPosts(ctx context.Context, req *Request) (*Response,
 		Posts: make([]*Post, 0, len(res)),
 	}
 	for _, post := range res {
-		resp.Posts = append(resp.Posts, &Post{
-			ID: post.ID,
-			Head: &Header{
-				AuthorID:    post.Header.AuthorID,
-				CommunityID: post.Header.CommunityID,
-				Author:      post.Header.Author,
-				Avatar:      string(post.Header.Avatar),
-			},
-			PostContent: &Content{
-				Text:      post.PostContent.Text,
-				File:      string(post.PostContent.File),
-				CreatedAt: post.PostContent.CreatedAt.Unix(),
-				UpdatedAt: post.PostContent.UpdatedAt.Unix(),
-			},
-			LikesCount: post.LikesCount,
-			IsLiked:    post.IsLiked,
-		})
+		resp.Posts = append(resp.Posts, marshalPost(post))
 	}
 
 	return resp, nil
 }
+
+func (a *Adapter) SearchPosts(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	cursor, err := models.ParseSearchCursor(req.Cursor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res, err := a.service.Search(ctx, req.UserID, &models.PostSearch{Query: req.Query, Cursor: cursor})
+	if errors.Is(err, my_err.ErrNoMoreContent) {
+		return &SearchResponse{}, nil
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &SearchResponse{
+		Posts:  make([]*Post, 0, len(res.Posts)),
+		Cursor: res.Cursor,
+	}
+	for _, post := range res.Posts {
+		resp.Posts = append(resp.Posts, marshalPost(post))
+	}
+
+	return resp, nil
+}
+
+func marshalPost(post *models.Post) *Post {
+	return &Post{
+		ID: post.ID,
+		Head: &Header{
+			AuthorID:    post.Header.AuthorID,
+			CommunityID: post.Header.CommunityID,
+			Author:      post.Header.Author,
+			Avatar:      string(post.Header.Avatar),
+		},
+		PostContent: &Content{
+			Text:      post.PostContent.Text,
+			File:      string(post.PostContent.File),
+			CreatedAt: post.PostContent.CreatedAt.Unix(),
+			UpdatedAt: post.PostContent.UpdatedAt.Unix(),
+		},
+		CommentCount: post.CommentCount,
+		Reactions:    marshalReactions(post.Reactions.Counts),
+		MyReaction:   string(post.Reactions.My),
+		Snippet:      post.Snippet,
+	}
+}
+
+func marshalReactions(counts map[models.ReactionType]uint32) map[string]uint32 {
+	res := make(map[string]uint32, len(counts))
+	for reaction, count := range counts {
+		res[string(reaction)] = count
+	}
+
+	return res
+}
@@ -12,6 +12,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
 type mocks struct {
@@ -71,6 +72,8 @@ func TestGetAuthorsPosts(t *testing.T) {
 								ID:          1,
 								PostContent: &Content{Text: "New Post", CreatedAt: createTime.Unix(), UpdatedAt: createTime.Unix()},
 								Head:        &Header{AuthorID: 1, Author: "Alexey Zemliakov"},
+								Reactions:   map[string]uint32{"love": 2},
+								MyReaction:  "love",
 							},
 						},
 					},
@@ -85,6 +88,10 @@ func TestGetAuthorsPosts(t *testing.T) {
 								ID:          1,
 								PostContent: models.Content{Text: "New Post", CreatedAt: createTime, UpdatedAt: createTime},
 								Header:      models.Header{AuthorID: 1, Author: "Alexey Zemliakov"},
+								Reactions: models.Reactions{
+									Counts: map[models.ReactionType]uint32{models.ReactionLove: 2},
+									My:     models.ReactionLove,
+								},
 							},
 						},
 						nil,
@@ -120,6 +127,120 @@ func TestGetAuthorsPosts(t *testing.T) {
 	}
 }
 
+func TestSearchPosts(t *testing.T) {
+	run := func(ctx context.Context, implementation *Adapter, request *SearchRequest) (*SearchResponse, error) {
+		return implementation.SearchPosts(ctx, request)
+	}
+	cursor := models.SearchCursor{Rank: 0.5, ID: 3}
+	tests := []TableTest[SearchResponse, SearchRequest]{
+		{
+			name: "1",
+			SetupInput: func() (*SearchRequest, error) {
+				return &SearchRequest{UserID: 1, Query: "cats", Cursor: "wrong"}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*SearchResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.InvalidArgument,
+			SetupMock:       func(request *SearchRequest, m *mocks) {},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*SearchRequest, error) {
+				return &SearchRequest{UserID: 1, Query: "cats"}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*SearchResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.Internal,
+			SetupMock: func(request *SearchRequest, m *mocks) {
+				m.postService.EXPECT().Search(gomock.Any(), uint32(1), &models.PostSearch{Query: "cats"}).
+					Return(nil, errors.New("error"))
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*SearchRequest, error) {
+				return &SearchRequest{UserID: 1, Query: "cats", Cursor: cursor.String()}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*SearchResponse, error) {
+				return &SearchResponse{}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *SearchRequest, m *mocks) {
+				m.postService.EXPECT().Search(gomock.Any(), uint32(1), &models.PostSearch{Query: "cats", Cursor: cursor}).
+					Return(nil, my_err.ErrNoMoreContent)
+			},
+		},
+		{
+			name: "4",
+			SetupInput: func() (*SearchRequest, error) {
+				return &SearchRequest{UserID: 1, Query: "cats"}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*SearchResponse, error) {
+				return &SearchResponse{
+					Posts: []*Post{
+						{
+							ID:          2,
+							Head:        &Header{AuthorID: 1},
+							PostContent: &Content{Text: "about cats", CreatedAt: time.Unix(0, 0).Unix(), UpdatedAt: time.Unix(0, 0).Unix()},
+							Reactions:   map[string]uint32{},
+							Snippet:     "about <mark>cats</mark>",
+						},
+					},
+					Cursor: cursor.String(),
+				}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *SearchRequest, m *mocks) {
+				m.postService.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&models.PostPage{
+						Posts: []*models.Post{
+							{
+								ID:          2,
+								Header:      models.Header{AuthorID: 1},
+								PostContent: models.Content{Text: "about cats", CreatedAt: time.Unix(0, 0), UpdatedAt: time.Unix(0, 0)},
+								Reactions:   models.NewReactions(),
+								Snippet:     "about <mark>cats</mark>",
+							},
+						},
+						Cursor: cursor.String(),
+					}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			assert.Equal(t, status.Code(err), v.ExpectedErrCode)
+		})
+	}
+}
+
 type TableTest[T, In any] struct {
 	name            string
 	SetupInput      func() (*In, error)
@@ -49,3 +49,18 @@ func (mr *MockPostServiceMockRecorder) GetAuthorsPosts(ctx, header, userID inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorsPosts", reflect.TypeOf((*MockPostService)(nil).GetAuthorsPosts), ctx, header, userID)
 }
+
+// Search mocks base method.
+func (m *MockPostService) Search(ctx context.Context, userID uint32, search *models.PostSearch) (*models.PostPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, userID, search)
+	ret0, _ := ret[0].(*models.PostPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockPostServiceMockRecorder) Search(ctx, userID, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPostService)(nil).Search), ctx, userID, search)
+}
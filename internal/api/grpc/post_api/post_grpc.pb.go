@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	PostService_GetAuthorsPosts_FullMethodName = "/post_api.PostService/GetAuthorsPosts"
+	PostService_SearchPosts_FullMethodName     = "/post_api.PostService/SearchPosts"
 )
 
 // PostServiceClient is the client API for PostService service.
@@ -27,6 +28,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PostServiceClient interface {
 	GetAuthorsPosts(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	SearchPosts(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 }
 
 type postServiceClient struct {
@@ -47,11 +49,22 @@ func (c *postServiceClient) GetAuthorsPosts(ctx context.Context, in *Request, op
 	return out, nil
 }
 
+func (c *postServiceClient) SearchPosts(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, PostService_SearchPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PostServiceServer is the server API for PostService service.
 // All implementations must embed UnimplementedPostServiceServer
 // for forward compatibility.
 type PostServiceServer interface {
 	GetAuthorsPosts(context.Context, *Request) (*Response, error)
+	SearchPosts(context.Context, *SearchRequest) (*SearchResponse, error)
 	mustEmbedUnimplementedPostServiceServer()
 }
 
@@ -65,6 +78,9 @@ type UnimplementedPostServiceServer struct{}
 func (UnimplementedPostServiceServer) GetAuthorsPosts(context.Context, *Request) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAuthorsPosts not implemented")
 }
+func (UnimplementedPostServiceServer) SearchPosts(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchPosts not implemented")
+}
 func (UnimplementedPostServiceServer) mustEmbedUnimplementedPostServiceServer() {}
 func (UnimplementedPostServiceServer) testEmbeddedByValue()                     {}
 
@@ -104,6 +120,24 @@ func _PostService_GetAuthorsPosts_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_SearchPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).SearchPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_SearchPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).SearchPosts(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PostService_ServiceDesc is the grpc.ServiceDesc for PostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +149,10 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAuthorsPosts",
 			Handler:    _PostService_GetAuthorsPosts_Handler,
 		},
+		{
+			MethodName: "SearchPosts",
+			Handler:    _PostService_SearchPosts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/post.proto",
@@ -14,9 +14,19 @@ import (
 //go:generate mockgen -destination=mock.go -source=$GOFILE -package=${GOPACKAGE}
 type profileService interface {
 	GetHeader(ctx context.Context, userID uint32) (*models.Header, error)
+	GetHeaders(ctx context.Context, userIDs []uint32) ([]*models.Header, error)
 	GetFriendsID(ctx context.Context, userID uint32) ([]uint32, error)
 	Create(ctx context.Context, user *models.User) (uint32, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByID(ctx context.Context, userID uint32) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID uint32, password string) error
+	VerifyEmail(ctx context.Context, userID uint32, email string) error
+	GetTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactor, error)
+	SaveTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error
+	DeleteTwoFactor(ctx context.Context, userID uint32) error
+	UseRecoveryCode(ctx context.Context, userID uint32, codeHash string) error
+	GetShortProfiles(ctx context.Context, selfID uint32, ids []uint32) ([]*models.ShortProfile, error)
+	Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error)
 }
 
 type Adapter struct {
@@ -49,6 +59,27 @@ func (a *Adapter) GetHeader(ctx context.Context, req *HeaderRequest) (*HeaderRes
 	return resp, nil
 }
 
+func (a *Adapter) GetHeaders(ctx context.Context, req *HeadersRequest) (*HeadersResponse, error) {
+	res, err := a.service.GetHeaders(ctx, req.UserIDs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &HeadersResponse{
+		Heads: make([]*Header, 0, len(res)),
+	}
+	for _, header := range res {
+		resp.Heads = append(resp.Heads, &Header{
+			AuthorID:    header.AuthorID,
+			CommunityID: header.CommunityID,
+			Author:      header.Author,
+			Avatar:      string(header.Avatar),
+		})
+	}
+
+	return resp, nil
+}
+
 func (a *Adapter) GetFriendsID(ctx context.Context, req *FriendsRequest) (*FriendsResponse, error) {
 	userID := req.UserID
 	res, err := a.service.GetFriendsID(ctx, userID)
@@ -104,14 +135,166 @@ func (a *Adapter) GetUserByEmail(ctx context.Context, req *GetByEmailRequest) (*
 
 	resp := &GetByEmailResponse{
 		User: &User{
-			ID:        user.ID,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Email:     email,
-			Password:  user.Password,
-			Avatar:    string(user.Avatar),
+			ID:            user.ID,
+			FirstName:     user.FirstName,
+			LastName:      user.LastName,
+			Email:         email,
+			Password:      user.Password,
+			Avatar:        string(user.Avatar),
+			EmailVerified: user.EmailVerified,
+		},
+	}
+
+	return resp, nil
+}
+
+func (a *Adapter) GetUserByID(ctx context.Context, req *GetByIDRequest) (*GetByIDResponse, error) {
+	user, err := a.service.GetByID(ctx, req.UserID)
+	if errors.Is(err, my_err.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &GetByIDResponse{
+		User: &User{
+			ID:            user.ID,
+			FirstName:     user.FirstName,
+			LastName:      user.LastName,
+			Email:         user.Email,
+			Password:      user.Password,
+			Avatar:        string(user.Avatar),
+			EmailVerified: user.EmailVerified,
 		},
 	}
 
 	return resp, nil
 }
+
+func (a *Adapter) UpdatePassword(ctx context.Context, req *UpdatePasswordRequest) (*UpdatePasswordResponse, error) {
+	err := a.service.UpdatePassword(ctx, req.UserID, req.Password)
+	if errors.Is(err, my_err.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &UpdatePasswordResponse{}, nil
+}
+
+func (a *Adapter) VerifyEmail(ctx context.Context, req *VerifyEmailRequest) (*VerifyEmailResponse, error) {
+	err := a.service.VerifyEmail(ctx, req.UserID, req.Email)
+	if errors.Is(err, my_err.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &VerifyEmailResponse{}, nil
+}
+
+func (a *Adapter) GetTwoFactor(ctx context.Context, req *TwoFactorRequest) (*TwoFactorResponse, error) {
+	twoFactor, err := a.service.GetTwoFactor(ctx, req.UserID)
+	if errors.Is(err, my_err.ErrTwoFactorNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &TwoFactorResponse{
+		Secret:  twoFactor.Secret,
+		Enabled: twoFactor.Enabled,
+	}, nil
+}
+
+func (a *Adapter) SaveTwoFactor(ctx context.Context, req *SaveTwoFactorRequest) (*SaveTwoFactorResponse, error) {
+	twoFactor := &models.TwoFactor{
+		UserID:        req.UserID,
+		Secret:        req.Secret,
+		Enabled:       req.Enabled,
+		RecoveryCodes: req.RecoveryCodes,
+	}
+	if err := a.service.SaveTwoFactor(ctx, twoFactor); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &SaveTwoFactorResponse{}, nil
+}
+
+func (a *Adapter) DeleteTwoFactor(ctx context.Context, req *TwoFactorRequest) (*DeleteTwoFactorResponse, error) {
+	if err := a.service.DeleteTwoFactor(ctx, req.UserID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &DeleteTwoFactorResponse{}, nil
+}
+
+func (a *Adapter) UseRecoveryCode(ctx context.Context, req *UseRecoveryCodeRequest) (*UseRecoveryCodeResponse, error) {
+	err := a.service.UseRecoveryCode(ctx, req.UserID, req.CodeHash)
+	if errors.Is(err, my_err.ErrWrongTwoFactorCode) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &UseRecoveryCodeResponse{}, nil
+}
+
+func (a *Adapter) GetShortProfiles(ctx context.Context, req *ShortProfilesRequest) (*ShortProfilesResponse, error) {
+	res, err := a.service.GetShortProfiles(ctx, req.SelfID, req.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &ShortProfilesResponse{
+		Profiles: make([]*ShortProfile, 0, len(res)),
+	}
+	for _, profile := range res {
+		resp.Profiles = append(resp.Profiles, marshalShortProfile(profile))
+	}
+
+	return resp, nil
+}
+
+func (a *Adapter) SearchProfiles(ctx context.Context, req *SearchRequest) (*SearchProfilesResponse, error) {
+	cursor, err := models.ParseSearchCursor(req.Cursor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res, err := a.service.Search(ctx, &models.ProfileSearch{Query: req.Query, SelfID: req.UserID, Cursor: cursor})
+	if errors.Is(err, my_err.ErrNoMoreContent) {
+		return &SearchProfilesResponse{}, nil
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &SearchProfilesResponse{
+		Profiles: make([]*ShortProfile, 0, len(res.Profiles)),
+		Cursor:   res.Cursor,
+	}
+	for _, profile := range res.Profiles {
+		resp.Profiles = append(resp.Profiles, marshalShortProfile(profile))
+	}
+
+	return resp, nil
+}
+
+func marshalShortProfile(profile *models.ShortProfile) *ShortProfile {
+	return &ShortProfile{
+		ID:             profile.ID,
+		FirstName:      profile.FirstName,
+		LastName:       profile.LastName,
+		Avatar:         string(profile.Avatar),
+		IsAuthor:       profile.IsAuthor,
+		IsFriend:       profile.IsFriend,
+		IsSubscriber:   profile.IsSubscriber,
+		IsSubscription: profile.IsSubscription,
+	}
+}
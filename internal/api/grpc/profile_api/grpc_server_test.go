@@ -107,6 +107,76 @@ func TestGetHeader(t *testing.T) {
 	}
 }
 
+func TestGetHeaders(t *testing.T) {
+	run := func(ctx context.Context, implementation *Adapter, request *HeadersRequest) (*HeadersResponse, error) {
+		return implementation.GetHeaders(ctx, request)
+	}
+	tests := []TableTest[HeadersResponse, HeadersRequest]{
+		{
+			name: "1",
+			SetupInput: func() (*HeadersRequest, error) {
+				return &HeadersRequest{UserIDs: []uint32{1, 2}}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*HeadersResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.Internal,
+			SetupMock: func(request *HeadersRequest, m *mocks) {
+				m.profileService.EXPECT().GetHeaders(gomock.Any(), []uint32{1, 2}).Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*HeadersRequest, error) {
+				return &HeadersRequest{UserIDs: []uint32{1, 2}}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*HeadersResponse, error) {
+				return &HeadersResponse{
+					Heads: []*Header{
+						{AuthorID: 1, Author: "first", Avatar: "/image"},
+						{AuthorID: 2, Author: "second"},
+					},
+				}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *HeadersRequest, m *mocks) {
+				m.profileService.EXPECT().GetHeaders(gomock.Any(), []uint32{1, 2}).Return([]*models.Header{
+					{AuthorID: 1, Author: "first", Avatar: "/image"},
+					{AuthorID: 2, Author: "second"},
+				}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			assert.Equal(t, status.Code(err), v.ExpectedErrCode)
+		})
+	}
+}
+
 func TestGetFriendID(t *testing.T) {
 	tests := []TableTest[FriendsResponse, FriendsRequest]{
 		{
@@ -344,6 +414,175 @@ func TestGetByEmail(t *testing.T) {
 	}
 }
 
+func TestGetShortProfiles(t *testing.T) {
+	tests := []TableTest[ShortProfilesResponse, ShortProfilesRequest]{
+		{
+			name: "1",
+			SetupInput: func() (*ShortProfilesRequest, error) {
+				return &ShortProfilesRequest{SelfID: 1, UserID: []uint32{2}}, nil
+			},
+			Run: func(ctx context.Context, implementation *Adapter, request *ShortProfilesRequest) (*ShortProfilesResponse, error) {
+				return implementation.GetShortProfiles(ctx, request)
+			},
+			ExpectedResult: func() (*ShortProfilesResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.Internal,
+			SetupMock: func(request *ShortProfilesRequest, m *mocks) {
+				m.profileService.EXPECT().GetShortProfiles(gomock.Any(), uint32(1), []uint32{2}).
+					Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*ShortProfilesRequest, error) {
+				return &ShortProfilesRequest{SelfID: 1, UserID: []uint32{2}}, nil
+			},
+			Run: func(ctx context.Context, implementation *Adapter, request *ShortProfilesRequest) (*ShortProfilesResponse, error) {
+				return implementation.GetShortProfiles(ctx, request)
+			},
+			ExpectedResult: func() (*ShortProfilesResponse, error) {
+				return &ShortProfilesResponse{
+					Profiles: []*ShortProfile{
+						{ID: 2, FirstName: "Alexey", LastName: "Zemliakov", Avatar: "/image/1", IsFriend: true},
+					},
+				}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *ShortProfilesRequest, m *mocks) {
+				m.profileService.EXPECT().GetShortProfiles(gomock.Any(), uint32(1), []uint32{2}).
+					Return([]*models.ShortProfile{
+						{ID: 2, FirstName: "Alexey", LastName: "Zemliakov", Avatar: "/image/1", IsFriend: true},
+					}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			assert.Equal(t, status.Code(err), v.ExpectedErrCode)
+		})
+	}
+}
+
+func TestSearchProfiles(t *testing.T) {
+	run := func(ctx context.Context, implementation *Adapter, request *SearchRequest) (*SearchProfilesResponse, error) {
+		return implementation.SearchProfiles(ctx, request)
+	}
+	cursor := models.SearchCursor{Rank: 0.5, ID: 10}.String()
+	search := &models.ProfileSearch{Query: "ivan", SelfID: 1, Cursor: models.SearchCursor{Rank: 0.5, ID: 10}}
+	tests := []TableTest[SearchProfilesResponse, SearchRequest]{
+		{
+			name: "0",
+			SetupInput: func() (*SearchRequest, error) {
+				return &SearchRequest{UserID: 1, Query: "ivan", Cursor: "wrong"}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*SearchProfilesResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.InvalidArgument,
+			SetupMock:       func(request *SearchRequest, m *mocks) {},
+		},
+		{
+			name: "1",
+			SetupInput: func() (*SearchRequest, error) {
+				return &SearchRequest{UserID: 1, Query: "ivan", Cursor: cursor}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*SearchProfilesResponse, error) {
+				return nil, nil
+			},
+			ExpectedErrCode: codes.Internal,
+			SetupMock: func(request *SearchRequest, m *mocks) {
+				m.profileService.EXPECT().Search(gomock.Any(), search).Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*SearchRequest, error) {
+				return &SearchRequest{UserID: 1, Query: "ivan", Cursor: cursor}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*SearchProfilesResponse, error) {
+				return &SearchProfilesResponse{}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *SearchRequest, m *mocks) {
+				m.profileService.EXPECT().Search(gomock.Any(), gomock.Any()).
+					Return(nil, my_err.ErrNoMoreContent)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*SearchRequest, error) {
+				return &SearchRequest{UserID: 1, Query: "ivan", Cursor: cursor}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*SearchProfilesResponse, error) {
+				return &SearchProfilesResponse{
+					Profiles: []*ShortProfile{{ID: 2, FirstName: "Ivan", Avatar: "/image", IsFriend: true}},
+					Cursor:   "next",
+				}, nil
+			},
+			ExpectedErrCode: codes.OK,
+			SetupMock: func(request *SearchRequest, m *mocks) {
+				m.profileService.EXPECT().Search(gomock.Any(), gomock.Any()).
+					Return(&models.ProfilePage{
+						Profiles: []*models.ShortProfile{{ID: 2, FirstName: "Ivan", Avatar: "/image", IsFriend: true}},
+						Cursor:   "next",
+					}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			assert.Equal(t, status.Code(err), v.ExpectedErrCode)
+		})
+	}
+}
+
 type TableTest[T, In any] struct {
 	name            string
 	SetupInput      func() (*In, error)
@@ -352,3 +591,143 @@ type TableTest[T, In any] struct {
 	ExpectedErrCode codes.Code
 	SetupMock       func(*In, *mocks)
 }
+
+func TestGetUserByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapter, m := getAdapter(ctrl)
+	ctx := context.Background()
+
+	m.profileService.EXPECT().GetByID(gomock.Any(), uint32(1)).
+		Return(&models.User{ID: 1, Email: "alex.zem@gigamail.com", Password: "hash", EmailVerified: true}, nil)
+	res, err := adapter.GetUserByID(ctx, &GetByIDRequest{UserID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, &GetByIDResponse{User: &User{
+		ID: 1, Email: "alex.zem@gigamail.com", Password: "hash", EmailVerified: true,
+	}}, res)
+
+	m.profileService.EXPECT().GetByID(gomock.Any(), uint32(2)).Return(nil, my_err.ErrUserNotFound)
+	_, err = adapter.GetUserByID(ctx, &GetByIDRequest{UserID: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	m.profileService.EXPECT().GetByID(gomock.Any(), uint32(3)).Return(nil, errMock)
+	_, err = adapter.GetUserByID(ctx, &GetByIDRequest{UserID: 3})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestUpdatePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapter, m := getAdapter(ctrl)
+	ctx := context.Background()
+
+	m.profileService.EXPECT().UpdatePassword(gomock.Any(), uint32(1), "hash").Return(nil)
+	res, err := adapter.UpdatePassword(ctx, &UpdatePasswordRequest{UserID: 1, Password: "hash"})
+	assert.NoError(t, err)
+	assert.Equal(t, &UpdatePasswordResponse{}, res)
+
+	m.profileService.EXPECT().UpdatePassword(gomock.Any(), uint32(2), "hash").Return(my_err.ErrUserNotFound)
+	_, err = adapter.UpdatePassword(ctx, &UpdatePasswordRequest{UserID: 2, Password: "hash"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	m.profileService.EXPECT().UpdatePassword(gomock.Any(), uint32(3), "hash").Return(errMock)
+	_, err = adapter.UpdatePassword(ctx, &UpdatePasswordRequest{UserID: 3, Password: "hash"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapter, m := getAdapter(ctrl)
+	ctx := context.Background()
+
+	m.profileService.EXPECT().VerifyEmail(gomock.Any(), uint32(1), "email@mail.ru").Return(nil)
+	res, err := adapter.VerifyEmail(ctx, &VerifyEmailRequest{UserID: 1, Email: "email@mail.ru"})
+	assert.NoError(t, err)
+	assert.Equal(t, &VerifyEmailResponse{}, res)
+
+	m.profileService.EXPECT().VerifyEmail(gomock.Any(), uint32(2), "email@mail.ru").Return(my_err.ErrUserNotFound)
+	_, err = adapter.VerifyEmail(ctx, &VerifyEmailRequest{UserID: 2, Email: "email@mail.ru"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	m.profileService.EXPECT().VerifyEmail(gomock.Any(), uint32(3), "email@mail.ru").Return(errMock)
+	_, err = adapter.VerifyEmail(ctx, &VerifyEmailRequest{UserID: 3, Email: "email@mail.ru"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGetTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapter, m := getAdapter(ctrl)
+	ctx := context.Background()
+
+	m.profileService.EXPECT().GetTwoFactor(gomock.Any(), uint32(1)).
+		Return(&models.TwoFactor{UserID: 1, Secret: "SECRET", Enabled: true}, nil)
+	res, err := adapter.GetTwoFactor(ctx, &TwoFactorRequest{UserID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "SECRET", res.Secret)
+	assert.True(t, res.Enabled)
+
+	m.profileService.EXPECT().GetTwoFactor(gomock.Any(), uint32(2)).Return(nil, my_err.ErrTwoFactorNotFound)
+	_, err = adapter.GetTwoFactor(ctx, &TwoFactorRequest{UserID: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	m.profileService.EXPECT().GetTwoFactor(gomock.Any(), uint32(3)).Return(nil, errMock)
+	_, err = adapter.GetTwoFactor(ctx, &TwoFactorRequest{UserID: 3})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestSaveTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapter, m := getAdapter(ctrl)
+	ctx := context.Background()
+
+	twoFactor := &models.TwoFactor{UserID: 1, Secret: "SECRET", Enabled: true, RecoveryCodes: []string{"hash"}}
+	m.profileService.EXPECT().SaveTwoFactor(gomock.Any(), twoFactor).Return(nil)
+	res, err := adapter.SaveTwoFactor(
+		ctx, &SaveTwoFactorRequest{UserID: 1, Secret: "SECRET", Enabled: true, RecoveryCodes: []string{"hash"}},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, &SaveTwoFactorResponse{}, res)
+
+	m.profileService.EXPECT().SaveTwoFactor(gomock.Any(), gomock.Any()).Return(errMock)
+	_, err = adapter.SaveTwoFactor(ctx, &SaveTwoFactorRequest{UserID: 2})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestDeleteTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapter, m := getAdapter(ctrl)
+	ctx := context.Background()
+
+	m.profileService.EXPECT().DeleteTwoFactor(gomock.Any(), uint32(1)).Return(nil)
+	res, err := adapter.DeleteTwoFactor(ctx, &TwoFactorRequest{UserID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, &DeleteTwoFactorResponse{}, res)
+
+	m.profileService.EXPECT().DeleteTwoFactor(gomock.Any(), uint32(2)).Return(errMock)
+	_, err = adapter.DeleteTwoFactor(ctx, &TwoFactorRequest{UserID: 2})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapter, m := getAdapter(ctrl)
+	ctx := context.Background()
+
+	m.profileService.EXPECT().UseRecoveryCode(gomock.Any(), uint32(1), "hash").Return(nil)
+	res, err := adapter.UseRecoveryCode(ctx, &UseRecoveryCodeRequest{UserID: 1, CodeHash: "hash"})
+	assert.NoError(t, err)
+	assert.Equal(t, &UseRecoveryCodeResponse{}, res)
+
+	m.profileService.EXPECT().UseRecoveryCode(gomock.Any(), uint32(2), "hash").Return(my_err.ErrWrongTwoFactorCode)
+	_, err = adapter.UseRecoveryCode(ctx, &UseRecoveryCodeRequest{UserID: 2, CodeHash: "hash"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	m.profileService.EXPECT().UseRecoveryCode(gomock.Any(), uint32(3), "hash").Return(errMock)
+	_, err = adapter.UseRecoveryCode(ctx, &UseRecoveryCodeRequest{UserID: 3, CodeHash: "hash"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
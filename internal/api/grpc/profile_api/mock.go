@@ -50,6 +50,20 @@ func (mr *MockprofileServiceMockRecorder) Create(ctx, user interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockprofileService)(nil).Create), ctx, user)
 }
 
+// DeleteTwoFactor mocks base method.
+func (m *MockprofileService) DeleteTwoFactor(ctx context.Context, userID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTwoFactor", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTwoFactor indicates an expected call of DeleteTwoFactor.
+func (mr *MockprofileServiceMockRecorder) DeleteTwoFactor(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactor", reflect.TypeOf((*MockprofileService)(nil).DeleteTwoFactor), ctx, userID)
+}
+
 // GetByEmail mocks base method.
 func (m *MockprofileService) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +79,21 @@ func (mr *MockprofileServiceMockRecorder) GetByEmail(ctx, email interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockprofileService)(nil).GetByEmail), ctx, email)
 }
 
+// GetByID mocks base method.
+func (m *MockprofileService) GetByID(ctx context.Context, userID uint32) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockprofileServiceMockRecorder) GetByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockprofileService)(nil).GetByID), ctx, userID)
+}
+
 // GetFriendsID mocks base method.
 func (m *MockprofileService) GetFriendsID(ctx context.Context, userID uint32) ([]uint32, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +123,119 @@ func (mr *MockprofileServiceMockRecorder) GetHeader(ctx, userID interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockprofileService)(nil).GetHeader), ctx, userID)
 }
+
+// GetHeaders mocks base method.
+func (m *MockprofileService) GetHeaders(ctx context.Context, userIDs []uint32) ([]*models.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeaders", ctx, userIDs)
+	ret0, _ := ret[0].([]*models.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeaders indicates an expected call of GetHeaders.
+func (mr *MockprofileServiceMockRecorder) GetHeaders(ctx, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeaders", reflect.TypeOf((*MockprofileService)(nil).GetHeaders), ctx, userIDs)
+}
+
+// GetShortProfiles mocks base method.
+func (m *MockprofileService) GetShortProfiles(ctx context.Context, selfID uint32, ids []uint32) ([]*models.ShortProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortProfiles", ctx, selfID, ids)
+	ret0, _ := ret[0].([]*models.ShortProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortProfiles indicates an expected call of GetShortProfiles.
+func (mr *MockprofileServiceMockRecorder) GetShortProfiles(ctx, selfID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortProfiles", reflect.TypeOf((*MockprofileService)(nil).GetShortProfiles), ctx, selfID, ids)
+}
+
+// GetTwoFactor mocks base method.
+func (m *MockprofileService) GetTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwoFactor", ctx, userID)
+	ret0, _ := ret[0].(*models.TwoFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactor indicates an expected call of GetTwoFactor.
+func (mr *MockprofileServiceMockRecorder) GetTwoFactor(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactor", reflect.TypeOf((*MockprofileService)(nil).GetTwoFactor), ctx, userID)
+}
+
+// SaveTwoFactor mocks base method.
+func (m *MockprofileService) SaveTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTwoFactor", ctx, twoFactor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTwoFactor indicates an expected call of SaveTwoFactor.
+func (mr *MockprofileServiceMockRecorder) SaveTwoFactor(ctx, twoFactor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTwoFactor", reflect.TypeOf((*MockprofileService)(nil).SaveTwoFactor), ctx, twoFactor)
+}
+
+// Search mocks base method.
+func (m *MockprofileService) Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search)
+	ret0, _ := ret[0].(*models.ProfilePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockprofileServiceMockRecorder) Search(ctx, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockprofileService)(nil).Search), ctx, search)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockprofileService) UpdatePassword(ctx context.Context, userID uint32, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userID, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockprofileServiceMockRecorder) UpdatePassword(ctx, userID, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockprofileService)(nil).UpdatePassword), ctx, userID, password)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockprofileService) UseRecoveryCode(ctx context.Context, userID uint32, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockprofileServiceMockRecorder) UseRecoveryCode(ctx, userID, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockprofileService)(nil).UseRecoveryCode), ctx, userID, codeHash)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockprofileService) VerifyEmail(ctx context.Context, userID uint32, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, userID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockprofileServiceMockRecorder) VerifyEmail(ctx, userID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockprofileService)(nil).VerifyEmail), ctx, userID, email)
+}
@@ -19,10 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProfileService_GetHeader_FullMethodName      = "/profile_api.ProfileService/GetHeader"
-	ProfileService_GetFriendsID_FullMethodName   = "/profile_api.ProfileService/GetFriendsID"
-	ProfileService_GetUserByEmail_FullMethodName = "/profile_api.ProfileService/GetUserByEmail"
-	ProfileService_Create_FullMethodName         = "/profile_api.ProfileService/Create"
+	ProfileService_GetHeader_FullMethodName        = "/profile_api.ProfileService/GetHeader"
+	ProfileService_GetHeaders_FullMethodName       = "/profile_api.ProfileService/GetHeaders"
+	ProfileService_GetFriendsID_FullMethodName     = "/profile_api.ProfileService/GetFriendsID"
+	ProfileService_GetUserByEmail_FullMethodName   = "/profile_api.ProfileService/GetUserByEmail"
+	ProfileService_GetUserByID_FullMethodName      = "/profile_api.ProfileService/GetUserByID"
+	ProfileService_UpdatePassword_FullMethodName   = "/profile_api.ProfileService/UpdatePassword"
+	ProfileService_VerifyEmail_FullMethodName      = "/profile_api.ProfileService/VerifyEmail"
+	ProfileService_GetTwoFactor_FullMethodName     = "/profile_api.ProfileService/GetTwoFactor"
+	ProfileService_SaveTwoFactor_FullMethodName    = "/profile_api.ProfileService/SaveTwoFactor"
+	ProfileService_DeleteTwoFactor_FullMethodName  = "/profile_api.ProfileService/DeleteTwoFactor"
+	ProfileService_UseRecoveryCode_FullMethodName  = "/profile_api.ProfileService/UseRecoveryCode"
+	ProfileService_Create_FullMethodName           = "/profile_api.ProfileService/Create"
+	ProfileService_GetShortProfiles_FullMethodName = "/profile_api.ProfileService/GetShortProfiles"
+	ProfileService_SearchProfiles_FullMethodName   = "/profile_api.ProfileService/SearchProfiles"
 )
 
 // ProfileServiceClient is the client API for ProfileService service.
@@ -30,9 +40,19 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ProfileServiceClient interface {
 	GetHeader(ctx context.Context, in *HeaderRequest, opts ...grpc.CallOption) (*HeaderResponse, error)
+	GetHeaders(ctx context.Context, in *HeadersRequest, opts ...grpc.CallOption) (*HeadersResponse, error)
 	GetFriendsID(ctx context.Context, in *FriendsRequest, opts ...grpc.CallOption) (*FriendsResponse, error)
 	GetUserByEmail(ctx context.Context, in *GetByEmailRequest, opts ...grpc.CallOption) (*GetByEmailResponse, error)
+	GetUserByID(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*GetByIDResponse, error)
+	UpdatePassword(ctx context.Context, in *UpdatePasswordRequest, opts ...grpc.CallOption) (*UpdatePasswordResponse, error)
+	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error)
+	GetTwoFactor(ctx context.Context, in *TwoFactorRequest, opts ...grpc.CallOption) (*TwoFactorResponse, error)
+	SaveTwoFactor(ctx context.Context, in *SaveTwoFactorRequest, opts ...grpc.CallOption) (*SaveTwoFactorResponse, error)
+	DeleteTwoFactor(ctx context.Context, in *TwoFactorRequest, opts ...grpc.CallOption) (*DeleteTwoFactorResponse, error)
+	UseRecoveryCode(ctx context.Context, in *UseRecoveryCodeRequest, opts ...grpc.CallOption) (*UseRecoveryCodeResponse, error)
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	GetShortProfiles(ctx context.Context, in *ShortProfilesRequest, opts ...grpc.CallOption) (*ShortProfilesResponse, error)
+	SearchProfiles(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchProfilesResponse, error)
 }
 
 type profileServiceClient struct {
@@ -53,6 +73,16 @@ func (c *profileServiceClient) GetHeader(ctx context.Context, in *HeaderRequest,
 	return out, nil
 }
 
+func (c *profileServiceClient) GetHeaders(ctx context.Context, in *HeadersRequest, opts ...grpc.CallOption) (*HeadersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeadersResponse)
+	err := c.cc.Invoke(ctx, ProfileService_GetHeaders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *profileServiceClient) GetFriendsID(ctx context.Context, in *FriendsRequest, opts ...grpc.CallOption) (*FriendsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FriendsResponse)
@@ -73,6 +103,76 @@ func (c *profileServiceClient) GetUserByEmail(ctx context.Context, in *GetByEmai
 	return out, nil
 }
 
+func (c *profileServiceClient) GetUserByID(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*GetByIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetByIDResponse)
+	err := c.cc.Invoke(ctx, ProfileService_GetUserByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) UpdatePassword(ctx context.Context, in *UpdatePasswordRequest, opts ...grpc.CallOption) (*UpdatePasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePasswordResponse)
+	err := c.cc.Invoke(ctx, ProfileService_UpdatePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyEmailResponse)
+	err := c.cc.Invoke(ctx, ProfileService_VerifyEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) GetTwoFactor(ctx context.Context, in *TwoFactorRequest, opts ...grpc.CallOption) (*TwoFactorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TwoFactorResponse)
+	err := c.cc.Invoke(ctx, ProfileService_GetTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) SaveTwoFactor(ctx context.Context, in *SaveTwoFactorRequest, opts ...grpc.CallOption) (*SaveTwoFactorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveTwoFactorResponse)
+	err := c.cc.Invoke(ctx, ProfileService_SaveTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) DeleteTwoFactor(ctx context.Context, in *TwoFactorRequest, opts ...grpc.CallOption) (*DeleteTwoFactorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTwoFactorResponse)
+	err := c.cc.Invoke(ctx, ProfileService_DeleteTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) UseRecoveryCode(ctx context.Context, in *UseRecoveryCodeRequest, opts ...grpc.CallOption) (*UseRecoveryCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UseRecoveryCodeResponse)
+	err := c.cc.Invoke(ctx, ProfileService_UseRecoveryCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *profileServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateResponse)
@@ -83,14 +183,44 @@ func (c *profileServiceClient) Create(ctx context.Context, in *CreateRequest, op
 	return out, nil
 }
 
+func (c *profileServiceClient) GetShortProfiles(ctx context.Context, in *ShortProfilesRequest, opts ...grpc.CallOption) (*ShortProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShortProfilesResponse)
+	err := c.cc.Invoke(ctx, ProfileService_GetShortProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) SearchProfiles(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchProfilesResponse)
+	err := c.cc.Invoke(ctx, ProfileService_SearchProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProfileServiceServer is the server API for ProfileService service.
 // All implementations must embed UnimplementedProfileServiceServer
 // for forward compatibility.
 type ProfileServiceServer interface {
 	GetHeader(context.Context, *HeaderRequest) (*HeaderResponse, error)
+	GetHeaders(context.Context, *HeadersRequest) (*HeadersResponse, error)
 	GetFriendsID(context.Context, *FriendsRequest) (*FriendsResponse, error)
 	GetUserByEmail(context.Context, *GetByEmailRequest) (*GetByEmailResponse, error)
+	GetUserByID(context.Context, *GetByIDRequest) (*GetByIDResponse, error)
+	UpdatePassword(context.Context, *UpdatePasswordRequest) (*UpdatePasswordResponse, error)
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error)
+	GetTwoFactor(context.Context, *TwoFactorRequest) (*TwoFactorResponse, error)
+	SaveTwoFactor(context.Context, *SaveTwoFactorRequest) (*SaveTwoFactorResponse, error)
+	DeleteTwoFactor(context.Context, *TwoFactorRequest) (*DeleteTwoFactorResponse, error)
+	UseRecoveryCode(context.Context, *UseRecoveryCodeRequest) (*UseRecoveryCodeResponse, error)
 	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	GetShortProfiles(context.Context, *ShortProfilesRequest) (*ShortProfilesResponse, error)
+	SearchProfiles(context.Context, *SearchRequest) (*SearchProfilesResponse, error)
 	mustEmbedUnimplementedProfileServiceServer()
 }
 
@@ -104,15 +234,45 @@ type UnimplementedProfileServiceServer struct{}
 func (UnimplementedProfileServiceServer) GetHeader(context.Context, *HeaderRequest) (*HeaderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetHeader not implemented")
 }
+func (UnimplementedProfileServiceServer) GetHeaders(context.Context, *HeadersRequest) (*HeadersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeaders not implemented")
+}
 func (UnimplementedProfileServiceServer) GetFriendsID(context.Context, *FriendsRequest) (*FriendsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFriendsID not implemented")
 }
 func (UnimplementedProfileServiceServer) GetUserByEmail(context.Context, *GetByEmailRequest) (*GetByEmailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserByEmail not implemented")
 }
+func (UnimplementedProfileServiceServer) GetUserByID(context.Context, *GetByIDRequest) (*GetByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserByID not implemented")
+}
+func (UnimplementedProfileServiceServer) UpdatePassword(context.Context, *UpdatePasswordRequest) (*UpdatePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePassword not implemented")
+}
+func (UnimplementedProfileServiceServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedProfileServiceServer) GetTwoFactor(context.Context, *TwoFactorRequest) (*TwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTwoFactor not implemented")
+}
+func (UnimplementedProfileServiceServer) SaveTwoFactor(context.Context, *SaveTwoFactorRequest) (*SaveTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveTwoFactor not implemented")
+}
+func (UnimplementedProfileServiceServer) DeleteTwoFactor(context.Context, *TwoFactorRequest) (*DeleteTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTwoFactor not implemented")
+}
+func (UnimplementedProfileServiceServer) UseRecoveryCode(context.Context, *UseRecoveryCodeRequest) (*UseRecoveryCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseRecoveryCode not implemented")
+}
 func (UnimplementedProfileServiceServer) Create(context.Context, *CreateRequest) (*CreateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
+func (UnimplementedProfileServiceServer) GetShortProfiles(context.Context, *ShortProfilesRequest) (*ShortProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShortProfiles not implemented")
+}
+func (UnimplementedProfileServiceServer) SearchProfiles(context.Context, *SearchRequest) (*SearchProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchProfiles not implemented")
+}
 func (UnimplementedProfileServiceServer) mustEmbedUnimplementedProfileServiceServer() {}
 func (UnimplementedProfileServiceServer) testEmbeddedByValue()                        {}
 
@@ -152,6 +312,24 @@ func _ProfileService_GetHeader_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_GetHeaders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeadersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetHeaders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetHeaders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetHeaders(ctx, req.(*HeadersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProfileService_GetFriendsID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FriendsRequest)
 	if err := dec(in); err != nil {
@@ -188,6 +366,132 @@ func _ProfileService_GetUserByEmail_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_GetUserByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetUserByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetUserByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetUserByID(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_UpdatePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).UpdatePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_UpdatePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).UpdatePassword(ctx, req.(*UpdatePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_VerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).VerifyEmail(ctx, req.(*VerifyEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_GetTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetTwoFactor(ctx, req.(*TwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_SaveTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).SaveTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_SaveTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).SaveTwoFactor(ctx, req.(*SaveTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_DeleteTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).DeleteTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_DeleteTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).DeleteTwoFactor(ctx, req.(*TwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_UseRecoveryCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UseRecoveryCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).UseRecoveryCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_UseRecoveryCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).UseRecoveryCode(ctx, req.(*UseRecoveryCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProfileService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +510,42 @@ func _ProfileService_Create_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_GetShortProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShortProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetShortProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetShortProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetShortProfiles(ctx, req.(*ShortProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_SearchProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).SearchProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_SearchProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).SearchProfiles(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProfileService_ServiceDesc is the grpc.ServiceDesc for ProfileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -217,6 +557,10 @@ var ProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetHeader",
 			Handler:    _ProfileService_GetHeader_Handler,
 		},
+		{
+			MethodName: "GetHeaders",
+			Handler:    _ProfileService_GetHeaders_Handler,
+		},
 		{
 			MethodName: "GetFriendsID",
 			Handler:    _ProfileService_GetFriendsID_Handler,
@@ -225,10 +569,46 @@ var ProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserByEmail",
 			Handler:    _ProfileService_GetUserByEmail_Handler,
 		},
+		{
+			MethodName: "GetUserByID",
+			Handler:    _ProfileService_GetUserByID_Handler,
+		},
+		{
+			MethodName: "UpdatePassword",
+			Handler:    _ProfileService_UpdatePassword_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _ProfileService_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "GetTwoFactor",
+			Handler:    _ProfileService_GetTwoFactor_Handler,
+		},
+		{
+			MethodName: "SaveTwoFactor",
+			Handler:    _ProfileService_SaveTwoFactor_Handler,
+		},
+		{
+			MethodName: "DeleteTwoFactor",
+			Handler:    _ProfileService_DeleteTwoFactor_Handler,
+		},
+		{
+			MethodName: "UseRecoveryCode",
+			Handler:    _ProfileService_UseRecoveryCode_Handler,
+		},
 		{
 			MethodName: "Create",
 			Handler:    _ProfileService_Create_Handler,
 		},
+		{
+			MethodName: "GetShortProfiles",
+			Handler:    _ProfileService_GetShortProfiles_Handler,
+		},
+		{
+			MethodName: "SearchProfiles",
+			Handler:    _ProfileService_SearchProfiles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/profile.proto",
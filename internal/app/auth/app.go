@@ -1,8 +1,11 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 
 	"github.com/gomodule/redigo/redis"
 	"github.com/sirupsen/logrus"
@@ -15,6 +18,7 @@ import (
 	"github.com/2024_2_BetterCallFirewall/internal/config"
 	"github.com/2024_2_BetterCallFirewall/internal/ext_grpc"
 	"github.com/2024_2_BetterCallFirewall/internal/ext_grpc/adapter/profile"
+	"github.com/2024_2_BetterCallFirewall/internal/mail"
 	metrics "github.com/2024_2_BetterCallFirewall/internal/metrics"
 	"github.com/2024_2_BetterCallFirewall/internal/middleware"
 	"github.com/2024_2_BetterCallFirewall/internal/models"
@@ -24,11 +28,18 @@ import (
 
 type SessionManager interface {
 	Check(string) (*models.Session, error)
-	Create(userID uint32) (*models.Session, error)
+	Create(user *models.User, device models.Device) (*models.Session, error)
+	Rotate(sess *models.Session) (*models.Session, error)
 	Destroy(sess *models.Session) error
+
+	ListSessions(sess *models.Session) ([]*models.SessionInfo, error)
+	RevokeSession(sess *models.Session, publicID string) error
+	RevokeOtherSessions(sess *models.Session) error
 }
 
-func GetHTTPServer(cfg *config.Config, authMetrics *metrics.HttpMetrics) (*http.Server, error) {
+func GetHTTPServer(
+	cfg *config.Config, authMetrics *metrics.HttpMetrics, limitMetrics *metrics.LimitMetrics,
+) (*http.Server, error) {
 	logger := logrus.New()
 	logger.Formatter = &logrus.TextFormatter{
 		FullTimestamp:   true,
@@ -52,11 +63,39 @@ func GetHTTPServer(cfg *config.Config, authMetrics *metrics.HttpMetrics) (*http.
 	}
 	prof := profile.New(profileProvider)
 
-	authServ := service.NewAuthServiceImpl(prof)
+	var mailOut io.Writer = os.Stdout
+	if cfg.MAIL.File != "" {
+		mailFile, err := os.OpenFile(cfg.MAIL.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("open mail file: %w", err)
+		}
+		mailOut = mailFile
+	}
+	if cfg.VERIFY.Secret == "" {
+		return nil, errors.New("no secret to sign the email verification tokens")
+	}
+	mailer := mail.NewFileSender(mailOut)
+	resetTokens := redismy.NewResetTokenRedisRepository(redisPool)
+	loginTokens := redismy.NewLoginTokenRedisRepository(redisPool)
+	cooldowns := redismy.NewCooldownRedisRepository(redisPool)
+	mailing := service.Mailing{
+		ResetURL:     cfg.MAIL.ResetURL,
+		VerifyURL:    cfg.MAIL.VerifyURL,
+		VerifySecret: []byte(cfg.VERIFY.Secret),
+	}
+
+	authServ := service.NewAuthServiceImpl(prof, resetTokens, loginTokens, cooldowns, mailer, mailing)
 	responder := router.NewResponder(logger)
 	sessionRepo := redismy.NewSessionRedisRepository(redisPool)
 	sessionManager := service.NewSessionManager(sessionRepo)
-	control := controller.NewAuthController(responder, authServ, sessionManager)
+	limiter := service.NewLimiter(
+		redismy.NewRateLimitRedisRepository(redisPool), redismy.NewLockoutRedisRepository(redisPool), limitMetrics,
+	)
+	proxies, err := controller.NewProxies(cfg.PROXY.Trusted)
+	if err != nil {
+		return nil, err
+	}
+	control := controller.NewAuthController(responder, authServ, sessionManager, limiter, proxies)
 
 	rout := auth.NewRouter(control, sessionManager, logger, authMetrics)
 
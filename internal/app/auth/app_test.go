@@ -10,9 +10,14 @@ import (
 )
 
 func TestGetHttpServer(t *testing.T) {
-	server, err := GetHTTPServer(&config.Config{}, &metrics.HttpMetrics{})
+	server, err := GetHTTPServer(
+		&config.Config{VERIFY: config.Verify{Secret: "secret"}}, &metrics.HttpMetrics{}, &metrics.LimitMetrics{},
+	)
 	assert.NoError(t, err)
 	assert.NotNil(t, server)
+
+	_, err = GetHTTPServer(&config.Config{}, &metrics.HttpMetrics{}, &metrics.LimitMetrics{})
+	assert.Error(t, err)
 }
 
 func TestGetGrpcServer(t *testing.T) {
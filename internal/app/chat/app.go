@@ -3,22 +3,31 @@ package chat
 import (
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/gomodule/redigo/redis"
 	"github.com/sirupsen/logrus"
 
 	ChatController "github.com/2024_2_BetterCallFirewall/internal/chat/controller"
 	chatRepository "github.com/2024_2_BetterCallFirewall/internal/chat/repository/postgres"
+	chatBroker "github.com/2024_2_BetterCallFirewall/internal/chat/repository/redis"
 	chatService "github.com/2024_2_BetterCallFirewall/internal/chat/service"
 	"github.com/2024_2_BetterCallFirewall/internal/config"
 	"github.com/2024_2_BetterCallFirewall/internal/ext_grpc"
 	"github.com/2024_2_BetterCallFirewall/internal/ext_grpc/adapter/auth"
 	"github.com/2024_2_BetterCallFirewall/internal/metrics"
+	"github.com/2024_2_BetterCallFirewall/internal/middleware"
+	presenceRepository "github.com/2024_2_BetterCallFirewall/internal/presence/repository/redis"
 	"github.com/2024_2_BetterCallFirewall/internal/router"
 	"github.com/2024_2_BetterCallFirewall/internal/router/chat"
 	"github.com/2024_2_BetterCallFirewall/pkg/start_postgres"
 )
 
-func GetServer(cfg *config.Config, chatMetrics *metrics.HttpMetrics) (*http.Server, error) {
+const redisTimeout = 3 * time.Second
+
+func GetServer(
+	cfg *config.Config, chatMetrics *metrics.HttpMetrics, connMetrics *metrics.ChatMetrics,
+) (*http.Server, error) {
 	logger := logrus.New()
 	logger.Formatter = &logrus.TextFormatter{
 		FullTimestamp:   true,
@@ -41,12 +50,30 @@ func GetServer(cfg *config.Config, chatMetrics *metrics.HttpMetrics) (*http.Serv
 		return nil, err
 	}
 
+	redisPool := &redis.Pool{
+		MaxIdle:   cfg.REDIS.MaxIdle,
+		MaxActive: cfg.REDIS.MaxActive,
+		// the hub and the broker must not hang on Redis that does not answer
+		Dial: func() (redis.Conn, error) {
+			addr := fmt.Sprintf("%s:%s", cfg.REDIS.Host, cfg.REDIS.Port)
+			return redis.Dial(
+				"tcp", addr,
+				redis.DialConnectTimeout(redisTimeout),
+				redis.DialReadTimeout(redisTimeout),
+				redis.DialWriteTimeout(redisTimeout),
+			)
+		},
+	}
+
 	responder := router.NewResponder(logger)
 
 	chatRepo := chatRepository.NewChatRepository(postgresDB)
-	chatServ := chatService.NewChatService(chatRepo)
-	chatControl := ChatController.NewChatController(chatServ, responder)
-	//defer close(chatControl.Messages)
+	presence := presenceRepository.NewPresenceRepository(redisPool)
+	chatServ := chatService.NewChatService(chatRepo, presence)
+	broker := chatBroker.NewMessageBroker(redisPool)
+	hub := ChatController.NewHub(broker, presence, connMetrics, logger)
+	go hub.Run()
+	chatControl := ChatController.NewChatController(chatServ, hub, responder)
 
 	provider, err := ext_grpc.GetGRPCProvider(cfg.AUTHGRPC.Host, cfg.AUTHGRPC.Port)
 	if err != nil {
@@ -54,7 +81,11 @@ func GetServer(cfg *config.Config, chatMetrics *metrics.HttpMetrics) (*http.Serv
 	}
 	sm := auth.New(provider)
 
-	rout := chat.NewRouter(chatControl, sm, logger, chatMetrics)
+	limits, err := middleware.NewLimits(cfg.VERIFY.Limits)
+	if err != nil {
+		return nil, err
+	}
+	rout := chat.NewRouter(chatControl, sm, limits, logger, chatMetrics)
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.CHAT.Port),
@@ -62,6 +93,10 @@ func GetServer(cfg *config.Config, chatMetrics *metrics.HttpMetrics) (*http.Serv
 		ReadTimeout:  cfg.CHAT.ReadTimeout,
 		WriteTimeout: cfg.CHAT.WriteTimeout,
 	}
+	server.RegisterOnShutdown(func() {
+		hub.Stop()
+		redisPool.Close()
+	})
 
 	return server, nil
 }
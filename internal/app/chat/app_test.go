@@ -19,7 +19,7 @@ func TestGetServer(t *testing.T) {
 			Pass:    "test",
 			SSLMode: "test",
 		},
-	}, &metrics.HttpMetrics{})
+	}, &metrics.HttpMetrics{}, &metrics.ChatMetrics{})
 	assert.NoError(t, err)
 	assert.NotNil(t, server)
 }
@@ -26,6 +26,8 @@ import (
 type communityManager interface {
 	CheckAccess(ctx context.Context, communityID, userID uint32) bool
 	GetHeader(ctx context.Context, communityID uint32) (*models.Header, error)
+	GetHeaders(ctx context.Context, communityIDs []uint32) ([]*models.Header, error)
+	Search(ctx context.Context, query string, userID, lastID uint32) ([]*models.CommunityCard, error)
 }
 
 func GetServers(cfg *config.Config, grpcMetrics *metrics.GrpcMetrics, communityMetrics *metrics.HttpMetrics) (*http.Server, *grpc.Server, error) {
@@ -63,7 +65,11 @@ func GetServers(cfg *config.Config, grpcMetrics *metrics.GrpcMetrics, communityM
 	}
 	sm := auth.New(provider)
 
-	rout := community.NewRouter(communityControl, sm, logger, communityMetrics)
+	limits, err := middleware.NewLimits(cfg.VERIFY.Limits)
+	if err != nil {
+		return nil, nil, err
+	}
+	rout := community.NewRouter(communityControl, sm, limits, logger, communityMetrics)
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.COMMUNITY.Port),
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/gomodule/redigo/redis"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 
@@ -13,23 +15,39 @@ import (
 	"github.com/2024_2_BetterCallFirewall/internal/ext_grpc"
 	"github.com/2024_2_BetterCallFirewall/internal/ext_grpc/adapter/auth"
 	"github.com/2024_2_BetterCallFirewall/internal/ext_grpc/adapter/community"
+	postAdapter "github.com/2024_2_BetterCallFirewall/internal/ext_grpc/adapter/post"
 	"github.com/2024_2_BetterCallFirewall/internal/ext_grpc/adapter/profile"
 	"github.com/2024_2_BetterCallFirewall/internal/metrics"
 	"github.com/2024_2_BetterCallFirewall/internal/middleware"
 	"github.com/2024_2_BetterCallFirewall/internal/models"
 	"github.com/2024_2_BetterCallFirewall/internal/post/controller"
 	"github.com/2024_2_BetterCallFirewall/internal/post/repository/postgres"
+	postCache "github.com/2024_2_BetterCallFirewall/internal/post/repository/redis"
 	"github.com/2024_2_BetterCallFirewall/internal/post/service"
 	"github.com/2024_2_BetterCallFirewall/internal/router"
 	"github.com/2024_2_BetterCallFirewall/internal/router/post"
+	searchController "github.com/2024_2_BetterCallFirewall/internal/search/controller"
+	searchService "github.com/2024_2_BetterCallFirewall/internal/search/service"
 	"github.com/2024_2_BetterCallFirewall/pkg/start_postgres"
 )
 
+// searchTimeout limits the time each service has to answer the global search
+const searchTimeout = 2 * time.Second
+
 type postManager interface {
 	GetAuthorsPosts(ctx context.Context, header *models.Header, userID uint32) ([]*models.Post, error)
+	Search(ctx context.Context, userID uint32, search *models.PostSearch) (*models.PostPage, error)
+}
+
+// postServer serves the posts of profiles and the post search to the other services
+type postServer struct {
+	*service.PostProfileImpl
+	*service.PostServiceImpl
 }
 
-func GetHTTPServer(cfg *config.Config, postMetric *metrics.HttpMetrics) (*http.Server, error) {
+func GetHTTPServer(
+	cfg *config.Config, postMetric *metrics.HttpMetrics, cacheMetrics *metrics.CacheMetrics,
+) (*http.Server, error) {
 	logger := logrus.New()
 	logger.Formatter = &logrus.TextFormatter{
 		FullTimestamp:   true,
@@ -72,16 +90,42 @@ func GetHTTPServer(cfg *config.Config, postMetric *metrics.HttpMetrics) (*http.S
 	}
 	cp := community.New(communityProvider)
 
-	postService := service.NewPostServiceImpl(repo, pp, cp)
+	redisPool := &redis.Pool{
+		MaxIdle:   cfg.REDIS.MaxIdle,
+		MaxActive: cfg.REDIS.MaxActive,
+		Dial: func() (redis.Conn, error) {
+			addr := fmt.Sprintf("%s:%s", cfg.REDIS.Host, cfg.REDIS.Port)
+			return redis.Dial("tcp", addr)
+		},
+	}
+	cache := postCache.NewCache(redisPool, cacheMetrics)
+
+	postService := service.NewCachedPostService(repo, pp, cp, cache)
 	postController := controller.NewPostController(postService, responder)
+	commentService := service.NewCachedCommentService(repo, pp, cache)
+	commentController := controller.NewCommentController(commentService, responder)
 
-	rout := post.NewRouter(postController, sm, logger, postMetric)
+	postProvider, err := ext_grpc.GetGRPCProvider(cfg.POSTGRPC.Host, cfg.POSTGRPC.Port)
+	if err != nil {
+		return nil, err
+	}
+	searcher := searchService.NewSearchService(pp, cp, postAdapter.New(postProvider), searchTimeout)
+	searchContr := searchController.NewSearchController(searcher, responder)
+
+	limits, err := middleware.NewLimits(cfg.VERIFY.Limits)
+	if err != nil {
+		return nil, err
+	}
+	rout := post.NewRouter(postController, commentController, searchContr, sm, limits, logger, postMetric)
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.POST.Port),
 		Handler:      rout,
 		ReadTimeout:  cfg.POST.ReadTimeout,
 		WriteTimeout: cfg.POST.WriteTimeout,
 	}
+	server.RegisterOnShutdown(func() {
+		redisPool.Close()
+	})
 
 	return server, nil
 }
@@ -116,7 +160,18 @@ func GetGRPCServer(cfg *config.Config, grpcMetrics *metrics.GrpcMetrics) (*grpc.
 	}
 
 	repo := postgres.NewAdapter(postgresDB)
-	postHelper := service.NewPostProfileImpl(repo)
+	profileProvider, err := ext_grpc.GetGRPCProvider(cfg.PROFILEGRPC.Host, cfg.PROFILEGRPC.Port)
+	if err != nil {
+		return nil, err
+	}
+	communityProvider, err := ext_grpc.GetGRPCProvider(cfg.COMMUNITYGRPC.Host, cfg.COMMUNITYGRPC.Port)
+	if err != nil {
+		return nil, err
+	}
+	postHelper := postServer{
+		PostProfileImpl: service.NewPostProfileImpl(repo),
+		PostServiceImpl: service.NewPostServiceImpl(repo, profile.New(profileProvider), community.New(communityProvider)),
+	}
 
 	metricsmw := middleware.NewGrpcMiddleware(grpcMetrics)
 	serv := getGRPC(postHelper, metricsmw)
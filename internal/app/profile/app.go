@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/gomodule/redigo/redis"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/2024_2_BetterCallFirewall/internal/metrics"
 	"github.com/2024_2_BetterCallFirewall/internal/middleware"
 	"github.com/2024_2_BetterCallFirewall/internal/models"
+	presenceRepository "github.com/2024_2_BetterCallFirewall/internal/presence/repository/redis"
 	"github.com/2024_2_BetterCallFirewall/internal/profile/controller"
 	"github.com/2024_2_BetterCallFirewall/internal/profile/repository"
 	"github.com/2024_2_BetterCallFirewall/internal/profile/service"
@@ -26,9 +28,19 @@ import (
 
 type profileManager interface {
 	GetHeader(ctx context.Context, userID uint32) (*models.Header, error)
+	GetHeaders(ctx context.Context, userIDs []uint32) ([]*models.Header, error)
 	GetFriendsID(ctx context.Context, userID uint32) ([]uint32, error)
 	Create(ctx context.Context, user *models.User) (uint32, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByID(ctx context.Context, userID uint32) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID uint32, password string) error
+	VerifyEmail(ctx context.Context, userID uint32, email string) error
+	GetTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactor, error)
+	SaveTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error
+	DeleteTwoFactor(ctx context.Context, userID uint32) error
+	UseRecoveryCode(ctx context.Context, userID uint32, codeHash string) error
+	GetShortProfiles(ctx context.Context, selfID uint32, ids []uint32) ([]*models.ShortProfile, error)
+	Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error)
 }
 
 func GetHTTPServer(cfg *config.Config, metric *metrics.HttpMetrics) (*http.Server, error) {
@@ -67,8 +79,17 @@ func GetHTTPServer(cfg *config.Config, metric *metrics.HttpMetrics) (*http.Serve
 	}
 	pp := post.New(postProvider)
 
+	redisPool := &redis.Pool{
+		MaxIdle:   cfg.REDIS.MaxIdle,
+		MaxActive: cfg.REDIS.MaxActive,
+		Dial: func() (redis.Conn, error) {
+			addr := fmt.Sprintf("%s:%s", cfg.REDIS.Host, cfg.REDIS.Port)
+			return redis.Dial("tcp", addr)
+		},
+	}
+
 	repo := repository.NewProfileRepo(postgresDB)
-	profileService := service.NewProfileUsecase(repo, pp)
+	profileService := service.NewProfileUsecase(repo, pp, presenceRepository.NewPresenceRepository(redisPool), logger)
 	profileController := controller.NewProfileController(profileService, responder)
 
 	rout := profile.NewRouter(profileController, sm, logger, metric)
@@ -6,6 +6,13 @@ import (
 
 type SessionManager interface {
 	Check(string) (*models.Session, error)
-	Create(userID uint32) (*models.Session, error)
+	Create(user *models.User, device models.Device) (*models.Session, error)
+	Rotate(sess *models.Session) (*models.Session, error)
 	Destroy(sess *models.Session) error
+
+	ListSessions(sess *models.Session) ([]*models.SessionInfo, error)
+	RevokeSession(sess *models.Session, publicID string) error
+	RevokeOtherSessions(sess *models.Session) error
+	DestroyUserSessions(userID uint32) error
+	MarkVerified(userID uint32) error
 }
@@ -7,5 +7,8 @@ import (
 type SessionRepository interface {
 	CreateSession(*models.Session) error
 	FindSession(sessID string) (*models.Session, error)
+	VerifySession(sessID string) error
+	TouchSession(sessID string, lastSeen int64) error
+	GetUserSessions(userID uint32) ([]*models.Session, error)
 	DestroySession(sessID string) error
 }
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/2024_2_BetterCallFirewall/internal/auth"
@@ -18,7 +21,17 @@ import (
 
 type AuthService interface {
 	Register(user models.User, ctx context.Context) (uint32, error)
-	Auth(user models.User, ctx context.Context) (uint32, error)
+	Auth(user models.User, ctx context.Context) (*models.User, error)
+	ChangePassword(ctx context.Context, userID uint32, change models.PasswordChange) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, reset models.PasswordReset) (uint32, error)
+	ResendVerification(ctx context.Context, userID uint32) error
+	VerifyEmail(ctx context.Context, token string) (uint32, error)
+	EnrollTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactorSetup, error)
+	ConfirmTwoFactor(ctx context.Context, userID uint32, code string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, userID uint32, code string) error
+	BeginLogin(ctx context.Context, user *models.User) (string, error)
+	CompleteLogin(ctx context.Context, login models.TwoFactorLogin) (*models.User, error)
 }
 
 type Responder interface {
@@ -26,20 +39,37 @@ type Responder interface {
 
 	ErrorBadRequest(w http.ResponseWriter, err error, requestID string)
 	ErrorInternal(w http.ResponseWriter, err error, requestID string)
+	ErrorTooManyRequests(w http.ResponseWriter, err error, retryAfter time.Duration, requestID string)
 	LogError(err error, requestID string)
 }
 
+// Limiter throttles the logins, the registrations and the letters to reset the password, the durations are how long the client has to wait
+type Limiter interface {
+	AllowLogin(ctx context.Context, ip, email string) (time.Duration, error)
+	AllowRegister(ctx context.Context, ip, email string) (time.Duration, error)
+	AllowPasswordReset(ctx context.Context, ip, email string) (time.Duration, error)
+	LoginFailed(ctx context.Context, ip, email string) (time.Duration, error)
+	LoginSucceeded(ctx context.Context, email string) error
+}
+
 type AuthController struct {
 	responder      Responder
 	serviceAuth    AuthService
 	SessionManager auth.SessionManager
+	limiter        Limiter
+	proxies        Proxies
 }
 
-func NewAuthController(responder Responder, serviceAuth AuthService, sessionManager auth.SessionManager) *AuthController {
+func NewAuthController(
+	responder Responder, serviceAuth AuthService, sessionManager auth.SessionManager, limiter Limiter,
+	proxies Proxies,
+) *AuthController {
 	return &AuthController{
 		responder:      responder,
 		serviceAuth:    serviceAuth,
 		SessionManager: sessionManager,
+		limiter:        limiter,
+		proxies:        proxies,
 	}
 }
 
@@ -56,7 +86,16 @@ func (c *AuthController) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	left, err := c.limiter.AllowRegister(r.Context(), c.deviceFromRequest(r).IP, user.Email)
+	if !c.allowed(w, left, err, "router register", reqID) {
+		return
+	}
+
 	user.ID, err = c.serviceAuth.Register(user, r.Context())
+	if errors.Is(err, my_err.ErrVerifyNotSent) {
+		c.responder.LogError(fmt.Errorf("router register: %w", err), reqID)
+		err = nil
+	}
 	if errors.Is(err, my_err.ErrUserAlreadyExists) || errors.Is(err, my_err.ErrNonValidEmail) || errors.Is(err, bcrypt.ErrPasswordTooLong) {
 		c.responder.ErrorBadRequest(w, err, reqID)
 		return
@@ -67,7 +106,7 @@ func (c *AuthController) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sess, err := c.SessionManager.Create(user.ID)
+	sess, err := c.SessionManager.Create(&user, c.deviceFromRequest(r))
 	if err != nil {
 		c.responder.ErrorInternal(w, fmt.Errorf("router register: %w", err), reqID)
 		return
@@ -99,7 +138,16 @@ func (c *AuthController) Auth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := c.serviceAuth.Auth(user, r.Context())
+	ip := c.deviceFromRequest(r).IP
+	left, err := c.limiter.AllowLogin(r.Context(), ip, user.Email)
+	if !c.allowed(w, left, err, "router auth", reqID) {
+		return
+	}
+
+	dbUser, err := c.serviceAuth.Auth(user, r.Context())
+	if errors.Is(err, my_err.ErrWrongEmailOrPassword) {
+		c.loginFailed(r, ip, user.Email, reqID)
+	}
 
 	if errors.Is(err, my_err.ErrWrongEmailOrPassword) || errors.Is(err, my_err.ErrNonValidEmail) {
 		c.responder.ErrorBadRequest(w, fmt.Errorf("router auth: %w", err), reqID)
@@ -110,12 +158,96 @@ func (c *AuthController) Auth(w http.ResponseWriter, r *http.Request) {
 		c.responder.ErrorInternal(w, fmt.Errorf("router auth: %w", err), reqID)
 		return
 	}
+	if err := c.limiter.LoginSucceeded(r.Context(), user.Email); err != nil {
+		c.responder.LogError(fmt.Errorf("router auth: %w", err), reqID)
+	}
 
-	sess, err := c.SessionManager.Create(id)
+	token, err := c.serviceAuth.BeginLogin(r.Context(), dbUser)
 	if err != nil {
 		c.responder.ErrorInternal(w, fmt.Errorf("router auth: %w", err), reqID)
 		return
 	}
+	// the session is not created until the second factor is checked
+	if token != "" {
+		c.responder.OutputJSON(w, models.TwoFactorChallenge{Required: true, Token: token}, reqID)
+		return
+	}
+
+	if err := c.startSession(w, r, dbUser); err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router auth: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, "user auth", reqID)
+}
+
+// LoginTwoFactor finishes the login of the user with the second factor by the code
+func (c *AuthController) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	login := models.TwoFactorLogin{}
+	if err := json.NewDecoder(r.Body).Decode(&login); err != nil {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router login two factor: %w", err), reqID)
+		return
+	}
+
+	ip := c.deviceFromRequest(r).IP
+	left, err := c.limiter.AllowLogin(r.Context(), ip, "")
+	if !c.allowed(w, left, err, "router login two factor", reqID) {
+		return
+	}
+
+	user, err := c.serviceAuth.CompleteLogin(r.Context(), login)
+	if errors.Is(err, my_err.ErrWrongTwoFactorCode) {
+		c.loginFailed(r, ip, "", reqID)
+	}
+	if errors.Is(err, my_err.ErrInvalidLoginToken) || errors.Is(err, my_err.ErrWrongTwoFactorCode) {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router login two factor: %w", err), reqID)
+		return
+	}
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router login two factor: %w", err), reqID)
+		return
+	}
+
+	if err := c.startSession(w, r, user); err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router login two factor: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, "user auth", reqID)
+}
+
+// allowed responds with 429 and returns false if the request has to wait or with 500 if the limits are not checked
+func (c *AuthController) allowed(w http.ResponseWriter, left time.Duration, err error, op, reqID string) bool {
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("%s: %w", op, err), reqID)
+		return false
+	}
+	if left > 0 {
+		c.responder.ErrorTooManyRequests(w, fmt.Errorf("%s: %w", op, my_err.ErrTooManyRequests), left, reqID)
+		return false
+	}
+
+	return true
+}
+
+// loginFailed counts the wrong password or code, the response does not depend on it,
+// the lockout is applied to the next login
+func (c *AuthController) loginFailed(r *http.Request, ip, email, reqID string) {
+	if _, err := c.limiter.LoginFailed(r.Context(), ip, email); err != nil {
+		c.responder.LogError(fmt.Errorf("count failed login: %w", err), reqID)
+	}
+}
+
+func (c *AuthController) startSession(w http.ResponseWriter, r *http.Request, user *models.User) error {
+	sess, err := c.SessionManager.Create(user, c.deviceFromRequest(r))
+	if err != nil {
+		return err
+	}
 	cookie := &http.Cookie{
 		Name:     "session_id",
 		Value:    sess.ID,
@@ -125,7 +257,7 @@ func (c *AuthController) Auth(w http.ResponseWriter, r *http.Request) {
 	}
 	http.SetCookie(w, cookie)
 
-	c.responder.OutputJSON(w, "user auth", reqID)
+	return nil
 }
 
 func (c *AuthController) Logout(w http.ResponseWriter, r *http.Request) {
@@ -163,3 +295,401 @@ func (c *AuthController) Logout(w http.ResponseWriter, r *http.Request) {
 
 	c.responder.OutputJSON(w, "user logout", reqID)
 }
+
+// ListSessions shows the sessions of the current user on all the devices
+func (c *AuthController) ListSessions(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := c.currentSession(r)
+	if err != nil {
+		c.responder.ErrorBadRequest(w, my_err.ErrNoAuth, reqID)
+		return
+	}
+
+	sessions, err := c.SessionManager.ListSessions(sess)
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router list sessions: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, sessions, reqID)
+}
+
+// RevokeSession logs the current user out on the device of the session with the id from the path
+func (c *AuthController) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := c.currentSession(r)
+	if err != nil {
+		c.responder.ErrorBadRequest(w, my_err.ErrNoAuth, reqID)
+		return
+	}
+
+	err = c.SessionManager.RevokeSession(sess, mux.Vars(r)["id"])
+	if errors.Is(err, my_err.ErrSessionNotFound) {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router revoke session: %w", err), reqID)
+		return
+	}
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router revoke session: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, "session revoked", reqID)
+}
+
+// RevokeOtherSessions logs the current user out everywhere but the current session
+func (c *AuthController) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := c.currentSession(r)
+	if err != nil {
+		c.responder.ErrorBadRequest(w, my_err.ErrNoAuth, reqID)
+		return
+	}
+
+	if err := c.SessionManager.RevokeOtherSessions(sess); err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router revoke other sessions: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, "other sessions revoked", reqID)
+}
+
+// ChangePassword sets the new password of the current user and logs the user out on the other devices
+func (c *AuthController) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := c.currentSession(r)
+	if err != nil {
+		c.responder.ErrorBadRequest(w, my_err.ErrNoAuth, reqID)
+		return
+	}
+
+	change := models.PasswordChange{}
+	if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router change password: %w", err), reqID)
+		return
+	}
+
+	err = c.serviceAuth.ChangePassword(r.Context(), sess.UserID, change)
+	if errors.Is(err, my_err.ErrWrongPassword) || errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router change password: %w", err), reqID)
+		return
+	}
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router change password: %w", err), reqID)
+		return
+	}
+
+	if err := c.SessionManager.RevokeOtherSessions(sess); err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router change password: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, "password changed", reqID)
+}
+
+// ForgotPassword sends the link to reset the password to the email from the body
+func (c *AuthController) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	request := models.PasswordResetRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router forgot password: %w", err), reqID)
+		return
+	}
+
+	left, err := c.limiter.AllowPasswordReset(r.Context(), c.deviceFromRequest(r).IP, request.Email)
+	if !c.allowed(w, left, err, "router forgot password", reqID) {
+		return
+	}
+
+	err = c.serviceAuth.RequestPasswordReset(r.Context(), request.Email)
+	if errors.Is(err, my_err.ErrNonValidEmail) {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router forgot password: %w", err), reqID)
+		return
+	}
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router forgot password: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, "reset link sent", reqID)
+}
+
+// ResetPassword sets the new password by the token from the letter and logs the user out on all the devices
+func (c *AuthController) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	reset := models.PasswordReset{}
+	if err := json.NewDecoder(r.Body).Decode(&reset); err != nil {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router reset password: %w", err), reqID)
+		return
+	}
+
+	userID, err := c.serviceAuth.ResetPassword(r.Context(), reset)
+	if errors.Is(err, my_err.ErrInvalidResetToken) || errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router reset password: %w", err), reqID)
+		return
+	}
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router reset password: %w", err), reqID)
+		return
+	}
+
+	if err := c.SessionManager.DestroyUserSessions(userID); err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router reset password: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, "password reset", reqID)
+}
+
+// VerifyEmail verifies the email by the token from the letter
+func (c *AuthController) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	verification := models.EmailVerification{}
+	if err := json.NewDecoder(r.Body).Decode(&verification); err != nil {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router verify email: %w", err), reqID)
+		return
+	}
+
+	userID, err := c.serviceAuth.VerifyEmail(r.Context(), verification.Token)
+	if errors.Is(err, my_err.ErrInvalidVerifyToken) {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router verify email: %w", err), reqID)
+		return
+	}
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router verify email: %w", err), reqID)
+		return
+	}
+
+	if err := c.SessionManager.MarkVerified(userID); err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router verify email: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, "email verified", reqID)
+}
+
+// ResendVerification sends the letter about the email verification to the current user once again
+func (c *AuthController) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := c.currentSession(r)
+	if err != nil {
+		c.responder.ErrorBadRequest(w, my_err.ErrNoAuth, reqID)
+		return
+	}
+
+	err = c.serviceAuth.ResendVerification(r.Context(), sess.UserID)
+	if errors.Is(err, my_err.ErrEmailAlreadyVerified) || errors.Is(err, my_err.ErrResendCooldown) {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router resend verification: %w", err), reqID)
+		return
+	}
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router resend verification: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, "verification letter sent", reqID)
+}
+
+// EnrollTwoFactor makes the secret of the second factor for the current user, it is enabled by ConfirmTwoFactor
+func (c *AuthController) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := c.currentSession(r)
+	if err != nil {
+		c.responder.ErrorBadRequest(w, my_err.ErrNoAuth, reqID)
+		return
+	}
+
+	setup, err := c.serviceAuth.EnrollTwoFactor(r.Context(), sess.UserID)
+	if errors.Is(err, my_err.ErrTwoFactorEnabled) {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router enroll two factor: %w", err), reqID)
+		return
+	}
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router enroll two factor: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, setup, reqID)
+}
+
+// ConfirmTwoFactor enables the second factor of the current user and returns the recovery codes
+func (c *AuthController) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := c.currentSession(r)
+	if err != nil {
+		c.responder.ErrorBadRequest(w, my_err.ErrNoAuth, reqID)
+		return
+	}
+
+	code := models.TwoFactorCode{}
+	if err := json.NewDecoder(r.Body).Decode(&code); err != nil {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router confirm two factor: %w", err), reqID)
+		return
+	}
+
+	recoveryCodes, err := c.serviceAuth.ConfirmTwoFactor(r.Context(), sess.UserID, code.Code)
+	if errors.Is(err, my_err.ErrTwoFactorNotFound) || errors.Is(err, my_err.ErrTwoFactorEnabled) ||
+		errors.Is(err, my_err.ErrWrongTwoFactorCode) {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router confirm two factor: %w", err), reqID)
+		return
+	}
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router confirm two factor: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, recoveryCodes, reqID)
+}
+
+// DisableTwoFactor turns the second factor of the current user off by the code or a recovery code
+func (c *AuthController) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		c.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := c.currentSession(r)
+	if err != nil {
+		c.responder.ErrorBadRequest(w, my_err.ErrNoAuth, reqID)
+		return
+	}
+
+	code := models.TwoFactorCode{}
+	if err := json.NewDecoder(r.Body).Decode(&code); err != nil {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router disable two factor: %w", err), reqID)
+		return
+	}
+
+	err = c.serviceAuth.DisableTwoFactor(r.Context(), sess.UserID, code.Code)
+	if errors.Is(err, my_err.ErrTwoFactorNotFound) || errors.Is(err, my_err.ErrWrongTwoFactorCode) {
+		c.responder.ErrorBadRequest(w, fmt.Errorf("router disable two factor: %w", err), reqID)
+		return
+	}
+	if err != nil {
+		c.responder.ErrorInternal(w, fmt.Errorf("router disable two factor: %w", err), reqID)
+		return
+	}
+
+	c.responder.OutputJSON(w, "two factor disabled", reqID)
+}
+
+func (c *AuthController) currentSession(r *http.Request) (*models.Session, error) {
+	sessionCookie, err := r.Cookie("session_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SessionManager.Check(sessionCookie.Value)
+}
+
+// Proxies are the networks of the trusted proxies, only they can tell the address of the client in the headers
+type Proxies []*net.IPNet
+
+// NewProxies parses the addresses and the networks of the trusted proxies
+func NewProxies(addrs []string) (Proxies, error) {
+	proxies := make(Proxies, 0, len(addrs))
+	for _, addr := range addrs {
+		if !strings.Contains(addr, "/") {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				return nil, fmt.Errorf("wrong address of the proxy: %s", addr)
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(addr)
+		if err != nil {
+			return nil, fmt.Errorf("wrong network of the proxy: %w", err)
+		}
+		proxies = append(proxies, network)
+	}
+
+	return proxies, nil
+}
+
+func (p Proxies) trusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clientIP returns the address the request comes from. If it is a trusted proxy, the client is the rightmost hop
+// of X-Forwarded-For which is not a trusted proxy, the hops to the left of it can be forged by the client.
+// X-Real-IP of the proxy is taken if there are no such hops
+func (p Proxies) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !p.trusted(host) {
+		return host
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop != "" && !p.trusted(hop) {
+			return hop
+		}
+	}
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); ip != "" {
+		return ip
+	}
+
+	return host
+}
+
+// deviceFromRequest describes the client
+func (c *AuthController) deviceFromRequest(r *http.Request) models.Device {
+	return models.Device{
+		UserAgent: r.UserAgent(),
+		IP:        c.proxies.clientIP(r),
+	}
+}
@@ -5,10 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
 	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
@@ -18,6 +23,9 @@ var (
 	mockErrorInternal = errors.New("mock internal error")
 )
 
+// badCookie is the cookie of a session the mock session manager fails on
+const badCookie = "bad"
+
 type MockAuthService struct{}
 
 func (m MockAuthService) Register(user models.User, ctx context.Context) (uint32, error) {
@@ -29,37 +37,194 @@ func (m MockAuthService) Register(user models.User, ctx context.Context) (uint32
 		return user.ID, mockErrorInternal
 	}
 
+	if user.ID == 4 {
+		return user.ID, fmt.Errorf("%w: %w", my_err.ErrVerifyNotSent, mockErrorInternal)
+	}
+
 	return user.ID, nil
 }
 
-func (m MockAuthService) Auth(user models.User, ctx context.Context) (uint32, error) {
+func (m MockAuthService) Auth(user models.User, ctx context.Context) (*models.User, error) {
 	if user.ID == 1 {
-		return user.ID, my_err.ErrWrongEmailOrPassword
+		return nil, my_err.ErrWrongEmailOrPassword
 	}
 
 	if user.ID == 0 {
-		return user.ID, mockErrorInternal
+		return nil, mockErrorInternal
 	}
 
-	return user.ID, nil
+	return &user, nil
+}
+
+func (m MockAuthService) ChangePassword(ctx context.Context, userID uint32, change models.PasswordChange) error {
+	switch change.OldPassword {
+	case "wrong":
+		return my_err.ErrWrongPassword
+	case "fail":
+		return mockErrorInternal
+	}
+	return nil
+}
+
+func (m MockAuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	switch email {
+	case "email":
+		return my_err.ErrNonValidEmail
+	case "fail@mail.ru":
+		return mockErrorInternal
+	}
+	return nil
+}
+
+func (m MockAuthService) ResetPassword(ctx context.Context, reset models.PasswordReset) (uint32, error) {
+	switch reset.Token {
+	case "used":
+		return 0, my_err.ErrInvalidResetToken
+	case "fail":
+		return 0, mockErrorInternal
+	case "other":
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func (m MockAuthService) ResendVerification(ctx context.Context, userID uint32) error {
+	switch userID {
+	case 0:
+		return mockErrorInternal
+	case 1:
+		return my_err.ErrEmailAlreadyVerified
+	case 2:
+		return my_err.ErrResendCooldown
+	}
+	return nil
+}
+
+func (m MockAuthService) VerifyEmail(ctx context.Context, token string) (uint32, error) {
+	switch token {
+	case "bad":
+		return 0, my_err.ErrInvalidVerifyToken
+	case "fail":
+		return 0, mockErrorInternal
+	case "other":
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func (m MockAuthService) EnrollTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactorSetup, error) {
+	switch userID {
+	case 0:
+		return nil, mockErrorInternal
+	case 1:
+		return nil, my_err.ErrTwoFactorEnabled
+	}
+	return &models.TwoFactorSetup{Secret: "SECRET", URI: "otpauth://totp/Vilka"}, nil
+}
+
+func (m MockAuthService) ConfirmTwoFactor(ctx context.Context, userID uint32, code string) ([]string, error) {
+	switch code {
+	case "wrong":
+		return nil, my_err.ErrWrongTwoFactorCode
+	case "fail":
+		return nil, mockErrorInternal
+	}
+	return []string{"recovery"}, nil
+}
+
+func (m MockAuthService) DisableTwoFactor(ctx context.Context, userID uint32, code string) error {
+	switch code {
+	case "wrong":
+		return my_err.ErrWrongTwoFactorCode
+	case "fail":
+		return mockErrorInternal
+	}
+	return nil
+}
+
+func (m MockAuthService) BeginLogin(ctx context.Context, user *models.User) (string, error) {
+	switch user.ID {
+	case 4:
+		return "token", nil
+	case 5:
+		return "", mockErrorInternal
+	}
+	return "", nil
+}
+
+func (m MockAuthService) CompleteLogin(ctx context.Context, login models.TwoFactorLogin) (*models.User, error) {
+	switch {
+	case login.Token == "bad" || login.Code == "wrong":
+		return nil, my_err.ErrWrongTwoFactorCode
+	case login.Code == "fail":
+		return nil, mockErrorInternal
+	case login.Token == "no session":
+		return &models.User{ID: 2}, nil
+	}
+	return &models.User{ID: 10}, nil
 }
 
 type MockSessionManager struct{}
 
 func (m MockSessionManager) Check(str string) (*models.Session, error) {
+	if str == badCookie {
+		return models.NewSession(0)
+	}
 	if len(str) > 0 {
 		return models.NewSession(10)
 	}
 	return nil, mockErrorInternal
 }
 
-func (m MockSessionManager) Create(userID uint32) (*models.Session, error) {
-	if userID == 2 {
+func (m MockSessionManager) Create(user *models.User, _ models.Device) (*models.Session, error) {
+	if user.ID == 2 {
 		return nil, mockErrorInternal
 	}
 	return models.NewSession(10)
 }
 
+func (m MockSessionManager) Rotate(sess *models.Session) (*models.Session, error) {
+	return models.NewSession(sess.UserID)
+}
+
+func (m MockSessionManager) ListSessions(sess *models.Session) ([]*models.SessionInfo, error) {
+	if sess.UserID == 0 {
+		return nil, mockErrorInternal
+	}
+	return []*models.SessionInfo{sess.Info(sess.ID)}, nil
+}
+
+func (m MockSessionManager) RevokeSession(sess *models.Session, publicID string) error {
+	if sess.UserID == 0 {
+		return mockErrorInternal
+	}
+	if publicID != "known" {
+		return my_err.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (m MockSessionManager) RevokeOtherSessions(sess *models.Session) error {
+	if sess.UserID == 0 {
+		return mockErrorInternal
+	}
+	return nil
+}
+
+func (m MockSessionManager) DestroyUserSessions(userID uint32) error {
+	if userID == 0 {
+		return mockErrorInternal
+	}
+	return nil
+}
+
+func (m MockSessionManager) MarkVerified(userID uint32) error {
+	if userID == 0 {
+		return mockErrorInternal
+	}
+	return nil
+}
+
 func (m MockSessionManager) Destroy(sess *models.Session) error {
 	if sess == nil {
 		return nil
@@ -99,8 +264,51 @@ func (r *MockResponder) ErrorForbidden(w http.ResponseWriter, _ error) {
 
 }
 
+func (r *MockResponder) ErrorTooManyRequests(w http.ResponseWriter, _ error, _ time.Duration, _ string) {
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = w.Write([]byte("too many requests error"))
+}
+
 func (r *MockResponder) LogError(err error, requestID string) {}
 
+// MockLimiter throttles the email or the address "limited" and fails on the email "fail"
+type MockLimiter struct {
+	failed *int
+}
+
+func (m MockLimiter) allow(ip, email string) (time.Duration, error) {
+	switch {
+	case ip == "limited" || email == "limited":
+		return time.Minute, nil
+	case email == "fail":
+		return 0, mockErrorInternal
+	}
+	return 0, nil
+}
+
+func (m MockLimiter) AllowLogin(ctx context.Context, ip, email string) (time.Duration, error) {
+	return m.allow(ip, email)
+}
+
+func (m MockLimiter) AllowRegister(ctx context.Context, ip, email string) (time.Duration, error) {
+	return m.allow(ip, email)
+}
+
+func (m MockLimiter) AllowPasswordReset(ctx context.Context, ip, email string) (time.Duration, error) {
+	return m.allow(ip, email)
+}
+
+func (m MockLimiter) LoginFailed(ctx context.Context, ip, email string) (time.Duration, error) {
+	if m.failed != nil {
+		*m.failed++
+	}
+	return 0, nil
+}
+
+func (m MockLimiter) LoginSucceeded(ctx context.Context, email string) error {
+	return nil
+}
+
 type TestCase struct {
 	w        *httptest.ResponseRecorder
 	r        *http.Request
@@ -109,11 +317,12 @@ type TestCase struct {
 }
 
 func TestRegister(t *testing.T) {
-	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{})
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{}, nil)
 	jsonUser0, _ := json.Marshal(models.User{ID: 0})
 	jsonUser1, _ := json.Marshal(models.User{ID: 1})
 	jsonUser2, _ := json.Marshal(models.User{ID: 2})
 	jsonUser3, _ := json.Marshal(models.User{ID: 3})
+	jsonUser4, _ := json.Marshal(models.User{ID: 4})
 
 	testCases := []TestCase{
 		{
@@ -146,6 +355,24 @@ func TestRegister(t *testing.T) {
 			wantCode: http.StatusOK,
 			wantBody: `"user create successful"`,
 		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        httptest.NewRequest(http.MethodPost, "/", bytes.NewBuffer(jsonUser4)),
+			wantCode: http.StatusOK,
+			wantBody: `"user create successful"`,
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"email":"limited"}`)),
+			wantCode: http.StatusTooManyRequests,
+			wantBody: "too many requests error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"email":"fail"}`)),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
 	}
 
 	for _, tt := range testCases {
@@ -160,11 +387,14 @@ func TestRegister(t *testing.T) {
 }
 
 func TestAuth(t *testing.T) {
-	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{})
+	failed := 0
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{failed: &failed}, nil)
 	jsonUser0, _ := json.Marshal(models.User{ID: 0})
 	jsonUser1, _ := json.Marshal(models.User{ID: 1})
 	jsonUser2, _ := json.Marshal(models.User{ID: 2})
 	jsonUser3, _ := json.Marshal(models.User{ID: 3})
+	jsonUser4, _ := json.Marshal(models.User{ID: 4})
+	jsonUser5, _ := json.Marshal(models.User{ID: 5})
 
 	testCases := []TestCase{
 		{
@@ -203,6 +433,30 @@ func TestAuth(t *testing.T) {
 			wantCode: http.StatusOK,
 			wantBody: `"user auth"`,
 		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(jsonUser4)),
+			wantCode: http.StatusOK,
+			wantBody: `{"two_factor_required":true,"token":"token"}`,
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(jsonUser5)),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"id":3,"email":"limited"}`)),
+			wantCode: http.StatusTooManyRequests,
+			wantBody: "too many requests error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"id":3,"email":"fail"}`)),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
 	}
 
 	for _, tt := range testCases {
@@ -214,6 +468,10 @@ func TestAuth(t *testing.T) {
 			t.Errorf("Auth() body = %s, want %s", tt.w.Body.String(), tt.wantBody)
 		}
 	}
+	// only the wrong password is counted for the lockout
+	if failed != 1 {
+		t.Errorf("Auth() counted %d failed logins, want 1", failed)
+	}
 }
 
 var (
@@ -227,7 +485,7 @@ var (
 )
 
 func TestLogout(t *testing.T) {
-	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{})
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{}, nil)
 
 	testCases := []TestCase{
 		{
@@ -261,3 +519,666 @@ func TestLogout(t *testing.T) {
 		}
 	}
 }
+
+func requestWithCookie(method, cookie string, vars map[string]string) *http.Request {
+	r := httptest.NewRequest(method, "/api/v1/auth/sessions", nil)
+	if cookie != "" {
+		r.AddCookie(&http.Cookie{Name: "session_id", Value: cookie})
+	}
+	return mux.SetURLVars(r, vars)
+}
+
+func TestListSessions(t *testing.T) {
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{}, nil)
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithCookie(http.MethodGet, "", nil),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithCookie(http.MethodGet, badCookie, nil),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithCookie(http.MethodGet, "cookie", nil),
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.ListSessions(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] ListSessions() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if tt.wantBody != "" && strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] ListSessions() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+
+	var sessions []*models.SessionInfo
+	if err := json.NewDecoder(testCases[2].w.Body).Decode(&sessions); err != nil {
+		t.Fatalf("ListSessions() body: %v", err)
+	}
+	if len(sessions) != 1 || !sessions[0].Current {
+		t.Errorf("ListSessions() sessions = %v, want the current session", sessions)
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{}, nil)
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithCookie(http.MethodDelete, "", map[string]string{"id": "known"}),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithCookie(http.MethodDelete, "cookie", map[string]string{"id": "unknown"}),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithCookie(http.MethodDelete, badCookie, map[string]string{"id": "known"}),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithCookie(http.MethodDelete, "cookie", map[string]string{"id": "known"}),
+			wantCode: http.StatusOK,
+			wantBody: `"session revoked"`,
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.RevokeSession(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] RevokeSession() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] RevokeSession() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+}
+
+func TestRevokeOtherSessions(t *testing.T) {
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{}, nil)
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithCookie(http.MethodDelete, "", nil),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithCookie(http.MethodDelete, badCookie, nil),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithCookie(http.MethodDelete, "cookie", nil),
+			wantCode: http.StatusOK,
+			wantBody: `"other sessions revoked"`,
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.RevokeOtherSessions(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] RevokeOtherSessions() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] RevokeOtherSessions() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+}
+
+func TestDeviceFromRequest(t *testing.T) {
+	proxies, err := NewProxies([]string{"10.0.0.1", "172.16.0.0/12"})
+	if err != nil {
+		t.Fatalf("NewProxies() error = %v", err)
+	}
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{}, proxies)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		realIP     string
+		forwarded  []string
+		want       string
+	}{
+		{name: "direct", remoteAddr: "192.0.2.1:1234", want: "192.0.2.1"},
+		{
+			name: "headers of untrusted", remoteAddr: "192.0.2.1:1234", realIP: "1.1.1.1",
+			forwarded: []string{"2.2.2.2"}, want: "192.0.2.1",
+		},
+		{name: "real ip of proxy", remoteAddr: "10.0.0.1:1234", realIP: "1.1.1.1", want: "1.1.1.1"},
+		{
+			name: "rightmost untrusted hop", remoteAddr: "10.0.0.1:1234", realIP: "3.3.3.3",
+			forwarded: []string{"6.6.6.6, 1.1.1.1", "2.2.2.2, 172.16.0.5"}, want: "2.2.2.2",
+		},
+		{name: "only proxies", remoteAddr: "172.16.0.2:1234", forwarded: []string{"10.0.0.1"}, want: "172.16.0.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			r.Header.Set("User-Agent", "Firefox")
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+
+			if got := controller.deviceFromRequest(r); got != (models.Device{UserAgent: "Firefox", IP: tt.want}) {
+				t.Errorf("deviceFromRequest() = %v, want ip %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewProxies(t *testing.T) {
+	if _, err := NewProxies(nil); err != nil {
+		t.Errorf("NewProxies() error = %v", err)
+	}
+	if _, err := NewProxies([]string{"::1", "fd00::/8"}); err != nil {
+		t.Errorf("NewProxies() error = %v", err)
+	}
+	for _, addr := range []string{"proxy", "10.0.0.0/33"} {
+		if _, err := NewProxies([]string{addr}); err == nil {
+			t.Errorf("NewProxies(%s) expected error", addr)
+		}
+	}
+}
+
+func requestWithBody(cookie string, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/auth/password", strings.NewReader(body))
+	if cookie != "" {
+		r.AddCookie(&http.Cookie{Name: "session_id", Value: cookie})
+	}
+	return r
+}
+
+func TestChangePassword(t *testing.T) {
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{}, nil)
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"old_password":"password","new_password":"new"}`),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("cookie", "wrong json"),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("cookie", `{"old_password":"wrong","new_password":"new"}`),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("cookie", `{"old_password":"fail","new_password":"new"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody(badCookie, `{"old_password":"password","new_password":"new"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("cookie", `{"old_password":"password","new_password":"new"}`),
+			wantCode: http.StatusOK,
+			wantBody: `"password changed"`,
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.ChangePassword(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] ChangePassword() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] ChangePassword() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+}
+
+func TestForgotPassword(t *testing.T) {
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{}, nil)
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", "wrong json"),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"email":"email"}`),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"email":"fail@mail.ru"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"email":"email@mail.ru"}`),
+			wantCode: http.StatusOK,
+			wantBody: `"reset link sent"`,
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"email":"limited"}`),
+			wantCode: http.StatusTooManyRequests,
+			wantBody: "too many requests error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"email":"fail"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.ForgotPassword(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] ForgotPassword() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] ForgotPassword() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{}, nil)
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", "wrong json"),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"used","password":"new"}`),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"fail","password":"new"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"other","password":"new"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"token","password":"new"}`),
+			wantCode: http.StatusOK,
+			wantBody: `"password reset"`,
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.ResetPassword(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] ResetPassword() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] ResetPassword() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{}, nil)
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", "wrong json"),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"bad"}`),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"fail"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"other"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"token"}`),
+			wantCode: http.StatusOK,
+			wantBody: `"email verified"`,
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.VerifyEmail(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] VerifyEmail() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] VerifyEmail() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+}
+
+// verifiedSessions is the session manager with the sessions of the users from the cookies
+type verifiedSessions struct {
+	MockSessionManager
+}
+
+func (m verifiedSessions) Check(str string) (*models.Session, error) {
+	id, err := strconv.Atoi(str)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewSession(uint32(id))
+}
+
+func TestResendVerification(t *testing.T) {
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, verifiedSessions{}, MockLimiter{}, nil)
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", ""),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("1", ""),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("2", ""),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("0", ""),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("10", ""),
+			wantCode: http.StatusOK,
+			wantBody: `"verification letter sent"`,
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.ResendVerification(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] ResendVerification() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] ResendVerification() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+}
+
+func TestLoginTwoFactor(t *testing.T) {
+	failed := 0
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, MockSessionManager{}, MockLimiter{failed: &failed}, nil)
+	limited := requestWithBody("", `{"token":"token","code":"123456"}`)
+	limited.RemoteAddr = "limited:1234"
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", "wrong json"),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"token","code":"wrong"}`),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"token","code":"fail"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"no session","code":"123456"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        limited,
+			wantCode: http.StatusTooManyRequests,
+			wantBody: "too many requests error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"token":"token","code":"123456"}`),
+			wantCode: http.StatusOK,
+			wantBody: `"user auth"`,
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.LoginTwoFactor(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] LoginTwoFactor() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] LoginTwoFactor() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+	if len(testCases[len(testCases)-1].w.Result().Cookies()) == 0 {
+		t.Errorf("LoginTwoFactor() has not set the session cookie")
+	}
+	if failed != 1 {
+		t.Errorf("LoginTwoFactor() counted %d failed logins, want 1", failed)
+	}
+}
+
+func TestEnrollTwoFactor(t *testing.T) {
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, verifiedSessions{}, MockLimiter{}, nil)
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", ""),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("1", ""),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("0", ""),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("10", ""),
+			wantCode: http.StatusOK,
+			wantBody: `{"secret":"SECRET","uri":"otpauth://totp/Vilka"}`,
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.EnrollTwoFactor(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] EnrollTwoFactor() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] EnrollTwoFactor() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+}
+
+func TestConfirmTwoFactor(t *testing.T) {
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, verifiedSessions{}, MockLimiter{}, nil)
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"code":"123456"}`),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("10", "wrong json"),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("10", `{"code":"wrong"}`),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("10", `{"code":"fail"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("10", `{"code":"123456"}`),
+			wantCode: http.StatusOK,
+			wantBody: `["recovery"]`,
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.ConfirmTwoFactor(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] ConfirmTwoFactor() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] ConfirmTwoFactor() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	controller := NewAuthController(&MockResponder{}, MockAuthService{}, verifiedSessions{}, MockLimiter{}, nil)
+
+	testCases := []TestCase{
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("", `{"code":"123456"}`),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("10", "wrong json"),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("10", `{"code":"wrong"}`),
+			wantCode: http.StatusBadRequest,
+			wantBody: "bad request error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("10", `{"code":"fail"}`),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "internal error",
+		},
+		{
+			w:        httptest.NewRecorder(),
+			r:        requestWithBody("10", `{"code":"123456"}`),
+			wantCode: http.StatusOK,
+			wantBody: `"two factor disabled"`,
+		},
+	}
+
+	for caseNum, tt := range testCases {
+		controller.DisableTwoFactor(tt.w, tt.r)
+
+		if tt.w.Code != tt.wantCode {
+			t.Errorf("[%d] DisableTwoFactor() code = %d, want %d", caseNum, tt.w.Code, tt.wantCode)
+		}
+		if strings.TrimSpace(tt.w.Body.String()) != tt.wantBody {
+			t.Errorf("[%d] DisableTwoFactor() body = %s, want %s", caseNum, tt.w.Body.String(), tt.wantBody)
+		}
+	}
+}
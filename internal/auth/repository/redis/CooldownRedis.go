@@ -0,0 +1,50 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// CooldownRedisRepository keeps the actions from being repeated too often
+type CooldownRedisRepository struct {
+	db *redis.Pool
+}
+
+func NewCooldownRedisRepository(db *redis.Pool) *CooldownRedisRepository {
+	return &CooldownRedisRepository{
+		db: db,
+	}
+}
+
+// Start starts the cooldown of the key for d. If the key is cooling down already, it returns the time left
+func (r *CooldownRedisRepository) Start(ctx context.Context, key string, d time.Duration) (time.Duration, error) {
+	conn, err := r.db.GetContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("start cooldown: %w", err)
+	}
+	defer conn.Close()
+
+	mkey := "cooldown:" + key
+	_, err = redis.String(conn.Do("SET", mkey, 1, "PX", d.Milliseconds(), "NX"))
+	if err == nil {
+		return 0, nil
+	}
+	if !errors.Is(err, redis.ErrNil) {
+		return 0, fmt.Errorf("start cooldown: %w", err)
+	}
+
+	left, err := redis.Int64(conn.Do("PTTL", mkey))
+	if err != nil {
+		return 0, fmt.Errorf("start cooldown: %w", err)
+	}
+	// the key has just expired
+	if left <= 0 {
+		return 0, nil
+	}
+
+	return time.Duration(left) * time.Millisecond, nil
+}
@@ -0,0 +1,34 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCooldown(t *testing.T) {
+	pool, mr := getPool(t)
+	repo := NewCooldownRedisRepository(pool)
+	ctx := context.Background()
+
+	left, err := repo.Start(ctx, "verify:1", time.Minute)
+	require.NoError(t, err)
+	assert.Zero(t, left)
+
+	mr.FastForward(20 * time.Second)
+	left, err = repo.Start(ctx, "verify:1", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 40*time.Second, left)
+
+	left, err = repo.Start(ctx, "verify:2", time.Minute)
+	require.NoError(t, err)
+	assert.Zero(t, left)
+
+	mr.FastForward(40 * time.Second)
+	left, err = repo.Start(ctx, "verify:1", time.Minute)
+	require.NoError(t, err)
+	assert.Zero(t, left)
+}
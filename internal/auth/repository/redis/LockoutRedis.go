@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+// failure counts the failure in KEYS[1] and locks KEYS[2] out once there are ARGV[2] failures.
+// The lock is ARGV[3] ms for the first time and doubles with every failure up to ARGV[4] ms,
+// the failures are kept for ARGV[1] ms after the last one. It returns the ms of the lock if it is started
+var failure = redis.NewScript(2, `
+local failures = redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[1])
+local threshold = tonumber(ARGV[2])
+if failures < threshold then
+	return 0
+end
+local lock = math.min(tonumber(ARGV[3]) * 2 ^ (failures - threshold), tonumber(ARGV[4]))
+lock = math.floor(lock)
+redis.call('SET', KEYS[2], failures, 'PX', lock)
+return lock
+`)
+
+// LockoutRedisRepository locks the keys out after the repeated failures
+type LockoutRedisRepository struct {
+	db *redis.Pool
+}
+
+func NewLockoutRedisRepository(db *redis.Pool) *LockoutRedisRepository {
+	return &LockoutRedisRepository{
+		db: db,
+	}
+}
+
+func failuresKey(key string) string {
+	return "failures:" + key
+}
+
+func lockoutKey(key string) string {
+	return "lockout:" + key
+}
+
+// Locked returns the time left until the key is unlocked, it is zero if the key is not locked
+func (r *LockoutRedisRepository) Locked(ctx context.Context, key string) (time.Duration, error) {
+	conn, err := r.db.GetContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("check lockout: %w", err)
+	}
+	defer conn.Close()
+
+	left, err := redis.Int64(conn.Do("PTTL", lockoutKey(key)))
+	if err != nil {
+		return 0, fmt.Errorf("check lockout: %w", err)
+	}
+	// PTTL is negative for the key that does not exist
+	if left <= 0 {
+		return 0, nil
+	}
+
+	return time.Duration(left) * time.Millisecond, nil
+}
+
+// Fail counts the failure of the key and returns the time the key is locked for if the failure locks it
+func (r *LockoutRedisRepository) Fail(ctx context.Context, key string, lockout models.Lockout) (time.Duration, error) {
+	conn, err := r.db.GetContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("count failure: %w", err)
+	}
+	defer conn.Close()
+
+	lock, err := redis.Int64(failure.Do(
+		conn, failuresKey(key), lockoutKey(key),
+		lockout.Memory.Milliseconds(), lockout.Threshold, lockout.Base.Milliseconds(), lockout.Max.Milliseconds(),
+	))
+	if err != nil {
+		return 0, fmt.Errorf("count failure: %w", err)
+	}
+
+	return time.Duration(lock) * time.Millisecond, nil
+}
+
+// Reset forgets the failures of the key, the lock that has been started is kept
+func (r *LockoutRedisRepository) Reset(ctx context.Context, key string) error {
+	conn, err := r.db.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("reset failures: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Do("DEL", failuresKey(key)); err != nil {
+		return fmt.Errorf("reset failures: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+func TestLockout(t *testing.T) {
+	pool, mr := getPool(t)
+	repo := NewLockoutRedisRepository(pool)
+	ctx := context.Background()
+	lockout := models.Lockout{Threshold: 3, Base: time.Minute, Max: 3 * time.Minute, Memory: time.Hour}
+
+	for i := 1; i < lockout.Threshold; i++ {
+		lock, err := repo.Fail(ctx, "email:a@mail.ru", lockout)
+		require.NoError(t, err)
+		assert.Zero(t, lock)
+	}
+	left, err := repo.Locked(ctx, "email:a@mail.ru")
+	require.NoError(t, err)
+	assert.Zero(t, left)
+
+	// the lock doubles with every next failure up to the max
+	for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+		lock, err := repo.Fail(ctx, "email:a@mail.ru", lockout)
+		require.NoError(t, err)
+		assert.Equal(t, want, lock)
+	}
+	left, err = repo.Locked(ctx, "email:a@mail.ru")
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Minute, left)
+
+	left, err = repo.Locked(ctx, "email:b@mail.ru")
+	require.NoError(t, err)
+	assert.Zero(t, left)
+
+	// the success forgets the failures but keeps the lock
+	require.NoError(t, repo.Reset(ctx, "email:a@mail.ru"))
+	assert.False(t, mr.Exists("failures:email:a@mail.ru"))
+	left, err = repo.Locked(ctx, "email:a@mail.ru")
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Minute, left)
+
+	mr.FastForward(3 * time.Minute)
+	left, err = repo.Locked(ctx, "email:a@mail.ru")
+	require.NoError(t, err)
+	assert.Zero(t, left)
+	lock, err := repo.Fail(ctx, "email:a@mail.ru", lockout)
+	require.NoError(t, err)
+	assert.Zero(t, lock)
+
+	// the failures are forgotten after the memory
+	_, err = repo.Fail(ctx, "ip:1", lockout)
+	require.NoError(t, err)
+	mr.FastForward(time.Hour)
+	assert.False(t, mr.Exists("failures:ip:1"))
+}
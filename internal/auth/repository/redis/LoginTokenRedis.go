@@ -0,0 +1,122 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+// failLogin counts the wrong code for the token KEYS[1] and deletes the token after ARGV[1] wrong codes.
+// The count is not started for the token that has expired
+var failLogin = redis.NewScript(1, `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+local attempts = redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+if attempts >= tonumber(ARGV[1]) then
+	redis.call('DEL', KEYS[1])
+end
+return attempts
+`)
+
+// LoginTokenRedisRepository keeps the tokens of the logins waiting for the second factor.
+// The token is the key of the user id and the count of the wrong codes, it is stored hashed like the reset tokens
+type LoginTokenRedisRepository struct {
+	db *redis.Pool
+}
+
+func NewLoginTokenRedisRepository(db *redis.Pool) *LoginTokenRedisRepository {
+	return &LoginTokenRedisRepository{
+		db: db,
+	}
+}
+
+func loginTokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "login_2fa:" + hex.EncodeToString(sum[:])
+}
+
+// Save stores the token of the user for ttl
+func (r *LoginTokenRedisRepository) Save(ctx context.Context, token string, userID uint32, ttl time.Duration) error {
+	conn, err := r.db.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("save login token: %w", err)
+	}
+	defer conn.Close()
+
+	key := loginTokenKey(token)
+	if err := conn.Send("MULTI"); err != nil {
+		return fmt.Errorf("save login token: %w", err)
+	}
+	if err := conn.Send("HSET", key, "user", userID, "attempts", 0); err != nil {
+		return fmt.Errorf("save login token: %w", err)
+	}
+	if err := conn.Send("PEXPIRE", key, ttl.Milliseconds()); err != nil {
+		return fmt.Errorf("save login token: %w", err)
+	}
+	if _, err := conn.Do("EXEC"); err != nil {
+		return fmt.Errorf("save login token: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the user of the token
+func (r *LoginTokenRedisRepository) Get(ctx context.Context, token string) (uint32, error) {
+	conn, err := r.db.GetContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get login token: %w", err)
+	}
+	defer conn.Close()
+
+	userID, err := redis.Uint64(conn.Do("HGET", loginTokenKey(token), "user"))
+	if errors.Is(err, redis.ErrNil) {
+		return 0, fmt.Errorf("get login token: %w", my_err.ErrInvalidLoginToken)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get login token: %w", err)
+	}
+
+	return uint32(userID), nil
+}
+
+// Fail counts the wrong code for the token, the token is deleted after maxAttempts wrong codes
+func (r *LoginTokenRedisRepository) Fail(ctx context.Context, token string, maxAttempts int) error {
+	conn, err := r.db.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("fail login token: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := failLogin.Do(conn, loginTokenKey(token), maxAttempts); err != nil {
+		return fmt.Errorf("fail login token: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the token after the login, it fails if the token has been used already
+func (r *LoginTokenRedisRepository) Delete(ctx context.Context, token string) error {
+	conn, err := r.db.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("delete login token: %w", err)
+	}
+	defer conn.Close()
+
+	deleted, err := redis.Int(conn.Do("DEL", loginTokenKey(token)))
+	if err != nil {
+		return fmt.Errorf("delete login token: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("delete login token: %w", my_err.ErrInvalidLoginToken)
+	}
+
+	return nil
+}
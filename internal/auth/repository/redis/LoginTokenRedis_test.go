@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+func TestLoginToken(t *testing.T) {
+	pool, mr := getPool(t)
+	repo := NewLoginTokenRedisRepository(pool)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, "token", 5, time.Minute))
+	assert.False(t, mr.Exists("login_2fa:token"))
+	userID, err := repo.Get(ctx, "token")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(5), userID)
+
+	require.NoError(t, repo.Delete(ctx, "token"))
+	assert.ErrorIs(t, repo.Delete(ctx, "token"), my_err.ErrInvalidLoginToken)
+	_, err = repo.Get(ctx, "token")
+	assert.ErrorIs(t, err, my_err.ErrInvalidLoginToken)
+
+	require.NoError(t, repo.Save(ctx, "expiring", 5, time.Minute))
+	mr.FastForward(time.Minute)
+	_, err = repo.Get(ctx, "expiring")
+	assert.ErrorIs(t, err, my_err.ErrInvalidLoginToken)
+	// the wrong code for the expired token does not bring it back
+	require.NoError(t, repo.Fail(ctx, "expiring", 3))
+	_, err = repo.Get(ctx, "expiring")
+	assert.ErrorIs(t, err, my_err.ErrInvalidLoginToken)
+}
+
+func TestLoginTokenAttempts(t *testing.T) {
+	pool, _ := getPool(t)
+	repo := NewLoginTokenRedisRepository(pool)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, "token", 5, time.Minute))
+	require.NoError(t, repo.Fail(ctx, "token", 3))
+	require.NoError(t, repo.Fail(ctx, "token", 3))
+	_, err := repo.Get(ctx, "token")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Fail(ctx, "token", 3))
+	_, err = repo.Get(ctx, "token")
+	assert.ErrorIs(t, err, my_err.ErrInvalidLoginToken)
+}
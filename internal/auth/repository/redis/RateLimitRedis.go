@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// slidingWindow lets the request in if the sorted set KEYS[1] has less than ARGV[3] requests
+// for the window of ARGV[2] ms before ARGV[1]. The request is added as ARGV[4] scored by its time,
+// the rejected requests are not added. It returns the ms left until the oldest request leaves the window
+var slidingWindow = redis.NewScript(1, `
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1] - ARGV[2])
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+	local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+	return math.max(1, oldest[2] + ARGV[2] - ARGV[1])
+end
+redis.call('ZADD', KEYS[1], ARGV[1], ARGV[4])
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return 0
+`)
+
+// RateLimitRedisRepository counts the requests in the sliding windows
+type RateLimitRedisRepository struct {
+	db  *redis.Pool
+	now func() time.Time
+}
+
+func NewRateLimitRedisRepository(db *redis.Pool) *RateLimitRedisRepository {
+	return &RateLimitRedisRepository{
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// Allow counts the request of the key if there were less than limit requests of the key for the window.
+// Otherwise it returns the time the request is let in after
+func (r *RateLimitRedisRepository) Allow(
+	ctx context.Context, key string, limit int, window time.Duration,
+) (time.Duration, error) {
+	conn, err := r.db.GetContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("rate limit: %w", err)
+	}
+	defer conn.Close()
+
+	member := make([]byte, 8)
+	if _, err := rand.Read(member); err != nil {
+		return 0, fmt.Errorf("rate limit: %w", err)
+	}
+	now := r.now().UnixMilli()
+
+	left, err := redis.Int64(slidingWindow.Do(
+		conn, "rate:"+key, now, window.Milliseconds(), limit, fmt.Sprintf("%d-%s", now, hex.EncodeToString(member)),
+	))
+	if err != nil {
+		return 0, fmt.Errorf("rate limit: %w", err)
+	}
+
+	return time.Duration(left) * time.Millisecond, nil
+}
@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	pool, mr := getPool(t)
+	repo := NewRateLimitRedisRepository(pool)
+	now := time.Unix(1000, 0)
+	repo.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		left, err := repo.Allow(ctx, "login:ip:1", 3, time.Minute)
+		require.NoError(t, err)
+		assert.Zero(t, left)
+		now = now.Add(10 * time.Second)
+	}
+
+	// the first request leaves the window in 30 seconds
+	left, err := repo.Allow(ctx, "login:ip:1", 3, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, left)
+
+	left, err = repo.Allow(ctx, "login:ip:2", 3, time.Minute)
+	require.NoError(t, err)
+	assert.Zero(t, left)
+
+	// the rejected requests do not take the place in the window
+	now = now.Add(30 * time.Second)
+	left, err = repo.Allow(ctx, "login:ip:1", 3, time.Minute)
+	require.NoError(t, err)
+	assert.Zero(t, left)
+	left, err = repo.Allow(ctx, "login:ip:1", 3, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, left)
+
+	members, err := mr.ZMembers("rate:login:ip:1")
+	require.NoError(t, err)
+	assert.Len(t, members, 3)
+	assert.Equal(t, time.Minute, mr.TTL("rate:login:ip:1"))
+}
@@ -0,0 +1,72 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+// ResetTokenRedisRepository keeps the tokens of the password reset. The token is the key of the user id,
+// it is stored hashed so the tokens can not be read from the database
+type ResetTokenRedisRepository struct {
+	db *redis.Pool
+}
+
+func NewResetTokenRedisRepository(db *redis.Pool) *ResetTokenRedisRepository {
+	return &ResetTokenRedisRepository{
+		db: db,
+	}
+}
+
+func resetTokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "password_reset:" + hex.EncodeToString(sum[:])
+}
+
+// Save stores the token of the user for ttl
+func (r *ResetTokenRedisRepository) Save(ctx context.Context, token string, userID uint32, ttl time.Duration) error {
+	conn, err := r.db.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("save reset token: %w", err)
+	}
+	defer conn.Close()
+
+	res, err := redis.String(conn.Do("SET", resetTokenKey(token), userID, "PX", ttl.Milliseconds(), "NX"))
+	if errors.Is(err, redis.ErrNil) {
+		return fmt.Errorf("save reset token: %w", my_err.ErrResNotOK)
+	}
+	if err != nil {
+		return fmt.Errorf("save reset token: %w", err)
+	}
+	if res != "OK" {
+		return fmt.Errorf("save reset token: %w", my_err.ErrResNotOK)
+	}
+
+	return nil
+}
+
+// Take returns the user of the token and deletes the token, so it can be used once
+func (r *ResetTokenRedisRepository) Take(ctx context.Context, token string) (uint32, error) {
+	conn, err := r.db.GetContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("take reset token: %w", err)
+	}
+	defer conn.Close()
+
+	userID, err := redis.Uint64(conn.Do("GETDEL", resetTokenKey(token)))
+	if errors.Is(err, redis.ErrNil) {
+		return 0, fmt.Errorf("take reset token: %w", my_err.ErrInvalidResetToken)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("take reset token: %w", err)
+	}
+
+	return uint32(userID), nil
+}
@@ -0,0 +1,35 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+func TestResetToken(t *testing.T) {
+	pool, mr := getPool(t)
+	repo := NewResetTokenRedisRepository(pool)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, "token", 1, time.Minute))
+	assert.False(t, mr.Exists("password_reset:token"))
+	assert.ErrorIs(t, repo.Save(ctx, "token", 2, time.Minute), my_err.ErrResNotOK)
+
+	userID, err := repo.Take(ctx, "token")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), userID)
+
+	// the token is used once
+	_, err = repo.Take(ctx, "token")
+	assert.ErrorIs(t, err, my_err.ErrInvalidResetToken)
+
+	require.NoError(t, repo.Save(ctx, "expired", 1, time.Minute))
+	mr.FastForward(time.Minute)
+	_, err = repo.Take(ctx, "expired")
+	assert.ErrorIs(t, err, my_err.ErrInvalidResetToken)
+}
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"github.com/gomodule/redigo/redis"
 
@@ -9,6 +10,37 @@ import (
 	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
+// sessionTTL is the life of a session in seconds
+const sessionTTL = 86400
+
+// touchSession sets LastSeen of the session KEYS[1] to ARGV[1] if it is later, the other fields are kept
+// as they are in redis, so a concurrent change of them is not overwritten
+var touchSession = redis.NewScript(1, `
+local data = redis.call('GET', KEYS[1])
+if not data then
+	return 0
+end
+local session = cjson.decode(data)
+local lastSeen = tonumber(ARGV[1])
+if (session.LastSeen or 0) < lastSeen then
+	session.LastSeen = lastSeen
+	redis.call('SET', KEYS[1], cjson.encode(session), 'KEEPTTL')
+end
+return 1
+`)
+
+// verifySession clears Unverified of the session KEYS[1] the same way
+var verifySession = redis.NewScript(1, `
+local data = redis.call('GET', KEYS[1])
+if not data then
+	return 0
+end
+local session = cjson.decode(data)
+session.Unverified = false
+redis.call('SET', KEYS[1], cjson.encode(session), 'KEEPTTL')
+return 1
+`)
+
 type SessionRedisRepository struct {
 	db *redis.Pool
 }
@@ -19,6 +51,12 @@ func NewSessionRedisRepository(db *redis.Pool) *SessionRedisRepository {
 	}
 }
 
+// userSessionsKey is the set of the session ids of the user. The ids of the sessions which are gone
+// are removed from it when the sessions are listed
+func userSessionsKey(userID uint32) string {
+	return "user_sessions:" + strconv.FormatUint(uint64(userID), 10)
+}
+
 func (s *SessionRedisRepository) CreateSession(session *models.Session) error {
 	conn := s.db.Get()
 	defer conn.Close()
@@ -28,11 +66,25 @@ func (s *SessionRedisRepository) CreateSession(session *models.Session) error {
 	}
 	mkey := "sessions:" + session.ID
 
-	res, err := redis.String(conn.Do("SET", mkey, dataSerialized, "EX", 86400))
+	if err := conn.Send("SET", mkey, dataSerialized, "EX", sessionTTL); err != nil {
+		return err
+	}
+	if err := conn.Send("SADD", userSessionsKey(session.UserID), session.ID); err != nil {
+		return err
+	}
+	// the index lives as long as the newest session of the user
+	if err := conn.Send("EXPIRE", userSessionsKey(session.UserID), sessionTTL); err != nil {
+		return err
+	}
+	values, err := redis.Values(conn.Do(""))
 	if err != nil {
 		return err
 	}
 
+	res, err := redis.String(values[0], nil)
+	if err != nil {
+		return err
+	}
 	if res != "OK" {
 		return my_err.ErrResNotOK
 	}
@@ -58,6 +110,86 @@ func (s *SessionRedisRepository) FindSession(sessID string) (*models.Session, er
 	return sess, nil
 }
 
+// VerifySession marks the session verified keeping the time it expires at
+func (s *SessionRedisRepository) VerifySession(sessID string) error {
+	conn := s.db.Get()
+	defer conn.Close()
+
+	found, err := redis.Bool(verifySession.Do(conn, "sessions:"+sessID))
+	if err != nil {
+		return err
+	}
+	if !found {
+		return my_err.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// TouchSession saves the last seen time of the session keeping the time it expires at
+func (s *SessionRedisRepository) TouchSession(sessID string, lastSeen int64) error {
+	conn := s.db.Get()
+	defer conn.Close()
+
+	found, err := redis.Bool(touchSession.Do(conn, "sessions:"+sessID, lastSeen))
+	if err != nil {
+		return err
+	}
+	if !found {
+		return my_err.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// GetUserSessions returns the alive sessions of the user
+func (s *SessionRedisRepository) GetUserSessions(userID uint32) ([]*models.Session, error) {
+	conn := s.db.Get()
+	defer conn.Close()
+
+	ids, err := redis.Strings(conn.Do("SMEMBERS", userSessionsKey(userID)))
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]any, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, "sessions:"+id)
+	}
+	values, err := redis.ByteSlices(conn.Do("MGET", keys...))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		res  = make([]*models.Session, 0, len(ids))
+		gone = []any{userSessionsKey(userID)}
+	)
+	for i, data := range values {
+		if data == nil {
+			gone = append(gone, ids[i])
+			continue
+		}
+
+		sess := &models.Session{}
+		if err := json.Unmarshal(data, sess); err != nil {
+			return nil, err
+		}
+		res = append(res, sess)
+	}
+
+	if len(gone) > 1 {
+		if _, err := conn.Do("SREM", gone...); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
 func (s *SessionRedisRepository) DestroySession(sessID string) error {
 	conn := s.db.Get()
 	defer conn.Close()
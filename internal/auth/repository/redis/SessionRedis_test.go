@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+func getPool(t *testing.T) (*redis.Pool, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", addr)
+		},
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	return pool, mr
+}
+
+func TestUserSessions(t *testing.T) {
+	pool, mr := getPool(t)
+	repo := NewSessionRedisRepository(pool)
+
+	first := &models.Session{ID: "first", UserID: 1, UserAgent: "Firefox", Unverified: true}
+	second := &models.Session{ID: "second", UserID: 1, UserAgent: "Safari"}
+	other := &models.Session{ID: "other", UserID: 2}
+	for _, sess := range []*models.Session{first, second, other} {
+		require.NoError(t, repo.CreateSession(sess))
+	}
+	assert.Equal(t, time.Duration(sessionTTL)*time.Second, mr.TTL("user_sessions:1"))
+
+	sessions, err := repo.GetUserSessions(1)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*models.Session{first, second}, sessions)
+
+	// only the changed field is written, the changes made meanwhile are kept
+	mr.FastForward(time.Hour)
+	require.NoError(t, repo.VerifySession("first"))
+	require.NoError(t, repo.TouchSession("first", 20))
+	require.NoError(t, repo.TouchSession("first", 15))
+	found, err := repo.FindSession("first")
+	require.NoError(t, err)
+	first.LastSeen = 20
+	first.Unverified = false
+	assert.Equal(t, first, found)
+	assert.Equal(t, time.Duration(sessionTTL)*time.Second-time.Hour, mr.TTL("sessions:first"))
+	assert.ErrorIs(t, repo.TouchSession("unknown", 20), my_err.ErrSessionNotFound)
+	assert.ErrorIs(t, repo.VerifySession("unknown"), my_err.ErrSessionNotFound)
+
+	// the sessions which are gone are dropped from the index
+	require.NoError(t, repo.DestroySession("second"))
+	sessions, err = repo.GetUserSessions(1)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Session{first}, sessions)
+	members, err := mr.SMembers("user_sessions:1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first"}, members)
+
+	sessions, err = repo.GetUserSessions(3)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
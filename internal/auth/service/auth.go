@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
+	"net/url"
 	"regexp"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
@@ -13,18 +17,69 @@ import (
 	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
+// resetTokenTTL is how long the link from the letter about the password reset works
+const resetTokenTTL = 30 * time.Minute
+
 type UserRepo interface {
 	Create(ctx context.Context, user *models.User) (uint32, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByID(ctx context.Context, userID uint32) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID uint32, password string) error
+	VerifyEmail(ctx context.Context, userID uint32, email string) error
+	GetTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactor, error)
+	SaveTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error
+	DeleteTwoFactor(ctx context.Context, userID uint32) error
+	UseRecoveryCode(ctx context.Context, userID uint32, codeHash string) error
+}
+
+type ResetTokenRepo interface {
+	Save(ctx context.Context, token string, userID uint32, ttl time.Duration) error
+	Take(ctx context.Context, token string) (uint32, error)
+}
+
+type LoginTokenRepo interface {
+	Save(ctx context.Context, token string, userID uint32, ttl time.Duration) error
+	Get(ctx context.Context, token string) (uint32, error)
+	Fail(ctx context.Context, token string, maxAttempts int) error
+	Delete(ctx context.Context, token string) error
+}
+
+type CooldownRepo interface {
+	Start(ctx context.Context, key string, d time.Duration) (time.Duration, error)
+}
+
+type MailSender interface {
+	Send(ctx context.Context, mail *models.Mail) error
+}
+
+// Mailing is what the links in the letters are made of. ResetURL and VerifyURL are the pages of the client
+// the tokens are sent to, VerifySecret signs the tokens of the email verification
+type Mailing struct {
+	ResetURL     string
+	VerifyURL    string
+	VerifySecret []byte
 }
 
 type AuthServiceImpl struct {
-	db UserRepo
+	db          UserRepo
+	resetTokens ResetTokenRepo
+	loginTokens LoginTokenRepo
+	cooldowns   CooldownRepo
+	mailer      MailSender
+	mailing     Mailing
 }
 
-func NewAuthServiceImpl(db UserRepo) *AuthServiceImpl {
+func NewAuthServiceImpl(
+	db UserRepo, resetTokens ResetTokenRepo, loginTokens LoginTokenRepo, cooldowns CooldownRepo, mailer MailSender,
+	mailing Mailing,
+) *AuthServiceImpl {
 	return &AuthServiceImpl{
-		db: db,
+		db:          db,
+		resetTokens: resetTokens,
+		loginTokens: loginTokens,
+		cooldowns:   cooldowns,
+		mailer:      mailer,
+		mailing:     mailing,
 	}
 }
 
@@ -43,30 +98,131 @@ func (a *AuthServiceImpl) Register(user models.User, ctx context.Context) (uint3
 	if status.Code(err) == codes.AlreadyExists {
 		return 0, fmt.Errorf("auth service: %w", my_err.ErrUserAlreadyExists)
 	}
+	if err != nil {
+		return 0, fmt.Errorf("auth service: %w", err)
+	}
+
+	// the user can ask for the letter once again, so the user is created even if it is not sent
+	if err = a.sendVerification(ctx, user.ID, user.Email); err != nil {
+		return user.ID, fmt.Errorf("auth service: %w: %w", my_err.ErrVerifyNotSent, err)
+	}
 
 	return user.ID, nil
 }
 
-func (a *AuthServiceImpl) Auth(user models.User, ctx context.Context) (uint32, error) {
+// Auth checks the email and the password and returns the user without the password
+func (a *AuthServiceImpl) Auth(user models.User, ctx context.Context) (*models.User, error) {
 	if !a.validateEmail(user.Email) {
-		return 0, fmt.Errorf("auth service: %w", my_err.ErrNonValidEmail)
+		return nil, fmt.Errorf("auth service: %w", my_err.ErrNonValidEmail)
 	}
 
 	dbUser, err := a.db.GetByEmail(ctx, user.Email)
 	if status.Code(err) == codes.NotFound {
-		return 0, fmt.Errorf("auth service: %w", my_err.ErrWrongEmailOrPassword)
+		return nil, fmt.Errorf("auth service: %w", my_err.ErrWrongEmailOrPassword)
 	}
 
 	if err != nil {
-		return 0, fmt.Errorf("auth service: %w", err)
+		return nil, fmt.Errorf("auth service: %w", err)
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(dbUser.Password), []byte(user.Password))
 	if err != nil {
-		return 0, fmt.Errorf("auth service: %w", my_err.ErrWrongEmailOrPassword)
+		return nil, fmt.Errorf("auth service: %w", my_err.ErrWrongEmailOrPassword)
+	}
+	dbUser.Password = ""
+
+	return dbUser, nil
+}
+
+func (a *AuthServiceImpl) ChangePassword(ctx context.Context, userID uint32, change models.PasswordChange) error {
+	user, err := a.db.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("change password: %w", err)
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(change.OldPassword))
+	if err != nil {
+		return fmt.Errorf("change password: %w", my_err.ErrWrongPassword)
+	}
+
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte(change.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("change password: %w", err)
+	}
+
+	if err := a.db.UpdatePassword(ctx, userID, string(hashPassword)); err != nil {
+		return fmt.Errorf("change password: %w", err)
+	}
+
+	return nil
+}
+
+// RequestPasswordReset sends the link to reset the password to the email. Nothing is sent if there is no such user,
+// but no error is returned either, not to tell who is registered
+func (a *AuthServiceImpl) RequestPasswordReset(ctx context.Context, email string) error {
+	if !a.validateEmail(email) {
+		return fmt.Errorf("request password reset: %w", my_err.ErrNonValidEmail)
+	}
+
+	user, err := a.db.GetByEmail(ctx, email)
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("request password reset: %w", err)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return fmt.Errorf("request password reset: %w", err)
+	}
+	if err := a.resetTokens.Save(ctx, token, user.ID, resetTokenTTL); err != nil {
+		return fmt.Errorf("request password reset: %w", err)
+	}
+
+	mail := &models.Mail{
+		To:      user.Email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf(
+			"Follow the link to set a new password: %s?token=%s\nThe link works for %d minutes. "+
+				"If you did not ask to reset the password, ignore this letter.",
+			a.mailing.ResetURL, url.QueryEscape(token), int(resetTokenTTL.Minutes()),
+		),
+	}
+	if err := a.mailer.Send(ctx, mail); err != nil {
+		return fmt.Errorf("request password reset: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets the new password of the user the token was sent to and returns the id of the user
+func (a *AuthServiceImpl) ResetPassword(ctx context.Context, reset models.PasswordReset) (uint32, error) {
+	// the password is checked before the token is used, so a wrong password does not waste the token
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte(reset.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, fmt.Errorf("reset password: %w", err)
+	}
+
+	userID, err := a.resetTokens.Take(ctx, reset.Token)
+	if err != nil {
+		return 0, fmt.Errorf("reset password: %w", err)
+	}
+
+	if err := a.db.UpdatePassword(ctx, userID, string(hashPassword)); err != nil {
+		return 0, fmt.Errorf("reset password: %w", err)
+	}
+
+	return userID, nil
+}
+
+func newToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
 	}
 
-	return dbUser.ID, nil
+	return base64.RawURLEncoding.EncodeToString(token), nil
 }
 
 func (a *AuthServiceImpl) validateEmail(email string) bool {
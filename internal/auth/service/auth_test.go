@@ -3,7 +3,13 @@ package service
 import (
 	"context"
 	"errors"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
@@ -40,13 +46,115 @@ func (m MockDB) GetByEmail(ctx context.Context, email string) (*models.User, err
 	}, nil
 }
 
+func (m MockDB) GetByID(ctx context.Context, userID uint32) (*models.User, error) {
+	if userID == 2 {
+		return nil, errMock
+	}
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	return &models.User{
+		ID:            userID,
+		Email:         "email@email.com",
+		Password:      string(hash),
+		EmailVerified: userID == 4,
+	}, nil
+}
+
+func (m MockDB) UpdatePassword(ctx context.Context, userID uint32, password string) error {
+	if userID == 3 {
+		return errMock
+	}
+	return nil
+}
+
+func (m MockDB) VerifyEmail(ctx context.Context, userID uint32, email string) error {
+	if userID == 3 {
+		return errMock
+	}
+	if email != "email@email.com" {
+		return status.Error(codes.NotFound, "")
+	}
+	return nil
+}
+
+func (m MockDB) GetTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactor, error) {
+	return nil, status.Error(codes.NotFound, "")
+}
+
+func (m MockDB) SaveTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error {
+	return errMock
+}
+
+func (m MockDB) DeleteTwoFactor(ctx context.Context, userID uint32) error {
+	return errMock
+}
+
+func (m MockDB) UseRecoveryCode(ctx context.Context, userID uint32, codeHash string) error {
+	return status.Error(codes.NotFound, "")
+}
+
+// memTokens keeps the reset tokens in memory and checks the ttl they are saved for
+type memTokens struct {
+	tokens map[string]uint32
+	ttl    time.Duration
+	err    error
+}
+
+func (m *memTokens) Save(ctx context.Context, token string, userID uint32, ttl time.Duration) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.tokens[token] = userID
+	m.ttl = ttl
+	return nil
+}
+
+func (m *memTokens) Take(ctx context.Context, token string) (uint32, error) {
+	userID, ok := m.tokens[token]
+	if !ok {
+		return 0, my_err.ErrInvalidResetToken
+	}
+	delete(m.tokens, token)
+	return userID, nil
+}
+
+// memCooldowns keeps the cooldowns in memory, they last until they are reset
+type memCooldowns struct {
+	started map[string]time.Duration
+}
+
+func newMemCooldowns() *memCooldowns {
+	return &memCooldowns{started: map[string]time.Duration{}}
+}
+
+func (m *memCooldowns) Start(ctx context.Context, key string, d time.Duration) (time.Duration, error) {
+	if left, ok := m.started[key]; ok {
+		return left, nil
+	}
+	m.started[key] = d
+	return 0, nil
+}
+
+type mailBox struct {
+	mails []*models.Mail
+	err   error
+}
+
+func (m *mailBox) Send(ctx context.Context, mail *models.Mail) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.mails = append(m.mails, mail)
+	return nil
+}
+
 type TestCase struct {
 	user      models.User
 	wantError error
 }
 
 func TestCreate(t *testing.T) {
-	serv := NewAuthServiceImpl(MockDB{})
+	serv := NewAuthServiceImpl(MockDB{}, nil, nil, newMemCooldowns(), &mailBox{}, Mailing{})
 
 	testCases := []TestCase{
 		{models.User{ID: 1, Email: "email@email.com", Password: "some password"}, nil},
@@ -66,7 +174,7 @@ func TestCreate(t *testing.T) {
 }
 
 func TestAuth(t *testing.T) {
-	serv := NewAuthServiceImpl(MockDB{})
+	serv := NewAuthServiceImpl(MockDB{}, nil, nil, newMemCooldowns(), &mailBox{}, Mailing{})
 
 	testCases := []TestCase{
 		{models.User{ID: 1, Email: "email@email.com", Password: "password"}, nil},
@@ -90,7 +198,7 @@ type TestCaseValidate struct {
 }
 
 func TestValidateEmail(t *testing.T) {
-	serv := NewAuthServiceImpl(MockDB{})
+	serv := NewAuthServiceImpl(MockDB{}, nil, nil, newMemCooldowns(), &mailBox{}, Mailing{})
 
 	testCases := []TestCaseValidate{
 		{email: "email@email.com", pass: true},
@@ -109,3 +217,154 @@ func TestValidateEmail(t *testing.T) {
 		}
 	}
 }
+
+func TestChangePassword(t *testing.T) {
+	serv := NewAuthServiceImpl(MockDB{}, nil, nil, newMemCooldowns(), &mailBox{}, Mailing{})
+	ctx := context.Background()
+
+	testCases := []struct {
+		userID    uint32
+		change    models.PasswordChange
+		wantError error
+	}{
+		{1, models.PasswordChange{OldPassword: "password", NewPassword: "new password"}, nil},
+		{1, models.PasswordChange{OldPassword: "wrong", NewPassword: "new password"}, my_err.ErrWrongPassword},
+		{2, models.PasswordChange{OldPassword: "password", NewPassword: "new password"}, errMock},
+		{3, models.PasswordChange{OldPassword: "password", NewPassword: "new password"}, errMock},
+		{1, models.PasswordChange{OldPassword: "password", NewPassword: strings.Repeat("a", 73)}, bcrypt.ErrPasswordTooLong},
+	}
+
+	for _, testCase := range testCases {
+		err := serv.ChangePassword(ctx, testCase.userID, testCase.change)
+		if !errors.Is(err, testCase.wantError) {
+			t.Errorf("ChangePassword() error = %v, wantErr %v", err, testCase.wantError)
+		}
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	tokens := &memTokens{tokens: map[string]uint32{}}
+	mails := &mailBox{}
+	serv := NewAuthServiceImpl(MockDB{}, tokens, nil, newMemCooldowns(), mails, Mailing{ResetURL: "http://localhost/reset"})
+	ctx := context.Background()
+
+	assert.ErrorIs(t, serv.RequestPasswordReset(ctx, "email"), my_err.ErrNonValidEmail)
+	assert.ErrorIs(t, serv.RequestPasswordReset(ctx, "email@wrong2.com"), errMock)
+	// nobody is told there is no such user
+	require.NoError(t, serv.RequestPasswordReset(ctx, "email@wrong.com"))
+	assert.Empty(t, mails.mails)
+
+	require.NoError(t, serv.RequestPasswordReset(ctx, "email@email.com"))
+	require.Len(t, mails.mails, 1)
+	assert.Equal(t, "email@email.com", mails.mails[0].To)
+	assert.Equal(t, resetTokenTTL, tokens.ttl)
+	require.Len(t, tokens.tokens, 1)
+	link, _, _ := strings.Cut(mails.mails[0].Body[strings.Index(mails.mails[0].Body, "http"):], "\n")
+	resetURL, err := url.Parse(link)
+	require.NoError(t, err)
+	token := resetURL.Query().Get("token")
+	assert.Equal(t, "/reset", resetURL.Path)
+	assert.Contains(t, tokens.tokens, token)
+
+	// a too long password does not use the token up
+	tokens.tokens[token] = 5
+	_, err = serv.ResetPassword(ctx, models.PasswordReset{Token: token, Password: strings.Repeat("a", 73)})
+	assert.ErrorIs(t, err, bcrypt.ErrPasswordTooLong)
+	userID, err := serv.ResetPassword(ctx, models.PasswordReset{Token: token, Password: "new password"})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(5), userID)
+	_, err = serv.ResetPassword(ctx, models.PasswordReset{Token: token, Password: "new password"})
+	assert.ErrorIs(t, err, my_err.ErrInvalidResetToken)
+
+	tokens.tokens["failing"] = 3
+	_, err = serv.ResetPassword(ctx, models.PasswordReset{Token: "failing", Password: "new password"})
+	assert.ErrorIs(t, err, errMock)
+
+	tokens.err = errMock
+	assert.ErrorIs(t, serv.RequestPasswordReset(ctx, "email@email.com"), errMock)
+	tokens.err = nil
+	mails.err = errMock
+	assert.ErrorIs(t, serv.RequestPasswordReset(ctx, "email@email.com"), errMock)
+}
+
+func TestVerifyToken(t *testing.T) {
+	serv := NewAuthServiceImpl(MockDB{}, nil, nil, nil, nil, Mailing{VerifySecret: []byte("secret")})
+	now := time.Now()
+	token := serv.signVerifyToken(7, "email@email.com", now.Add(time.Hour))
+
+	userID, email, err := serv.parseVerifyToken(token, now)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(7), userID)
+	assert.Equal(t, "email@email.com", email)
+
+	other := NewAuthServiceImpl(MockDB{}, nil, nil, nil, nil, Mailing{VerifySecret: []byte("other secret")})
+	tampered := strings.Replace(token, "7.", "8.", 1)
+
+	for _, tt := range []struct {
+		name  string
+		token string
+		now   time.Time
+		serv  *AuthServiceImpl
+	}{
+		{name: "expired", token: token, now: now.Add(2 * time.Hour), serv: serv},
+		{name: "tampered", token: tampered, now: now, serv: serv},
+		{name: "other secret", token: token, now: now, serv: other},
+		{name: "no signature", token: "7", now: now, serv: serv},
+		{name: "garbage", token: "a.b.c." + serv.verifySignature("a.b.c"), now: now, serv: serv},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.serv.parseVerifyToken(tt.token, tt.now)
+			assert.ErrorIs(t, err, my_err.ErrInvalidVerifyToken)
+		})
+	}
+}
+
+func TestEmailVerification(t *testing.T) {
+	mails := &mailBox{}
+	cooldowns := newMemCooldowns()
+	serv := NewAuthServiceImpl(
+		MockDB{}, nil, nil, cooldowns, mails,
+		Mailing{VerifyURL: "http://localhost/verify", VerifySecret: []byte("secret")},
+	)
+	ctx := context.Background()
+
+	userID, err := serv.Register(models.User{ID: 1, Email: "email@email.com", Password: "password"}, ctx)
+	require.NoError(t, err)
+	require.Len(t, mails.mails, 1)
+	assert.Equal(t, "email@email.com", mails.mails[0].To)
+	assert.Equal(t, resendCooldown, cooldowns.started["verify:1"])
+	link, _, _ := strings.Cut(mails.mails[0].Body[strings.Index(mails.mails[0].Body, "http"):], "\n")
+	verifyURL, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "/verify", verifyURL.Path)
+
+	verified, err := serv.VerifyEmail(ctx, verifyURL.Query().Get("token"))
+	require.NoError(t, err)
+	assert.Equal(t, userID, verified)
+
+	_, err = serv.VerifyEmail(ctx, "wrong token")
+	assert.ErrorIs(t, err, my_err.ErrInvalidVerifyToken)
+	// the email has been changed since the letter
+	_, err = serv.VerifyEmail(ctx, serv.signVerifyToken(1, "old@email.com", time.Now().Add(time.Hour)))
+	assert.ErrorIs(t, err, my_err.ErrInvalidVerifyToken)
+	_, err = serv.VerifyEmail(ctx, serv.signVerifyToken(3, "email@email.com", time.Now().Add(time.Hour)))
+	assert.ErrorIs(t, err, errMock)
+
+	cooldowns.started["verify:1"] = 30 * time.Second
+	assert.ErrorIs(t, serv.ResendVerification(ctx, 1), my_err.ErrResendCooldown)
+	delete(cooldowns.started, "verify:1")
+	require.NoError(t, serv.ResendVerification(ctx, 1))
+	assert.Len(t, mails.mails, 2)
+
+	assert.ErrorIs(t, serv.ResendVerification(ctx, 4), my_err.ErrEmailAlreadyVerified)
+	assert.ErrorIs(t, serv.ResendVerification(ctx, 2), errMock)
+	mails.err = errMock
+	assert.ErrorIs(t, serv.ResendVerification(ctx, 5), errMock)
+
+	// the user is created even if the letter is not sent
+	delete(cooldowns.started, "verify:1")
+	userID, err = serv.Register(models.User{ID: 1, Email: "email@email.com", Password: "password"}, ctx)
+	assert.ErrorIs(t, err, my_err.ErrVerifyNotSent)
+	assert.ErrorIs(t, err, errMock)
+	assert.Equal(t, uint32(1), userID)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+type RateLimitRepo interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (time.Duration, error)
+}
+
+type LockoutRepo interface {
+	Locked(ctx context.Context, key string) (time.Duration, error)
+	Fail(ctx context.Context, key string, lockout models.Lockout) (time.Duration, error)
+	Reset(ctx context.Context, key string) error
+}
+
+type LimitMetrics interface {
+	Lockout(scope string)
+	Reject(action, reason string)
+}
+
+// window is how many requests are let in for the sliding window
+type window struct {
+	limit  int
+	period time.Duration
+}
+
+var (
+	loginByIP       = window{limit: 20, period: time.Minute}
+	loginByEmail    = window{limit: 10, period: time.Minute}
+	registerByIP    = window{limit: 10, period: time.Hour}
+	registerByEmail = window{limit: 3, period: time.Hour}
+	resetByIP       = window{limit: 10, period: time.Hour}
+	resetByEmail    = window{limit: 3, period: time.Hour}
+
+	// the address is shared by the users behind the NAT, so it is locked out later than the email
+	lockoutByEmail = models.Lockout{Threshold: 5, Base: time.Minute, Max: time.Hour, Memory: 24 * time.Hour}
+	lockoutByIP    = models.Lockout{Threshold: 20, Base: time.Minute, Max: time.Hour, Memory: 24 * time.Hour}
+)
+
+const (
+	actionLogin    = "login"
+	actionRegister = "register"
+	actionReset    = "reset"
+	scopeIP        = "ip"
+	scopeEmail     = "email"
+)
+
+// Limiter throttles the logins, the registrations and the letters to reset the password by the address and the email of the request
+// and locks them out after the repeated wrong passwords
+type Limiter struct {
+	limits   RateLimitRepo
+	lockouts LockoutRepo
+	metrics  LimitMetrics
+}
+
+func NewLimiter(limits RateLimitRepo, lockouts LockoutRepo, metrics LimitMetrics) *Limiter {
+	return &Limiter{
+		limits:   limits,
+		lockouts: lockouts,
+		metrics:  metrics,
+	}
+}
+
+// scopeKey makes the key of the address or the email, it is empty for the empty value
+func scopeKey(scope, value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return ""
+	}
+
+	return scope + ":" + value
+}
+
+// AllowLogin returns the time the login is let in after, it is zero if the login can be tried now.
+// The email is empty for the steps of the login that do not know it
+func (l *Limiter) AllowLogin(ctx context.Context, ip, email string) (time.Duration, error) {
+	keys := []string{scopeKey(scopeIP, ip), scopeKey(scopeEmail, email)}
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		left, err := l.lockouts.Locked(ctx, key)
+		if err != nil {
+			return 0, fmt.Errorf("allow login: %w", err)
+		}
+		if left > 0 {
+			l.metrics.Reject(actionLogin, "lockout")
+			return left, nil
+		}
+	}
+
+	left, err := l.allow(ctx, actionLogin, keys, []window{loginByIP, loginByEmail})
+	if err != nil {
+		return 0, fmt.Errorf("allow login: %w", err)
+	}
+
+	return left, nil
+}
+
+// AllowRegister returns the time the registration is let in after, it is zero if it can be tried now
+func (l *Limiter) AllowRegister(ctx context.Context, ip, email string) (time.Duration, error) {
+	keys := []string{scopeKey(scopeIP, ip), scopeKey(scopeEmail, email)}
+	left, err := l.allow(ctx, actionRegister, keys, []window{registerByIP, registerByEmail})
+	if err != nil {
+		return 0, fmt.Errorf("allow register: %w", err)
+	}
+
+	return left, nil
+}
+
+// AllowPasswordReset returns the time the letter to reset the password is let in after,
+// it is zero if it can be sent now
+func (l *Limiter) AllowPasswordReset(ctx context.Context, ip, email string) (time.Duration, error) {
+	keys := []string{scopeKey(scopeIP, ip), scopeKey(scopeEmail, email)}
+	left, err := l.allow(ctx, actionReset, keys, []window{resetByIP, resetByEmail})
+	if err != nil {
+		return 0, fmt.Errorf("allow password reset: %w", err)
+	}
+
+	return left, nil
+}
+
+// LoginFailed counts the wrong password and returns the time the login is locked for if it has been locked now
+func (l *Limiter) LoginFailed(ctx context.Context, ip, email string) (time.Duration, error) {
+	var locked time.Duration
+	for _, scope := range []struct {
+		name    string
+		key     string
+		lockout models.Lockout
+	}{
+		{name: scopeIP, key: scopeKey(scopeIP, ip), lockout: lockoutByIP},
+		{name: scopeEmail, key: scopeKey(scopeEmail, email), lockout: lockoutByEmail},
+	} {
+		if scope.key == "" {
+			continue
+		}
+		lock, err := l.lockouts.Fail(ctx, scope.key, scope.lockout)
+		if err != nil {
+			return 0, fmt.Errorf("login failed: %w", err)
+		}
+		if lock > 0 {
+			l.metrics.Lockout(scope.name)
+		}
+		locked = max(locked, lock)
+	}
+
+	return locked, nil
+}
+
+// LoginSucceeded forgets the wrong passwords of the email. The failures of the address are kept,
+// or the one who knows a password could try the others from the same address without the lockout
+func (l *Limiter) LoginSucceeded(ctx context.Context, email string) error {
+	key := scopeKey(scopeEmail, email)
+	if key == "" {
+		return nil
+	}
+	if err := l.lockouts.Reset(ctx, key); err != nil {
+		return fmt.Errorf("login succeeded: %w", err)
+	}
+
+	return nil
+}
+
+func (l *Limiter) allow(ctx context.Context, action string, keys []string, windows []window) (time.Duration, error) {
+	for i, key := range keys {
+		if key == "" {
+			continue
+		}
+		left, err := l.limits.Allow(ctx, action+":"+key, windows[i].limit, windows[i].period)
+		if err != nil {
+			return 0, err
+		}
+		if left > 0 {
+			l.metrics.Reject(action, strings.SplitN(key, ":", 2)[0])
+			return left, nil
+		}
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+// memLimits counts the requests of the keys in memory, the windows do not slide
+type memLimits struct {
+	requests map[string]int
+}
+
+func (m *memLimits) Allow(ctx context.Context, key string, limit int, window time.Duration) (time.Duration, error) {
+	if key == "login:ip:broken" {
+		return 0, errMock
+	}
+	if m.requests[key] >= limit {
+		return window, nil
+	}
+	m.requests[key]++
+	return 0, nil
+}
+
+// memLockouts locks the keys out for the base lock, the locks do not expire
+type memLockouts struct {
+	failures map[string]int
+	locks    map[string]time.Duration
+}
+
+func (m *memLockouts) Locked(ctx context.Context, key string) (time.Duration, error) {
+	return m.locks[key], nil
+}
+
+func (m *memLockouts) Fail(ctx context.Context, key string, lockout models.Lockout) (time.Duration, error) {
+	m.failures[key]++
+	if m.failures[key] < lockout.Threshold {
+		return 0, nil
+	}
+	m.locks[key] = lockout.Base
+	return lockout.Base, nil
+}
+
+func (m *memLockouts) Reset(ctx context.Context, key string) error {
+	delete(m.failures, key)
+	return nil
+}
+
+type memLimitMetrics struct {
+	lockouts   map[string]int
+	rejections map[string]int
+}
+
+func (m *memLimitMetrics) Lockout(scope string) {
+	m.lockouts[scope]++
+}
+
+func (m *memLimitMetrics) Reject(action, reason string) {
+	m.rejections[action+":"+reason]++
+}
+
+func newTestLimiter() (*Limiter, *memLockouts, *memLimitMetrics) {
+	lockouts := &memLockouts{failures: map[string]int{}, locks: map[string]time.Duration{}}
+	metrics := &memLimitMetrics{lockouts: map[string]int{}, rejections: map[string]int{}}
+	return NewLimiter(&memLimits{requests: map[string]int{}}, lockouts, metrics), lockouts, metrics
+}
+
+func TestAllowLogin(t *testing.T) {
+	limiter, _, metrics := newTestLimiter()
+	ctx := context.Background()
+
+	for i := 0; i < loginByEmail.limit; i++ {
+		left, err := limiter.AllowLogin(ctx, "1.1.1.1", "Email@Mail.ru")
+		require.NoError(t, err)
+		require.Zero(t, left)
+	}
+	// the email is the same in any case
+	left, err := limiter.AllowLogin(ctx, "2.2.2.2", " email@mail.ru")
+	require.NoError(t, err)
+	assert.Equal(t, loginByEmail.period, left)
+	assert.Equal(t, 1, metrics.rejections["login:email"])
+
+	for i := loginByEmail.limit; i < loginByIP.limit; i++ {
+		left, err = limiter.AllowLogin(ctx, "1.1.1.1", "")
+		require.NoError(t, err)
+		require.Zero(t, left)
+	}
+	left, err = limiter.AllowLogin(ctx, "1.1.1.1", "other@mail.ru")
+	require.NoError(t, err)
+	assert.Equal(t, loginByIP.period, left)
+	assert.Equal(t, 1, metrics.rejections["login:ip"])
+
+	_, err = limiter.AllowLogin(ctx, "broken", "other@mail.ru")
+	assert.ErrorIs(t, err, errMock)
+}
+
+func TestAllowRegister(t *testing.T) {
+	limiter, _, metrics := newTestLimiter()
+	ctx := context.Background()
+
+	for i := 0; i < registerByEmail.limit; i++ {
+		left, err := limiter.AllowRegister(ctx, "1.1.1.1", "email@mail.ru")
+		require.NoError(t, err)
+		require.Zero(t, left)
+	}
+	left, err := limiter.AllowRegister(ctx, "2.2.2.2", "email@mail.ru")
+	require.NoError(t, err)
+	assert.Equal(t, registerByEmail.period, left)
+	assert.Equal(t, 1, metrics.rejections["register:email"])
+
+	// the registrations do not take the logins of the same address
+	left, err = limiter.AllowLogin(ctx, "1.1.1.1", "email@mail.ru")
+	require.NoError(t, err)
+	assert.Zero(t, left)
+}
+
+func TestAllowPasswordReset(t *testing.T) {
+	limiter, _, metrics := newTestLimiter()
+	ctx := context.Background()
+
+	for i := 0; i < resetByEmail.limit; i++ {
+		left, err := limiter.AllowPasswordReset(ctx, "1.1.1.1", "email@mail.ru")
+		require.NoError(t, err)
+		require.Zero(t, left)
+	}
+	left, err := limiter.AllowPasswordReset(ctx, "2.2.2.2", "Email@mail.ru")
+	require.NoError(t, err)
+	assert.Equal(t, resetByEmail.period, left)
+	assert.Equal(t, 1, metrics.rejections["reset:email"])
+
+	// the letters to the other emails are limited by the address
+	for i := resetByEmail.limit; i < resetByIP.limit; i++ {
+		left, err := limiter.AllowPasswordReset(ctx, "1.1.1.1", fmt.Sprintf("user%d@mail.ru", i))
+		require.NoError(t, err)
+		require.Zero(t, left)
+	}
+	left, err = limiter.AllowPasswordReset(ctx, "1.1.1.1", "last@mail.ru")
+	require.NoError(t, err)
+	assert.Equal(t, resetByIP.period, left)
+	assert.Equal(t, 1, metrics.rejections["reset:ip"])
+}
+
+func TestLockout(t *testing.T) {
+	limiter, lockouts, metrics := newTestLimiter()
+	ctx := context.Background()
+
+	for i := 1; i < lockoutByEmail.Threshold; i++ {
+		locked, err := limiter.LoginFailed(ctx, "1.1.1.1", "email@mail.ru")
+		require.NoError(t, err)
+		require.Zero(t, locked)
+	}
+	// the right password forgets the failures of the email only
+	require.NoError(t, limiter.LoginSucceeded(ctx, "email@mail.ru"))
+	assert.Zero(t, lockouts.failures["email:email@mail.ru"])
+	assert.Equal(t, lockoutByEmail.Threshold-1, lockouts.failures["ip:1.1.1.1"])
+	require.NoError(t, limiter.LoginSucceeded(ctx, ""))
+
+	for i := 1; i < lockoutByEmail.Threshold; i++ {
+		_, err := limiter.LoginFailed(ctx, "1.1.1.1", "email@mail.ru")
+		require.NoError(t, err)
+	}
+	locked, err := limiter.LoginFailed(ctx, "1.1.1.1", "email@mail.ru")
+	require.NoError(t, err)
+	assert.Equal(t, lockoutByEmail.Base, locked)
+	assert.Equal(t, 1, metrics.lockouts["email"])
+	assert.Zero(t, metrics.lockouts["ip"])
+
+	left, err := limiter.AllowLogin(ctx, "2.2.2.2", "email@mail.ru")
+	require.NoError(t, err)
+	assert.Equal(t, lockoutByEmail.Base, left)
+	assert.Equal(t, 1, metrics.rejections["login:lockout"])
+	left, err = limiter.AllowLogin(ctx, "1.1.1.1", "other@mail.ru")
+	require.NoError(t, err)
+	assert.Zero(t, left)
+
+	// the second factor step only knows the address
+	for i := lockouts.failures["ip:1.1.1.1"]; i < lockoutByIP.Threshold; i++ {
+		_, err = limiter.LoginFailed(ctx, "1.1.1.1", "")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, metrics.lockouts["ip"])
+	left, err = limiter.AllowLogin(ctx, "1.1.1.1", "")
+	require.NoError(t, err)
+	assert.Equal(t, lockoutByIP.Base, left)
+}
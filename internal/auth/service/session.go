@@ -1,13 +1,20 @@
 package service
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/2024_2_BetterCallFirewall/internal/auth"
 	"github.com/2024_2_BetterCallFirewall/internal/models"
 	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
+// lastSeenStep is how often the last seen time of a session is saved, not to write on every request
+const lastSeenStep = time.Minute
+
 type SessionManagerImpl struct {
 	DB auth.SessionRepository
 }
@@ -24,14 +31,25 @@ func (sm *SessionManagerImpl) Check(cookie string) (*models.Session, error) {
 		return nil, fmt.Errorf("session check: %w", err)
 	}
 
+	now := time.Now().Unix()
+	if now-sess.LastSeen >= int64(lastSeenStep.Seconds()) {
+		sess.LastSeen = now
+		// the session is valid anyway, the last seen time is saved on the next request
+		_ = sm.DB.TouchSession(sess.ID, now)
+	}
+
 	return sess, nil
 }
 
-func (sm *SessionManagerImpl) Create(userID uint32) (*models.Session, error) {
-	sess, err := models.NewSession(userID)
+func (sm *SessionManagerImpl) Create(user *models.User, device models.Device) (*models.Session, error) {
+	sess, err := models.NewSession(user.ID)
 	if err != nil {
 		return nil, fmt.Errorf("create session: %w", err)
 	}
+	sess.UserAgent = device.UserAgent
+	sess.IP = device.IP
+	sess.Unverified = !user.EmailVerified
+
 	err = sm.DB.CreateSession(sess)
 	if err != nil {
 		return nil, fmt.Errorf("session creation: %w", err)
@@ -40,6 +58,37 @@ func (sm *SessionManagerImpl) Create(userID uint32) (*models.Session, error) {
 	return sess, nil
 }
 
+// Rotate reissues the session with a new id, the new session stays the same login on the same device
+func (sm *SessionManagerImpl) Rotate(sess *models.Session) (*models.Session, error) {
+	if sess == nil {
+		return nil, my_err.ErrNoAuth
+	}
+	old, err := sm.DB.FindSession(sess.ID)
+	if err != nil {
+		return nil, fmt.Errorf("session rotate: %w", err)
+	}
+
+	res, err := models.NewSession(old.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("session rotate: %w", err)
+	}
+	res.UserAgent = old.UserAgent
+	res.IP = old.IP
+	res.Unverified = old.Unverified
+	if old.LoginAt != 0 {
+		res.LoginAt = old.LoginAt
+	}
+
+	if err := sm.DB.CreateSession(res); err != nil {
+		return nil, fmt.Errorf("session rotate: %w", err)
+	}
+	if err := sm.DB.DestroySession(old.ID); err != nil {
+		return nil, fmt.Errorf("session rotate: %w", err)
+	}
+
+	return res, nil
+}
+
 func (sm *SessionManagerImpl) Destroy(sess *models.Session) error {
 	if sess == nil {
 		return my_err.ErrNoAuth
@@ -51,3 +100,106 @@ func (sm *SessionManagerImpl) Destroy(sess *models.Session) error {
 
 	return nil
 }
+
+// ListSessions returns the sessions of the owner of sess, the recently used go first
+func (sm *SessionManagerImpl) ListSessions(sess *models.Session) ([]*models.SessionInfo, error) {
+	if sess == nil {
+		return nil, my_err.ErrNoAuth
+	}
+	sessions, err := sm.DB.GetUserSessions(sess.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+
+	res := make([]*models.SessionInfo, 0, len(sessions))
+	for _, s := range sessions {
+		res = append(res, s.Info(sess.ID))
+	}
+	slices.SortFunc(res, func(a, b *models.SessionInfo) int {
+		if a.LastSeen != b.LastSeen {
+			return cmp.Compare(b.LastSeen, a.LastSeen)
+		}
+		return cmp.Compare(b.CreatedAt, a.CreatedAt)
+	})
+
+	return res, nil
+}
+
+// RevokeSession ends the session of the owner of sess with the public id
+func (sm *SessionManagerImpl) RevokeSession(sess *models.Session, publicID string) error {
+	if sess == nil {
+		return my_err.ErrNoAuth
+	}
+	sessions, err := sm.DB.GetUserSessions(sess.UserID)
+	if err != nil {
+		return fmt.Errorf("revoke session: %w", err)
+	}
+
+	for _, s := range sessions {
+		if s.PublicID() == publicID {
+			if err := sm.DB.DestroySession(s.ID); err != nil {
+				return fmt.Errorf("revoke session: %w", err)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("revoke session: %w", my_err.ErrSessionNotFound)
+}
+
+// RevokeOtherSessions ends all the sessions of the owner of sess but sess itself
+func (sm *SessionManagerImpl) RevokeOtherSessions(sess *models.Session) error {
+	if sess == nil {
+		return my_err.ErrNoAuth
+	}
+	sessions, err := sm.DB.GetUserSessions(sess.UserID)
+	if err != nil {
+		return fmt.Errorf("revoke other sessions: %w", err)
+	}
+
+	for _, s := range sessions {
+		if s.ID == sess.ID {
+			continue
+		}
+		if err := sm.DB.DestroySession(s.ID); err != nil {
+			return fmt.Errorf("revoke other sessions: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DestroyUserSessions ends all the sessions of the user
+func (sm *SessionManagerImpl) DestroyUserSessions(userID uint32) error {
+	sessions, err := sm.DB.GetUserSessions(userID)
+	if err != nil {
+		return fmt.Errorf("destroy user sessions: %w", err)
+	}
+
+	for _, s := range sessions {
+		if err := sm.DB.DestroySession(s.ID); err != nil {
+			return fmt.Errorf("destroy user sessions: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// MarkVerified marks all the sessions of the user verified once the email is
+func (sm *SessionManagerImpl) MarkVerified(userID uint32) error {
+	sessions, err := sm.DB.GetUserSessions(userID)
+	if err != nil {
+		return fmt.Errorf("mark sessions verified: %w", err)
+	}
+
+	for _, s := range sessions {
+		if !s.Unverified {
+			continue
+		}
+		if err := sm.DB.VerifySession(s.ID); err != nil && !errors.Is(err, my_err.ErrSessionNotFound) {
+			return fmt.Errorf("mark sessions verified: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -3,7 +3,12 @@ package service
 import (
 	"errors"
 	"reflect"
+	"slices"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
 	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
@@ -39,6 +44,34 @@ func (m *MocSessDB) FindSession(sessID string) (*models.Session, error) {
 	return session, nil
 }
 
+func (m *MocSessDB) VerifySession(sessID string) error {
+	session, ok := m.Storage[sessID]
+	if !ok {
+		return my_err.ErrSessionNotFound
+	}
+	session.Unverified = false
+	return nil
+}
+
+func (m *MocSessDB) TouchSession(sessID string, lastSeen int64) error {
+	session, ok := m.Storage[sessID]
+	if !ok {
+		return my_err.ErrSessionNotFound
+	}
+	session.LastSeen = max(session.LastSeen, lastSeen)
+	return nil
+}
+
+func (m *MocSessDB) GetUserSessions(userID uint32) ([]*models.Session, error) {
+	var res []*models.Session
+	for _, val := range m.Storage {
+		if val.UserID == userID {
+			res = append(res, val)
+		}
+	}
+	return res, nil
+}
+
 func (m *MocSessDB) DestroySession(sessID string) error {
 	if _, ok := m.Storage[sessID]; !ok {
 		return my_err.ErrSessionNotFound
@@ -55,8 +88,9 @@ const (
 
 var (
 	activeSession = &models.Session{
-		ID:     CookieInBase,
-		UserID: IdInBase,
+		ID:       CookieInBase,
+		UserID:   IdInBase,
+		LastSeen: time.Now().Unix(),
 	}
 	inactiveSession = &models.Session{
 		ID:     CookieNotInBase,
@@ -79,8 +113,9 @@ func TestCheck(t *testing.T) {
 		{
 			testCookie: CookieInBase,
 			testRes: &models.Session{
-				ID:     CookieInBase,
-				UserID: IdInBase,
+				ID:       CookieInBase,
+				UserID:   IdInBase,
+				LastSeen: activeSession.LastSeen,
 			},
 			err: nil,
 		},
@@ -124,7 +159,7 @@ func TestCreateSession(t *testing.T) {
 	}
 
 	for caseNum, test := range tests {
-		res, err := sm.Create(test.testId)
+		res, err := sm.Create(&models.User{ID: test.testId}, models.Device{})
 		if err != nil && test.err == nil {
 			t.Errorf("[%d] unexpected error: %#v", caseNum, err)
 		}
@@ -169,3 +204,140 @@ func TestDestroy(t *testing.T) {
 		}
 	}
 }
+
+// memSessDB keeps any number of sessions of a user like the redis repository does
+type memSessDB struct {
+	MocSessDB
+}
+
+func newMemSessDB(sessions ...*models.Session) *memSessDB {
+	m := &memSessDB{MocSessDB{Storage: map[string]*models.Session{}}}
+	for _, sess := range sessions {
+		m.Storage[sess.ID] = sess
+	}
+	return m
+}
+
+func (m *memSessDB) CreateSession(session *models.Session) error {
+	m.Storage[session.ID] = session
+	return nil
+}
+
+func (m *memSessDB) DestroySession(sessID string) error {
+	delete(m.Storage, sessID)
+	return nil
+}
+
+func (m *memSessDB) ids() []string {
+	res := make([]string, 0, len(m.Storage))
+	for key := range m.Storage {
+		res = append(res, key)
+	}
+	slices.Sort(res)
+	return res
+}
+
+func TestCheckLastSeen(t *testing.T) {
+	stale := &models.Session{ID: "stale", UserID: 3, LastSeen: time.Now().Add(-time.Hour).Unix()}
+	base := newMemSessDB(stale)
+	manager := NewSessionManager(base)
+
+	res, err := manager.Check(stale.ID)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, res.LastSeen, time.Now().Add(-time.Second).Unix())
+	assert.Equal(t, res.LastSeen, base.Storage[stale.ID].LastSeen)
+}
+
+func TestRotate(t *testing.T) {
+	old := &models.Session{ID: "old", UserID: 7, UserAgent: "Firefox", IP: "10.0.0.1", CreatedAt: 5, LoginAt: 5}
+	base := newMemSessDB(old)
+	manager := NewSessionManager(base)
+
+	res, err := manager.Rotate(&models.Session{ID: old.ID})
+	require.NoError(t, err)
+	assert.NotEqual(t, old.ID, res.ID)
+	assert.Equal(t, old.UserID, res.UserID)
+	assert.Equal(t, old.UserAgent, res.UserAgent)
+	assert.Equal(t, old.IP, res.IP)
+	assert.Equal(t, old.LoginAt, res.LoginAt)
+	assert.Equal(t, []string{res.ID}, base.ids())
+
+	_, err = manager.Rotate(old)
+	assert.ErrorIs(t, err, my_err.ErrNoAuth)
+	_, err = manager.Rotate(nil)
+	assert.ErrorIs(t, err, my_err.ErrNoAuth)
+}
+
+func TestSessionsOfUser(t *testing.T) {
+	phone := &models.Session{ID: "phone", UserID: 5, UserAgent: "Safari", LoginAt: 1, LastSeen: 2}
+	tablet := &models.Session{ID: "tablet", UserID: 5, UserAgent: "Chrome", LoginAt: 1, LastSeen: 3}
+	other := &models.Session{ID: "other", UserID: 6}
+	base := newMemSessDB(phone, tablet, other)
+	manager := NewSessionManager(base)
+
+	laptop, err := manager.Create(&models.User{ID: 5}, models.Device{UserAgent: "Firefox", IP: "10.0.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Firefox", laptop.UserAgent)
+	assert.Equal(t, "10.0.0.1", laptop.IP)
+
+	sessions, err := manager.ListSessions(laptop)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.SessionInfo{laptop.Info(laptop.ID), tablet.Info(laptop.ID), phone.Info(laptop.ID)}, sessions)
+	assert.True(t, sessions[0].Current)
+	assert.False(t, sessions[1].Current)
+	assert.NotEqual(t, laptop.ID, sessions[0].ID)
+
+	assert.ErrorIs(t, manager.RevokeSession(laptop, "unknown"), my_err.ErrSessionNotFound)
+	assert.ErrorIs(t, manager.RevokeSession(laptop, other.PublicID()), my_err.ErrSessionNotFound)
+	require.NoError(t, manager.RevokeSession(laptop, phone.PublicID()))
+	assert.Equal(t, []string{laptop.ID, "other", "tablet"}, base.ids())
+
+	require.NoError(t, manager.RevokeOtherSessions(laptop))
+	assert.Equal(t, []string{laptop.ID, "other"}, base.ids())
+
+	_, err = manager.ListSessions(nil)
+	assert.ErrorIs(t, err, my_err.ErrNoAuth)
+	assert.ErrorIs(t, manager.RevokeSession(nil, "id"), my_err.ErrNoAuth)
+	assert.ErrorIs(t, manager.RevokeOtherSessions(nil), my_err.ErrNoAuth)
+}
+
+func TestDestroyUserSessions(t *testing.T) {
+	base := newMemSessDB(
+		&models.Session{ID: "phone", UserID: 5},
+		&models.Session{ID: "laptop", UserID: 5},
+		&models.Session{ID: "other", UserID: 6},
+	)
+	manager := NewSessionManager(base)
+
+	require.NoError(t, manager.DestroyUserSessions(5))
+	assert.Equal(t, []string{"other"}, base.ids())
+	require.NoError(t, manager.DestroyUserSessions(7))
+}
+
+func TestMarkVerified(t *testing.T) {
+	base := newMemSessDB(&models.Session{ID: "other", UserID: 6})
+	manager := NewSessionManager(base)
+
+	phone, err := manager.Create(&models.User{ID: 5}, models.Device{})
+	require.NoError(t, err)
+	assert.True(t, phone.Unverified)
+	tablet, err := manager.Create(&models.User{ID: 6}, models.Device{})
+	require.NoError(t, err)
+
+	require.NoError(t, manager.MarkVerified(5))
+	assert.False(t, base.Storage[phone.ID].Unverified)
+	assert.True(t, base.Storage[tablet.ID].Unverified)
+
+	rotated, err := manager.Rotate(phone)
+	require.NoError(t, err)
+	assert.False(t, rotated.Unverified)
+
+	laptop, err := manager.Create(&models.User{ID: 5, EmailVerified: true}, models.Device{})
+	require.NoError(t, err)
+	assert.False(t, laptop.Unverified)
+
+	// the sessions issued before the verification was introduced stay verified when they are reissued
+	rotated, err = manager.Rotate(base.Storage["other"])
+	require.NoError(t, err)
+	assert.False(t, rotated.Unverified)
+}
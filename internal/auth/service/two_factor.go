@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+	"github.com/2024_2_BetterCallFirewall/pkg/totp"
+)
+
+const (
+	// twoFactorIssuer is the name the authenticator apps show the codes under
+	twoFactorIssuer = "Vilka"
+	// loginTokenTTL is how long the login waits for the second factor
+	loginTokenTTL = 5 * time.Minute
+	// maxLoginAttempts is how many wrong codes the login takes before it has to be started again
+	maxLoginAttempts = 5
+	// codeSkew is how many periods around the current one the codes are taken from
+	codeSkew          = 1
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 10
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EnrollTwoFactor makes the new secret of the user. The second factor is not enabled until it is confirmed,
+// so the user who has not set up the authenticator app can still log in
+func (a *AuthServiceImpl) EnrollTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactorSetup, error) {
+	twoFactor, err := a.getTwoFactor(ctx, userID)
+	if err != nil && !errors.Is(err, my_err.ErrTwoFactorNotFound) {
+		return nil, fmt.Errorf("enroll two factor: %w", err)
+	}
+	if err == nil && twoFactor.Enabled {
+		return nil, fmt.Errorf("enroll two factor: %w", my_err.ErrTwoFactorEnabled)
+	}
+
+	user, err := a.db.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("enroll two factor: %w", err)
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("enroll two factor: %w", err)
+	}
+	if err := a.db.SaveTwoFactor(ctx, &models.TwoFactor{UserID: userID, Secret: secret}); err != nil {
+		return nil, fmt.Errorf("enroll two factor: %w", err)
+	}
+
+	return &models.TwoFactorSetup{
+		Secret: secret,
+		URI:    totp.URI(twoFactorIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor enables the second factor by the code from the authenticator app
+// and returns the recovery codes, they are shown to the user once
+func (a *AuthServiceImpl) ConfirmTwoFactor(ctx context.Context, userID uint32, code string) ([]string, error) {
+	twoFactor, err := a.getTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("confirm two factor: %w", err)
+	}
+	if twoFactor.Enabled {
+		return nil, fmt.Errorf("confirm two factor: %w", my_err.ErrTwoFactorEnabled)
+	}
+	if err := a.checkCode(ctx, twoFactor, code); err != nil {
+		return nil, fmt.Errorf("confirm two factor: %w", err)
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("confirm two factor: %w", err)
+	}
+	twoFactor.Enabled = true
+	twoFactor.RecoveryCodes = hashes
+	if err := a.db.SaveTwoFactor(ctx, twoFactor); err != nil {
+		return nil, fmt.Errorf("confirm two factor: %w", err)
+	}
+
+	return codes, nil
+}
+
+// DisableTwoFactor turns the second factor off, it takes the code from the app or a recovery code
+func (a *AuthServiceImpl) DisableTwoFactor(ctx context.Context, userID uint32, code string) error {
+	twoFactor, err := a.enabledTwoFactor(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("disable two factor: %w", err)
+	}
+	if err := a.checkSecondFactor(ctx, twoFactor, code); err != nil {
+		return fmt.Errorf("disable two factor: %w", err)
+	}
+	if err := a.db.DeleteTwoFactor(ctx, userID); err != nil {
+		return fmt.Errorf("disable two factor: %w", err)
+	}
+
+	return nil
+}
+
+// BeginLogin returns the token the login is finished by if the user has the second factor enabled.
+// The token is empty if the user can be logged in right away
+func (a *AuthServiceImpl) BeginLogin(ctx context.Context, user *models.User) (string, error) {
+	_, err := a.enabledTwoFactor(ctx, user.ID)
+	if errors.Is(err, my_err.ErrTwoFactorNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("begin login: %w", err)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return "", fmt.Errorf("begin login: %w", err)
+	}
+	if err := a.loginTokens.Save(ctx, token, user.ID, loginTokenTTL); err != nil {
+		return "", fmt.Errorf("begin login: %w", err)
+	}
+
+	return token, nil
+}
+
+// CompleteLogin checks the second factor of the login started by BeginLogin and returns the user without the password
+func (a *AuthServiceImpl) CompleteLogin(ctx context.Context, login models.TwoFactorLogin) (*models.User, error) {
+	userID, err := a.loginTokens.Get(ctx, login.Token)
+	if err != nil {
+		return nil, fmt.Errorf("complete login: %w", err)
+	}
+
+	twoFactor, err := a.enabledTwoFactor(ctx, userID)
+	if errors.Is(err, my_err.ErrTwoFactorNotFound) {
+		// the second factor has been disabled since the login was started
+		return nil, fmt.Errorf("complete login: %w", my_err.ErrInvalidLoginToken)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("complete login: %w", err)
+	}
+
+	err = a.checkSecondFactor(ctx, twoFactor, login.Code)
+	if errors.Is(err, my_err.ErrWrongTwoFactorCode) {
+		if err := a.loginTokens.Fail(ctx, login.Token, maxLoginAttempts); err != nil {
+			return nil, fmt.Errorf("complete login: %w", err)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("complete login: %w", err)
+	}
+
+	if err := a.loginTokens.Delete(ctx, login.Token); err != nil {
+		return nil, fmt.Errorf("complete login: %w", err)
+	}
+	user, err := a.db.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("complete login: %w", err)
+	}
+	user.Password = ""
+
+	return user, nil
+}
+
+func (a *AuthServiceImpl) getTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactor, error) {
+	twoFactor, err := a.db.GetTwoFactor(ctx, userID)
+	if status.Code(err) == codes.NotFound {
+		return nil, my_err.ErrTwoFactorNotFound
+	}
+
+	return twoFactor, err
+}
+
+func (a *AuthServiceImpl) enabledTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactor, error) {
+	twoFactor, err := a.getTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !twoFactor.Enabled {
+		return nil, my_err.ErrTwoFactorNotFound
+	}
+
+	return twoFactor, nil
+}
+
+// checkSecondFactor takes the code from the app or, if it does not look like one, a recovery code
+func (a *AuthServiceImpl) checkSecondFactor(ctx context.Context, twoFactor *models.TwoFactor, code string) error {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) == totp.Digits {
+		return a.checkCode(ctx, twoFactor, code)
+	}
+
+	err := a.db.UseRecoveryCode(ctx, twoFactor.UserID, hashRecoveryCode(code))
+	if status.Code(err) == codes.NotFound {
+		return my_err.ErrWrongTwoFactorCode
+	}
+
+	return err
+}
+
+// checkCode checks the code from the app, the code can not be used twice
+func (a *AuthServiceImpl) checkCode(ctx context.Context, twoFactor *models.TwoFactor, code string) error {
+	step, ok := totp.Validate(twoFactor.Secret, strings.TrimSpace(code), time.Now(), codeSkew)
+	if !ok {
+		return my_err.ErrWrongTwoFactorCode
+	}
+
+	key := fmt.Sprintf("totp:%d:%d", twoFactor.UserID, step)
+	left, err := a.cooldowns.Start(ctx, key, (2*codeSkew+1)*totp.Period)
+	if err != nil {
+		return err
+	}
+	if left > 0 {
+		return my_err.ErrWrongTwoFactorCode
+	}
+
+	return nil
+}
+
+// newRecoveryCodes makes the recovery codes and their hashes, the codes are random enough for sha256 to be safe
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(recoveryEncoding.EncodeToString(raw))
+		code = code[:4] + "-" + code[4:8] + "-" + code[8:12] + "-" + code[12:16]
+
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode does not depend on the case and the dashes, the users type the codes in by hand
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(code, "-", ""))
+	sum := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(sum[:])
+}
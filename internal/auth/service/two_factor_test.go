@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+	"github.com/2024_2_BetterCallFirewall/pkg/totp"
+)
+
+// memTwoFactors keeps the second factors in memory like the profile service does
+type memTwoFactors struct {
+	MockDB
+	factors map[uint32]*models.TwoFactor
+}
+
+func newMemTwoFactors() *memTwoFactors {
+	return &memTwoFactors{factors: map[uint32]*models.TwoFactor{}}
+}
+
+func (m *memTwoFactors) GetTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactor, error) {
+	twoFactor, ok := m.factors[userID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "")
+	}
+	return &models.TwoFactor{UserID: userID, Secret: twoFactor.Secret, Enabled: twoFactor.Enabled}, nil
+}
+
+func (m *memTwoFactors) SaveTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error {
+	saved := *twoFactor
+	m.factors[twoFactor.UserID] = &saved
+	return nil
+}
+
+func (m *memTwoFactors) DeleteTwoFactor(ctx context.Context, userID uint32) error {
+	delete(m.factors, userID)
+	return nil
+}
+
+func (m *memTwoFactors) UseRecoveryCode(ctx context.Context, userID uint32, codeHash string) error {
+	twoFactor, ok := m.factors[userID]
+	if !ok {
+		return status.Error(codes.NotFound, "")
+	}
+	for i, hash := range twoFactor.RecoveryCodes {
+		if hash == codeHash {
+			twoFactor.RecoveryCodes = append(twoFactor.RecoveryCodes[:i], twoFactor.RecoveryCodes[i+1:]...)
+			return nil
+		}
+	}
+	return status.Error(codes.NotFound, "")
+}
+
+// memLoginTokens keeps the login tokens in memory, they do not expire
+type memLoginTokens struct {
+	tokens   map[string]uint32
+	attempts map[string]int
+}
+
+func newMemLoginTokens() *memLoginTokens {
+	return &memLoginTokens{tokens: map[string]uint32{}, attempts: map[string]int{}}
+}
+
+func (m *memLoginTokens) Save(ctx context.Context, token string, userID uint32, ttl time.Duration) error {
+	m.tokens[token] = userID
+	return nil
+}
+
+func (m *memLoginTokens) Get(ctx context.Context, token string) (uint32, error) {
+	userID, ok := m.tokens[token]
+	if !ok {
+		return 0, my_err.ErrInvalidLoginToken
+	}
+	return userID, nil
+}
+
+func (m *memLoginTokens) Fail(ctx context.Context, token string, maxAttempts int) error {
+	m.attempts[token]++
+	if m.attempts[token] >= maxAttempts {
+		delete(m.tokens, token)
+	}
+	return nil
+}
+
+func (m *memLoginTokens) Delete(ctx context.Context, token string) error {
+	if _, ok := m.tokens[token]; !ok {
+		return my_err.ErrInvalidLoginToken
+	}
+	delete(m.tokens, token)
+	return nil
+}
+
+func currentCode(t *testing.T, secret string, shift int64) string {
+	code, err := totp.Code(secret, totp.Step(time.Now())+shift)
+	require.NoError(t, err)
+	return code
+}
+
+func TestEnrollTwoFactor(t *testing.T) {
+	db := newMemTwoFactors()
+	serv := NewAuthServiceImpl(db, nil, newMemLoginTokens(), newMemCooldowns(), nil, Mailing{})
+	ctx := context.Background()
+
+	_, err := serv.ConfirmTwoFactor(ctx, 1, "123456")
+	assert.ErrorIs(t, err, my_err.ErrTwoFactorNotFound)
+
+	setup, err := serv.EnrollTwoFactor(ctx, 1)
+	require.NoError(t, err)
+	assert.Contains(t, setup.URI, "otpauth://totp/Vilka:email@email.com?")
+	assert.Contains(t, setup.URI, "secret="+setup.Secret)
+	assert.False(t, db.factors[1].Enabled)
+
+	// the second factor is not asked for until it is confirmed
+	token, err := serv.BeginLogin(ctx, &models.User{ID: 1})
+	require.NoError(t, err)
+	assert.Empty(t, token)
+
+	// the enrollment can be started again before the confirmation
+	setup, err = serv.EnrollTwoFactor(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, setup.Secret, db.factors[1].Secret)
+
+	_, err = serv.ConfirmTwoFactor(ctx, 1, "000000")
+	assert.ErrorIs(t, err, my_err.ErrWrongTwoFactorCode)
+	recoveryCodes, err := serv.ConfirmTwoFactor(ctx, 1, currentCode(t, setup.Secret, 0))
+	require.NoError(t, err)
+	assert.Len(t, recoveryCodes, recoveryCodeCount)
+	assert.Len(t, db.factors[1].RecoveryCodes, recoveryCodeCount)
+	assert.NotContains(t, db.factors[1].RecoveryCodes, recoveryCodes[0])
+	assert.True(t, db.factors[1].Enabled)
+
+	_, err = serv.EnrollTwoFactor(ctx, 1)
+	assert.ErrorIs(t, err, my_err.ErrTwoFactorEnabled)
+	_, err = serv.ConfirmTwoFactor(ctx, 1, currentCode(t, setup.Secret, 1))
+	assert.ErrorIs(t, err, my_err.ErrTwoFactorEnabled)
+
+	_, err = serv.EnrollTwoFactor(ctx, 2)
+	assert.ErrorIs(t, err, errMock)
+}
+
+func TestTwoFactorLogin(t *testing.T) {
+	db := newMemTwoFactors()
+	logins := newMemLoginTokens()
+	serv := NewAuthServiceImpl(db, nil, logins, newMemCooldowns(), nil, Mailing{})
+	ctx := context.Background()
+
+	setup, err := serv.EnrollTwoFactor(ctx, 1)
+	require.NoError(t, err)
+	recoveryCodes, err := serv.ConfirmTwoFactor(ctx, 1, currentCode(t, setup.Secret, -1))
+	require.NoError(t, err)
+
+	token, err := serv.BeginLogin(ctx, &models.User{ID: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	_, err = serv.CompleteLogin(ctx, models.TwoFactorLogin{Token: "unknown", Code: currentCode(t, setup.Secret, 0)})
+	assert.ErrorIs(t, err, my_err.ErrInvalidLoginToken)
+	_, err = serv.CompleteLogin(ctx, models.TwoFactorLogin{Token: token, Code: "000000"})
+	assert.ErrorIs(t, err, my_err.ErrWrongTwoFactorCode)
+	assert.Equal(t, 1, logins.attempts[token])
+	// the code the second factor has been confirmed by can not be used again
+	_, err = serv.CompleteLogin(ctx, models.TwoFactorLogin{Token: token, Code: currentCode(t, setup.Secret, -1)})
+	assert.ErrorIs(t, err, my_err.ErrWrongTwoFactorCode)
+
+	user, err := serv.CompleteLogin(ctx, models.TwoFactorLogin{Token: token, Code: currentCode(t, setup.Secret, 0)})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), user.ID)
+	assert.Empty(t, user.Password)
+	_, err = serv.CompleteLogin(ctx, models.TwoFactorLogin{Token: token, Code: currentCode(t, setup.Secret, 1)})
+	assert.ErrorIs(t, err, my_err.ErrInvalidLoginToken)
+
+	// a recovery code works once, it is typed in any case
+	token, err = serv.BeginLogin(ctx, &models.User{ID: 1})
+	require.NoError(t, err)
+	_, err = serv.CompleteLogin(ctx, models.TwoFactorLogin{Token: token, Code: " " + recoveryCodes[0] + " "})
+	require.NoError(t, err)
+	token, err = serv.BeginLogin(ctx, &models.User{ID: 1})
+	require.NoError(t, err)
+	_, err = serv.CompleteLogin(ctx, models.TwoFactorLogin{Token: token, Code: recoveryCodes[0]})
+	assert.ErrorIs(t, err, my_err.ErrWrongTwoFactorCode)
+
+	// the login is dropped after too many wrong codes
+	for i := 1; i < maxLoginAttempts; i++ {
+		_, err = serv.CompleteLogin(ctx, models.TwoFactorLogin{Token: token, Code: "000000"})
+		assert.ErrorIs(t, err, my_err.ErrWrongTwoFactorCode)
+	}
+	_, err = serv.CompleteLogin(ctx, models.TwoFactorLogin{Token: token, Code: currentCode(t, setup.Secret, 1)})
+	assert.ErrorIs(t, err, my_err.ErrInvalidLoginToken)
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	db := newMemTwoFactors()
+	logins := newMemLoginTokens()
+	serv := NewAuthServiceImpl(db, nil, logins, newMemCooldowns(), nil, Mailing{})
+	ctx := context.Background()
+
+	assert.ErrorIs(t, serv.DisableTwoFactor(ctx, 1, "123456"), my_err.ErrTwoFactorNotFound)
+
+	setup, err := serv.EnrollTwoFactor(ctx, 1)
+	require.NoError(t, err)
+	assert.ErrorIs(t, serv.DisableTwoFactor(ctx, 1, "123456"), my_err.ErrTwoFactorNotFound)
+	recoveryCodes, err := serv.ConfirmTwoFactor(ctx, 1, currentCode(t, setup.Secret, 0))
+	require.NoError(t, err)
+
+	token, err := serv.BeginLogin(ctx, &models.User{ID: 1})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, serv.DisableTwoFactor(ctx, 1, "wrong-recovery-code"), my_err.ErrWrongTwoFactorCode)
+	require.NoError(t, serv.DisableTwoFactor(ctx, 1, recoveryCodes[1]))
+	assert.NotContains(t, db.factors, uint32(1))
+
+	// the login started before the second factor was disabled is not finished
+	_, err = serv.CompleteLogin(ctx, models.TwoFactorLogin{Token: token, Code: recoveryCodes[2]})
+	assert.ErrorIs(t, err, my_err.ErrInvalidLoginToken)
+
+	_, err = NewAuthServiceImpl(MockDB{}, nil, logins, nil, nil, Mailing{}).BeginLogin(ctx, &models.User{ID: 1})
+	assert.NoError(t, err)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := newRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	assert.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`, codes[0])
+	assert.NotEqual(t, codes[0], codes[1])
+	assert.Equal(t, hashes[0], hashRecoveryCode(codes[0]))
+	assert.Equal(t, hashes[0], hashRecoveryCode(strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))))
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+const (
+	// verifyTokenTTL is how long the link from the letter about the email verification works
+	verifyTokenTTL = 24 * time.Hour
+	// resendCooldown is how often the letter about the email verification can be sent
+	resendCooldown = time.Minute
+)
+
+// ResendVerification sends the letter about the email verification to the user once again
+func (a *AuthServiceImpl) ResendVerification(ctx context.Context, userID uint32) error {
+	user, err := a.db.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("resend verification: %w", err)
+	}
+	if user.EmailVerified {
+		return fmt.Errorf("resend verification: %w", my_err.ErrEmailAlreadyVerified)
+	}
+
+	if err := a.sendVerification(ctx, user.ID, user.Email); err != nil {
+		return fmt.Errorf("resend verification: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail marks the email from the token verified and returns the id of its user.
+// The token does not work if the user has changed the email since it was sent
+func (a *AuthServiceImpl) VerifyEmail(ctx context.Context, token string) (uint32, error) {
+	userID, email, err := a.parseVerifyToken(token, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("verify email: %w", err)
+	}
+
+	err = a.db.VerifyEmail(ctx, userID, email)
+	if status.Code(err) == codes.NotFound {
+		return 0, fmt.Errorf("verify email: %w", my_err.ErrInvalidVerifyToken)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("verify email: %w", err)
+	}
+
+	return userID, nil
+}
+
+func (a *AuthServiceImpl) sendVerification(ctx context.Context, userID uint32, email string) error {
+	left, err := a.cooldowns.Start(ctx, "verify:"+strconv.FormatUint(uint64(userID), 10), resendCooldown)
+	if err != nil {
+		return err
+	}
+	if left > 0 {
+		return fmt.Errorf("%w, try again in %d seconds", my_err.ErrResendCooldown, int(left.Seconds())+1)
+	}
+
+	token := a.signVerifyToken(userID, email, time.Now().Add(verifyTokenTTL))
+	mail := &models.Mail{
+		To:      email,
+		Subject: "Email verification",
+		Body: fmt.Sprintf(
+			"Follow the link to verify the email: %s?token=%s\nThe link works for %d hours.",
+			a.mailing.VerifyURL, url.QueryEscape(token), int(verifyTokenTTL.Hours()),
+		),
+	}
+
+	return a.mailer.Send(ctx, mail)
+}
+
+// signVerifyToken makes the token of the email verification, it is the user id, the time it expires at
+// and the email signed by the secret of the service, so it is not stored anywhere
+func (a *AuthServiceImpl) signVerifyToken(userID uint32, email string, expires time.Time) string {
+	payload := fmt.Sprintf(
+		"%d.%d.%s", userID, expires.Unix(), base64.RawURLEncoding.EncodeToString([]byte(email)),
+	)
+
+	return payload + "." + a.verifySignature(payload)
+}
+
+func (a *AuthServiceImpl) parseVerifyToken(token string, now time.Time) (uint32, string, error) {
+	payload, signature, found := cutLast(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(a.verifySignature(payload))) {
+		return 0, "", my_err.ErrInvalidVerifyToken
+	}
+
+	parts := strings.Split(payload, ".")
+	if len(parts) != 3 {
+		return 0, "", my_err.ErrInvalidVerifyToken
+	}
+	userID, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, "", my_err.ErrInvalidVerifyToken
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || now.Unix() > expires {
+		return 0, "", my_err.ErrInvalidVerifyToken
+	}
+	email, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return 0, "", my_err.ErrInvalidVerifyToken
+	}
+
+	return uint32(userID), string(email), nil
+}
+
+func (a *AuthServiceImpl) verifySignature(payload string) string {
+	mac := hmac.New(sha256.New, a.mailing.VerifySecret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func cutLast(s, sep string) (string, string, bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+
+	return s[:i], s[i+len(sep):], true
+}
@@ -1,48 +1,210 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"net"
 	"time"
 
 	"github.com/gorilla/websocket"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
 type Client struct {
 	Socket         *websocket.Conn
-	Receive        chan *models.Message
+	Receive        chan *models.ChatEvent
+	userID         uint32
 	chatController *ChatController
+	// watching is owned by the hub, it lists the users whose presence the client is subscribed to
+	watching []uint32
+	// closeCode is set by the hub before it closes Receive, it tells the client why it is disconnected
+	closeCode int
 }
 
-func (c *Client) Read(userID uint32) {
+// Read handles the frames of the client until it disconnects, stays silent longer than pongWait
+// or sends a frame bigger than maxMessageSize. A frame that is not a valid event is answered with an error
+func (c *Client) Read(ctx context.Context, reqID string) {
 	defer c.Socket.Close()
+
+	conf := c.chatController.socket
+	c.Socket.SetReadLimit(conf.maxMessageSize)
+	if err := c.Socket.SetReadDeadline(time.Now().Add(conf.pongWait)); err != nil {
+		return
+	}
+	c.Socket.SetPongHandler(func(string) error {
+		return c.Socket.SetReadDeadline(time.Now().Add(conf.pongWait))
+	})
+
 	for {
-		msg := &models.Message{}
 		_, jsonMessage, err := c.Socket.ReadMessage()
 		if err != nil {
+			c.countDrop(err)
 			return
 		}
-		err = json.Unmarshal(jsonMessage, msg)
+		c.chatController.hub.metrics.MessageIn()
+
+		event, err := parseEvent(jsonMessage)
+		if err == nil {
+			err = c.handle(ctx, event)
+		}
 		if err != nil {
-			return
+			c.chatController.responder.LogError(err, reqID)
+			c.chatController.hub.Reply(c, errorEvent(err, event))
 		}
-		msg.Sender = userID
-		c.chatController.Messages <- msg
 	}
 }
 
+func (c *Client) handle(ctx context.Context, event *models.ChatEvent) error {
+	switch event.Type {
+	case models.ChatEventMessage:
+		event.Message.Sender = c.userID
+		return c.chatController.SendChatMsg(ctx, c, event.Message)
+
+	case models.ChatEventEdit:
+		event.Message.Sender = c.userID
+		return c.chatController.editChatMsg(ctx, event.Message)
+
+	case models.ChatEventDelete:
+		return c.chatController.deleteChatMsg(ctx, event.Message.ID, c.userID)
+
+	case models.ChatEventTyping:
+		event.Typing.Sender = c.userID
+		return c.chatController.sendTyping(ctx, c, event.Typing)
+
+	case models.ChatEventRead:
+		var err error
+		if event.Read.GroupID != 0 {
+			_, err = c.chatController.readGroup(ctx, c, c.userID, event.Read.GroupID)
+		} else {
+			_, err = c.chatController.readChat(ctx, c, c.userID, event.Read.Sender)
+		}
+		return err
+
+	case models.ChatEventPresence:
+		return c.chatController.watchPresence(c, event.Presence.UserID)
+
+	case models.ChatEventResume:
+		return c.chatController.resume(ctx, c, event.Resume)
+	}
+
+	return my_err.ErrWrongEvent
+}
+
+// Write sends the events to the client and pings it every pingPeriod. When the hub closes Receive
+// the client gets a close frame with the code the hub has set
 func (c *Client) Write() {
-	defer c.Socket.Close()
-	for msg := range c.Receive {
-		msg.CreatedAt = time.Now()
-		jsonForSend, err := json.Marshal(msg)
-		if err != nil {
-			return
+	conf := c.chatController.socket
+	ping := time.NewTicker(conf.pingPeriod)
+	defer func() {
+		ping.Stop()
+		c.Socket.Close()
+	}()
+
+	for {
+		select {
+		case event, ok := <-c.Receive:
+			if !ok {
+				c.writeClose(conf.writeWait)
+				return
+			}
+
+			jsonForSend, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+			if err := c.Socket.SetWriteDeadline(time.Now().Add(conf.writeWait)); err != nil {
+				return
+			}
+			if err := c.Socket.WriteMessage(websocket.TextMessage, jsonForSend); err != nil {
+				c.countDrop(err)
+				return
+			}
+			c.chatController.hub.metrics.MessageOut()
+
+		case <-ping.C:
+			if err := c.Socket.WriteControl(websocket.PingMessage, nil, time.Now().Add(conf.writeWait)); err != nil {
+				c.countDrop(err)
+				return
+			}
 		}
-		err = c.Socket.WriteMessage(websocket.TextMessage, jsonForSend)
-		if err != nil {
-			return
+	}
+}
+
+func (c *Client) writeClose(wait time.Duration) {
+	var msg []byte
+	switch c.closeCode {
+	case websocket.CloseTryAgainLater:
+		msg = websocket.FormatCloseMessage(c.closeCode, dropSlowConsumer)
+	case 0:
+		msg = websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	default:
+		msg = websocket.FormatCloseMessage(c.closeCode, "")
+	}
+
+	// the client may be gone already, there is nobody to tell it
+	_ = c.Socket.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wait))
+}
+
+// countDrop counts the connection as dropped if it is closed because of the limits of the server.
+// The client that sends a too big frame gets a close frame from the socket itself
+func (c *Client) countDrop(err error) {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, websocket.ErrReadLimit):
+		c.chatController.hub.metrics.Dropped(dropTooBig)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		c.chatController.hub.metrics.Dropped(dropTimeout)
+	}
+}
+
+// parseEvent reads an incoming frame, a frame without type is a bare message sent by older clients
+func parseEvent(data []byte) (*models.ChatEvent, error) {
+	event := &models.ChatEvent{}
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, my_err.ErrWrongEvent
+	}
+
+	if event.Type == "" {
+		msg := &models.Message{}
+		if err := json.Unmarshal(data, msg); err != nil {
+			return nil, my_err.ErrWrongEvent
+		}
+		return &models.ChatEvent{Type: models.ChatEventMessage, Message: msg}, nil
+	}
+
+	switch {
+	case (event.Type == models.ChatEventMessage || event.Type == models.ChatEventEdit ||
+		event.Type == models.ChatEventDelete) && event.Message == nil,
+		event.Type == models.ChatEventTyping && event.Typing == nil,
+		event.Type == models.ChatEventRead && event.Read == nil,
+		event.Type == models.ChatEventPresence && event.Presence == nil,
+		event.Type == models.ChatEventResume && event.Resume == nil:
+		return nil, my_err.ErrWrongEvent
+	}
+
+	return event, nil
+}
+
+// errorEvent tells the client what went wrong with the event without exposing internal errors,
+// an error about a message points to it by its ids
+func errorEvent(err error, event *models.ChatEvent) *models.ChatEvent {
+	text := "internal error"
+	for _, public := range []error{
+		my_err.ErrWrongEvent, my_err.ErrMessageNotFound, my_err.ErrMessageTooLong, my_err.ErrGroupNotFound,
+		my_err.ErrWrongFile, my_err.ErrWrongFiletype, my_err.ErrWrongClientID,
+	} {
+		if errors.Is(err, public) {
+			text = public.Error()
 		}
 	}
+
+	res := &models.ChatEvent{Type: models.ChatEventError, Error: text}
+	if event != nil && event.Message != nil {
+		res.Message = &models.Message{ID: event.Message.ID, ClientID: event.Message.ClientID}
+	}
+
+	return res
 }
@@ -2,9 +2,11 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"math"
 	"net/http"
+	"regexp"
 	"strconv"
 	"time"
 
@@ -28,15 +30,30 @@ type Responder interface {
 
 type ChatController struct {
 	chatService chat.ChatService
-	Messages    chan *models.Message
+	hub         *Hub
 	responder   Responder
+	socket      socketConfig
 }
 
-func NewChatController(service chat.ChatService, responder Responder) *ChatController {
+// socketConfig limits how long a connection may stay silent and how big its frames may be
+type socketConfig struct {
+	writeWait      time.Duration
+	pongWait       time.Duration
+	pingPeriod     time.Duration
+	maxMessageSize int64
+}
+
+func NewChatController(service chat.ChatService, hub *Hub, responder Responder) *ChatController {
 	return &ChatController{
 		chatService: service,
-		Messages:    make(chan *models.Message),
+		hub:         hub,
 		responder:   responder,
+		socket: socketConfig{
+			writeWait:      writeWait,
+			pongWait:       pongWait,
+			pingPeriod:     pongWait * 9 / 10,
+			maxMessageSize: maxMessageSize,
+		},
 	}
 }
 
@@ -44,16 +61,29 @@ const (
 	socketBufferSize  = 1024
 	messageBufferSize = 256
 	layout            = "2006-01-02T15:04:05Z"
+	clientIDLen       = 64
+	// resumeBatch keeps the replay well below the buffer of a client, so it is not dropped as a slow one
+	resumeBatch = 100
+	// writeWait is how long a frame may be written, a client that does not take it in time is dropped
+	writeWait = 10 * time.Second
+	// pongWait is how long a client may stay silent, the server pings it more often than that
+	pongWait = 60 * time.Second
+	// maxMessageSize fits any valid event, a bigger frame closes the connection
+	maxMessageSize = 16 * 1024
 )
 
-var (
-	upgrader = websocket.Upgrader{
-		ReadBufferSize: socketBufferSize, WriteBufferSize: socketBufferSize,
-		CheckOrigin: func(r *http.Request) bool { return true },
-	}
-	mapUserConn = make(map[uint32]*Client)
+// reasons the server closes a connection, counted by ConnMetrics
+const (
+	dropSlowConsumer = "slow_consumer"
+	dropTooBig       = "message_too_big"
+	dropTimeout      = "timeout"
 )
 
+var upgrader = websocket.Upgrader{
+	ReadBufferSize: socketBufferSize, WriteBufferSize: socketBufferSize,
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
 func (cc *ChatController) SetConnection(w http.ResponseWriter, r *http.Request) {
 	reqID, ok := r.Context().Value("requestID").(string)
 	if !ok {
@@ -66,7 +96,6 @@ func (cc *ChatController) SetConnection(w http.ResponseWriter, r *http.Request)
 		cc.responder.ErrorBadRequest(w, err, reqID)
 		return
 	}
-	ctx := r.Context()
 
 	socket, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -76,33 +105,216 @@ func (cc *ChatController) SetConnection(w http.ResponseWriter, r *http.Request)
 
 	client := &Client{
 		Socket:         socket,
-		Receive:        make(chan *models.Message, messageBufferSize),
+		Receive:        make(chan *models.ChatEvent, messageBufferSize),
+		userID:         sess.UserID,
 		chatController: cc,
 	}
-	mapUserConn[sess.UserID] = client
-	defer func() {
-		delete(mapUserConn, sess.UserID)
-		close(client.Receive)
-	}()
+	if !cc.hub.Register(client) {
+		socket.Close()
+		return
+	}
+	defer cc.hub.Unregister(client)
+
 	go client.Write()
-	go client.Read(sess.UserID)
-	cc.SendChatMsg(ctx, reqID)
+	client.Read(r.Context(), reqID)
 }
 
-func (cc *ChatController) SendChatMsg(ctx context.Context, reqID string) {
-	for msg := range cc.Messages {
-		err := cc.chatService.SendNewMessage(ctx, msg.Receiver, msg.Sender, msg.Content)
-		if err != nil {
-			cc.responder.LogError(err, reqID)
-			return
-		}
+// SendChatMsg stores the message and hands it to the hub for delivery.
+// A message sent again with the same client id is only acked, it has been delivered the first time
+func (cc *ChatController) SendChatMsg(ctx context.Context, from *Client, msg *models.Message) error {
+	if err := validateMessage(msg); err != nil {
+		return err
+	}
 
-		resConn, ok := mapUserConn[msg.Receiver]
-		if ok {
-			//resConn.Socket.ReadMessage()
-			resConn.Receive <- msg
+	err := cc.chatService.SendNewMessage(ctx, msg)
+	if errors.Is(err, my_err.ErrMessageExists) {
+		if from != nil {
+			cc.hub.Reply(from, &models.ChatEvent{Type: models.ChatEventAck, Message: msg})
 		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	event := &models.ChatEvent{Type: models.ChatEventMessage, Message: msg}
+	if err := cc.toGroup(ctx, event, msg.GroupID, msg.Sender); err != nil {
+		return err
+	}
+	if err := cc.hub.Send(event, from); err != nil {
+		return err
+	}
+
+	if from != nil {
+		cc.hub.Reply(from, &models.ChatEvent{Type: models.ChatEventAck, Message: msg})
+	}
+
+	return nil
+}
+
+// editChatMsg changes the message of its sender and shows the change to every connection of both users,
+// the file attached to the message stays as it is
+func (cc *ChatController) editChatMsg(ctx context.Context, msg *models.Message) error {
+	msg.File = ""
+	if err := validateMessage(msg); err != nil {
+		return err
 	}
+
+	if err := cc.chatService.EditMessage(ctx, msg); err != nil {
+		return err
+	}
+
+	event := &models.ChatEvent{Type: models.ChatEventEdit, Message: msg}
+	if err := cc.toGroup(ctx, event, msg.GroupID, msg.Sender); err != nil {
+		return err
+	}
+
+	return cc.hub.Send(event, nil)
+}
+
+// deleteChatMsg deletes the message of its sender and shows the change to every connection of both users
+func (cc *ChatController) deleteChatMsg(ctx context.Context, messageID uint32, userID uint32) error {
+	msg, err := cc.chatService.DeleteMessage(ctx, messageID, userID)
+	if err != nil {
+		return err
+	}
+
+	event := &models.ChatEvent{Type: models.ChatEventDelete, Message: msg}
+	if err := cc.toGroup(ctx, event, msg.GroupID, userID); err != nil {
+		return err
+	}
+
+	return cc.hub.Send(event, nil)
+}
+
+// sendTyping passes the typing indicator to the receiver or to the members of the group
+func (cc *ChatController) sendTyping(ctx context.Context, from *Client, typing *models.Typing) error {
+	event := &models.ChatEvent{Type: models.ChatEventTyping, Typing: typing}
+	if err := cc.toGroup(ctx, event, typing.GroupID, typing.Sender); err != nil {
+		return err
+	}
+
+	return cc.hub.Send(event, from)
+}
+
+// toGroup addresses the event to the members of the group, userID must be one of them.
+// Events of direct chats are left as they are
+func (cc *ChatController) toGroup(ctx context.Context, event *models.ChatEvent, groupID uint32, userID uint32) error {
+	if groupID == 0 {
+		return nil
+	}
+
+	members, err := cc.chatService.GetGroupMembers(ctx, groupID, userID)
+	if err != nil {
+		return err
+	}
+	event.Members = members
+
+	return nil
+}
+
+// resume replays to the client up to resumeBatch messages it has missed after the last one it has seen.
+// The resume event after them tells the last replayed message and if the client should ask for more
+func (cc *ChatController) resume(ctx context.Context, from *Client, resume *models.Resume) error {
+	messages, err := cc.chatService.GetMissedMessages(ctx, from.userID, resume.LastID, resume.LastTime, resumeBatch+1)
+	if err != nil {
+		return err
+	}
+
+	res := &models.Resume{LastID: resume.LastID, LastTime: resume.LastTime}
+	if len(messages) > resumeBatch {
+		messages = messages[:resumeBatch]
+		res.HasMore = true
+	}
+
+	for _, msg := range messages {
+		cc.hub.Reply(from, &models.ChatEvent{Type: models.ChatEventMessage, Message: msg})
+		res.LastID = msg.ID
+		res.LastTime = msg.CreatedAt
+	}
+	cc.hub.Reply(from, &models.ChatEvent{Type: models.ChatEventResume, Resume: res})
+
+	return nil
+}
+
+// watchPresence subscribes the client to presence of the user and sends the current one right away
+func (cc *ChatController) watchPresence(from *Client, userID uint32) error {
+	cc.hub.Watch(from, userID)
+
+	presence, err := cc.hub.Presence([]uint32{userID})
+	if err != nil {
+		return err
+	}
+
+	p := presence[userID]
+	p.UserID = userID
+	cc.hub.Reply(from, &models.ChatEvent{Type: models.ChatEventPresence, Presence: &p})
+
+	return nil
+}
+
+// attachmentPath matches the paths of files saved by the file service, documents are saved with an extension
+var attachmentPath = regexp.MustCompile(`^/image/[0-9a-f-]{36}(\.[a-z]+)?$`)
+
+func validateMessage(msg *models.Message) error {
+	if len(msg.Content) > 499 {
+		return my_err.ErrMessageTooLong
+	}
+
+	if len(msg.ClientID) > clientIDLen {
+		return my_err.ErrWrongClientID
+	}
+
+	if msg.File == "" {
+		return nil
+	}
+
+	ext := attachmentPath.FindStringSubmatch(string(msg.File))
+	if ext == nil {
+		return my_err.ErrWrongFile
+	}
+	if ext[1] != "" && !msg.File.IsDocument() {
+		return my_err.ErrWrongFiletype
+	}
+
+	return nil
+}
+
+// readChat marks the chat as read and tells the sender about it if anything was unread
+func (cc *ChatController) readChat(
+	ctx context.Context, from *Client, userID uint32, chatID uint32,
+) (*models.ReadReceipt, error) {
+	receipt, err := cc.chatService.MarkChatAsRead(ctx, userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.LastReadID == 0 {
+		return receipt, nil
+	}
+
+	return receipt, cc.hub.Send(&models.ChatEvent{Type: models.ChatEventRead, Read: receipt}, from)
+}
+
+// readGroup marks the group as read and tells the members about it if anything was unread
+func (cc *ChatController) readGroup(
+	ctx context.Context, from *Client, userID uint32, groupID uint32,
+) (*models.ReadReceipt, error) {
+	receipt, err := cc.chatService.MarkGroupAsRead(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.LastReadID == 0 {
+		return receipt, nil
+	}
+
+	event := &models.ChatEvent{Type: models.ChatEventRead, Read: receipt}
+	if err := cc.toGroup(ctx, event, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	return receipt, cc.hub.Send(event, from)
 }
 
 func (cc *ChatController) GetAllChats(w http.ResponseWriter, r *http.Request) {
@@ -148,8 +360,12 @@ func (cc *ChatController) GetAllChats(w http.ResponseWriter, r *http.Request) {
 }
 
 func GetIdFromURL(r *http.Request) (uint32, error) {
+	return getURLVarID(r, "id")
+}
+
+func getURLVarID(r *http.Request, name string) (uint32, error) {
 	vars := mux.Vars(r)
-	id := vars["id"]
+	id := vars[name]
 	if id == "" {
 		return 0, my_err.ErrEmptyId
 	}
@@ -210,3 +426,187 @@ func (cc *ChatController) GetChat(w http.ResponseWriter, r *http.Request) {
 
 	cc.responder.OutputJSON(w, messages, reqID)
 }
+
+func (cc *ChatController) EditMessage(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	msg := &models.Message{}
+	if err := json.NewDecoder(r.Body).Decode(msg); err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+	msg.ID = id
+	msg.Sender = sess.UserID
+
+	err = cc.editChatMsg(r.Context(), msg)
+	if errors.Is(err, my_err.ErrMessageTooLong) || errors.Is(err, my_err.ErrMessageNotFound) {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, msg, reqID)
+}
+
+func (cc *ChatController) DeleteMessage(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	err = cc.deleteChatMsg(r.Context(), id, sess.UserID)
+	if errors.Is(err, my_err.ErrMessageNotFound) {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, "message is deleted", reqID)
+}
+
+func (cc *ChatController) ReadChat(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	receipt, err := cc.readChat(r.Context(), nil, sess.UserID, id)
+	if err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, receipt, reqID)
+}
+
+func (cc *ChatController) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	count, err := cc.chatService.GetUnreadCount(r.Context(), sess.UserID)
+	if err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, &models.UnreadCounter{Count: count}, reqID)
+}
+
+func (cc *ChatController) SetReactionOnMessage(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	var reaction models.Reaction
+	if err := json.NewDecoder(r.Body).Decode(&reaction); err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	err = cc.chatService.SetReactionToMessage(r.Context(), id, sess.UserID, reaction.Type)
+	if errors.Is(err, my_err.ErrWrongReaction) || errors.Is(err, my_err.ErrMessageNotFound) {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, reaction, reqID)
+}
+
+func (cc *ChatController) DeleteReactionFromMessage(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if err := cc.chatService.DeleteReactionFromMessage(r.Context(), id, sess.UserID); err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, "reaction is unset from message", reqID)
+}
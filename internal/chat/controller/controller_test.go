@@ -5,16 +5,20 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
 	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
+var errMock = errors.New("mock error")
+
 type mocks struct {
 	chatService *MockChatService
 	responder   *MockResponder
@@ -26,7 +30,7 @@ func getController(ctrl *gomock.Controller) (*ChatController, *mocks) {
 		responder:   NewMockResponder(ctrl),
 	}
 
-	return NewChatController(m.chatService, m.responder), m
+	return NewChatController(m.chatService, NewHub(nil, nil, nil, logrus.New()), m.responder), m
 }
 
 func TestNewController(t *testing.T) {
@@ -455,6 +459,421 @@ func TestGetChat(t *testing.T) {
 	}
 }
 
+func TestReadChat(t *testing.T) {
+	tests := []TableTest[Response, Request]{
+		{
+			name: "1",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodPut, "/api/v1/messages/chat/1/read", nil)
+				w := httptest.NewRecorder()
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ChatController, request Request) (Response, error) {
+				implementation.ReadChat(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusBadRequest, Body: "bad request"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(
+					func(w, err, req any) {
+						request.w.WriteHeader(http.StatusBadRequest)
+						request.w.Write([]byte("bad request"))
+					},
+				)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodPut, "/api/v1/messages/chat/1/read", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				req = mux.SetURLVars(req, map[string]string{"id": "2"})
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ChatController, request Request) (Response, error) {
+				implementation.ReadChat(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusInternalServerError, Body: "error"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.chatService.EXPECT().MarkChatAsRead(gomock.Any(), uint32(1), uint32(2)).Return(nil, errMock)
+				m.responder.EXPECT().ErrorInternal(request.w, gomock.Any(), gomock.Any()).Do(
+					func(w, err, req any) {
+						request.w.WriteHeader(http.StatusInternalServerError)
+						request.w.Write([]byte("error"))
+					},
+				)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodPut, "/api/v1/messages/chat/1/read", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				req = mux.SetURLVars(req, map[string]string{"id": "2"})
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ChatController, request Request) (Response, error) {
+				implementation.ReadChat(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusOK, Body: "OK"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.chatService.EXPECT().MarkChatAsRead(gomock.Any(), uint32(1), uint32(2)).
+					Return(&models.ReadReceipt{Reader: 1, Sender: 2, LastReadID: 5}, nil)
+				m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(
+					func(w, data, req any) {
+						request.w.WriteHeader(http.StatusOK)
+						request.w.Write([]byte("OK"))
+					},
+				)
+			},
+		},
+	}
+
+	runTableTests(t, tests)
+}
+
+func TestGetUnreadCount(t *testing.T) {
+	tests := []TableTest[Response, Request]{
+		{
+			name: "1",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/messages/unread", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ChatController, request Request) (Response, error) {
+				implementation.GetUnreadCount(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusInternalServerError, Body: "error"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.chatService.EXPECT().GetUnreadCount(gomock.Any(), uint32(1)).Return(uint32(0), errMock)
+				m.responder.EXPECT().ErrorInternal(request.w, gomock.Any(), gomock.Any()).Do(
+					func(w, err, req any) {
+						request.w.WriteHeader(http.StatusInternalServerError)
+						request.w.Write([]byte("error"))
+					},
+				)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/messages/unread", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ChatController, request Request) (Response, error) {
+				implementation.GetUnreadCount(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusOK, Body: "OK"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.chatService.EXPECT().GetUnreadCount(gomock.Any(), uint32(1)).Return(uint32(4), nil)
+				m.responder.EXPECT().OutputJSON(request.w, &models.UnreadCounter{Count: 4}, gomock.Any()).Do(
+					func(w, data, req any) {
+						request.w.WriteHeader(http.StatusOK)
+						request.w.Write([]byte("OK"))
+					},
+				)
+			},
+		},
+	}
+
+	runTableTests(t, tests)
+}
+
+func TestEditMessage(t *testing.T) {
+	tests := []TableTest[Response, Request]{
+		{
+			name: "1",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodPut, "/api/v1/messages/10", strings.NewReader("{"))
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				req = mux.SetURLVars(req, map[string]string{"id": "10"})
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ChatController, request Request) (Response, error) {
+				implementation.EditMessage(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusBadRequest, Body: "bad request"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(
+					func(w, err, req any) {
+						request.w.WriteHeader(http.StatusBadRequest)
+						request.w.Write([]byte("bad request"))
+					},
+				)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodPut, "/api/v1/messages/10", strings.NewReader(`{"content":"hi"}`))
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				req = mux.SetURLVars(req, map[string]string{"id": "10"})
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ChatController, request Request) (Response, error) {
+				implementation.EditMessage(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusBadRequest, Body: "bad request"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.chatService.EXPECT().EditMessage(gomock.Any(), &models.Message{ID: 10, Sender: 1, Content: "hi"}).
+					Return(my_err.ErrMessageNotFound)
+				m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(
+					func(w, err, req any) {
+						request.w.WriteHeader(http.StatusBadRequest)
+						request.w.Write([]byte("bad request"))
+					},
+				)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodPut, "/api/v1/messages/10", strings.NewReader(`{"content":"hi"}`))
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				req = mux.SetURLVars(req, map[string]string{"id": "10"})
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ChatController, request Request) (Response, error) {
+				implementation.EditMessage(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusOK, Body: "OK"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.chatService.EXPECT().EditMessage(gomock.Any(), &models.Message{ID: 10, Sender: 1, Content: "hi"}).
+					Return(nil)
+				m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(
+					func(w, data, req any) {
+						request.w.WriteHeader(http.StatusOK)
+						request.w.Write([]byte("OK"))
+					},
+				)
+			},
+		},
+	}
+
+	runTableTests(t, tests)
+}
+
+func TestDeleteMessage(t *testing.T) {
+	tests := []TableTest[Response, Request]{
+		{
+			name: "1",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodDelete, "/api/v1/messages/10", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				req = mux.SetURLVars(req, map[string]string{"id": "10"})
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ChatController, request Request) (Response, error) {
+				implementation.DeleteMessage(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusBadRequest, Body: "bad request"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.chatService.EXPECT().DeleteMessage(gomock.Any(), uint32(10), uint32(1)).
+					Return(nil, my_err.ErrMessageNotFound)
+				m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(
+					func(w, err, req any) {
+						request.w.WriteHeader(http.StatusBadRequest)
+						request.w.Write([]byte("bad request"))
+					},
+				)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodDelete, "/api/v1/messages/10", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				req = mux.SetURLVars(req, map[string]string{"id": "10"})
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ChatController, request Request) (Response, error) {
+				implementation.DeleteMessage(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusInternalServerError, Body: "error"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.chatService.EXPECT().DeleteMessage(gomock.Any(), uint32(10), uint32(1)).Return(nil, errMock)
+				m.responder.EXPECT().ErrorInternal(request.w, gomock.Any(), gomock.Any()).Do(
+					func(w, err, req any) {
+						request.w.WriteHeader(http.StatusInternalServerError)
+						request.w.Write([]byte("error"))
+					},
+				)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodDelete, "/api/v1/messages/10", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				req = mux.SetURLVars(req, map[string]string{"id": "10"})
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ChatController, request Request) (Response, error) {
+				implementation.DeleteMessage(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusOK, Body: "OK"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.chatService.EXPECT().DeleteMessage(gomock.Any(), uint32(10), uint32(1)).
+					Return(&models.Message{ID: 10, Sender: 1, Receiver: 2}, nil)
+				m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(
+					func(w, data, req any) {
+						request.w.WriteHeader(http.StatusOK)
+						request.w.Write([]byte("OK"))
+					},
+				)
+			},
+		},
+	}
+
+	runTableTests(t, tests)
+}
+
+func TestValidateMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     *models.Message
+		wantErr error
+	}{
+		{name: "text", msg: &models.Message{Content: "hi"}},
+		{name: "too long", msg: &models.Message{Content: strings.Repeat("a", 500)}, wantErr: my_err.ErrMessageTooLong},
+		{name: "photo", msg: &models.Message{File: "/image/0b6f4c1e-3c2a-4d7e-9a51-8f0e2b7c1d3a"}},
+		{name: "document", msg: &models.Message{File: "/image/0b6f4c1e-3c2a-4d7e-9a51-8f0e2b7c1d3a.pdf"}},
+		{
+			name:    "unknown extension",
+			msg:     &models.Message{File: "/image/0b6f4c1e-3c2a-4d7e-9a51-8f0e2b7c1d3a.exe"},
+			wantErr: my_err.ErrWrongFiletype,
+		},
+		{name: "foreign path", msg: &models.Message{File: "/etc/passwd"}, wantErr: my_err.ErrWrongFile},
+		{name: "path traversal", msg: &models.Message{File: "/image/../../etc/passwd"}, wantErr: my_err.ErrWrongFile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, validateMessage(tt.msg), tt.wantErr)
+		})
+	}
+}
+
+func runTableTests(t *testing.T, tests []TableTest[Response, Request]) {
+	for _, v := range tests {
+		t.Run(
+			v.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				serv, mock := getController(ctrl)
+				ctx := context.Background()
+
+				input, err := v.SetupInput()
+				if err != nil {
+					t.Error(err)
+				}
+
+				v.SetupMock(*input, mock)
+
+				res, err := v.ExpectedResult()
+				if err != nil {
+					t.Error(err)
+				}
+
+				actual, err := v.Run(ctx, serv, *input)
+				assert.Equal(t, res, actual)
+				if !errors.Is(err, v.ExpectedErr) {
+					t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+				}
+			},
+		)
+	}
+}
+
 type Request struct {
 	w *httptest.ResponseRecorder
 	r *http.Request
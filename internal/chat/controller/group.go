@@ -0,0 +1,346 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+const groupTitleLen = 50
+
+func validateGroup(group *models.GroupRequest) error {
+	group.Title = strings.TrimSpace(group.Title)
+	if group.Title == "" || utf8.RuneCountInString(group.Title) > groupTitleLen {
+		return my_err.ErrWrongGroupTitle
+	}
+
+	return nil
+}
+
+// notifyGroup shows the change of the group to its members and to the users who have just left it
+func (cc *ChatController) notifyGroup(group *models.GroupChat, left ...uint32) error {
+	event := &models.ChatEvent{Type: models.ChatEventGroup, Group: group, Members: left}
+	for _, m := range group.Members {
+		event.Members = append(event.Members, m.AuthorID)
+	}
+
+	if len(event.Members) == 0 {
+		return nil
+	}
+
+	return cc.hub.Send(event, nil)
+}
+
+// outputGroup sends the changed group in response and to the connections of its members
+func (cc *ChatController) outputGroup(
+	w http.ResponseWriter, reqID string, group *models.GroupChat, err error, left ...uint32,
+) {
+	if errors.Is(err, my_err.ErrGroupNotFound) || errors.Is(err, my_err.ErrUserNotFound) ||
+		errors.Is(err, my_err.ErrSameUser) || errors.Is(err, my_err.ErrAccessDenied) {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	if err := cc.notifyGroup(group, left...); err != nil {
+		cc.responder.LogError(err, reqID)
+	}
+
+	cc.responder.OutputJSON(w, group, reqID)
+}
+
+func (cc *ChatController) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	request := &models.GroupRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if err := validateGroup(request); err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	group, err := cc.chatService.CreateGroup(r.Context(), sess.UserID, request)
+	cc.outputGroup(w, reqID, group, err)
+}
+
+func (cc *ChatController) GetGroup(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	group, err := cc.chatService.GetGroup(r.Context(), id, sess.UserID)
+	if errors.Is(err, my_err.ErrGroupNotFound) {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, group, reqID)
+}
+
+func (cc *ChatController) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	request := &models.GroupRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if err := validateGroup(request); err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	group, err := cc.chatService.UpdateGroup(r.Context(), id, sess.UserID, request)
+	cc.outputGroup(w, reqID, group, err)
+}
+
+func (cc *ChatController) GetGroupChat(w http.ResponseWriter, r *http.Request) {
+	var (
+		reqID, ok     = r.Context().Value("requestID").(string)
+		lastTimeQuery = r.URL.Query().Get("lastTime")
+		lastTime      time.Time
+		err           error
+	)
+
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	if lastTimeQuery == "" {
+		lastTime = time.Now()
+	} else {
+		lastTime, err = time.Parse(layout, lastTimeQuery)
+		if err != nil {
+			cc.responder.ErrorBadRequest(w, my_err.ErrWrongDateFormat, reqID)
+			return
+		}
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	messages, err := cc.chatService.GetGroupChat(r.Context(), sess.UserID, id, lastTime)
+	if errors.Is(err, my_err.ErrNoMoreContent) {
+		cc.responder.OutputNoMoreContentJSON(w, reqID)
+		return
+	}
+
+	if err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, messages, reqID)
+}
+
+func (cc *ChatController) ReadGroup(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	receipt, err := cc.readGroup(r.Context(), nil, sess.UserID, id)
+	if errors.Is(err, my_err.ErrGroupNotFound) {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, receipt, reqID)
+}
+
+func (cc *ChatController) AddGroupMembers(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	request := &models.GroupRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	group, err := cc.chatService.AddGroupMembers(r.Context(), id, sess.UserID, request.Members)
+	cc.outputGroup(w, reqID, group, err)
+}
+
+func (cc *ChatController) KickGroupMember(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	memberID, err := getURLVarID(r, "user")
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	group, err := cc.chatService.KickGroupMember(r.Context(), id, sess.UserID, memberID)
+	cc.outputGroup(w, reqID, group, err, memberID)
+}
+
+func (cc *ChatController) LeaveGroup(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	group, err := cc.chatService.LeaveGroup(r.Context(), id, sess.UserID)
+	cc.outputGroup(w, reqID, group, err, sess.UserID)
+}
+
+func (cc *ChatController) SetGroupAdmin(w http.ResponseWriter, r *http.Request) {
+	cc.setGroupAdmin(w, r, true)
+}
+
+func (cc *ChatController) UnsetGroupAdmin(w http.ResponseWriter, r *http.Request) {
+	cc.setGroupAdmin(w, r, false)
+}
+
+func (cc *ChatController) setGroupAdmin(w http.ResponseWriter, r *http.Request, isAdmin bool) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	id, err := GetIdFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	memberID, err := getURLVarID(r, "user")
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	group, err := cc.chatService.SetGroupAdmin(r.Context(), id, sess.UserID, memberID, isAdmin)
+	cc.outputGroup(w, reqID, group, err)
+}
@@ -0,0 +1,559 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+const (
+	brokerRetryDelay        = time.Second
+	presenceRefreshInterval = 30 * time.Second
+)
+
+// Broker passes events to the other chat instances, so users connected to different instances can talk
+type Broker interface {
+	Publish(event *models.ChatEvent) error
+	Listen(ctx context.Context, handle func(*models.ChatEvent)) error
+}
+
+// PresenceTracker stores online status of the users connected to this instance where other services can read it
+type PresenceTracker interface {
+	Online(userID uint32) (bool, error)
+	Offline(userID uint32, lastSeen time.Time) (bool, error)
+	Refresh(userIDs []uint32) error
+	GetPresence(userIDs []uint32) (map[uint32]models.Presence, error)
+}
+
+// ConnMetrics counts the connections of this instance and the frames passed through them
+type ConnMetrics interface {
+	Connected()
+	Disconnected()
+	MessageIn()
+	MessageOut()
+	Dropped(reason string)
+	PresenceCoalesced()
+}
+
+type noMetrics struct{}
+
+func (noMetrics) Connected()         {}
+func (noMetrics) Disconnected()      {}
+func (noMetrics) MessageIn()         {}
+func (noMetrics) MessageOut()        {}
+func (noMetrics) Dropped(string)     {}
+func (noMetrics) PresenceCoalesced() {}
+
+type delivery struct {
+	event *models.ChatEvent
+	from  *Client
+}
+
+type connQuery struct {
+	userID uint32
+	resp   chan int
+}
+
+type watchRequest struct {
+	client *Client
+	userID uint32
+}
+
+type presenceChange struct {
+	online bool
+	at     time.Time
+}
+
+// presenceQueue holds the presence changes that are not saved yet. Only the latest change of every user is kept,
+// so the hub never waits for the tracker and the broker however slow they are
+type presenceQueue struct {
+	mu        sync.Mutex
+	changes   map[uint32]presenceChange
+	refresh   []uint32
+	coalesced int
+	closed    bool
+	ready     chan struct{}
+}
+
+func newPresenceQueue() *presenceQueue {
+	return &presenceQueue{
+		changes: make(map[uint32]presenceChange),
+		ready:   make(chan struct{}, 1),
+	}
+}
+
+// push returns zero if the change of the user is new. If it replaces the change not saved yet,
+// it returns how many changes have been replaced since the queue was taken last time
+func (q *presenceQueue) push(userID uint32, change presenceChange) int {
+	q.mu.Lock()
+	coalesced := 0
+	if _, ok := q.changes[userID]; ok {
+		q.coalesced++
+		coalesced = q.coalesced
+	}
+	q.changes[userID] = change
+	q.mu.Unlock()
+	q.signal()
+
+	return coalesced
+}
+
+// pushRefresh replaces the users to refresh, the later list has all the users still connected
+func (q *presenceQueue) pushRefresh(userIDs []uint32) {
+	q.mu.Lock()
+	q.refresh = append(make([]uint32, 0, len(userIDs)), userIDs...)
+	q.mu.Unlock()
+	q.signal()
+}
+
+func (q *presenceQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.signal()
+}
+
+func (q *presenceQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// take waits for the changes, ok is false once the queue is closed and everything is taken
+func (q *presenceQueue) take() (changes map[uint32]presenceChange, refresh []uint32, ok bool) {
+	for {
+		q.mu.Lock()
+		if len(q.changes) != 0 || q.refresh != nil {
+			changes, refresh = q.changes, q.refresh
+			q.changes, q.refresh, q.coalesced = make(map[uint32]presenceChange), nil, 0
+			q.mu.Unlock()
+			return changes, refresh, true
+		}
+		closed := q.closed
+		q.mu.Unlock()
+		if closed {
+			return nil, nil, false
+		}
+		<-q.ready
+	}
+}
+
+// Hub owns the registry of open connections. All access to the registry goes
+// through Run, so connections of one user may come and go from any goroutine.
+type Hub struct {
+	clients    map[uint32]map[*Client]struct{}
+	watchers   map[uint32]map[*Client]struct{}
+	register   chan *Client
+	unregister chan *Client
+	broadcast  chan delivery
+	reply      chan delivery
+	remote     chan *models.ChatEvent
+	watch      chan watchRequest
+	query      chan connQuery
+	presence   *presenceQueue
+	done       chan struct{}
+	stopped    chan struct{}
+	stopOnce   sync.Once
+	broker     Broker
+	tracker    PresenceTracker
+	metrics    ConnMetrics
+	logger     *logrus.Logger
+}
+
+// NewHub creates a hub, broker and tracker may be nil if the chat runs as a single instance,
+// metrics may be nil if nothing is counted
+func NewHub(broker Broker, tracker PresenceTracker, metrics ConnMetrics, logger *logrus.Logger) *Hub {
+	if metrics == nil {
+		metrics = noMetrics{}
+	}
+
+	return &Hub{
+		clients:    make(map[uint32]map[*Client]struct{}),
+		watchers:   make(map[uint32]map[*Client]struct{}),
+		register:   make(chan *Client),
+		unregister: make(chan *Client),
+		broadcast:  make(chan delivery, messageBufferSize),
+		reply:      make(chan delivery, messageBufferSize),
+		remote:     make(chan *models.ChatEvent, messageBufferSize),
+		watch:      make(chan watchRequest),
+		query:      make(chan connQuery),
+		presence:   newPresenceQueue(),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+		broker:     broker,
+		tracker:    tracker,
+		metrics:    metrics,
+		logger:     logger,
+	}
+}
+
+func (h *Hub) Run() {
+	defer close(h.stopped)
+
+	if h.broker != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		listening := make(chan struct{})
+		go func() {
+			defer close(listening)
+			h.listen(ctx)
+		}()
+		defer func() {
+			cancel()
+			<-listening
+		}()
+	}
+
+	updating := make(chan struct{})
+	go func() {
+		defer close(updating)
+		h.update()
+	}()
+	defer func() {
+		h.presence.close()
+		<-updating
+	}()
+
+	refresh := time.NewTicker(presenceRefreshInterval)
+	defer refresh.Stop()
+
+	for {
+		select {
+		case client := <-h.register:
+			conns, ok := h.clients[client.userID]
+			if !ok {
+				conns = make(map[*Client]struct{})
+				h.clients[client.userID] = conns
+			}
+			conns[client] = struct{}{}
+			h.metrics.Connected()
+			if len(conns) == 1 {
+				h.presenceChanged(client.userID, true)
+			}
+
+		case client := <-h.unregister:
+			h.remove(client)
+
+		case d := <-h.broadcast:
+			h.dispatch(d.event, d.from)
+
+		case d := <-h.reply:
+			h.send(d.from, d.event)
+
+		case event := <-h.remote:
+			h.dispatch(event, nil)
+
+		case w := <-h.watch:
+			h.addWatcher(w.client, w.userID)
+
+		case q := <-h.query:
+			q.resp <- len(h.clients[q.userID])
+
+		case <-refresh.C:
+			users := make([]uint32, 0, len(h.clients))
+			for userID := range h.clients {
+				users = append(users, userID)
+			}
+			h.presence.pushRefresh(users)
+
+		case <-h.done:
+			now := time.Now()
+			for userID, conns := range h.clients {
+				for client := range conns {
+					client.closeCode = websocket.CloseGoingAway
+					close(client.Receive)
+					h.metrics.Disconnected()
+				}
+				h.queuePresence(userID, presenceChange{online: false, at: now})
+			}
+			h.clients = make(map[uint32]map[*Client]struct{})
+			h.watchers = make(map[uint32]map[*Client]struct{})
+			return
+		}
+	}
+}
+
+// Register adds the client to the hub, it returns false if the hub is already stopped
+func (h *Hub) Register(client *Client) bool {
+	select {
+	case h.register <- client:
+		return true
+	case <-h.done:
+		return false
+	}
+}
+
+func (h *Hub) Unregister(client *Client) {
+	select {
+	case h.unregister <- client:
+	case <-h.done:
+	}
+}
+
+// Send delivers the event to every connection of the user it is addressed to
+// and, for messages and receipts, to the other connections of the user who caused it, except from.
+// Events of a group chat go to every connection of the listed members, except from.
+// With a broker the event also goes to the connections held by the other instances
+func (h *Hub) Send(event *models.ChatEvent, from *Client) error {
+	select {
+	case h.broadcast <- delivery{event: event, from: from}:
+	case <-h.done:
+		return nil
+	}
+
+	if h.broker == nil {
+		return nil
+	}
+
+	return h.broker.Publish(event)
+}
+
+// Reply sends the event to the client only
+func (h *Hub) Reply(client *Client, event *models.ChatEvent) {
+	select {
+	case h.reply <- delivery{event: event, from: client}:
+	case <-h.done:
+	}
+}
+
+// Watch subscribes the client to presence changes of the user while the client is connected
+func (h *Hub) Watch(client *Client, userID uint32) {
+	select {
+	case h.watch <- watchRequest{client: client, userID: userID}:
+	case <-h.done:
+	}
+}
+
+// Connections returns the number of open connections of the user
+func (h *Hub) Connections(userID uint32) int {
+	q := connQuery{userID: userID, resp: make(chan int, 1)}
+	select {
+	case h.query <- q:
+		return <-q.resp
+	case <-h.done:
+		return 0
+	}
+}
+
+// Presence returns online status of the users, without a tracker only connections to this instance are seen
+func (h *Hub) Presence(userIDs []uint32) (map[uint32]models.Presence, error) {
+	if h.tracker != nil {
+		return h.tracker.GetPresence(userIDs)
+	}
+
+	res := make(map[uint32]models.Presence, len(userIDs))
+	for _, id := range userIDs {
+		res[id] = models.Presence{UserID: id, IsOnline: h.Connections(id) > 0}
+	}
+
+	return res, nil
+}
+
+// Stop closes all registered connections and waits for Run to return
+func (h *Hub) Stop() {
+	h.stopOnce.Do(func() {
+		close(h.done)
+	})
+	<-h.stopped
+}
+
+// listen receives events from the other instances until ctx is done, resubscribing if the broker fails
+func (h *Hub) listen(ctx context.Context) {
+	for {
+		err := h.broker.Listen(ctx, func(event *models.ChatEvent) {
+			select {
+			case h.remote <- event:
+			case <-ctx.Done():
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		h.logger.Errorf("chat broker: %v", err)
+
+		select {
+		case <-time.After(brokerRetryDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// update saves presence changes and passes them to the watchers and the other instances.
+// The hub does not wait for it, the changes of a user made while the tracker is slow are coalesced
+func (h *Hub) update() {
+	for {
+		changes, refresh, ok := h.presence.take()
+		if !ok {
+			return
+		}
+
+		if refresh != nil && h.tracker != nil {
+			if err := h.tracker.Refresh(refresh); err != nil {
+				h.logger.Errorf("chat presence: %v", err)
+			}
+		}
+		for userID, change := range changes {
+			h.savePresence(userID, change)
+		}
+	}
+}
+
+// savePresence tells about the change only if the other instances do not hold the user,
+// otherwise the user has been and is still online for everyone else
+func (h *Hub) savePresence(userID uint32, change presenceChange) {
+	event := presenceEvent(userID, change.online, change.at)
+	if h.tracker != nil {
+		changed, err := h.trackPresence(event.Presence)
+		if err != nil {
+			h.logger.Errorf("chat presence: %v", err)
+		} else if !changed {
+			return
+		}
+	}
+
+	select {
+	case h.remote <- event:
+	case <-h.done:
+	}
+
+	if h.broker != nil {
+		if err := h.broker.Publish(event); err != nil {
+			h.logger.Errorf("chat broker: %v", err)
+		}
+	}
+}
+
+func (h *Hub) trackPresence(p *models.Presence) (bool, error) {
+	if p.IsOnline {
+		return h.tracker.Online(p.UserID)
+	}
+
+	return h.tracker.Offline(p.UserID, p.LastSeen)
+}
+
+func (h *Hub) presenceChanged(userID uint32, online bool) {
+	h.queuePresence(userID, presenceChange{online: online, at: time.Now()})
+}
+
+func (h *Hub) queuePresence(userID uint32, change presenceChange) {
+	coalesced := h.presence.push(userID, change)
+	if coalesced == 0 {
+		return
+	}
+
+	h.metrics.PresenceCoalesced()
+	// once until the tracker catches up, the metric counts the rest
+	if coalesced == 1 {
+		h.logger.Warnf("chat presence: the tracker falls behind, the changes of the users are coalesced")
+	}
+}
+
+func presenceEvent(userID uint32, online bool, now time.Time) *models.ChatEvent {
+	presence := &models.Presence{UserID: userID, IsOnline: online}
+	if !online {
+		presence.LastSeen = now.UTC().Truncate(time.Second)
+	}
+
+	return &models.ChatEvent{Type: models.ChatEventPresence, Presence: presence}
+}
+
+func (h *Hub) addWatcher(client *Client, userID uint32) {
+	if _, ok := h.clients[client.userID][client]; !ok {
+		return
+	}
+
+	watchers, ok := h.watchers[userID]
+	if !ok {
+		watchers = make(map[*Client]struct{})
+		h.watchers[userID] = watchers
+	}
+	if _, ok := watchers[client]; ok {
+		return
+	}
+	watchers[client] = struct{}{}
+	client.watching = append(client.watching, userID)
+}
+
+func (h *Hub) dispatch(event *models.ChatEvent, from *Client) {
+	if len(event.Members) != 0 {
+		for _, userID := range event.Members {
+			h.deliver(h.clients[userID], event, from)
+		}
+		return
+	}
+
+	switch event.Type {
+	case models.ChatEventMessage, models.ChatEventEdit, models.ChatEventDelete:
+		h.deliverBoth(event.Message.Receiver, event.Message.Sender, event, from)
+	case models.ChatEventRead:
+		h.deliverBoth(event.Read.Sender, event.Read.Reader, event, from)
+	case models.ChatEventTyping:
+		h.deliver(h.clients[event.Typing.Receiver], event, nil)
+	case models.ChatEventPresence:
+		h.deliver(h.watchers[event.Presence.UserID], event, nil)
+	}
+}
+
+// deliverBoth sends the event to the user it is addressed to and to the other connections of its author
+func (h *Hub) deliverBoth(to, by uint32, event *models.ChatEvent, from *Client) {
+	h.deliver(h.clients[to], event, nil)
+	if by != to {
+		h.deliver(h.clients[by], event, from)
+	}
+}
+
+func (h *Hub) deliver(clients map[*Client]struct{}, event *models.ChatEvent, skip *Client) {
+	for client := range clients {
+		if client != skip {
+			h.send(client, event)
+		}
+	}
+}
+
+func (h *Hub) send(client *Client, event *models.ChatEvent) {
+	if _, ok := h.clients[client.userID][client]; !ok {
+		return
+	}
+
+	select {
+	case client.Receive <- event:
+	default:
+		// the client does not keep up, drop it instead of blocking the whole hub,
+		// it may reconnect and resume from the last message it has got
+		client.closeCode = websocket.CloseTryAgainLater
+		h.metrics.Dropped(dropSlowConsumer)
+		h.remove(client)
+	}
+}
+
+func (h *Hub) remove(client *Client) {
+	conns, ok := h.clients[client.userID]
+	if !ok {
+		return
+	}
+	if _, ok := conns[client]; !ok {
+		return
+	}
+
+	delete(conns, client)
+	close(client.Receive)
+	h.metrics.Disconnected()
+	for _, userID := range client.watching {
+		delete(h.watchers[userID], client)
+		if len(h.watchers[userID]) == 0 {
+			delete(h.watchers, userID)
+		}
+	}
+	client.watching = nil
+
+	if len(conns) == 0 {
+		delete(h.clients, client.userID)
+		h.presenceChanged(client.userID, false)
+	}
+}
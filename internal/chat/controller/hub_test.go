@@ -0,0 +1,1012 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/gomodule/redigo/redis"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	chatRedis "github.com/2024_2_BetterCallFirewall/internal/chat/repository/redis"
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	presenceRedis "github.com/2024_2_BetterCallFirewall/internal/presence/repository/redis"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+func newTestClient(userID uint32) *Client {
+	return &Client{userID: userID, Receive: make(chan *models.ChatEvent, messageBufferSize)}
+}
+
+func messageEvent(msg *models.Message) *models.ChatEvent {
+	return &models.ChatEvent{Type: models.ChatEventMessage, Message: msg}
+}
+
+func startHub(t *testing.T) *Hub {
+	hub := NewHub(nil, nil, nil, logrus.New())
+	go hub.Run()
+	t.Cleanup(hub.Stop)
+
+	return hub
+}
+
+func TestHubFanOut(t *testing.T) {
+	hub := startHub(t)
+
+	senderTabs := []*Client{newTestClient(1), newTestClient(1), newTestClient(1)}
+	receiverTabs := []*Client{newTestClient(2), newTestClient(2)}
+	other := newTestClient(3)
+	for _, c := range append(append(senderTabs, receiverTabs...), other) {
+		require.True(t, hub.Register(c))
+	}
+	assert.Equal(t, 3, hub.Connections(1))
+	assert.Equal(t, 2, hub.Connections(2))
+
+	event := messageEvent(&models.Message{Sender: 1, Receiver: 2, Content: "hi"})
+	require.NoError(t, hub.Send(event, senderTabs[0]))
+
+	for _, c := range append(receiverTabs, senderTabs[1:]...) {
+		select {
+		case got := <-c.Receive:
+			assert.Equal(t, event, got)
+		case <-time.After(time.Second):
+			t.Fatal("message was not delivered")
+		}
+	}
+
+	// a query is served after the broadcast, so nothing else is on the way
+	hub.Connections(1)
+	assert.Empty(t, senderTabs[0].Receive)
+	assert.Empty(t, other.Receive)
+}
+
+func TestHubUnregister(t *testing.T) {
+	hub := startHub(t)
+
+	c := newTestClient(1)
+	require.True(t, hub.Register(c))
+	hub.Unregister(c)
+	hub.Unregister(c)
+
+	_, ok := <-c.Receive
+	assert.False(t, ok)
+	assert.Equal(t, 0, hub.Connections(1))
+}
+
+func TestHubDropsSlowClient(t *testing.T) {
+	hub := startHub(t)
+
+	slow := &Client{userID: 2, Receive: make(chan *models.ChatEvent, 1)}
+	require.True(t, hub.Register(slow))
+
+	hub.Send(messageEvent(&models.Message{Sender: 1, Receiver: 2}), nil)
+	hub.Send(messageEvent(&models.Message{Sender: 1, Receiver: 2}), nil)
+
+	assert.Eventually(t, func() bool { return hub.Connections(2) == 0 }, time.Second, time.Millisecond)
+}
+
+func TestHubStop(t *testing.T) {
+	hub := NewHub(nil, nil, nil, logrus.New())
+	go hub.Run()
+
+	c := newTestClient(1)
+	require.True(t, hub.Register(c))
+	hub.Stop()
+	hub.Stop()
+
+	_, ok := <-c.Receive
+	assert.False(t, ok)
+
+	assert.False(t, hub.Register(newTestClient(1)))
+	hub.Unregister(c)
+	assert.NoError(t, hub.Send(messageEvent(&models.Message{Sender: 1, Receiver: 2}), nil))
+	assert.Equal(t, 0, hub.Connections(1))
+}
+
+func startInstance(t *testing.T, mr *miniredis.Miniredis) *Hub {
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", mr.Addr())
+		},
+	}
+	hub := NewHub(chatRedis.NewMessageBroker(pool), presenceRedis.NewPresenceRepository(pool), nil, logrus.New())
+	go hub.Run()
+	t.Cleanup(func() {
+		hub.Stop()
+		pool.Close()
+	})
+
+	return hub
+}
+
+func TestHubAcrossInstances(t *testing.T) {
+	mr := miniredis.RunT(t)
+	first := startInstance(t, mr)
+	second := startInstance(t, mr)
+	require.Eventually(t, func() bool {
+		return mr.PubSubNumSub("chat:messages")["chat:messages"] == 2
+	}, time.Second, time.Millisecond)
+
+	senderHere := newTestClient(1)
+	senderThere := newTestClient(1)
+	receiver := newTestClient(2)
+	require.True(t, first.Register(senderHere))
+	require.True(t, second.Register(senderThere))
+	require.True(t, second.Register(receiver))
+
+	msg := &models.Message{ID: 1, Sender: 1, Receiver: 2, Content: "hi"}
+	require.NoError(t, first.Send(messageEvent(msg), senderHere))
+
+	for _, c := range []*Client{receiver, senderThere} {
+		select {
+		case got := <-c.Receive:
+			assert.Equal(t, msg.ID, got.Message.ID)
+			assert.Equal(t, msg.Content, got.Message.Content)
+		case <-time.After(time.Second):
+			t.Fatal("message was not delivered across instances")
+		}
+	}
+
+	// the receipt from the other instance comes back to the first one
+	receipt := &models.ReadReceipt{Reader: 2, Sender: 1, LastReadID: 1}
+	require.NoError(t, second.Send(&models.ChatEvent{Type: models.ChatEventRead, Read: receipt}, receiver))
+	select {
+	case got := <-senderHere.Receive:
+		assert.Equal(t, receipt.LastReadID, got.Read.LastReadID)
+	case <-time.After(time.Second):
+		t.Fatal("message was not delivered across instances")
+	}
+	assert.Eventually(t, func() bool { return len(senderThere.Receive) == 1 }, time.Second, time.Millisecond)
+	assert.Empty(t, receiver.Receive)
+}
+
+func TestHubConcurrentClients(t *testing.T) {
+	const (
+		users = 20
+		tabs  = 5
+	)
+	hub := startHub(t)
+
+	var wg sync.WaitGroup
+	for u := uint32(1); u <= users; u++ {
+		for i := 0; i < tabs; i++ {
+			wg.Add(1)
+			go func(userID uint32) {
+				defer wg.Done()
+
+				c := newTestClient(userID)
+				if !hub.Register(c) {
+					return
+				}
+				hub.Send(messageEvent(&models.Message{Sender: userID, Receiver: userID%users + 1}), c)
+				hub.Connections(userID)
+				hub.Unregister(c)
+				for range c.Receive {
+				}
+			}(u)
+		}
+	}
+	wg.Wait()
+
+	for u := uint32(1); u <= users; u++ {
+		assert.Equal(t, 0, hub.Connections(u))
+	}
+}
+
+// startChatServer serves the chat websocket, the user is taken from the user query parameter
+func startChatServer(t *testing.T, cc *ChatController) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.ParseUint(r.URL.Query().Get("user"), 10, 32)
+		ctx := models.ContextWithSession(r.Context(), &models.Session{ID: "1", UserID: uint32(id)})
+		ctx = context.WithValue(ctx, "requestID", "1")
+		cc.SetConnection(w, r.WithContext(ctx))
+	}))
+	t.Cleanup(server.Close)
+
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestSetConnectionFanOut(t *testing.T) {
+	const (
+		users = 10
+		tabs  = 3
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := startHub(t)
+	m := &mocks{chatService: NewMockChatService(ctrl), responder: NewMockResponder(ctrl)}
+	m.chatService.EXPECT().SendNewMessage(gomock.Any(), gomock.Any()).
+		Return(nil).Times(users)
+	m.responder.EXPECT().LogError(gomock.Any(), gomock.Any()).AnyTimes()
+	cc := NewChatController(m.chatService, hub, m.responder)
+
+	wsURL := startChatServer(t, cc)
+
+	conns := make(map[uint32][]*websocket.Conn)
+	for u := uint32(1); u <= users; u++ {
+		for i := 0; i < tabs; i++ {
+			conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("%s?user=%d", wsURL, u), nil)
+			require.NoError(t, err)
+			defer conn.Close()
+			conns[u] = append(conns[u], conn)
+		}
+	}
+	for u := uint32(1); u <= users; u++ {
+		userID := u
+		require.Eventually(t, func() bool { return hub.Connections(userID) == tabs }, time.Second, time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+	for u := uint32(1); u <= users; u++ {
+		wg.Add(1)
+		go func(userID uint32) {
+			defer wg.Done()
+			err := conns[userID][0].WriteJSON(&models.Message{Receiver: userID%users + 1, Content: "hi"})
+			assert.NoError(t, err)
+		}(u)
+	}
+	wg.Wait()
+
+	// every tab gets the incoming message, the sending tab gets an ack
+	// and the other tabs of the sender get a copy of the sent message
+	for u := uint32(1); u <= users; u++ {
+		for i, conn := range conns[u] {
+			acks := 0
+			for j := 0; j < 2; j++ {
+				var event models.ChatEvent
+				require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+				require.NoError(t, conn.ReadJSON(&event))
+				if event.Type == models.ChatEventAck {
+					acks++
+				} else {
+					require.Equal(t, models.ChatEventMessage, event.Type)
+				}
+				assert.True(t, event.Message.Sender == u || event.Message.Receiver == u)
+			}
+			if i == 0 {
+				assert.Equal(t, 1, acks)
+			} else {
+				assert.Equal(t, 0, acks)
+			}
+		}
+	}
+
+	hub.Stop()
+	for _, tabs := range conns {
+		for _, conn := range tabs {
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+			_, _, err := conn.ReadMessage()
+			assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway))
+		}
+	}
+}
+
+func TestReadReceiptOverSocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := startHub(t)
+	m := &mocks{chatService: NewMockChatService(ctrl), responder: NewMockResponder(ctrl)}
+	m.chatService.EXPECT().SendNewMessage(gomock.Any(), &models.Message{Sender: 1, Receiver: 2, Content: "hi"}).
+		Return(nil)
+	m.chatService.EXPECT().MarkChatAsRead(gomock.Any(), uint32(2), uint32(1)).
+		Return(&models.ReadReceipt{Reader: 2, Sender: 1, LastReadID: 7}, nil)
+	cc := NewChatController(m.chatService, hub, m.responder)
+	wsURL := startChatServer(t, cc)
+
+	sender, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=1", nil)
+	require.NoError(t, err)
+	defer sender.Close()
+	reader, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=2", nil)
+	require.NoError(t, err)
+	defer reader.Close()
+	require.Eventually(t, func() bool {
+		return hub.Connections(1) == 1 && hub.Connections(2) == 1
+	}, time.Second, time.Millisecond)
+
+	// a bare message is still accepted from older clients
+	require.NoError(t, sender.WriteJSON(&models.Message{Receiver: 2, Content: "hi"}))
+	var event models.ChatEvent
+	require.NoError(t, reader.SetReadDeadline(time.Now().Add(time.Second)))
+	require.NoError(t, reader.ReadJSON(&event))
+	require.Equal(t, models.ChatEventMessage, event.Type)
+	assert.Equal(t, "hi", event.Message.Content)
+
+	event = models.ChatEvent{}
+	require.NoError(t, sender.SetReadDeadline(time.Now().Add(time.Second)))
+	require.NoError(t, sender.ReadJSON(&event))
+	require.Equal(t, models.ChatEventAck, event.Type)
+	assert.Equal(t, "hi", event.Message.Content)
+
+	require.NoError(t, reader.WriteJSON(&models.ChatEvent{
+		Type: models.ChatEventRead,
+		Read: &models.ReadReceipt{Sender: 1},
+	}))
+	event = models.ChatEvent{}
+	require.NoError(t, sender.SetReadDeadline(time.Now().Add(time.Second)))
+	require.NoError(t, sender.ReadJSON(&event))
+	require.Equal(t, models.ChatEventRead, event.Type)
+	assert.Equal(t, uint32(2), event.Read.Reader)
+	assert.Equal(t, uint32(7), event.Read.LastReadID)
+}
+
+func TestHubTypingAndPresence(t *testing.T) {
+	hub := startHub(t)
+
+	watcher := newTestClient(3)
+	require.True(t, hub.Register(watcher))
+	hub.Watch(watcher, 1)
+	hub.Watch(watcher, 1)
+
+	first, second := newTestClient(1), newTestClient(1)
+	require.True(t, hub.Register(first))
+	require.True(t, hub.Register(second))
+
+	receive := func(c *Client) *models.ChatEvent {
+		select {
+		case got := <-c.Receive:
+			return got
+		case <-time.After(time.Second):
+			t.Fatal("event was not delivered")
+		}
+		return nil
+	}
+
+	online := receive(watcher)
+	require.Equal(t, models.ChatEventPresence, online.Type)
+	assert.Equal(t, models.Presence{UserID: 1, IsOnline: true}, *online.Presence)
+
+	typing := &models.ChatEvent{Type: models.ChatEventTyping, Typing: &models.Typing{Sender: 1, Receiver: 3}}
+	require.NoError(t, hub.Send(typing, first))
+	assert.Equal(t, typing, receive(watcher))
+
+	hub.Reply(first, &models.ChatEvent{Type: models.ChatEventError, Error: "wrong event"})
+	assert.Equal(t, models.ChatEventError, receive(first).Type)
+
+	hub.Unregister(first)
+	hub.Unregister(second)
+	offline := receive(watcher)
+	require.Equal(t, models.ChatEventPresence, offline.Type)
+	assert.False(t, offline.Presence.IsOnline)
+	assert.False(t, offline.Presence.LastSeen.IsZero())
+
+	// only one presence change per user, the typing event is not copied to the other tab
+	hub.Connections(1)
+	assert.Empty(t, watcher.Receive)
+	_, ok := <-second.Receive
+	assert.False(t, ok)
+
+	presence, err := hub.Presence([]uint32{1, 3})
+	require.NoError(t, err)
+	assert.False(t, presence[1].IsOnline)
+	assert.True(t, presence[3].IsOnline)
+}
+
+func TestHubPresenceAcrossInstances(t *testing.T) {
+	mr := miniredis.RunT(t)
+	first := startInstance(t, mr)
+	second := startInstance(t, mr)
+	require.Eventually(t, func() bool {
+		return mr.PubSubNumSub("chat:messages")["chat:messages"] == 2
+	}, time.Second, time.Millisecond)
+
+	watcher := newTestClient(2)
+	require.True(t, second.Register(watcher))
+	second.Watch(watcher, 1)
+
+	c := newTestClient(1)
+	require.True(t, first.Register(c))
+
+	select {
+	case got := <-watcher.Receive:
+		require.Equal(t, models.ChatEventPresence, got.Type)
+		assert.True(t, got.Presence.IsOnline)
+	case <-time.After(time.Second):
+		t.Fatal("presence was not delivered across instances")
+	}
+
+	presence, err := second.Presence([]uint32{1})
+	require.NoError(t, err)
+	assert.True(t, presence[1].IsOnline)
+
+	first.Unregister(c)
+	require.Eventually(t, func() bool {
+		presence, err := second.Presence([]uint32{1})
+		return err == nil && !presence[1].IsOnline && !presence[1].LastSeen.IsZero()
+	}, time.Second, time.Millisecond)
+}
+
+func TestHubPresenceHeldByAnotherInstance(t *testing.T) {
+	mr := miniredis.RunT(t)
+	first := startInstance(t, mr)
+	second := startInstance(t, mr)
+	third := startInstance(t, mr)
+	require.Eventually(t, func() bool {
+		return mr.PubSubNumSub("chat:messages")["chat:messages"] == 3
+	}, time.Second, time.Millisecond)
+
+	watcher := newTestClient(2)
+	require.True(t, third.Register(watcher))
+	third.Watch(watcher, 1)
+	receive := func() *models.Presence {
+		select {
+		case got := <-watcher.Receive:
+			require.Equal(t, models.ChatEventPresence, got.Type)
+			return got.Presence
+		case <-time.After(time.Second):
+			t.Fatal("presence was not delivered across instances")
+		}
+		return nil
+	}
+	held := func(instances int) func() bool {
+		return func() bool {
+			members, _ := mr.ZMembers("presence:1")
+			return len(members) == instances
+		}
+	}
+
+	here, there := newTestClient(1), newTestClient(1)
+	require.True(t, first.Register(here))
+	assert.True(t, receive().IsOnline)
+
+	// the user is online already, the second instance does not say it again
+	require.True(t, second.Register(there))
+	require.Eventually(t, held(2), time.Second, time.Millisecond)
+	first.Unregister(here)
+	require.Eventually(t, held(1), time.Second, time.Millisecond)
+	assert.Never(t, func() bool { return len(watcher.Receive) != 0 }, 100*time.Millisecond, 5*time.Millisecond)
+	presence, err := third.Presence([]uint32{1})
+	require.NoError(t, err)
+	assert.True(t, presence[1].IsOnline)
+
+	second.Unregister(there)
+	offline := receive()
+	assert.False(t, offline.IsOnline)
+	assert.False(t, offline.LastSeen.IsZero())
+}
+
+// slowTracker does not answer until it is released, like Redis that does not respond
+type slowTracker struct {
+	release chan struct{}
+}
+
+func (s *slowTracker) Online(uint32) (bool, error) {
+	<-s.release
+	return true, nil
+}
+
+func (s *slowTracker) Offline(uint32, time.Time) (bool, error) {
+	<-s.release
+	return true, nil
+}
+
+func (s *slowTracker) Refresh([]uint32) error {
+	<-s.release
+	return nil
+}
+
+func (s *slowTracker) GetPresence([]uint32) (map[uint32]models.Presence, error) {
+	return nil, nil
+}
+
+func TestHubDoesNotWaitForTracker(t *testing.T) {
+	tracker := &slowTracker{release: make(chan struct{})}
+	metrics := &testMetrics{}
+	hub := NewHub(nil, tracker, metrics, logrus.New())
+	go hub.Run()
+	t.Cleanup(hub.Stop)
+
+	watcher := newTestClient(3)
+	require.True(t, hub.Register(watcher))
+	hub.Watch(watcher, 1)
+
+	// far more presence changes than any buffer holds
+	for i := 0; i < 2*messageBufferSize; i++ {
+		c := newTestClient(1)
+		require.True(t, hub.Register(c))
+		hub.Unregister(c)
+	}
+	require.NoError(t, hub.Send(messageEvent(&models.Message{Sender: 1, Receiver: 3}), nil))
+	select {
+	case got := <-watcher.Receive:
+		assert.Equal(t, models.ChatEventMessage, got.Type)
+	case <-time.After(time.Second):
+		t.Fatal("the hub waits for the tracker")
+	}
+	metrics.mu.Lock()
+	assert.Positive(t, metrics.coalesced)
+	metrics.mu.Unlock()
+
+	// the changes are coalesced to the latest state of the user once the tracker answers
+	close(tracker.release)
+	changes := 0
+	for online := true; online; changes++ {
+		select {
+		case got := <-watcher.Receive:
+			require.Equal(t, models.ChatEventPresence, got.Type)
+			assert.Equal(t, uint32(1), got.Presence.UserID)
+			online = got.Presence.IsOnline
+		case <-time.After(time.Second):
+			t.Fatal("presence was not delivered")
+		}
+	}
+	assert.LessOrEqual(t, changes, 2)
+}
+
+func TestPresenceOverSocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := startHub(t)
+	m := &mocks{chatService: NewMockChatService(ctrl), responder: NewMockResponder(ctrl)}
+	m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+	cc := NewChatController(m.chatService, hub, m.responder)
+	wsURL := startChatServer(t, cc)
+
+	watcher, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=2", nil)
+	require.NoError(t, err)
+	defer watcher.Close()
+	require.Eventually(t, func() bool { return hub.Connections(2) == 1 }, time.Second, time.Millisecond)
+
+	read := func() models.ChatEvent {
+		var event models.ChatEvent
+		require.NoError(t, watcher.SetReadDeadline(time.Now().Add(time.Second)))
+		require.NoError(t, watcher.ReadJSON(&event))
+		return event
+	}
+
+	require.NoError(t, watcher.WriteJSON(&models.ChatEvent{
+		Type:     models.ChatEventPresence,
+		Presence: &models.Presence{UserID: 1},
+	}))
+	event := read()
+	require.Equal(t, models.ChatEventPresence, event.Type)
+	assert.Equal(t, models.Presence{UserID: 1}, *event.Presence)
+
+	user, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=1", nil)
+	require.NoError(t, err)
+	defer user.Close()
+	event = read()
+	require.Equal(t, models.ChatEventPresence, event.Type)
+	assert.True(t, event.Presence.IsOnline)
+
+	require.NoError(t, user.WriteJSON(&models.ChatEvent{
+		Type:   models.ChatEventTyping,
+		Typing: &models.Typing{Receiver: 2},
+	}))
+	event = read()
+	require.Equal(t, models.ChatEventTyping, event.Type)
+	assert.Equal(t, uint32(1), event.Typing.Sender)
+
+	require.NoError(t, watcher.WriteJSON(map[string]string{"type": "typing"}))
+	event = read()
+	require.Equal(t, models.ChatEventError, event.Type)
+	assert.Equal(t, "wrong event", event.Error)
+}
+
+func TestEditDeleteOverSocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := startHub(t)
+	m := &mocks{chatService: NewMockChatService(ctrl), responder: NewMockResponder(ctrl)}
+	m.chatService.EXPECT().SendNewMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, msg *models.Message) error {
+			msg.ID = 10
+			msg.ReplyTo = &models.Quote{ID: msg.ReplyTo.ID, Sender: 2, Content: "question"}
+			return nil
+		},
+	)
+	m.chatService.EXPECT().EditMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, msg *models.Message) error {
+			msg.Receiver = 2
+			msg.IsEdited = true
+			return nil
+		},
+	)
+	m.chatService.EXPECT().DeleteMessage(gomock.Any(), uint32(10), uint32(1)).
+		Return(&models.Message{ID: 10, Sender: 1, Receiver: 2}, nil)
+	m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+	cc := NewChatController(m.chatService, hub, m.responder)
+	wsURL := startChatServer(t, cc)
+
+	sender, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=1", nil)
+	require.NoError(t, err)
+	defer sender.Close()
+	receiver, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=2", nil)
+	require.NoError(t, err)
+	defer receiver.Close()
+	require.Eventually(t, func() bool {
+		return hub.Connections(1) == 1 && hub.Connections(2) == 1
+	}, time.Second, time.Millisecond)
+
+	read := func(conn *websocket.Conn, want models.ChatEventType) *models.Message {
+		var event models.ChatEvent
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		require.NoError(t, conn.ReadJSON(&event))
+		require.Equal(t, want, event.Type)
+		return event.Message
+	}
+
+	require.NoError(t, sender.WriteJSON(&models.ChatEvent{
+		Type:    models.ChatEventMessage,
+		Message: &models.Message{Receiver: 2, Content: "answer", ReplyTo: &models.Quote{ID: 9}},
+	}))
+	got := read(receiver, models.ChatEventMessage)
+	assert.Equal(t, uint32(10), got.ID)
+	assert.Equal(t, &models.Quote{ID: 9, Sender: 2, Content: "question"}, got.ReplyTo)
+	assert.Equal(t, uint32(10), read(sender, models.ChatEventAck).ID)
+
+	require.NoError(t, sender.WriteJSON(&models.ChatEvent{
+		Type:    models.ChatEventEdit,
+		Message: &models.Message{ID: 10, Content: strings.Repeat("a", 500)},
+	}))
+	var event models.ChatEvent
+	require.NoError(t, sender.SetReadDeadline(time.Now().Add(time.Second)))
+	require.NoError(t, sender.ReadJSON(&event))
+	require.Equal(t, models.ChatEventError, event.Type)
+	assert.Equal(t, "message len is too big", event.Error)
+
+	require.NoError(t, sender.WriteJSON(&models.ChatEvent{
+		Type:    models.ChatEventEdit,
+		Message: &models.Message{ID: 10, Content: "edited answer"},
+	}))
+	for _, conn := range []*websocket.Conn{receiver, sender} {
+		got = read(conn, models.ChatEventEdit)
+		assert.Equal(t, "edited answer", got.Content)
+		assert.True(t, got.IsEdited)
+	}
+
+	require.NoError(t, sender.WriteJSON(&models.ChatEvent{
+		Type:    models.ChatEventDelete,
+		Message: &models.Message{ID: 10},
+	}))
+	for _, conn := range []*websocket.Conn{receiver, sender} {
+		assert.Equal(t, uint32(10), read(conn, models.ChatEventDelete).ID)
+	}
+}
+
+func TestGroupOverSocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := startHub(t)
+	m := &mocks{chatService: NewMockChatService(ctrl), responder: NewMockResponder(ctrl)}
+	m.chatService.EXPECT().SendNewMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, msg *models.Message) error {
+			if msg.Sender == 4 {
+				return my_err.ErrGroupNotFound
+			}
+			msg.ID = 10
+			return nil
+		},
+	).Times(2)
+	m.chatService.EXPECT().GetGroupMembers(gomock.Any(), uint32(1), gomock.Any()).
+		Return([]uint32{1, 2, 3}, nil).Times(2)
+	m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+	cc := NewChatController(m.chatService, hub, m.responder)
+	wsURL := startChatServer(t, cc)
+
+	conns := make(map[uint32]*websocket.Conn)
+	for u := uint32(1); u <= 4; u++ {
+		conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("%s?user=%d", wsURL, u), nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		conns[u] = conn
+	}
+	require.Eventually(t, func() bool {
+		return hub.Connections(1) == 1 && hub.Connections(4) == 1
+	}, time.Second, time.Millisecond)
+
+	read := func(conn *websocket.Conn, want models.ChatEventType) *models.ChatEvent {
+		var event models.ChatEvent
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		require.NoError(t, conn.ReadJSON(&event))
+		require.Equal(t, want, event.Type)
+		return &event
+	}
+
+	require.NoError(t, conns[1].WriteJSON(&models.ChatEvent{
+		Type:    models.ChatEventMessage,
+		Message: &models.Message{GroupID: 1, Content: "hi all"},
+	}))
+	for _, u := range []uint32{2, 3} {
+		got := read(conns[u], models.ChatEventMessage)
+		assert.Equal(t, uint32(1), got.Message.GroupID)
+		assert.Equal(t, []uint32{1, 2, 3}, got.Members)
+	}
+	assert.Equal(t, uint32(10), read(conns[1], models.ChatEventAck).Message.ID)
+
+	require.NoError(t, conns[2].WriteJSON(&models.ChatEvent{
+		Type:   models.ChatEventTyping,
+		Typing: &models.Typing{GroupID: 1},
+	}))
+	for _, u := range []uint32{1, 3} {
+		assert.Equal(t, uint32(2), read(conns[u], models.ChatEventTyping).Typing.Sender)
+	}
+
+	require.NoError(t, conns[4].WriteJSON(&models.ChatEvent{
+		Type:    models.ChatEventMessage,
+		Message: &models.Message{GroupID: 1, Content: "let me in"},
+	}))
+	assert.Equal(t, "group not found", read(conns[4], models.ChatEventError).Error)
+
+	// the error is served after the message would have been delivered, so nothing is on the way
+	for _, u := range []uint32{1, 2, 3} {
+		require.NoError(t, conns[u].SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+		_, _, err := conns[u].ReadMessage()
+		assert.Error(t, err)
+	}
+}
+
+func TestResumeOverSocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := startHub(t)
+	m := &mocks{chatService: NewMockChatService(ctrl), responder: NewMockResponder(ctrl)}
+	var missed []*models.Message
+	for id := uint32(6); id <= 6+resumeBatch; id++ {
+		missed = append(missed, &models.Message{ID: id, Sender: 2, Receiver: 1})
+	}
+	m.chatService.EXPECT().GetMissedMessages(gomock.Any(), uint32(1), uint32(5), gomock.Any(), uint32(resumeBatch+1)).
+		Return(missed, nil)
+	cc := NewChatController(m.chatService, hub, m.responder)
+	wsURL := startChatServer(t, cc)
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=1", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(&models.ChatEvent{Type: models.ChatEventResume, Resume: &models.Resume{LastID: 5}}))
+	for id := uint32(6); id < 6+resumeBatch; id++ {
+		var event models.ChatEvent
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		require.NoError(t, conn.ReadJSON(&event))
+		require.Equal(t, models.ChatEventMessage, event.Type)
+		require.Equal(t, id, event.Message.ID)
+	}
+
+	var event models.ChatEvent
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	require.NoError(t, conn.ReadJSON(&event))
+	require.Equal(t, models.ChatEventResume, event.Type)
+	assert.Equal(t, uint32(5+resumeBatch), event.Resume.LastID)
+	assert.True(t, event.Resume.HasMore)
+}
+
+func TestSendAgainOverSocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := startHub(t)
+	m := &mocks{chatService: NewMockChatService(ctrl), responder: NewMockResponder(ctrl)}
+	saved := false
+	m.chatService.EXPECT().SendNewMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, msg *models.Message) error {
+			msg.ID = 200
+			if saved {
+				return my_err.ErrMessageExists
+			}
+			saved = true
+			return nil
+		},
+	).Times(2)
+	m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+	cc := NewChatController(m.chatService, hub, m.responder)
+	wsURL := startChatServer(t, cc)
+
+	sender, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=1", nil)
+	require.NoError(t, err)
+	defer sender.Close()
+	receiver, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=2", nil)
+	require.NoError(t, err)
+	defer receiver.Close()
+	require.Eventually(t, func() bool {
+		return hub.Connections(1) == 1 && hub.Connections(2) == 1
+	}, time.Second, time.Millisecond)
+
+	read := func(conn *websocket.Conn, want models.ChatEventType) *models.Message {
+		var event models.ChatEvent
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		require.NoError(t, conn.ReadJSON(&event))
+		require.Equal(t, want, event.Type)
+		return event.Message
+	}
+
+	msg := &models.Message{Receiver: 2, Content: "hi", ClientID: "key"}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, sender.WriteJSON(&models.ChatEvent{Type: models.ChatEventMessage, Message: msg}))
+		ack := read(sender, models.ChatEventAck)
+		assert.Equal(t, uint32(200), ack.ID)
+		assert.Equal(t, "key", ack.ClientID)
+	}
+
+	require.NoError(t, sender.WriteJSON(&models.ChatEvent{
+		Type:    models.ChatEventMessage,
+		Message: &models.Message{Receiver: 2, Content: "hi", ClientID: strings.Repeat("k", 65)},
+	}))
+	failed := read(sender, models.ChatEventError)
+	assert.Equal(t, strings.Repeat("k", 65), failed.ClientID)
+
+	// the receiver gets the message sent twice only once
+	assert.Equal(t, uint32(200), read(receiver, models.ChatEventMessage).ID)
+	require.NoError(t, receiver.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+	_, _, err = receiver.ReadMessage()
+	assert.Error(t, err)
+}
+
+type testMetrics struct {
+	mu        sync.Mutex
+	sockets   int
+	in, out   int
+	drops     []string
+	coalesced int
+}
+
+func (m *testMetrics) Connected()    { m.add(&m.sockets, 1) }
+func (m *testMetrics) Disconnected() { m.add(&m.sockets, -1) }
+func (m *testMetrics) MessageIn()    { m.add(&m.in, 1) }
+func (m *testMetrics) MessageOut()   { m.add(&m.out, 1) }
+
+func (m *testMetrics) PresenceCoalesced() { m.add(&m.coalesced, 1) }
+
+func (m *testMetrics) Dropped(reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.drops = append(m.drops, reason)
+}
+
+func (m *testMetrics) add(counter *int, n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	*counter += n
+}
+
+func (m *testMetrics) get() (sockets, in, out int, drops []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sockets, m.in, m.out, slices.Clone(m.drops)
+}
+
+func startMeteredHub(t *testing.T) (*Hub, *testMetrics) {
+	m := &testMetrics{}
+	hub := NewHub(nil, nil, m, logrus.New())
+	go hub.Run()
+	t.Cleanup(hub.Stop)
+
+	return hub, m
+}
+
+func TestHeartbeatOverSocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub, metrics := startMeteredHub(t)
+	m := &mocks{chatService: NewMockChatService(ctrl), responder: NewMockResponder(ctrl)}
+	cc := NewChatController(m.chatService, hub, m.responder)
+	cc.socket.pongWait = 100 * time.Millisecond
+	cc.socket.pingPeriod = 30 * time.Millisecond
+	wsURL := startChatServer(t, cc)
+
+	alive, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=1", nil)
+	require.NoError(t, err)
+	defer alive.Close()
+	pings := make(chan struct{}, 100)
+	alive.SetPingHandler(func(data string) error {
+		pings <- struct{}{}
+		return alive.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	go func() {
+		for {
+			if _, _, err := alive.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// the connection that does not answer pings is closed after pongWait
+	silent, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=2", nil)
+	require.NoError(t, err)
+	defer silent.Close()
+
+	require.Eventually(t, func() bool {
+		_, _, _, drops := metrics.get()
+		return slices.Equal(drops, []string{dropTimeout})
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 0, hub.Connections(2))
+
+	time.Sleep(3 * cc.socket.pongWait)
+	assert.Equal(t, 1, hub.Connections(1))
+	assert.NotEmpty(t, pings)
+	sockets, _, _, _ := metrics.get()
+	assert.Equal(t, 1, sockets)
+}
+
+func TestTooBigMessageOverSocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub, metrics := startMeteredHub(t)
+	m := &mocks{chatService: NewMockChatService(ctrl), responder: NewMockResponder(ctrl)}
+	m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+	cc := NewChatController(m.chatService, hub, m.responder)
+	wsURL := startChatServer(t, cc)
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL+"?user=1", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// a bad frame is answered, the connection stays open
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("{")))
+	var event models.ChatEvent
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	require.NoError(t, conn.ReadJSON(&event))
+	assert.Equal(t, models.ChatEventError, event.Type)
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, make([]byte, maxMessageSize+1)))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseMessageTooBig))
+
+	require.Eventually(t, func() bool { return hub.Connections(1) == 0 }, time.Second, time.Millisecond)
+	sockets, in, out, drops := metrics.get()
+	assert.Equal(t, 0, sockets)
+	assert.Equal(t, 1, in)
+	assert.Equal(t, 1, out)
+	assert.Equal(t, []string{dropTooBig}, drops)
+}
+
+func TestSlowConsumerOverSocket(t *testing.T) {
+	hub, metrics := startMeteredHub(t)
+	cc := NewChatController(nil, hub, nil)
+
+	clients := make(chan *Client, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		socket, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		clients <- &Client{Socket: socket, Receive: make(chan *models.ChatEvent, 1), userID: 2, chatController: cc}
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	slow := <-clients
+	require.True(t, hub.Register(slow))
+
+	// the second message does not fit in the buffer of the client, it is dropped before it writes anything
+	require.NoError(t, hub.Send(messageEvent(&models.Message{ID: 1, Sender: 1, Receiver: 2}), nil))
+	require.NoError(t, hub.Send(messageEvent(&models.Message{ID: 2, Sender: 1, Receiver: 2}), nil))
+	require.Eventually(t, func() bool { return hub.Connections(2) == 0 }, time.Second, time.Millisecond)
+	go slow.Write()
+
+	var event models.ChatEvent
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	require.NoError(t, conn.ReadJSON(&event))
+	assert.Equal(t, uint32(1), event.Message.ID)
+
+	_, _, err = conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	require.ErrorAs(t, err, &closeErr)
+	assert.Equal(t, websocket.CloseTryAgainLater, closeErr.Code)
+	assert.Equal(t, dropSlowConsumer, closeErr.Text)
+
+	sockets, _, out, drops := metrics.get()
+	assert.Equal(t, 0, sockets)
+	assert.Equal(t, 1, out)
+	assert.Equal(t, []string{dropSlowConsumer}, drops)
+}
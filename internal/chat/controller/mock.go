@@ -120,6 +120,79 @@ func (m *MockChatService) EXPECT() *MockChatServiceMockRecorder {
 	return m.recorder
 }
 
+// AddGroupMembers mocks base method.
+func (m *MockChatService) AddGroupMembers(ctx context.Context, groupID, userID uint32, members []uint32) (*models.GroupChat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGroupMembers", ctx, groupID, userID, members)
+	ret0, _ := ret[0].(*models.GroupChat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGroupMembers indicates an expected call of AddGroupMembers.
+func (mr *MockChatServiceMockRecorder) AddGroupMembers(ctx, groupID, userID, members interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupMembers", reflect.TypeOf((*MockChatService)(nil).AddGroupMembers), ctx, groupID, userID, members)
+}
+
+// CreateGroup mocks base method.
+func (m *MockChatService) CreateGroup(ctx context.Context, ownerID uint32, group *models.GroupRequest) (*models.GroupChat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, ownerID, group)
+	ret0, _ := ret[0].(*models.GroupChat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockChatServiceMockRecorder) CreateGroup(ctx, ownerID, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockChatService)(nil).CreateGroup), ctx, ownerID, group)
+}
+
+// DeleteMessage mocks base method.
+func (m *MockChatService) DeleteMessage(ctx context.Context, messageID, userID uint32) (*models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessage", ctx, messageID, userID)
+	ret0, _ := ret[0].(*models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMessage indicates an expected call of DeleteMessage.
+func (mr *MockChatServiceMockRecorder) DeleteMessage(ctx, messageID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockChatService)(nil).DeleteMessage), ctx, messageID, userID)
+}
+
+// DeleteReactionFromMessage mocks base method.
+func (m *MockChatService) DeleteReactionFromMessage(ctx context.Context, messageID, userID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReactionFromMessage", ctx, messageID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReactionFromMessage indicates an expected call of DeleteReactionFromMessage.
+func (mr *MockChatServiceMockRecorder) DeleteReactionFromMessage(ctx, messageID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReactionFromMessage", reflect.TypeOf((*MockChatService)(nil).DeleteReactionFromMessage), ctx, messageID, userID)
+}
+
+// EditMessage mocks base method.
+func (m *MockChatService) EditMessage(ctx context.Context, msg *models.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditMessage", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditMessage indicates an expected call of EditMessage.
+func (mr *MockChatServiceMockRecorder) EditMessage(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditMessage", reflect.TypeOf((*MockChatService)(nil).EditMessage), ctx, msg)
+}
+
 // GetAllChats mocks base method.
 func (m *MockChatService) GetAllChats(ctx context.Context, userID uint32, lastUpdateTime time.Time) ([]*models.Chat, error) {
 	m.ctrl.T.Helper()
@@ -150,16 +223,195 @@ func (mr *MockChatServiceMockRecorder) GetChat(ctx, userID, chatID, lastSentTime
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChat", reflect.TypeOf((*MockChatService)(nil).GetChat), ctx, userID, chatID, lastSentTime)
 }
 
+// GetGroup mocks base method.
+func (m *MockChatService) GetGroup(ctx context.Context, groupID, userID uint32) (*models.GroupChat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroup", ctx, groupID, userID)
+	ret0, _ := ret[0].(*models.GroupChat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroup indicates an expected call of GetGroup.
+func (mr *MockChatServiceMockRecorder) GetGroup(ctx, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockChatService)(nil).GetGroup), ctx, groupID, userID)
+}
+
+// GetGroupChat mocks base method.
+func (m *MockChatService) GetGroupChat(ctx context.Context, userID, groupID uint32, lastSentTime time.Time) ([]*models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupChat", ctx, userID, groupID, lastSentTime)
+	ret0, _ := ret[0].([]*models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupChat indicates an expected call of GetGroupChat.
+func (mr *MockChatServiceMockRecorder) GetGroupChat(ctx, userID, groupID, lastSentTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupChat", reflect.TypeOf((*MockChatService)(nil).GetGroupChat), ctx, userID, groupID, lastSentTime)
+}
+
+// GetGroupMembers mocks base method.
+func (m *MockChatService) GetGroupMembers(ctx context.Context, groupID, userID uint32) ([]uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupMembers", ctx, groupID, userID)
+	ret0, _ := ret[0].([]uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupMembers indicates an expected call of GetGroupMembers.
+func (mr *MockChatServiceMockRecorder) GetGroupMembers(ctx, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupMembers", reflect.TypeOf((*MockChatService)(nil).GetGroupMembers), ctx, groupID, userID)
+}
+
+// GetMissedMessages mocks base method.
+func (m *MockChatService) GetMissedMessages(ctx context.Context, userID, lastID uint32, lastTime time.Time, limit uint32) ([]*models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissedMessages", ctx, userID, lastID, lastTime, limit)
+	ret0, _ := ret[0].([]*models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissedMessages indicates an expected call of GetMissedMessages.
+func (mr *MockChatServiceMockRecorder) GetMissedMessages(ctx, userID, lastID, lastTime, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissedMessages", reflect.TypeOf((*MockChatService)(nil).GetMissedMessages), ctx, userID, lastID, lastTime, limit)
+}
+
+// GetUnreadCount mocks base method.
+func (m *MockChatService) GetUnreadCount(ctx context.Context, userID uint32) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadCount", ctx, userID)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadCount indicates an expected call of GetUnreadCount.
+func (mr *MockChatServiceMockRecorder) GetUnreadCount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadCount", reflect.TypeOf((*MockChatService)(nil).GetUnreadCount), ctx, userID)
+}
+
+// KickGroupMember mocks base method.
+func (m *MockChatService) KickGroupMember(ctx context.Context, groupID, userID, memberID uint32) (*models.GroupChat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KickGroupMember", ctx, groupID, userID, memberID)
+	ret0, _ := ret[0].(*models.GroupChat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KickGroupMember indicates an expected call of KickGroupMember.
+func (mr *MockChatServiceMockRecorder) KickGroupMember(ctx, groupID, userID, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KickGroupMember", reflect.TypeOf((*MockChatService)(nil).KickGroupMember), ctx, groupID, userID, memberID)
+}
+
+// LeaveGroup mocks base method.
+func (m *MockChatService) LeaveGroup(ctx context.Context, groupID, userID uint32) (*models.GroupChat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveGroup", ctx, groupID, userID)
+	ret0, _ := ret[0].(*models.GroupChat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeaveGroup indicates an expected call of LeaveGroup.
+func (mr *MockChatServiceMockRecorder) LeaveGroup(ctx, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveGroup", reflect.TypeOf((*MockChatService)(nil).LeaveGroup), ctx, groupID, userID)
+}
+
+// MarkChatAsRead mocks base method.
+func (m *MockChatService) MarkChatAsRead(ctx context.Context, userID, chatID uint32) (*models.ReadReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkChatAsRead", ctx, userID, chatID)
+	ret0, _ := ret[0].(*models.ReadReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkChatAsRead indicates an expected call of MarkChatAsRead.
+func (mr *MockChatServiceMockRecorder) MarkChatAsRead(ctx, userID, chatID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkChatAsRead", reflect.TypeOf((*MockChatService)(nil).MarkChatAsRead), ctx, userID, chatID)
+}
+
+// MarkGroupAsRead mocks base method.
+func (m *MockChatService) MarkGroupAsRead(ctx context.Context, userID, groupID uint32) (*models.ReadReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkGroupAsRead", ctx, userID, groupID)
+	ret0, _ := ret[0].(*models.ReadReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkGroupAsRead indicates an expected call of MarkGroupAsRead.
+func (mr *MockChatServiceMockRecorder) MarkGroupAsRead(ctx, userID, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkGroupAsRead", reflect.TypeOf((*MockChatService)(nil).MarkGroupAsRead), ctx, userID, groupID)
+}
+
 // SendNewMessage mocks base method.
-func (m *MockChatService) SendNewMessage(ctx context.Context, receiver, sender uint32, message string) error {
+func (m *MockChatService) SendNewMessage(ctx context.Context, msg *models.Message) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendNewMessage", ctx, receiver, sender, message)
+	ret := m.ctrl.Call(m, "SendNewMessage", ctx, msg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SendNewMessage indicates an expected call of SendNewMessage.
-func (mr *MockChatServiceMockRecorder) SendNewMessage(ctx, receiver, sender, message interface{}) *gomock.Call {
+func (mr *MockChatServiceMockRecorder) SendNewMessage(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendNewMessage", reflect.TypeOf((*MockChatService)(nil).SendNewMessage), ctx, msg)
+}
+
+// SetGroupAdmin mocks base method.
+func (m *MockChatService) SetGroupAdmin(ctx context.Context, groupID, userID, memberID uint32, isAdmin bool) (*models.GroupChat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGroupAdmin", ctx, groupID, userID, memberID, isAdmin)
+	ret0, _ := ret[0].(*models.GroupChat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetGroupAdmin indicates an expected call of SetGroupAdmin.
+func (mr *MockChatServiceMockRecorder) SetGroupAdmin(ctx, groupID, userID, memberID, isAdmin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGroupAdmin", reflect.TypeOf((*MockChatService)(nil).SetGroupAdmin), ctx, groupID, userID, memberID, isAdmin)
+}
+
+// SetReactionToMessage mocks base method.
+func (m *MockChatService) SetReactionToMessage(ctx context.Context, messageID, userID uint32, reaction models.ReactionType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReactionToMessage", ctx, messageID, userID, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReactionToMessage indicates an expected call of SetReactionToMessage.
+func (mr *MockChatServiceMockRecorder) SetReactionToMessage(ctx, messageID, userID, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReactionToMessage", reflect.TypeOf((*MockChatService)(nil).SetReactionToMessage), ctx, messageID, userID, reaction)
+}
+
+// UpdateGroup mocks base method.
+func (m *MockChatService) UpdateGroup(ctx context.Context, groupID, userID uint32, group *models.GroupRequest) (*models.GroupChat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", ctx, groupID, userID, group)
+	ret0, _ := ret[0].(*models.GroupChat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup.
+func (mr *MockChatServiceMockRecorder) UpdateGroup(ctx, groupID, userID, group interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendNewMessage", reflect.TypeOf((*MockChatService)(nil).SendNewMessage), ctx, receiver, sender, message)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockChatService)(nil).UpdateGroup), ctx, groupID, userID, group)
 }
@@ -10,5 +10,32 @@ import (
 type ChatRepository interface {
 	GetChats(ctx context.Context, userID uint32, lastUpdateTime time.Time) ([]*models.Chat, error)
 	GetMessages(ctx context.Context, userID uint32, chatID uint32, lastSentTime time.Time) ([]*models.Message, error)
-	SendNewMessage(ctx context.Context, receiver uint32, sender uint32, message string) error
+	SendNewMessage(ctx context.Context, msg *models.Message) error
+	GetMessagesAfter(ctx context.Context, userID uint32, lastID uint32, lastTime time.Time, limit uint32) ([]*models.Message, error)
+	UpdateMessage(ctx context.Context, msg *models.Message) error
+	DeleteMessage(ctx context.Context, messageID uint32, userID uint32) (*models.Message, error)
+	MarkChatAsRead(ctx context.Context, userID uint32, chatID uint32) (uint32, error)
+	GetUnreadCount(ctx context.Context, userID uint32) (uint32, error)
+
+	SetReactionToMessage(ctx context.Context, messageID, userID uint32, reaction models.ReactionType) error
+	DeleteReactionFromMessage(ctx context.Context, messageID, userID uint32) error
+	GetReactionsOnMessages(ctx context.Context, messageIDs []uint32, userID uint32) (map[uint32]models.Reactions, error)
+
+	GetGroupMessages(ctx context.Context, userID uint32, groupID uint32, lastSentTime time.Time) ([]*models.Message, error)
+	SendGroupMessage(ctx context.Context, msg *models.Message) error
+	MarkGroupAsRead(ctx context.Context, userID uint32, groupID uint32) (uint32, error)
+	CreateGroup(ctx context.Context, ownerID uint32, group *models.GroupRequest) (uint32, error)
+	GetGroup(ctx context.Context, groupID uint32) (*models.GroupChat, error)
+	GetGroupMemberIDs(ctx context.Context, groupID uint32) ([]uint32, error)
+	GetGroupRole(ctx context.Context, groupID uint32, userID uint32) (models.GroupRole, error)
+	UpdateGroup(ctx context.Context, groupID uint32, group *models.GroupRequest) error
+	AddGroupMembers(ctx context.Context, groupID uint32, userIDs []uint32) error
+	RemoveGroupMember(ctx context.Context, groupID uint32, userID uint32) error
+	SetGroupAdmin(ctx context.Context, groupID uint32, userID uint32, isAdmin bool) error
+	LeaveGroup(ctx context.Context, groupID uint32, userID uint32) error
+}
+
+// PresenceRepository tells who of the users is online right now
+type PresenceRepository interface {
+	GetPresence(userIDs []uint32) (map[uint32]models.Presence, error)
 }
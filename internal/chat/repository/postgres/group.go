@@ -0,0 +1,274 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+// GetGroupMessages returns the messages of the group sent before lastSentTime if userID is its member
+func (cr *Repo) GetGroupMessages(
+	ctx context.Context, userID uint32, groupID uint32, lastSentTime time.Time,
+) ([]*models.Message, error) {
+	var messages []*models.Message
+
+	rows, err := cr.db.QueryContext(ctx, getGroupMessagesBatch, userID, groupID, pq.FormatTimestamp(lastSentTime))
+	if err != nil {
+		return nil, fmt.Errorf("postgres get group messages: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			msg       = &models.Message{}
+			updatedAt time.Time
+			quote     models.Quote
+		)
+		if err := rows.Scan(
+			&msg.ID, &msg.Sender, &msg.GroupID, &msg.Content, &msg.File, &msg.CreatedAt, &msg.IsEdited, &updatedAt,
+			&quote.ID, &quote.Sender, &quote.Content, &msg.IsRead,
+		); err != nil {
+			return nil, fmt.Errorf("postgres get group messages: %w", err)
+		}
+		if msg.IsEdited {
+			msg.EditedAt = &updatedAt
+		}
+		if quote.ID != 0 {
+			msg.ReplyTo = &quote
+		}
+		messages = append(messages, msg)
+	}
+	if len(messages) == 0 {
+		return nil, my_err.ErrNoMoreContent
+	}
+
+	return messages, nil
+}
+
+// SendGroupMessage stores the message of a group member and sets its id and creation time.
+// The message replied to must belong to the same group, a message sent again is handled as by SendNewMessage
+func (cr *Repo) SendGroupMessage(ctx context.Context, msg *models.Message) error {
+	var (
+		replyTo   uint32
+		quote     models.Quote
+		duplicate bool
+	)
+	if msg.ReplyTo != nil {
+		replyTo = msg.ReplyTo.ID
+	}
+
+	err := cr.db.QueryRowContext(
+		ctx, sendGroupMessage, msg.GroupID, msg.Sender, msg.Content, replyTo, msg.File, msg.ClientID,
+	).Scan(&msg.ID, &msg.CreatedAt, &duplicate, &quote.Sender, &quote.Content)
+	if errors.Is(err, sql.ErrNoRows) {
+		return my_err.ErrGroupNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("postgres send group message: %w", err)
+	}
+	if msg.ID == 0 {
+		return my_err.ErrMessageNotFound
+	}
+
+	if replyTo != 0 {
+		quote.ID = replyTo
+		msg.ReplyTo = &quote
+	}
+
+	if duplicate {
+		return my_err.ErrMessageExists
+	}
+
+	return nil
+}
+
+// MarkGroupAsRead moves the read mark of userID to the last message of the group and returns its id,
+// zero if there were no unread messages
+func (cr *Repo) MarkGroupAsRead(ctx context.Context, userID uint32, groupID uint32) (uint32, error) {
+	var lastID uint32
+	err := cr.db.QueryRowContext(ctx, markGroupAsRead, userID, groupID).Scan(&lastID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("postgres mark group as read: %w", err)
+	}
+
+	return lastID, nil
+}
+
+// CreateGroup creates the group owned by ownerID, members without a profile are skipped
+func (cr *Repo) CreateGroup(ctx context.Context, ownerID uint32, group *models.GroupRequest) (uint32, error) {
+	var (
+		id  uint32
+		row *sql.Row
+	)
+	if group.Avatar == "" {
+		row = cr.db.QueryRowContext(ctx, createGroup, group.Title, ownerID, pq.Array(group.Members))
+	} else {
+		row = cr.db.QueryRowContext(ctx, createGroupWithAvatar, group.Title, ownerID, pq.Array(group.Members), group.Avatar)
+	}
+
+	if err := row.Scan(&id); err != nil {
+		return 0, fmt.Errorf("postgres create group: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetGroup returns the group with its members, the oldest members go first
+func (cr *Repo) GetGroup(ctx context.Context, groupID uint32) (*models.GroupChat, error) {
+	group := &models.GroupChat{}
+	err := cr.db.QueryRowContext(ctx, getGroup, groupID).Scan(&group.ID, &group.Title, &group.Avatar, &group.OwnerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, my_err.ErrGroupNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("postgres get group: %w", err)
+	}
+
+	rows, err := cr.db.QueryContext(ctx, getGroupMembers, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres get group members: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			member  models.GroupMember
+			isAdmin bool
+		)
+		if err := rows.Scan(&member.AuthorID, &member.Author, &member.Avatar, &isAdmin); err != nil {
+			return nil, fmt.Errorf("postgres get group members: %w", err)
+		}
+		switch {
+		case member.AuthorID == group.OwnerID:
+			member.Role = models.GroupRoleOwner
+		case isAdmin:
+			member.Role = models.GroupRoleAdmin
+		default:
+			member.Role = models.GroupRoleMember
+		}
+		group.Members = append(group.Members, member)
+	}
+
+	return group, nil
+}
+
+func (cr *Repo) GetGroupMemberIDs(ctx context.Context, groupID uint32) ([]uint32, error) {
+	var res []uint32
+
+	rows, err := cr.db.QueryContext(ctx, getGroupMemberIDs, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres get group member ids: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uint32
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("postgres get group member ids: %w", err)
+		}
+		res = append(res, id)
+	}
+
+	return res, nil
+}
+
+// GetGroupRole returns the role of userID in the group, ErrGroupNotFound if userID is not a member
+func (cr *Repo) GetGroupRole(ctx context.Context, groupID uint32, userID uint32) (models.GroupRole, error) {
+	var isAdmin, isOwner bool
+	err := cr.db.QueryRowContext(ctx, getGroupRole, groupID, userID).Scan(&isAdmin, &isOwner)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", my_err.ErrGroupNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("postgres get group role: %w", err)
+	}
+
+	switch {
+	case isOwner:
+		return models.GroupRoleOwner, nil
+	case isAdmin:
+		return models.GroupRoleAdmin, nil
+	}
+
+	return models.GroupRoleMember, nil
+}
+
+// UpdateGroup changes the title of the group and its avatar if a new one is set
+func (cr *Repo) UpdateGroup(ctx context.Context, groupID uint32, group *models.GroupRequest) error {
+	var err error
+	if group.Avatar == "" {
+		_, err = cr.db.ExecContext(ctx, updateGroupWithoutAvatar, group.Title, groupID)
+	} else {
+		_, err = cr.db.ExecContext(ctx, updateGroupWithAvatar, group.Title, group.Avatar, groupID)
+	}
+	if err != nil {
+		return fmt.Errorf("postgres update group: %w", err)
+	}
+
+	return nil
+}
+
+// AddGroupMembers adds the users to the group, members and users without a profile are skipped
+func (cr *Repo) AddGroupMembers(ctx context.Context, groupID uint32, userIDs []uint32) error {
+	if _, err := cr.db.ExecContext(ctx, addGroupMembers, groupID, pq.Array(userIDs)); err != nil {
+		return fmt.Errorf("postgres add group members: %w", err)
+	}
+
+	return nil
+}
+
+func (cr *Repo) RemoveGroupMember(ctx context.Context, groupID uint32, userID uint32) error {
+	res, err := cr.db.ExecContext(ctx, removeGroupMember, groupID, userID)
+	if err != nil {
+		return fmt.Errorf("postgres remove group member: %w", err)
+	}
+
+	return checkAffected(res, my_err.ErrUserNotFound)
+}
+
+func (cr *Repo) SetGroupAdmin(ctx context.Context, groupID uint32, userID uint32, isAdmin bool) error {
+	res, err := cr.db.ExecContext(ctx, setGroupAdmin, groupID, userID, isAdmin)
+	if err != nil {
+		return fmt.Errorf("postgres set group admin: %w", err)
+	}
+
+	return checkAffected(res, my_err.ErrUserNotFound)
+}
+
+// LeaveGroup removes userID from the group. The owner passes the group to the oldest admin or member,
+// the last member deletes the group
+func (cr *Repo) LeaveGroup(ctx context.Context, groupID uint32, userID uint32) error {
+	var left uint32
+	if err := cr.db.QueryRowContext(ctx, leaveGroup, groupID, userID).Scan(&left); err != nil {
+		return fmt.Errorf("postgres leave group: %w", err)
+	}
+
+	if left == 0 {
+		return my_err.ErrGroupNotFound
+	}
+
+	return nil
+}
+
+func checkAffected(res sql.Result, notFound error) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("postgres rows affected: %w", err)
+	}
+
+	if affected == 0 {
+		return notFound
+	}
+
+	return nil
+}
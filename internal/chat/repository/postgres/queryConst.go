@@ -8,6 +8,7 @@ const (
             ELSE sender
         END AS related_user,
         content,
+        file_path,
         created_at,
         ROW_NUMBER() OVER (PARTITION BY
             CASE
@@ -18,30 +19,237 @@ const (
     FROM
         message
     WHERE
-        sender = $1 OR receiver = $1
-)
-
-SELECT
-   	related_user,
-    profile.first_name || ' ' || profile.last_name AS chat,
-    avatar AS pic,
-    last_messages.content AS last_message_content,
-    last_messages.created_at AS last_message_time
-FROM
-    last_messages
-        INNER JOIN profile ON related_user = profile.id
+        (sender = $1 OR receiver = $1) AND group_id IS NULL
+), chats AS (
+    SELECT
+        related_user,
+        0 AS group_id,
+        profile.first_name || ' ' || profile.last_name AS chat,
+        avatar AS pic,
+        last_messages.content AS last_message_content,
+        last_messages.file_path AS last_message_file,
+        last_messages.created_at AS last_message_time,
+        (SELECT COUNT(*) FROM message
+         WHERE sender = related_user AND receiver = $1 AND is_read = FALSE) AS unread_count
+    FROM
+        last_messages
+            INNER JOIN profile ON related_user = profile.id
+    WHERE
+        rn = 1
+    UNION ALL
+    SELECT
+        0,
+        group_chat.id,
+        group_chat.title,
+        group_chat.avatar,
+        COALESCE(last_message.content, ''),
+        COALESCE(last_message.file_path, ''),
+        COALESCE(last_message.created_at, group_chat.created_at),
+        (SELECT COUNT(*) FROM message
+         WHERE group_id = group_chat.id AND id > group_member.last_read_id AND sender != $1)
+    FROM
+        group_member
+            INNER JOIN group_chat ON group_member.group_id = group_chat.id
+            LEFT JOIN LATERAL (
+                SELECT content, file_path, created_at FROM message
+                WHERE group_id = group_chat.id
+                ORDER BY created_at DESC
+                LIMIT 1
+            ) last_message ON TRUE
+    WHERE
+        group_member.profile_id = $1
+)
+
+SELECT * FROM chats
 WHERE
-    rn = 1 AND last_messages.created_at < $2
+    last_message_time < $2
 ORDER BY
-    last_messages.created_at DESC
+    last_message_time DESC
 LIMIT 15;`
 
-	getLatestMessagesBatch = `SELECT sender, receiver, content, created_at
-FROM message
-WHERE ((sender = $1 AND receiver = $2) OR (sender = $2 AND receiver = $1)) 
-AND created_at < $3
-ORDER BY created_at DESC
+	getLatestMessagesBatch = `SELECT m.id, m.sender, m.receiver, m.content, m.file_path, m.created_at, m.is_read, m.is_edited, m.updated_at,
+       COALESCE(r.id, 0), COALESCE(r.sender, 0), COALESCE(r.content, '')
+FROM message m
+LEFT JOIN message r ON r.id = m.reply_to
+WHERE ((m.sender = $1 AND m.receiver = $2) OR (m.sender = $2 AND m.receiver = $1))
+AND m.created_at < $3
+ORDER BY m.created_at DESC
 LIMIT 20;`
 
-	sendNewMessage = `INSERT INTO message(receiver, sender, content) VALUES ($1, $2, $3)`
+	// a message with the client id the sender has already used in the chat is not saved again, the saved one
+	// is returned. The message saved by a concurrent retry is not seen by existing, the conflict returns it then:
+	// the row is only locked by the update, xmax is set for it and is zero for the inserted one
+	sendNewMessage = `WITH quote AS (
+    SELECT id, sender, content FROM message
+    WHERE id = $4::int AND ((sender = $1 AND receiver = $2) OR (sender = $2 AND receiver = $1))
+), existing AS (
+    SELECT id, created_at FROM message WHERE sender = $2 AND receiver = $1 AND client_id = NULLIF($6, '')
+), inserted AS (
+    INSERT INTO message(receiver, sender, content, file_path, reply_to, client_id)
+    SELECT $1, $2, $3, $5, (SELECT id FROM quote), NULLIF($6, '')
+    WHERE NOT EXISTS (SELECT 1 FROM existing) AND ($4::int = 0 OR EXISTS (SELECT 1 FROM quote))
+    ON CONFLICT (sender, receiver, client_id) WHERE client_id IS NOT NULL DO UPDATE SET client_id = EXCLUDED.client_id
+    RETURNING id, created_at, xmax::text <> '0' AS duplicate
+), saved AS (
+    SELECT id, created_at, duplicate FROM inserted
+    UNION ALL
+    SELECT id, created_at, TRUE FROM existing
+)
+SELECT saved.id, saved.created_at, saved.duplicate, COALESCE(quote.sender, 0), COALESCE(quote.content, '')
+FROM saved LEFT JOIN quote ON TRUE;`
+
+	updateMessage = `UPDATE message SET content = $1, is_edited = TRUE, updated_at = NOW()
+WHERE id = $2 AND sender = $3
+RETURNING COALESCE(receiver, 0), COALESCE(group_id, 0), file_path, created_at, updated_at, is_read;`
+
+	deleteMessage = `DELETE FROM message WHERE id = $1 AND sender = $2 RETURNING COALESCE(receiver, 0), COALESCE(group_id, 0);`
+
+	markChatAsRead = `WITH updated AS (
+    UPDATE message SET is_read = TRUE, updated_at = NOW()
+    WHERE receiver = $1 AND sender = $2 AND is_read = FALSE
+    RETURNING id
+)
+SELECT COALESCE(MAX(id), 0) FROM updated;`
+
+	getUnreadCount = `SELECT
+    (SELECT COUNT(*) FROM message WHERE receiver = $1 AND is_read = FALSE) +
+    (SELECT COUNT(*) FROM message
+     INNER JOIN group_member ON message.group_id = group_member.group_id
+     WHERE group_member.profile_id = $1 AND message.id > group_member.last_read_id AND message.sender != $1);`
+
+	setReactionToMessage = `INSERT INTO reaction (message_id, user_id, type)
+SELECT $1, $2, $3
+WHERE EXISTS (
+    SELECT 1 FROM message WHERE id = $1 AND (sender = $2 OR receiver = $2 OR
+        EXISTS (SELECT 1 FROM group_member WHERE group_id = message.group_id AND profile_id = $2))
+)
+ON CONFLICT (message_id, user_id) DO UPDATE SET type = EXCLUDED.type, updated_at = NOW();`
+
+	deleteReactionFromMessage = `DELETE FROM reaction WHERE message_id = $1 AND user_id = $2;`
+
+	getReactionsOnMessages = `SELECT message_id, type, COUNT(*), BOOL_OR(user_id = $2)
+FROM reaction
+WHERE message_id = ANY($1::int[])
+GROUP BY message_id, type;`
+
+	getGroupMessagesBatch = `SELECT m.id, m.sender, m.group_id, m.content, m.file_path, m.created_at, m.is_edited, m.updated_at,
+       COALESCE(r.id, 0), COALESCE(r.sender, 0), COALESCE(r.content, ''),
+       EXISTS (SELECT 1 FROM group_member o
+               WHERE o.group_id = m.group_id AND o.profile_id != m.sender AND o.last_read_id >= m.id)
+FROM message m
+LEFT JOIN message r ON r.id = m.reply_to
+WHERE m.group_id = $2
+AND EXISTS (SELECT 1 FROM group_member WHERE group_id = $2 AND profile_id = $1)
+AND m.created_at < $3
+ORDER BY m.created_at DESC
+LIMIT 20;`
+
+	sendGroupMessage = `WITH member AS (
+    SELECT 1 FROM group_member WHERE group_id = $1 AND profile_id = $2
+), quote AS (
+    SELECT id, sender, content FROM message WHERE id = $4::int AND group_id = $1
+), existing AS (
+    SELECT id, created_at FROM message WHERE sender = $2 AND group_id = $1 AND client_id = NULLIF($6, '')
+), inserted AS (
+    INSERT INTO message(group_id, sender, content, file_path, reply_to, client_id)
+    SELECT $1, $2, $3, $5, (SELECT id FROM quote), NULLIF($6, '')
+    WHERE EXISTS (SELECT 1 FROM member) AND NOT EXISTS (SELECT 1 FROM existing)
+    AND ($4::int = 0 OR EXISTS (SELECT 1 FROM quote))
+    ON CONFLICT (sender, group_id, client_id) WHERE client_id IS NOT NULL DO UPDATE SET client_id = EXCLUDED.client_id
+    RETURNING id, created_at, xmax::text <> '0' AS duplicate
+), saved AS (
+    SELECT id, created_at, duplicate FROM inserted
+    UNION ALL
+    SELECT id, created_at, TRUE FROM existing
+)
+SELECT COALESCE(saved.id, 0), COALESCE(saved.created_at, NOW()), COALESCE(saved.duplicate, FALSE),
+       COALESCE(quote.sender, 0), COALESCE(quote.content, '')
+FROM member LEFT JOIN saved ON TRUE LEFT JOIN quote ON TRUE;`
+
+	markGroupAsRead = `WITH last_message AS (
+    SELECT COALESCE(MAX(id), 0) AS id FROM message WHERE group_id = $2
+)
+UPDATE group_member SET last_read_id = last_message.id, updated_at = NOW()
+FROM last_message
+WHERE group_id = $2 AND profile_id = $1 AND last_read_id < last_message.id
+RETURNING last_read_id;`
+
+	createGroup = `WITH new_group AS (
+    INSERT INTO group_chat(title, owner) VALUES ($1, $2) RETURNING id
+), owner AS (
+    INSERT INTO group_member(group_id, profile_id, is_admin) SELECT id, $2, TRUE FROM new_group
+), members AS (
+    INSERT INTO group_member(group_id, profile_id)
+    SELECT new_group.id, profile.id FROM new_group, profile WHERE profile.id = ANY($3::int[]) AND profile.id != $2
+)
+SELECT id FROM new_group;`
+
+	createGroupWithAvatar = `WITH new_group AS (
+    INSERT INTO group_chat(title, avatar, owner) VALUES ($1, $4, $2) RETURNING id
+), owner AS (
+    INSERT INTO group_member(group_id, profile_id, is_admin) SELECT id, $2, TRUE FROM new_group
+), members AS (
+    INSERT INTO group_member(group_id, profile_id)
+    SELECT new_group.id, profile.id FROM new_group, profile WHERE profile.id = ANY($3::int[]) AND profile.id != $2
+)
+SELECT id FROM new_group;`
+
+	getGroup = `SELECT id, title, avatar, COALESCE(owner, 0) FROM group_chat WHERE id = $1;`
+
+	getGroupMembers = `SELECT profile.id, profile.first_name || ' ' || profile.last_name, profile.avatar, group_member.is_admin
+FROM group_member
+INNER JOIN profile ON group_member.profile_id = profile.id
+WHERE group_member.group_id = $1
+ORDER BY group_member.created_at, profile.id;`
+
+	getGroupMemberIDs = `SELECT profile_id FROM group_member WHERE group_id = $1;`
+
+	getGroupRole = `SELECT group_member.is_admin, COALESCE(group_chat.owner, 0) = group_member.profile_id
+FROM group_member
+INNER JOIN group_chat ON group_member.group_id = group_chat.id
+WHERE group_member.group_id = $1 AND group_member.profile_id = $2;`
+
+	updateGroupWithoutAvatar = `UPDATE group_chat SET title = $1, updated_at = NOW() WHERE id = $2;`
+	updateGroupWithAvatar    = `UPDATE group_chat SET title = $1, avatar = $2, updated_at = NOW() WHERE id = $3;`
+
+	addGroupMembers = `INSERT INTO group_member(group_id, profile_id)
+SELECT $1, id FROM profile WHERE id = ANY($2::int[])
+ON CONFLICT (group_id, profile_id) DO NOTHING;`
+
+	removeGroupMember = `DELETE FROM group_member WHERE group_id = $1 AND profile_id = $2;`
+
+	setGroupAdmin = `UPDATE group_member SET is_admin = $3, updated_at = NOW() WHERE group_id = $1 AND profile_id = $2;`
+
+	// the owner who leaves passes the group to the oldest admin or member, the group without members is deleted
+	leaveGroup = `WITH left_member AS (
+    DELETE FROM group_member WHERE group_id = $1 AND profile_id = $2 RETURNING profile_id
+), successor AS (
+    SELECT profile_id FROM group_member
+    WHERE group_id = $1 AND profile_id != $2
+    ORDER BY is_admin DESC, created_at, profile_id
+    LIMIT 1
+), promoted AS (
+    UPDATE group_member SET is_admin = TRUE, updated_at = NOW()
+    WHERE group_id = $1 AND profile_id = (SELECT profile_id FROM successor)
+    AND EXISTS (SELECT 1 FROM left_member) AND EXISTS (SELECT 1 FROM group_chat WHERE id = $1 AND owner = $2)
+), new_owner AS (
+    UPDATE group_chat SET owner = (SELECT profile_id FROM successor), updated_at = NOW()
+    WHERE id = $1 AND owner = $2 AND EXISTS (SELECT 1 FROM left_member) AND EXISTS (SELECT 1 FROM successor)
+), deleted AS (
+    DELETE FROM group_chat
+    WHERE id = $1 AND EXISTS (SELECT 1 FROM left_member) AND NOT EXISTS (SELECT 1 FROM successor)
+)
+SELECT COUNT(*) FROM left_member;`
+
+	getMessagesAfter = `SELECT m.id, m.sender, COALESCE(m.receiver, 0), COALESCE(m.group_id, 0), COALESCE(m.client_id, ''),
+       m.content, m.file_path, m.created_at, m.is_read, m.is_edited, m.updated_at,
+       COALESCE(r.id, 0), COALESCE(r.sender, 0), COALESCE(r.content, '')
+FROM message m
+LEFT JOIN message r ON r.id = m.reply_to
+WHERE (m.sender = $1 OR m.receiver = $1 OR EXISTS (
+    SELECT 1 FROM group_member g WHERE g.group_id = m.group_id AND g.profile_id = $1 AND g.created_at <= m.created_at
+))
+AND m.id > $2 AND m.created_at > $3
+ORDER BY m.id
+LIMIT $4;`
 )
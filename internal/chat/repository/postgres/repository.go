@@ -37,10 +37,17 @@ func (cr *Repo) GetChats(ctx context.Context, userID uint32, lastUpdateTime time
 	defer rows.Close()
 
 	for rows.Next() {
-		chat := &models.Chat{}
-		if err := rows.Scan(&chat.Receiver.AuthorID, &chat.Receiver.Author, &chat.Receiver.Avatar, &chat.LastMessage, &chat.LastDate); err != nil {
+		var (
+			chat    = &models.Chat{}
+			groupID uint32
+		)
+		if err := rows.Scan(&chat.Receiver.AuthorID, &groupID, &chat.Receiver.Author, &chat.Receiver.Avatar, &chat.LastMessage, &chat.LastFile, &chat.LastDate, &chat.UnreadCount); err != nil {
 			return nil, fmt.Errorf("postgres get chats: %w", err)
 		}
+		if groupID != 0 {
+			chat.Group = &models.GroupChat{ID: groupID, Title: chat.Receiver.Author, Avatar: chat.Receiver.Avatar}
+			chat.Receiver = models.Header{}
+		}
 		chats = append(chats, chat)
 	}
 
@@ -65,10 +72,23 @@ func (cr *Repo) GetMessages(ctx context.Context, userID uint32, chatID uint32, l
 	defer rows.Close()
 
 	for rows.Next() {
-		msg := &models.Message{}
-		if err := rows.Scan(&msg.Sender, &msg.Receiver, &msg.Content, &msg.CreatedAt); err != nil {
+		var (
+			msg       = &models.Message{}
+			updatedAt time.Time
+			quote     models.Quote
+		)
+		if err := rows.Scan(
+			&msg.ID, &msg.Sender, &msg.Receiver, &msg.Content, &msg.File, &msg.CreatedAt, &msg.IsRead, &msg.IsEdited, &updatedAt,
+			&quote.ID, &quote.Sender, &quote.Content,
+		); err != nil {
 			return nil, fmt.Errorf("postgres get messages: %w", err)
 		}
+		if msg.IsEdited {
+			msg.EditedAt = &updatedAt
+		}
+		if quote.ID != 0 {
+			msg.ReplyTo = &quote
+		}
 		messages = append(messages, msg)
 	}
 	if len(messages) == 0 {
@@ -79,10 +99,190 @@ func (cr *Repo) GetMessages(ctx context.Context, userID uint32, chatID uint32, l
 
 }
 
-func (cr *Repo) SendNewMessage(ctx context.Context, receiver uint32, sender uint32, message string) error {
-	_, err := cr.db.ExecContext(ctx, sendNewMessage, receiver, sender, message)
+// SendNewMessage stores the message and sets its id and creation time.
+// The message replied to must belong to the same chat. If the sender has already sent to the chat
+// a message with the same client id, the message gets the id of that one and ErrMessageExists is returned
+func (cr *Repo) SendNewMessage(ctx context.Context, msg *models.Message) error {
+	var (
+		replyTo   uint32
+		quote     models.Quote
+		duplicate bool
+	)
+	if msg.ReplyTo != nil {
+		replyTo = msg.ReplyTo.ID
+	}
+
+	err := cr.db.QueryRowContext(
+		ctx, sendNewMessage, msg.Receiver, msg.Sender, msg.Content, replyTo, msg.File, msg.ClientID,
+	).Scan(&msg.ID, &msg.CreatedAt, &duplicate, &quote.Sender, &quote.Content)
+	if errors.Is(err, sql.ErrNoRows) {
+		return my_err.ErrMessageNotFound
+	}
 	if err != nil {
 		return fmt.Errorf("postgres send new message: %w", err)
 	}
+
+	if replyTo != 0 {
+		quote.ID = replyTo
+		msg.ReplyTo = &quote
+	}
+
+	if duplicate {
+		return my_err.ErrMessageExists
+	}
+
+	return nil
+}
+
+// GetMessagesAfter returns up to limit messages of all chats of the user sent after the message with lastID
+// and after lastTime, the oldest go first. The messages of a group sent before the user joined it are left out
+func (cr *Repo) GetMessagesAfter(
+	ctx context.Context, userID uint32, lastID uint32, lastTime time.Time, limit uint32,
+) ([]*models.Message, error) {
+	var messages []*models.Message
+
+	rows, err := cr.db.QueryContext(ctx, getMessagesAfter, userID, lastID, pq.FormatTimestamp(lastTime), limit)
+	if err != nil {
+		return nil, fmt.Errorf("postgres get messages after: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			msg       = &models.Message{}
+			updatedAt time.Time
+			quote     models.Quote
+		)
+		if err := rows.Scan(
+			&msg.ID, &msg.Sender, &msg.Receiver, &msg.GroupID, &msg.ClientID,
+			&msg.Content, &msg.File, &msg.CreatedAt, &msg.IsRead, &msg.IsEdited, &updatedAt,
+			&quote.ID, &quote.Sender, &quote.Content,
+		); err != nil {
+			return nil, fmt.Errorf("postgres get messages after: %w", err)
+		}
+		if msg.IsEdited {
+			msg.EditedAt = &updatedAt
+		}
+		if quote.ID != 0 {
+			msg.ReplyTo = &quote
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// UpdateMessage changes the content of the message if msg.Sender is its author and fills the rest of it
+func (cr *Repo) UpdateMessage(ctx context.Context, msg *models.Message) error {
+	var editedAt time.Time
+	err := cr.db.QueryRowContext(ctx, updateMessage, msg.Content, msg.ID, msg.Sender).
+		Scan(&msg.Receiver, &msg.GroupID, &msg.File, &msg.CreatedAt, &editedAt, &msg.IsRead)
+	if errors.Is(err, sql.ErrNoRows) {
+		return my_err.ErrMessageNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("postgres update message: %w", err)
+	}
+
+	msg.IsEdited = true
+	msg.EditedAt = &editedAt
+
+	return nil
+}
+
+// DeleteMessage deletes the message if userID is its author and returns whom the message was sent to
+func (cr *Repo) DeleteMessage(ctx context.Context, messageID uint32, userID uint32) (*models.Message, error) {
+	msg := &models.Message{ID: messageID, Sender: userID}
+	err := cr.db.QueryRowContext(ctx, deleteMessage, messageID, userID).Scan(&msg.Receiver, &msg.GroupID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, my_err.ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("postgres delete message: %w", err)
+	}
+
+	return msg, nil
+}
+
+// MarkChatAsRead marks the messages sent by chatID to userID as read and returns the id of the last of them,
+// zero if there were no unread messages
+func (cr *Repo) MarkChatAsRead(ctx context.Context, userID uint32, chatID uint32) (uint32, error) {
+	var lastID uint32
+	if err := cr.db.QueryRowContext(ctx, markChatAsRead, userID, chatID).Scan(&lastID); err != nil {
+		return 0, fmt.Errorf("postgres mark chat as read: %w", err)
+	}
+
+	return lastID, nil
+}
+
+func (cr *Repo) GetUnreadCount(ctx context.Context, userID uint32) (uint32, error) {
+	var count uint32
+	if err := cr.db.QueryRowContext(ctx, getUnreadCount, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("postgres get unread count: %w", err)
+	}
+
+	return count, nil
+}
+
+func (cr *Repo) SetReactionToMessage(ctx context.Context, messageID, userID uint32, reaction models.ReactionType) error {
+	res, err := cr.db.ExecContext(ctx, setReactionToMessage, messageID, userID, reaction)
+	if err != nil {
+		return fmt.Errorf("postgres set reaction to message: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("postgres set reaction to message: %w", err)
+	}
+
+	if affected == 0 {
+		return my_err.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+func (cr *Repo) DeleteReactionFromMessage(ctx context.Context, messageID, userID uint32) error {
+	_, err := cr.db.ExecContext(ctx, deleteReactionFromMessage, messageID, userID)
+	if err != nil {
+		return fmt.Errorf("postgres delete reaction from message: %w", err)
+	}
+
 	return nil
 }
+
+func (cr *Repo) GetReactionsOnMessages(
+	ctx context.Context, messageIDs []uint32, userID uint32,
+) (map[uint32]models.Reactions, error) {
+	res := make(map[uint32]models.Reactions, len(messageIDs))
+
+	rows, err := cr.db.QueryContext(ctx, getReactionsOnMessages, pq.Array(messageIDs), userID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres get reactions on messages: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			messageID uint32
+			reaction  models.ReactionType
+			count     uint32
+			my        bool
+		)
+		if err := rows.Scan(&messageID, &reaction, &count, &my); err != nil {
+			return nil, fmt.Errorf("postgres get reactions on messages: %w", err)
+		}
+
+		reactions, ok := res[messageID]
+		if !ok {
+			reactions = models.NewReactions()
+		}
+		reactions.Counts[reaction] = count
+		if my {
+			reactions.My = reaction
+		}
+		res[messageID] = reactions
+	}
+
+	return res, nil
+}
@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+const messagesChannel = "chat:messages"
+
+type envelope struct {
+	Origin string            `json:"origin"`
+	Event  *models.ChatEvent `json:"event"`
+}
+
+// MessageBroker spreads chat events between chat instances over Redis pub/sub.
+// Every instance gets its own id, so events published by the instance itself are not delivered twice
+type MessageBroker struct {
+	db         *redis.Pool
+	instanceID string
+}
+
+func NewMessageBroker(db *redis.Pool) *MessageBroker {
+	return &MessageBroker{
+		db:         db,
+		instanceID: uuid.NewString(),
+	}
+}
+
+func (b *MessageBroker) Publish(event *models.ChatEvent) error {
+	conn := b.db.Get()
+	defer conn.Close()
+
+	data, err := json.Marshal(envelope{Origin: b.instanceID, Event: event})
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.Do("PUBLISH", messagesChannel, data); err != nil {
+		return fmt.Errorf("publish event: %w", err)
+	}
+
+	return nil
+}
+
+// Listen calls handle for every event published by the other instances.
+// It blocks until ctx is done or the subscription fails
+func (b *MessageBroker) Listen(ctx context.Context, handle func(*models.ChatEvent)) error {
+	psc := redis.PubSubConn{Conn: b.db.Get()}
+	defer psc.Close()
+
+	if err := psc.Subscribe(messagesChannel); err != nil {
+		return fmt.Errorf("subscribe: %w", err)
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			_ = psc.Unsubscribe()
+		case <-done:
+		}
+	}()
+	defer func() {
+		close(done)
+		<-stopped
+	}()
+
+	for {
+		// the subscription waits for the events as long as it takes, whatever the read timeout of the pool is
+		switch v := psc.ReceiveWithTimeout(0).(type) {
+		case redis.Message:
+			var env envelope
+			if err := json.Unmarshal(v.Data, &env); err != nil || env.Event == nil {
+				continue
+			}
+			if env.Origin == b.instanceID {
+				continue
+			}
+			handle(env.Event)
+
+		case redis.Subscription:
+			if v.Count == 0 {
+				return ctx.Err()
+			}
+
+		case error:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("receive: %w", v)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+func getPool(t *testing.T) (*redis.Pool, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", addr)
+		},
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	return pool, mr
+}
+
+func listen(t *testing.T, b *MessageBroker) (chan *models.ChatEvent, context.CancelFunc, chan error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	got := make(chan *models.ChatEvent, 10)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- b.Listen(ctx, func(event *models.ChatEvent) { got <- event })
+	}()
+	t.Cleanup(cancel)
+
+	return got, cancel, errs
+}
+
+func waitSubscribers(t *testing.T, mr *miniredis.Miniredis, n int) {
+	require.Eventually(t, func() bool {
+		return mr.PubSubNumSub(messagesChannel)[messagesChannel] == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestMessageBroker(t *testing.T) {
+	pool, mr := getPool(t)
+	first := NewMessageBroker(pool)
+	second := NewMessageBroker(pool)
+
+	firstGot, _, _ := listen(t, first)
+	secondGot, _, _ := listen(t, second)
+	waitSubscribers(t, mr, 2)
+
+	msg := &models.Message{ID: 1, Sender: 1, Receiver: 2, Content: "hi", CreatedAt: time.Now().UTC()}
+	require.NoError(t, first.Publish(&models.ChatEvent{Type: models.ChatEventMessage, Message: msg}))
+
+	select {
+	case got := <-secondGot:
+		assert.Equal(t, models.ChatEventMessage, got.Type)
+		assert.Equal(t, msg.ID, got.Message.ID)
+		assert.Equal(t, msg.Content, got.Message.Content)
+		assert.True(t, msg.CreatedAt.Equal(got.Message.CreatedAt))
+	case <-time.After(time.Second):
+		t.Fatal("message was not delivered to the other instance")
+	}
+
+	// the publisher skips its own message, the next one proves nothing is on the way
+	receipt := &models.ReadReceipt{Reader: 2, Sender: 1, LastReadID: 1}
+	require.NoError(t, second.Publish(&models.ChatEvent{Type: models.ChatEventRead, Read: receipt}))
+	select {
+	case got := <-firstGot:
+		assert.Equal(t, models.ChatEventRead, got.Type)
+		assert.Equal(t, receipt.LastReadID, got.Read.LastReadID)
+	case <-time.After(time.Second):
+		t.Fatal("message was not delivered to the other instance")
+	}
+	assert.Empty(t, firstGot)
+}
+
+func TestMessageBrokerStop(t *testing.T) {
+	pool, mr := getPool(t)
+	b := NewMessageBroker(pool)
+
+	_, cancel, errs := listen(t, b)
+	waitSubscribers(t, mr, 1)
+	cancel()
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("listen did not stop")
+	}
+}
+
+func TestMessageBrokerFail(t *testing.T) {
+	pool, mr := getPool(t)
+	b := NewMessageBroker(pool)
+
+	_, _, errs := listen(t, b)
+	waitSubscribers(t, mr, 1)
+	mr.Close()
+
+	select {
+	case err := <-errs:
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("listen did not stop")
+	}
+	assert.Error(t, b.Publish(&models.ChatEvent{}))
+}
@@ -2,20 +2,29 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/2024_2_BetterCallFirewall/internal/chat"
 	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+const (
+	photoPreview    = "📷 Photo"
+	documentPreview = "📎 File"
 )
 
 type ChatService struct {
-	repo chat.ChatRepository
+	repo     chat.ChatRepository
+	presence chat.PresenceRepository
 }
 
-func NewChatService(repo chat.ChatRepository) *ChatService {
+func NewChatService(repo chat.ChatRepository, presence chat.PresenceRepository) *ChatService {
 	return &ChatService{
-		repo: repo,
+		repo:     repo,
+		presence: presence,
 	}
 }
 
@@ -26,6 +35,30 @@ func (cs *ChatService) GetAllChats(ctx context.Context, userID uint32, lastUpdat
 		return nil, fmt.Errorf("get all chats: %w", err)
 	}
 
+	ids := make([]uint32, 0, len(chats))
+	for _, c := range chats {
+		if c.LastMessage == "" && c.LastFile != "" {
+			c.LastMessage = filePreview(c.LastFile)
+		}
+		if c.Group == nil {
+			ids = append(ids, c.Receiver.AuthorID)
+		}
+	}
+
+	presence, err := cs.presence.GetPresence(ids)
+	if err != nil {
+		return nil, fmt.Errorf("get presence: %w", err)
+	}
+
+	for _, c := range chats {
+		if c.Group != nil {
+			continue
+		}
+		if p, ok := presence[c.Receiver.AuthorID]; ok {
+			c.Receiver.Presence = &p
+		}
+	}
+
 	return chats, nil
 }
 
@@ -35,22 +68,150 @@ func (cs *ChatService) GetChat(ctx context.Context, userID uint32, chatID uint32
 		return nil, fmt.Errorf("get all messages: %w", err)
 	}
 
+	return cs.prepareMessages(ctx, messages, userID)
+}
+
+// prepareMessages sets reactions of the messages as userID sees them and converts their time
+func (cs *ChatService) prepareMessages(
+	ctx context.Context, messages []*models.Message, userID uint32,
+) ([]*models.Message, error) {
+	ids := make([]uint32, 0, len(messages))
+	for _, m := range messages {
+		ids = append(ids, m.ID)
+	}
+
+	reactions, err := cs.repo.GetReactionsOnMessages(ctx, ids, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get reactions: %w", err)
+	}
+
 	for i, m := range messages {
 		messages[i].CreatedAt = convertTime(m.CreatedAt)
+		if m.EditedAt != nil {
+			editedAt := convertTime(*m.EditedAt)
+			messages[i].EditedAt = &editedAt
+		}
+		if r, ok := reactions[m.ID]; ok {
+			messages[i].Reactions = r
+		} else {
+			messages[i].Reactions = models.NewReactions()
+		}
 	}
 
 	return messages, nil
 }
 
-func (cs *ChatService) SendNewMessage(ctx context.Context, receiver uint32, sender uint32, message string) error {
-	err := cs.repo.SendNewMessage(ctx, receiver, sender, message)
-	if err != nil {
+// SendNewMessage stores the message to the receiver or, if GroupID is set, to the group
+func (cs *ChatService) SendNewMessage(ctx context.Context, msg *models.Message) error {
+	var err error
+	if msg.GroupID != 0 {
+		err = cs.repo.SendGroupMessage(ctx, msg)
+	} else {
+		err = cs.repo.SendNewMessage(ctx, msg)
+	}
+	if err != nil && !errors.Is(err, my_err.ErrMessageExists) {
 		return fmt.Errorf("send new message: %w", err)
 	}
 
+	msg.CreatedAt = convertTime(msg.CreatedAt)
+	msg.Reactions = models.NewReactions()
+
+	// the message sent again is filled as the saved one, so the client can match them
+	return err
+}
+
+// GetMissedMessages returns up to limit messages of all chats of the user sent after the message with lastID
+// and after lastTime in the order they were sent
+func (cs *ChatService) GetMissedMessages(
+	ctx context.Context, userID uint32, lastID uint32, lastTime time.Time, limit uint32,
+) ([]*models.Message, error) {
+	messages, err := cs.repo.GetMessagesAfter(ctx, userID, lastID, lastTime, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get missed messages: %w", err)
+	}
+
+	return cs.prepareMessages(ctx, messages, userID)
+}
+
+func (cs *ChatService) EditMessage(ctx context.Context, msg *models.Message) error {
+	if err := cs.repo.UpdateMessage(ctx, msg); err != nil {
+		return fmt.Errorf("edit message: %w", err)
+	}
+
+	msg.CreatedAt = convertTime(msg.CreatedAt)
+	if msg.EditedAt != nil {
+		editedAt := convertTime(*msg.EditedAt)
+		msg.EditedAt = &editedAt
+	}
+
 	return nil
 }
 
+// DeleteMessage deletes the message of userID, the result holds what the other party needs to drop it
+func (cs *ChatService) DeleteMessage(ctx context.Context, messageID uint32, userID uint32) (*models.Message, error) {
+	msg, err := cs.repo.DeleteMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete message: %w", err)
+	}
+
+	return msg, nil
+}
+
+// MarkChatAsRead marks the chat with chatID as read by userID, the receipt has zero LastReadID if nothing was unread
+func (cs *ChatService) MarkChatAsRead(ctx context.Context, userID uint32, chatID uint32) (*models.ReadReceipt, error) {
+	lastID, err := cs.repo.MarkChatAsRead(ctx, userID, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("mark chat as read: %w", err)
+	}
+
+	return &models.ReadReceipt{
+		Reader:     userID,
+		Sender:     chatID,
+		LastReadID: lastID,
+		ReadAt:     convertTime(time.Now()),
+	}, nil
+}
+
+func (cs *ChatService) GetUnreadCount(ctx context.Context, userID uint32) (uint32, error) {
+	count, err := cs.repo.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("get unread count: %w", err)
+	}
+
+	return count, nil
+}
+
+func (cs *ChatService) SetReactionToMessage(
+	ctx context.Context, messageID, userID uint32, reaction models.ReactionType,
+) error {
+	if !reaction.IsValid() {
+		return my_err.ErrWrongReaction
+	}
+
+	if err := cs.repo.SetReactionToMessage(ctx, messageID, userID, reaction); err != nil {
+		return fmt.Errorf("set reaction to message: %w", err)
+	}
+
+	return nil
+}
+
+func (cs *ChatService) DeleteReactionFromMessage(ctx context.Context, messageID, userID uint32) error {
+	if err := cs.repo.DeleteReactionFromMessage(ctx, messageID, userID); err != nil {
+		return fmt.Errorf("delete reaction from message: %w", err)
+	}
+
+	return nil
+}
+
+// filePreview is shown in the chat list instead of the last message which has a file only
+func filePreview(file models.Picture) string {
+	if file.IsDocument() {
+		return documentPreview
+	}
+
+	return photoPreview
+}
+
 func convertTime(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
 }
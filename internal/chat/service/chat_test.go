@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
 var (
@@ -22,25 +24,140 @@ func (m MockRepo) GetChats(ctx context.Context, userID uint32, lastUpdateTime ti
 	if userID == 0 {
 		return nil, errMock
 	}
+	if userID == 3 {
+		return []*models.Chat{
+			{Receiver: models.Header{AuthorID: 5}},
+			{Receiver: models.Header{AuthorID: 6}},
+		}, nil
+	}
+	if userID == 4 {
+		return []*models.Chat{{Receiver: models.Header{AuthorID: 0}}}, nil
+	}
+	if userID == 7 {
+		return []*models.Chat{
+			{LastFile: "/image/photo", Receiver: models.Header{AuthorID: 6}},
+			{LastFile: "/image/doc.pdf", Group: &models.GroupChat{ID: 1}},
+			{LastMessage: "look", LastFile: "/image/photo", Receiver: models.Header{AuthorID: 8}},
+		}, nil
+	}
 	return []*models.Chat{}, nil
 }
 
+type MockPresence struct{}
+
+func (m MockPresence) GetPresence(userIDs []uint32) (map[uint32]models.Presence, error) {
+	res := make(map[uint32]models.Presence)
+	for _, id := range userIDs {
+		if id == 0 {
+			return nil, errMock
+		}
+		if id == 5 {
+			res[id] = models.Presence{UserID: id, IsOnline: true}
+		}
+	}
+	return res, nil
+}
+
 func (m MockRepo) GetMessages(ctx context.Context, userID uint32, chatID uint32, lastSentTime time.Time) ([]*models.Message, error) {
 	if userID == 0 || chatID == 0 {
 		return nil, errMock
 	}
 	return []*models.Message{
-		{CreatedAt: createTime},
+		{ID: 1, CreatedAt: createTime},
+		{ID: 2, CreatedAt: createTime},
 	}, nil
 }
 
-func (m MockRepo) SendNewMessage(ctx context.Context, receiver uint32, sender uint32, message string) error {
-	if receiver == 0 || sender == 0 || message == "" {
+func (m MockRepo) SendNewMessage(ctx context.Context, msg *models.Message) error {
+	if msg.Receiver == 0 || msg.Sender == 0 || msg.Content == "" {
+		return errMock
+	}
+	if msg.ClientID == "sent" {
+		msg.ID = 7
+		msg.CreatedAt = createTime
+		return my_err.ErrMessageExists
+	}
+	msg.ID = 1
+	msg.CreatedAt = createTime
+	return nil
+}
+
+func (m MockRepo) GetMessagesAfter(
+	ctx context.Context, userID uint32, lastID uint32, lastTime time.Time, limit uint32,
+) ([]*models.Message, error) {
+	if userID == 0 {
+		return nil, errMock
+	}
+	var res []*models.Message
+	for id := lastID + 1; id <= 3 && uint32(len(res)) < limit; id++ {
+		res = append(res, &models.Message{ID: id, CreatedAt: createTime})
+	}
+	return res, nil
+}
+
+func (m MockRepo) UpdateMessage(ctx context.Context, msg *models.Message) error {
+	if msg.ID == 0 {
+		return my_err.ErrMessageNotFound
+	}
+	msg.Receiver = 2
+	msg.CreatedAt = createTime
+	msg.IsEdited = true
+	msg.EditedAt = &createTime
+	return nil
+}
+
+func (m MockRepo) DeleteMessage(ctx context.Context, messageID uint32, userID uint32) (*models.Message, error) {
+	if messageID == 0 {
+		return nil, my_err.ErrMessageNotFound
+	}
+	return &models.Message{ID: messageID, Sender: userID, Receiver: 2}, nil
+}
+
+func (m MockRepo) MarkChatAsRead(ctx context.Context, userID uint32, chatID uint32) (uint32, error) {
+	if userID == 0 {
+		return 0, errMock
+	}
+	if chatID == 0 {
+		return 0, nil
+	}
+	return 10, nil
+}
+
+func (m MockRepo) GetUnreadCount(ctx context.Context, userID uint32) (uint32, error) {
+	if userID == 0 {
+		return 0, errMock
+	}
+	return 3, nil
+}
+
+func (m MockRepo) SetReactionToMessage(ctx context.Context, messageID, userID uint32, reaction models.ReactionType) error {
+	if userID == 0 {
 		return errMock
 	}
+	if messageID == 0 {
+		return my_err.ErrMessageNotFound
+	}
 	return nil
 }
 
+func (m MockRepo) DeleteReactionFromMessage(ctx context.Context, messageID, userID uint32) error {
+	if messageID == 0 || userID == 0 {
+		return errMock
+	}
+	return nil
+}
+
+func (m MockRepo) GetReactionsOnMessages(
+	ctx context.Context, messageIDs []uint32, userID uint32,
+) (map[uint32]models.Reactions, error) {
+	if userID == 2 {
+		return nil, errMock
+	}
+	return map[uint32]models.Reactions{
+		1: {Counts: map[models.ReactionType]uint32{models.ReactionLove: 1}, My: models.ReactionLove},
+	}, nil
+}
+
 type TestStructGetAllChats struct {
 	userID         uint32
 	lastUpdateTime time.Time
@@ -49,7 +166,7 @@ type TestStructGetAllChats struct {
 }
 
 func TestGetAllChats(t *testing.T) {
-	chatServ := NewChatService(MockRepo{})
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
 	tests := []TestStructGetAllChats{
 		{
 			userID:    0,
@@ -61,6 +178,28 @@ func TestGetAllChats(t *testing.T) {
 			wantChats: []*models.Chat{},
 			wantErr:   nil,
 		},
+		{
+			userID: 3,
+			wantChats: []*models.Chat{
+				{Receiver: models.Header{AuthorID: 5, Presence: &models.Presence{UserID: 5, IsOnline: true}}},
+				{Receiver: models.Header{AuthorID: 6}},
+			},
+			wantErr: nil,
+		},
+		{
+			userID:    4,
+			wantChats: nil,
+			wantErr:   errMock,
+		},
+		{
+			userID: 7,
+			wantChats: []*models.Chat{
+				{LastMessage: "📷 Photo", LastFile: "/image/photo", Receiver: models.Header{AuthorID: 6}},
+				{LastMessage: "📎 File", LastFile: "/image/doc.pdf", Group: &models.GroupChat{ID: 1}},
+				{LastMessage: "look", LastFile: "/image/photo", Receiver: models.Header{AuthorID: 8}},
+			},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -81,7 +220,7 @@ type TestStructGetChat struct {
 }
 
 func TestGetChat(t *testing.T) {
-	chatServ := NewChatService(MockRepo{})
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
 	tests := []TestStructGetChat{
 		{
 			userID:      0,
@@ -96,10 +235,26 @@ func TestGetChat(t *testing.T) {
 			wantErr:     errMock,
 		},
 		{
-			userID:      1,
+			userID:      2,
 			chatID:      100,
-			wantMessage: []*models.Message{{CreatedAt: convertTime(createTime)}},
-			wantErr:     nil,
+			wantMessage: nil,
+			wantErr:     errMock,
+		},
+		{
+			userID: 1,
+			chatID: 100,
+			wantMessage: []*models.Message{
+				{
+					ID:        1,
+					CreatedAt: convertTime(createTime),
+					Reactions: models.Reactions{
+						Counts: map[models.ReactionType]uint32{models.ReactionLove: 1},
+						My:     models.ReactionLove,
+					},
+				},
+				{ID: 2, CreatedAt: convertTime(createTime), Reactions: models.NewReactions()},
+			},
+			wantErr: nil,
 		},
 	}
 
@@ -120,7 +275,7 @@ type TestStructSendNewMessage struct {
 }
 
 func TestSendNewMessage(t *testing.T) {
-	chatServ := NewChatService(MockRepo{})
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
 	tests := []TestStructSendNewMessage{
 		{
 			sender:   0,
@@ -149,9 +304,142 @@ func TestSendNewMessage(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		err := chatServ.SendNewMessage(context.Background(), tt.receiver, tt.sender, tt.message)
+		msg := &models.Message{Receiver: tt.receiver, Sender: tt.sender, Content: tt.message}
+		err := chatServ.SendNewMessage(context.Background(), msg)
 		if !errors.Is(err, tt.wantErr) {
 			t.Errorf("GetAllChats() error = %v, wantErr %v", err, tt.wantErr)
 		}
+		if err == nil {
+			assert.Equal(t, uint32(1), msg.ID)
+			assert.Equal(t, convertTime(createTime), msg.CreatedAt)
+		}
+	}
+}
+
+type TestStructSetReaction struct {
+	messageID uint32
+	userID    uint32
+	reaction  models.ReactionType
+	wantErr   error
+}
+
+func TestSetReactionToMessage(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+	tests := []TestStructSetReaction{
+		{messageID: 1, userID: 1, reaction: "wow", wantErr: my_err.ErrWrongReaction},
+		{messageID: 1, userID: 0, reaction: models.ReactionSad, wantErr: errMock},
+		{messageID: 0, userID: 1, reaction: models.ReactionSad, wantErr: my_err.ErrMessageNotFound},
+		{messageID: 1, userID: 1, reaction: models.ReactionSad, wantErr: nil},
+	}
+
+	for _, tt := range tests {
+		err := chatServ.SetReactionToMessage(context.Background(), tt.messageID, tt.userID, tt.reaction)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("SetReactionToMessage() error = %v, wantErr %v", err, tt.wantErr)
+		}
+	}
+}
+
+func TestDeleteReactionFromMessage(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+	tests := []TestStructSetReaction{
+		{messageID: 0, userID: 1, wantErr: errMock},
+		{messageID: 1, userID: 1, wantErr: nil},
+	}
+
+	for _, tt := range tests {
+		err := chatServ.DeleteReactionFromMessage(context.Background(), tt.messageID, tt.userID)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("DeleteReactionFromMessage() error = %v, wantErr %v", err, tt.wantErr)
+		}
+	}
+}
+
+func TestMarkChatAsRead(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+	tests := []struct {
+		userID     uint32
+		chatID     uint32
+		wantLastID uint32
+		wantErr    error
+	}{
+		{userID: 0, chatID: 1, wantErr: errMock},
+		{userID: 1, chatID: 0, wantLastID: 0},
+		{userID: 1, chatID: 2, wantLastID: 10},
+	}
+
+	for _, tt := range tests {
+		res, err := chatServ.MarkChatAsRead(context.Background(), tt.userID, tt.chatID)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("MarkChatAsRead() error = %v, wantErr %v", err, tt.wantErr)
+		}
+		if err != nil {
+			assert.Nil(t, res)
+			continue
+		}
+		assert.Equal(t, tt.userID, res.Reader)
+		assert.Equal(t, tt.chatID, res.Sender)
+		assert.Equal(t, tt.wantLastID, res.LastReadID)
 	}
 }
+
+func TestGetUnreadCount(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	_, err := chatServ.GetUnreadCount(context.Background(), 0)
+	assert.ErrorIs(t, err, errMock)
+
+	count, err := chatServ.GetUnreadCount(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), count)
+}
+
+func TestEditMessage(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	err := chatServ.EditMessage(context.Background(), &models.Message{Sender: 1, Content: "hi"})
+	assert.ErrorIs(t, err, my_err.ErrMessageNotFound)
+
+	msg := &models.Message{ID: 1, Sender: 1, Content: "hi"}
+	require.NoError(t, chatServ.EditMessage(context.Background(), msg))
+	assert.Equal(t, uint32(2), msg.Receiver)
+	assert.True(t, msg.IsEdited)
+	require.NotNil(t, msg.EditedAt)
+	assert.Equal(t, convertTime(createTime), *msg.EditedAt)
+}
+
+func TestDeleteMessage(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	_, err := chatServ.DeleteMessage(context.Background(), 0, 1)
+	assert.ErrorIs(t, err, my_err.ErrMessageNotFound)
+
+	msg, err := chatServ.DeleteMessage(context.Background(), 5, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &models.Message{ID: 5, Sender: 1, Receiver: 2}, msg)
+}
+
+func TestSendMessageAgain(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	msg := &models.Message{Sender: 1, Receiver: 2, Content: "hi", ClientID: "sent"}
+	err := chatServ.SendNewMessage(context.Background(), msg)
+	assert.ErrorIs(t, err, my_err.ErrMessageExists)
+	assert.Equal(t, uint32(7), msg.ID)
+	assert.Equal(t, convertTime(createTime), msg.CreatedAt)
+}
+
+func TestGetMissedMessages(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	_, err := chatServ.GetMissedMessages(context.Background(), 0, 0, time.Time{}, 10)
+	assert.ErrorIs(t, err, errMock)
+
+	messages, err := chatServ.GetMissedMessages(context.Background(), 1, 1, time.Time{}, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, uint32(2), messages[0].ID)
+	assert.Equal(t, uint32(3), messages[1].ID)
+	assert.Equal(t, convertTime(createTime), messages[0].CreatedAt)
+	assert.NotNil(t, messages[1].Reactions.Counts)
+}
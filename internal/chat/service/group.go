@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+func (cs *ChatService) GetGroupChat(
+	ctx context.Context, userID uint32, groupID uint32, lastSent time.Time,
+) ([]*models.Message, error) {
+	messages, err := cs.repo.GetGroupMessages(ctx, userID, groupID, lastSent)
+	if err != nil {
+		return nil, fmt.Errorf("get group messages: %w", err)
+	}
+
+	return cs.prepareMessages(ctx, messages, userID)
+}
+
+// MarkGroupAsRead marks the group as read by userID, the receipt has zero LastReadID if nothing was unread
+func (cs *ChatService) MarkGroupAsRead(ctx context.Context, userID uint32, groupID uint32) (*models.ReadReceipt, error) {
+	lastID, err := cs.repo.MarkGroupAsRead(ctx, userID, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("mark group as read: %w", err)
+	}
+
+	return &models.ReadReceipt{
+		Reader:     userID,
+		GroupID:    groupID,
+		LastReadID: lastID,
+		ReadAt:     convertTime(time.Now()),
+	}, nil
+}
+
+// GetGroupMembers returns ids of the group members if userID is one of them
+func (cs *ChatService) GetGroupMembers(ctx context.Context, groupID uint32, userID uint32) ([]uint32, error) {
+	members, err := cs.repo.GetGroupMemberIDs(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("get group members: %w", err)
+	}
+
+	if !slices.Contains(members, userID) {
+		return nil, my_err.ErrGroupNotFound
+	}
+
+	return members, nil
+}
+
+func (cs *ChatService) CreateGroup(
+	ctx context.Context, ownerID uint32, group *models.GroupRequest,
+) (*models.GroupChat, error) {
+	id, err := cs.repo.CreateGroup(ctx, ownerID, group)
+	if err != nil {
+		return nil, fmt.Errorf("create group: %w", err)
+	}
+
+	return cs.getGroup(ctx, id)
+}
+
+// GetGroup returns the group with its members if userID is one of them
+func (cs *ChatService) GetGroup(ctx context.Context, groupID uint32, userID uint32) (*models.GroupChat, error) {
+	if _, err := cs.getRole(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	return cs.getGroup(ctx, groupID)
+}
+
+// UpdateGroup changes the title and the avatar of the group, only the owner and admins may do it
+func (cs *ChatService) UpdateGroup(
+	ctx context.Context, groupID uint32, userID uint32, group *models.GroupRequest,
+) (*models.GroupChat, error) {
+	if err := cs.checkManager(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := cs.repo.UpdateGroup(ctx, groupID, group); err != nil {
+		return nil, fmt.Errorf("update group: %w", err)
+	}
+
+	return cs.getGroup(ctx, groupID)
+}
+
+// AddGroupMembers lets the users join the group, only the owner and admins may add them
+func (cs *ChatService) AddGroupMembers(
+	ctx context.Context, groupID uint32, userID uint32, members []uint32,
+) (*models.GroupChat, error) {
+	if err := cs.checkManager(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := cs.repo.AddGroupMembers(ctx, groupID, members); err != nil {
+		return nil, fmt.Errorf("add group members: %w", err)
+	}
+
+	return cs.getGroup(ctx, groupID)
+}
+
+// KickGroupMember removes the member from the group. Admins may kick members,
+// only the owner may kick admins and nobody may kick the owner
+func (cs *ChatService) KickGroupMember(
+	ctx context.Context, groupID uint32, userID uint32, memberID uint32,
+) (*models.GroupChat, error) {
+	if userID == memberID {
+		return nil, my_err.ErrSameUser
+	}
+
+	role, err := cs.getRole(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberRole, err := cs.getRole(ctx, groupID, memberID)
+	if errors.Is(err, my_err.ErrGroupNotFound) {
+		return nil, my_err.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !role.CanManage() || memberRole == models.GroupRoleOwner ||
+		(memberRole == models.GroupRoleAdmin && role != models.GroupRoleOwner) {
+		return nil, my_err.ErrAccessDenied
+	}
+
+	if err := cs.repo.RemoveGroupMember(ctx, groupID, memberID); err != nil {
+		return nil, fmt.Errorf("kick group member: %w", err)
+	}
+
+	return cs.getGroup(ctx, groupID)
+}
+
+// SetGroupAdmin grants or revokes admin rights of the member, only the owner may do it
+func (cs *ChatService) SetGroupAdmin(
+	ctx context.Context, groupID uint32, userID uint32, memberID uint32, isAdmin bool,
+) (*models.GroupChat, error) {
+	if userID == memberID {
+		return nil, my_err.ErrSameUser
+	}
+
+	role, err := cs.getRole(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.GroupRoleOwner {
+		return nil, my_err.ErrAccessDenied
+	}
+
+	if err := cs.repo.SetGroupAdmin(ctx, groupID, memberID, isAdmin); err != nil {
+		return nil, fmt.Errorf("set group admin: %w", err)
+	}
+
+	return cs.getGroup(ctx, groupID)
+}
+
+// LeaveGroup removes userID from the group, the result has no members if the group is deleted with the last of them
+func (cs *ChatService) LeaveGroup(ctx context.Context, groupID uint32, userID uint32) (*models.GroupChat, error) {
+	if err := cs.repo.LeaveGroup(ctx, groupID, userID); err != nil {
+		return nil, fmt.Errorf("leave group: %w", err)
+	}
+
+	group, err := cs.repo.GetGroup(ctx, groupID)
+	if errors.Is(err, my_err.ErrGroupNotFound) {
+		return &models.GroupChat{ID: groupID}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get group: %w", err)
+	}
+
+	return group, nil
+}
+
+func (cs *ChatService) getGroup(ctx context.Context, groupID uint32) (*models.GroupChat, error) {
+	group, err := cs.repo.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("get group: %w", err)
+	}
+
+	return group, nil
+}
+
+func (cs *ChatService) getRole(ctx context.Context, groupID uint32, userID uint32) (models.GroupRole, error) {
+	role, err := cs.repo.GetGroupRole(ctx, groupID, userID)
+	if err != nil {
+		return "", fmt.Errorf("get group role: %w", err)
+	}
+
+	return role, nil
+}
+
+func (cs *ChatService) checkManager(ctx context.Context, groupID uint32, userID uint32) error {
+	role, err := cs.getRole(ctx, groupID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !role.CanManage() {
+		return my_err.ErrAccessDenied
+	}
+
+	return nil
+}
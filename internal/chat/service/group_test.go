@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+// the group 1 is owned by the user 1, the user 2 is its admin and the user 3 is a member,
+// the group 2 is deleted with its last member
+var groupRoles = map[uint32]models.GroupRole{
+	1: models.GroupRoleOwner,
+	2: models.GroupRoleAdmin,
+	3: models.GroupRoleMember,
+}
+
+func (m MockRepo) GetGroupMessages(
+	ctx context.Context, userID uint32, groupID uint32, lastSentTime time.Time,
+) ([]*models.Message, error) {
+	if groupID != 1 {
+		return nil, my_err.ErrNoMoreContent
+	}
+	return []*models.Message{{ID: 1, GroupID: groupID, CreatedAt: createTime}}, nil
+}
+
+func (m MockRepo) SendGroupMessage(ctx context.Context, msg *models.Message) error {
+	if _, ok := groupRoles[msg.Sender]; !ok || msg.GroupID != 1 {
+		return my_err.ErrGroupNotFound
+	}
+	msg.ID = 1
+	msg.CreatedAt = createTime
+	return nil
+}
+
+func (m MockRepo) MarkGroupAsRead(ctx context.Context, userID uint32, groupID uint32) (uint32, error) {
+	if userID == 0 {
+		return 0, errMock
+	}
+	return 10, nil
+}
+
+func (m MockRepo) CreateGroup(ctx context.Context, ownerID uint32, group *models.GroupRequest) (uint32, error) {
+	if ownerID == 0 {
+		return 0, errMock
+	}
+	return 1, nil
+}
+
+func (m MockRepo) GetGroup(ctx context.Context, groupID uint32) (*models.GroupChat, error) {
+	switch groupID {
+	case 1:
+		return &models.GroupChat{ID: 1, Title: "group", OwnerID: 1}, nil
+	case 2:
+		return nil, my_err.ErrGroupNotFound
+	}
+	return nil, errMock
+}
+
+func (m MockRepo) GetGroupMemberIDs(ctx context.Context, groupID uint32) ([]uint32, error) {
+	if groupID != 1 {
+		return nil, errMock
+	}
+	return []uint32{1, 2, 3}, nil
+}
+
+func (m MockRepo) GetGroupRole(ctx context.Context, groupID uint32, userID uint32) (models.GroupRole, error) {
+	role, ok := groupRoles[userID]
+	if !ok || groupID != 1 {
+		return "", my_err.ErrGroupNotFound
+	}
+	return role, nil
+}
+
+func (m MockRepo) UpdateGroup(ctx context.Context, groupID uint32, group *models.GroupRequest) error {
+	return nil
+}
+
+func (m MockRepo) AddGroupMembers(ctx context.Context, groupID uint32, userIDs []uint32) error {
+	if len(userIDs) == 0 {
+		return errMock
+	}
+	return nil
+}
+
+func (m MockRepo) RemoveGroupMember(ctx context.Context, groupID uint32, userID uint32) error {
+	return nil
+}
+
+func (m MockRepo) SetGroupAdmin(ctx context.Context, groupID uint32, userID uint32, isAdmin bool) error {
+	if _, ok := groupRoles[userID]; !ok {
+		return my_err.ErrUserNotFound
+	}
+	return nil
+}
+
+func (m MockRepo) LeaveGroup(ctx context.Context, groupID uint32, userID uint32) error {
+	if groupID == 0 {
+		return my_err.ErrGroupNotFound
+	}
+	return nil
+}
+
+func TestSendGroupMessage(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	err := chatServ.SendNewMessage(context.Background(), &models.Message{Sender: 4, GroupID: 1, Content: "hi"})
+	assert.ErrorIs(t, err, my_err.ErrGroupNotFound)
+
+	msg := &models.Message{Sender: 3, GroupID: 1, Content: "hi"}
+	require.NoError(t, chatServ.SendNewMessage(context.Background(), msg))
+	assert.Equal(t, uint32(1), msg.ID)
+	assert.Equal(t, convertTime(createTime), msg.CreatedAt)
+}
+
+func TestGetGroupChat(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	_, err := chatServ.GetGroupChat(context.Background(), 1, 5, time.Now())
+	assert.ErrorIs(t, err, my_err.ErrNoMoreContent)
+
+	messages, err := chatServ.GetGroupChat(context.Background(), 1, 1, time.Now())
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, models.ReactionLove, messages[0].Reactions.My)
+	assert.Equal(t, convertTime(createTime), messages[0].CreatedAt)
+}
+
+func TestMarkGroupAsRead(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	_, err := chatServ.MarkGroupAsRead(context.Background(), 0, 1)
+	assert.ErrorIs(t, err, errMock)
+
+	receipt, err := chatServ.MarkGroupAsRead(context.Background(), 3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), receipt.Reader)
+	assert.Equal(t, uint32(1), receipt.GroupID)
+	assert.Equal(t, uint32(10), receipt.LastReadID)
+}
+
+func TestGetGroupMembers(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	_, err := chatServ.GetGroupMembers(context.Background(), 5, 1)
+	assert.ErrorIs(t, err, errMock)
+
+	_, err = chatServ.GetGroupMembers(context.Background(), 1, 4)
+	assert.ErrorIs(t, err, my_err.ErrGroupNotFound)
+
+	members, err := chatServ.GetGroupMembers(context.Background(), 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2, 3}, members)
+}
+
+func TestCreateGroup(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	_, err := chatServ.CreateGroup(context.Background(), 0, &models.GroupRequest{Title: "group"})
+	assert.ErrorIs(t, err, errMock)
+
+	group, err := chatServ.CreateGroup(context.Background(), 1, &models.GroupRequest{Title: "group"})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), group.ID)
+}
+
+type TestStructManageGroup struct {
+	name     string
+	userID   uint32
+	memberID uint32
+	wantErr  error
+}
+
+func TestUpdateGroup(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+	tests := []TestStructManageGroup{
+		{name: "not a member", userID: 4, wantErr: my_err.ErrGroupNotFound},
+		{name: "member", userID: 3, wantErr: my_err.ErrAccessDenied},
+		{name: "admin", userID: 2},
+		{name: "owner", userID: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group, err := chatServ.UpdateGroup(context.Background(), 1, tt.userID, &models.GroupRequest{Title: "group"})
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, uint32(1), group.ID)
+			}
+		})
+	}
+}
+
+func TestAddGroupMembers(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	_, err := chatServ.AddGroupMembers(context.Background(), 1, 3, []uint32{5})
+	assert.ErrorIs(t, err, my_err.ErrAccessDenied)
+
+	_, err = chatServ.AddGroupMembers(context.Background(), 1, 2, nil)
+	assert.ErrorIs(t, err, errMock)
+
+	group, err := chatServ.AddGroupMembers(context.Background(), 1, 2, []uint32{5})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), group.ID)
+}
+
+func TestKickGroupMember(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+	tests := []TestStructManageGroup{
+		{name: "self", userID: 2, memberID: 2, wantErr: my_err.ErrSameUser},
+		{name: "not a member", userID: 4, memberID: 3, wantErr: my_err.ErrGroupNotFound},
+		{name: "kick not a member", userID: 1, memberID: 4, wantErr: my_err.ErrUserNotFound},
+		{name: "member kicks member", userID: 3, memberID: 2, wantErr: my_err.ErrAccessDenied},
+		{name: "admin kicks owner", userID: 2, memberID: 1, wantErr: my_err.ErrAccessDenied},
+		{name: "admin kicks member", userID: 2, memberID: 3},
+		{name: "owner kicks admin", userID: 1, memberID: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := chatServ.KickGroupMember(context.Background(), 1, tt.userID, tt.memberID)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSetGroupAdmin(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+	tests := []TestStructManageGroup{
+		{name: "self", userID: 1, memberID: 1, wantErr: my_err.ErrSameUser},
+		{name: "admin", userID: 2, memberID: 3, wantErr: my_err.ErrAccessDenied},
+		{name: "not a member", userID: 1, memberID: 4, wantErr: my_err.ErrUserNotFound},
+		{name: "owner", userID: 1, memberID: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := chatServ.SetGroupAdmin(context.Background(), 1, tt.userID, tt.memberID, true)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLeaveGroup(t *testing.T) {
+	chatServ := NewChatService(MockRepo{}, MockPresence{})
+
+	_, err := chatServ.LeaveGroup(context.Background(), 0, 3)
+	assert.ErrorIs(t, err, my_err.ErrGroupNotFound)
+
+	group, err := chatServ.LeaveGroup(context.Background(), 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "group", group.Title)
+
+	group, err = chatServ.LeaveGroup(context.Background(), 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, &models.GroupChat{ID: 2}, group)
+}
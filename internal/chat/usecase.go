@@ -10,5 +10,24 @@ import (
 type ChatService interface {
 	GetAllChats(ctx context.Context, userID uint32, lastUpdateTime time.Time) ([]*models.Chat, error)
 	GetChat(ctx context.Context, userID uint32, chatID uint32, lastSentTime time.Time) ([]*models.Message, error)
-	SendNewMessage(ctx context.Context, receiver uint32, sender uint32, message string) error
+	SendNewMessage(ctx context.Context, msg *models.Message) error
+	GetMissedMessages(ctx context.Context, userID uint32, lastID uint32, lastTime time.Time, limit uint32) ([]*models.Message, error)
+	EditMessage(ctx context.Context, msg *models.Message) error
+	DeleteMessage(ctx context.Context, messageID uint32, userID uint32) (*models.Message, error)
+	MarkChatAsRead(ctx context.Context, userID uint32, chatID uint32) (*models.ReadReceipt, error)
+	GetUnreadCount(ctx context.Context, userID uint32) (uint32, error)
+
+	SetReactionToMessage(ctx context.Context, messageID, userID uint32, reaction models.ReactionType) error
+	DeleteReactionFromMessage(ctx context.Context, messageID, userID uint32) error
+
+	GetGroupChat(ctx context.Context, userID uint32, groupID uint32, lastSentTime time.Time) ([]*models.Message, error)
+	MarkGroupAsRead(ctx context.Context, userID uint32, groupID uint32) (*models.ReadReceipt, error)
+	GetGroupMembers(ctx context.Context, groupID uint32, userID uint32) ([]uint32, error)
+	CreateGroup(ctx context.Context, ownerID uint32, group *models.GroupRequest) (*models.GroupChat, error)
+	GetGroup(ctx context.Context, groupID uint32, userID uint32) (*models.GroupChat, error)
+	UpdateGroup(ctx context.Context, groupID uint32, userID uint32, group *models.GroupRequest) (*models.GroupChat, error)
+	AddGroupMembers(ctx context.Context, groupID uint32, userID uint32, members []uint32) (*models.GroupChat, error)
+	KickGroupMember(ctx context.Context, groupID uint32, userID uint32, memberID uint32) (*models.GroupChat, error)
+	SetGroupAdmin(ctx context.Context, groupID uint32, userID uint32, memberID uint32, isAdmin bool) (*models.GroupChat, error)
+	LeaveGroup(ctx context.Context, groupID uint32, userID uint32) (*models.GroupChat, error)
 }
@@ -32,10 +32,11 @@ FROM community
 WHERE 
     (name ILIKE '%' || $1 || '%' OR about ILIKE '%' || $1 || '%')
 	AND community.id < $2
-ORDER BY community.name ASC
+ORDER BY community.id DESC
 LIMIT $3;`
 
 	GetHeader      = `SELECT id, name, avatar FROM community WHERE id = $1`
+	GetHeaders     = `SELECT id, name, avatar FROM community WHERE id = ANY($1::int[])`
 	IsFollow       = `SELECT COUNT(*) FROM community_profile WHERE community_id = $1 AND profile_id = $2`
 	InsertNewAdmin = `INSERT INTO admin(community_id, admin_id) VALUES ($1, $2)`
 	CheckAccess    = `SELECT COUNT(*) FROM admin WHERE community_id = $1 AND admin_id = $2`
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/lib/pq"
+
 	"github.com/2024_2_BetterCallFirewall/internal/models"
 	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
@@ -180,6 +182,29 @@ func (c CommunityRepository) GetHeader(ctx context.Context, communityID uint32)
 	return header, nil
 }
 
+// GetHeaders returns the headers of the existing communities of ids in no particular order
+func (c CommunityRepository) GetHeaders(ctx context.Context, ids []uint32) ([]*models.Header, error) {
+	rows, err := c.db.QueryContext(ctx, GetHeaders, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("get headers: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.Header, 0, len(ids))
+	for rows.Next() {
+		header := &models.Header{}
+		if err := rows.Scan(&header.CommunityID, &header.Author, &header.Avatar); err != nil {
+			return nil, fmt.Errorf("get headers: %w", err)
+		}
+		res = append(res, header)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get headers: %w", err)
+	}
+
+	return res, nil
+}
+
 func (c CommunityRepository) IsFollowed(ctx context.Context, communityID, userID uint32) (bool, error) {
 	res := c.db.QueryRowContext(ctx, IsFollow, communityID, userID)
 	err := res.Err()
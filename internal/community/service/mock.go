@@ -193,3 +193,56 @@ func (mr *MockRepoMockRecorder) Update(ctx, community interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepo)(nil).Update), ctx, community)
 }
+
+// MocksearchRepo is a mock of searchRepo interface.
+type MocksearchRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MocksearchRepoMockRecorder
+}
+
+// MocksearchRepoMockRecorder is the mock recorder for MocksearchRepo.
+type MocksearchRepoMockRecorder struct {
+	mock *MocksearchRepo
+}
+
+// NewMocksearchRepo creates a new mock instance.
+func NewMocksearchRepo(ctrl *gomock.Controller) *MocksearchRepo {
+	mock := &MocksearchRepo{ctrl: ctrl}
+	mock.recorder = &MocksearchRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksearchRepo) EXPECT() *MocksearchRepoMockRecorder {
+	return m.recorder
+}
+
+// IsFollowed mocks base method.
+func (m *MocksearchRepo) IsFollowed(ctx context.Context, communityID, userID uint32) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFollowed", ctx, communityID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsFollowed indicates an expected call of IsFollowed.
+func (mr *MocksearchRepoMockRecorder) IsFollowed(ctx, communityID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFollowed", reflect.TypeOf((*MocksearchRepo)(nil).IsFollowed), ctx, communityID, userID)
+}
+
+// Search mocks base method.
+func (m *MocksearchRepo) Search(ctx context.Context, query string, lastID uint32) ([]*models.CommunityCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, lastID)
+	ret0, _ := ret[0].([]*models.CommunityCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MocksearchRepoMockRecorder) Search(ctx, query, lastID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MocksearchRepo)(nil).Search), ctx, query, lastID)
+}
@@ -63,3 +63,48 @@ func (mr *MockrepoHelperMockRecorder) GetHeader(ctx, communityID interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockrepoHelper)(nil).GetHeader), ctx, communityID)
 }
+
+// GetHeaders mocks base method.
+func (m *MockrepoHelper) GetHeaders(ctx context.Context, communityIDs []uint32) ([]*models.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeaders", ctx, communityIDs)
+	ret0, _ := ret[0].([]*models.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeaders indicates an expected call of GetHeaders.
+func (mr *MockrepoHelperMockRecorder) GetHeaders(ctx, communityIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeaders", reflect.TypeOf((*MockrepoHelper)(nil).GetHeaders), ctx, communityIDs)
+}
+
+// IsFollowed mocks base method.
+func (m *MockrepoHelper) IsFollowed(ctx context.Context, communityID, userID uint32) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFollowed", ctx, communityID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsFollowed indicates an expected call of IsFollowed.
+func (mr *MockrepoHelperMockRecorder) IsFollowed(ctx, communityID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFollowed", reflect.TypeOf((*MockrepoHelper)(nil).IsFollowed), ctx, communityID, userID)
+}
+
+// Search mocks base method.
+func (m *MockrepoHelper) Search(ctx context.Context, query string, lastID uint32) ([]*models.CommunityCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, lastID)
+	ret0, _ := ret[0].([]*models.CommunityCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockrepoHelperMockRecorder) Search(ctx, query, lastID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockrepoHelper)(nil).Search), ctx, query, lastID)
+}
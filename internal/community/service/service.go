@@ -131,13 +131,23 @@ func (s *Service) AddAdmin(ctx context.Context, communityId, author uint32) erro
 }
 
 func (s *Service) Search(ctx context.Context, query string, userID, lastID uint32) ([]*models.CommunityCard, error) {
-	cards, err := s.repo.Search(ctx, query, lastID)
+	return search(ctx, s.repo, query, userID, lastID)
+}
+
+type searchRepo interface {
+	Search(ctx context.Context, query string, lastID uint32) ([]*models.CommunityCard, error)
+	IsFollowed(ctx context.Context, communityID, userID uint32) (bool, error)
+}
+
+// search finds the communities by name or description and marks the ones userID follows
+func search(ctx context.Context, repo searchRepo, query string, userID, lastID uint32) ([]*models.CommunityCard, error) {
+	cards, err := repo.Search(ctx, query, lastID)
 	if err != nil {
 		return nil, fmt.Errorf("search community: %w", err)
 	}
 
 	for i, card := range cards {
-		follow, err := s.repo.IsFollowed(ctx, card.ID, userID)
+		follow, err := repo.IsFollowed(ctx, card.ID, userID)
 		if err != nil {
 			return nil, fmt.Errorf("get community list: %w", err)
 		}
@@ -10,6 +10,9 @@ import (
 type repoHelper interface {
 	CheckAccess(ctx context.Context, communityID, userID uint32) bool
 	GetHeader(ctx context.Context, communityID uint32) (*models.Header, error)
+	GetHeaders(ctx context.Context, communityIDs []uint32) ([]*models.Header, error)
+	Search(ctx context.Context, query string, lastID uint32) ([]*models.CommunityCard, error)
+	IsFollowed(ctx context.Context, communityID, userID uint32) (bool, error)
 }
 
 type ServiceHelper struct {
@@ -34,3 +37,11 @@ func (s *ServiceHelper) GetHeader(ctx context.Context, communityID uint32) (*mod
 
 	return header, nil
 }
+
+func (s *ServiceHelper) GetHeaders(ctx context.Context, communityIDs []uint32) ([]*models.Header, error) {
+	return s.repo.GetHeaders(ctx, communityIDs)
+}
+
+func (s *ServiceHelper) Search(ctx context.Context, query string, userID, lastID uint32) ([]*models.CommunityCard, error) {
+	return search(ctx, s.repo, query, userID, lastID)
+}
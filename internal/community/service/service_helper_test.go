@@ -168,6 +168,74 @@ func TestGetHeader(t *testing.T) {
 	}
 }
 
+func TestSearchHelper(t *testing.T) {
+	tests := []TableTest2[[]*models.CommunityCard, InputCheckAccess]{
+		{
+			name: "1",
+			SetupInput: func() (*InputCheckAccess, error) {
+				return &InputCheckAccess{userID: 1, communityID: 10}, nil
+			},
+			Run: func(ctx context.Context, implementation *ServiceHelper, input InputCheckAccess) ([]*models.CommunityCard, error) {
+				return implementation.Search(ctx, "cats", input.userID, input.communityID)
+			},
+			ExpectedResult: func() ([]*models.CommunityCard, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(input InputCheckAccess, m *mocksHelper) {
+				m.repo.EXPECT().Search(gomock.Any(), "cats", uint32(10)).Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*InputCheckAccess, error) {
+				return &InputCheckAccess{userID: 1, communityID: 10}, nil
+			},
+			Run: func(ctx context.Context, implementation *ServiceHelper, input InputCheckAccess) ([]*models.CommunityCard, error) {
+				return implementation.Search(ctx, "cats", input.userID, input.communityID)
+			},
+			ExpectedResult: func() ([]*models.CommunityCard, error) {
+				return []*models.CommunityCard{{ID: 3, Name: "cats", IsFollowed: true}, {ID: 2, Name: "more cats"}}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(input InputCheckAccess, m *mocksHelper) {
+				m.repo.EXPECT().Search(gomock.Any(), "cats", uint32(10)).
+					Return([]*models.CommunityCard{{ID: 3, Name: "cats"}, {ID: 2, Name: "more cats"}}, nil)
+				m.repo.EXPECT().IsFollowed(gomock.Any(), uint32(3), uint32(1)).Return(true, nil)
+				m.repo.EXPECT().IsFollowed(gomock.Any(), uint32(2), uint32(1)).Return(false, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getHelper(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
 type TableTest2[T, In any] struct {
 	name           string
 	SetupInput     func() (*In, error)
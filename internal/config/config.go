@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -36,6 +37,27 @@ type GRPCServer struct {
 	Host string
 }
 
+// Mail is where the letters to the users go. The letters are written to File, to stdout if it is empty,
+// ResetURL and VerifyURL are the pages of the client the links of the password reset and the email verification lead to
+type Mail struct {
+	File      string
+	ResetURL  string
+	VerifyURL string
+}
+
+// Verify is how the emails are verified. Secret signs the tokens of the verification,
+// Limits are the actions the users with the unverified email can not do
+type Verify struct {
+	Secret string
+	Limits []string
+}
+
+// Proxy is the proxies in front of the services. The addresses of the clients are taken from the headers
+// of the requests coming from Trusted only, it is the list of the addresses and the networks of the proxies
+type Proxy struct {
+	Trusted []string
+}
+
 type Config struct {
 	DB            DBConnect
 	REDIS         Redis
@@ -49,6 +71,9 @@ type Config struct {
 	PROFILEGRPC   GRPCServer
 	POSTGRPC      GRPCServer
 	COMMUNITYGRPC GRPCServer
+	MAIL          Mail
+	VERIFY        Verify
+	PROXY         Proxy
 }
 
 func GetConfig(configFilePath string) (*Config, error) {
@@ -118,6 +143,18 @@ func GetConfig(configFilePath string) (*Config, error) {
 				Port: os.Getenv("COMMUNITY_GRPC_PORT"),
 				Host: os.Getenv("COMMUNITY_GRPC_HOST"),
 			},
+			MAIL: Mail{
+				File:      os.Getenv("MAIL_FILE"),
+				ResetURL:  os.Getenv("MAIL_RESET_URL"),
+				VerifyURL: os.Getenv("MAIL_VERIFY_URL"),
+			},
+			VERIFY: Verify{
+				Secret: os.Getenv("VERIFY_SECRET"),
+				Limits: getListEnv("VERIFY_LIMITS"),
+			},
+			PROXY: Proxy{
+				Trusted: getListEnv("TRUSTED_PROXIES"),
+			},
 		},
 		nil
 }
@@ -130,3 +167,14 @@ func getIntEnv(key string) int {
 	}
 	return res
 }
+
+// getListEnv reads the comma separated list, the list is empty if the key is not set
+func getListEnv(key string) []string {
+	var res []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
@@ -14,4 +14,7 @@ func TestGetConfig(t *testing.T) {
 	cfg, err = GetConfig("./test.env")
 	assert.NoError(t, err)
 	assert.NotNil(t, cfg)
+	assert.Equal(t, "test-secret", cfg.VERIFY.Secret)
+	assert.Equal(t, []string{"post", "comment"}, cfg.VERIFY.Limits)
+	assert.Equal(t, []string{"127.0.0.1", "10.0.0.0/8"}, cfg.PROXY.Trusted)
 }
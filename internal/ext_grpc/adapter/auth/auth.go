@@ -43,6 +43,18 @@ func (s *GrpcSender) Check(cookie string) (*models.Session, error) {
 	return res, nil
 }
 
+// Rotate reissues the session keeping its device and the time of the login
+func (s *GrpcSender) Rotate(session *models.Session) (*models.Session, error) {
+	req := auth.NewRotateRequest(session)
+	resp, err := s.client.Rotate(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := auth.UnmarshalRotateResponse(resp)
+	return res, nil
+}
+
 func (s *GrpcSender) Destroy(session *models.Session) error {
 	req := auth.NewDestroyRequest(session)
 	_, err := s.client.Destroy(context.Background(), req)
@@ -68,8 +68,9 @@ func TestCreate(t *testing.T) {
 				m.client.EXPECT().Create(gomock.Any(), gomock.Any()).
 					Return(&auth_api.CreateResponse{
 						Sess: &auth_api.Session{
-							ID:     "session",
-							UserID: 1,
+							ID:       "session",
+							UserID:   1,
+							Verified: true,
 						},
 					}, nil)
 			},
@@ -147,8 +148,9 @@ func TestCheck(t *testing.T) {
 				m.client.EXPECT().Check(gomock.Any(), gomock.Any()).
 					Return(&auth_api.CheckResponse{
 						Sess: &auth_api.Session{
-							ID:     "session",
-							UserID: 1,
+							ID:       "session",
+							UserID:   1,
+							Verified: true,
 						},
 					}, nil)
 			},
@@ -184,6 +186,93 @@ func TestCheck(t *testing.T) {
 	}
 }
 
+func TestRotate(t *testing.T) {
+	tests := []TableTest[*models.Session, models.Session]{
+		{
+			name: "1",
+			SetupInput: func() (*models.Session, error) {
+				return &models.Session{ID: "session", UserID: 1}, nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request *models.Session) (*models.Session, error) {
+				return implementation.Rotate(request)
+			},
+			ExpectedErr: errMock,
+			ExpectedResult: func() (*models.Session, error) {
+				return nil, nil
+			},
+			SetupMock: func(request *models.Session, m *mocks) {
+				m.client.EXPECT().Rotate(gomock.Any(), gomock.Any()).
+					Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*models.Session, error) {
+				return &models.Session{ID: "session", UserID: 1, UserAgent: "Firefox"}, nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request *models.Session) (*models.Session, error) {
+				return implementation.Rotate(request)
+			},
+			ExpectedErr: nil,
+			ExpectedResult: func() (*models.Session, error) {
+				return &models.Session{
+					ID:        "new",
+					UserID:    1,
+					CreatedAt: 20,
+					UserAgent: "Firefox",
+					IP:        "127.0.0.1",
+					LoginAt:   10,
+					LastSeen:  20,
+				}, nil
+			},
+			SetupMock: func(request *models.Session, m *mocks) {
+				m.client.EXPECT().Rotate(gomock.Any(), &auth_api.RotateRequest{
+					Sess: &auth_api.Session{ID: "session", UserID: 1, UserAgent: "Firefox", Verified: true},
+				}).Return(&auth_api.RotateResponse{
+					Sess: &auth_api.Session{
+						ID:        "new",
+						UserID:    1,
+						CreatedAt: 20,
+						UserAgent: "Firefox",
+						IP:        "127.0.0.1",
+						LoginAt:   10,
+						LastSeen:  20,
+						Verified:  true,
+					},
+				}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expected error %v, got %v", v.ExpectedErr, err)
+			}
+			assert.Equal(t, res, actual)
+		})
+	}
+}
+
 func TestDestroy(t *testing.T) {
 	tests := []TableTest[struct{}, models.Session]{
 		{
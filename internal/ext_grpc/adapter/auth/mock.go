@@ -95,3 +95,83 @@ func (mr *MockAuthServiceClientMockRecorder) Destroy(ctx, in interface{}, opts .
 	varargs := append([]interface{}{ctx, in}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockAuthServiceClient)(nil).Destroy), varargs...)
 }
+
+// ListSessions mocks base method.
+func (m *MockAuthServiceClient) ListSessions(ctx context.Context, in *auth_api.ListSessionsRequest, opts ...grpc.CallOption) (*auth_api.ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSessions", varargs...)
+	ret0, _ := ret[0].(*auth_api.ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthServiceClientMockRecorder) ListSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthServiceClient)(nil).ListSessions), varargs...)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockAuthServiceClient) RevokeOtherSessions(ctx context.Context, in *auth_api.RevokeOtherSessionsRequest, opts ...grpc.CallOption) (*auth_api.EmptyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", varargs...)
+	ret0, _ := ret[0].(*auth_api.EmptyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockAuthServiceClientMockRecorder) RevokeOtherSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockAuthServiceClient)(nil).RevokeOtherSessions), varargs...)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthServiceClient) RevokeSession(ctx context.Context, in *auth_api.RevokeSessionRequest, opts ...grpc.CallOption) (*auth_api.EmptyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeSession", varargs...)
+	ret0, _ := ret[0].(*auth_api.EmptyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthServiceClientMockRecorder) RevokeSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthServiceClient)(nil).RevokeSession), varargs...)
+}
+
+// Rotate mocks base method.
+func (m *MockAuthServiceClient) Rotate(ctx context.Context, in *auth_api.RotateRequest, opts ...grpc.CallOption) (*auth_api.RotateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Rotate", varargs...)
+	ret0, _ := ret[0].(*auth_api.RotateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockAuthServiceClientMockRecorder) Rotate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockAuthServiceClient)(nil).Rotate), varargs...)
+}
@@ -44,3 +44,27 @@ func (g *GrpcSender) GetHeader(ctx context.Context, communityID uint32) (*models
 	res := community.UnmarshallHeaderResponse(resp)
 	return res, nil
 }
+
+func (g *GrpcSender) GetHeaders(ctx context.Context, communityIDs []uint32) ([]*models.Header, error) {
+	req := community.NewHeadersRequest(communityIDs)
+	resp, err := g.client.GetHeaders(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := community.UnmarshallHeadersResponse(resp)
+	return res, nil
+}
+
+func (g *GrpcSender) SearchCommunities(
+	ctx context.Context, userID uint32, query string, lastID uint32,
+) ([]*models.CommunityCard, error) {
+	req := community.NewSearchRequest(userID, query, lastID)
+	resp, err := g.client.SearchCommunities(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := community.UnmarshallSearchResponse(resp)
+	return res, nil
+}
@@ -175,6 +175,152 @@ func TestGetHeader(t *testing.T) {
 	}
 }
 
+func TestGetHeaders(t *testing.T) {
+	errMock := errors.New("mock error")
+	tests := []TableTest[[]*models.Header, []uint32]{
+		{
+			name: "1",
+			SetupInput: func() ([]uint32, error) {
+				return []uint32{1, 2}, nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request []uint32) ([]*models.Header, error) {
+				return implementation.GetHeaders(ctx, request)
+			},
+			ExpectedResult: func() ([]*models.Header, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request []uint32, m *mocks) {
+				m.client.EXPECT().GetHeaders(gomock.Any(), gomock.Any()).
+					Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() ([]uint32, error) {
+				return []uint32{1, 2}, nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request []uint32) ([]*models.Header, error) {
+				return implementation.GetHeaders(ctx, request)
+			},
+			ExpectedResult: func() ([]*models.Header, error) {
+				return []*models.Header{
+					{CommunityID: 1, Author: "Community"},
+					{CommunityID: 2, Author: "Other", Avatar: "/avatar"},
+				}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request []uint32, m *mocks) {
+				m.client.EXPECT().GetHeaders(gomock.Any(), &community_api.GetHeadersRequest{CommunityIDs: request}).
+					Return(&community_api.GetHeadersResponse{Heads: []*community_api.Header{
+						{CommunityID: 1, Author: "Community"},
+						{CommunityID: 2, Author: "Other", Avatar: "/avatar"},
+					}}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSearchCommunities(t *testing.T) {
+	errMock := errors.New("mock error")
+	tests := []TableTest[[]*models.CommunityCard, *input]{
+		{
+			name: "1",
+			SetupInput: func() (*input, error) {
+				return &input{}, nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request *input) ([]*models.CommunityCard, error) {
+				return implementation.SearchCommunities(ctx, request.userID, "community", request.communityID)
+			},
+			ExpectedErr: errMock,
+			ExpectedResult: func() ([]*models.CommunityCard, error) {
+				return nil, nil
+			},
+			SetupMock: func(request *input, m *mocks) {
+				m.client.EXPECT().SearchCommunities(gomock.Any(), gomock.Any()).Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*input, error) {
+				return &input{userID: 1, communityID: 10}, nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request *input) ([]*models.CommunityCard, error) {
+				return implementation.SearchCommunities(ctx, request.userID, "community", request.communityID)
+			},
+			ExpectedErr: nil,
+			ExpectedResult: func() ([]*models.CommunityCard, error) {
+				return []*models.CommunityCard{{ID: 3, Name: "community", Avatar: "/avatar", IsFollowed: true}}, nil
+			},
+			SetupMock: func(request *input, m *mocks) {
+				m.client.EXPECT().SearchCommunities(
+					gomock.Any(), &community_api.SearchRequest{UserID: 1, Query: "community", LastID: 10},
+				).Return(&community_api.SearchCommunitiesResponse{
+					Communities: []*community_api.CommunityCard{
+						{ID: 3, Name: "community", Avatar: "/avatar", IsFollowed: true},
+					},
+				}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
 type TableTest[T, In any] struct {
 	name           string
 	SetupInput     func() (In, error)
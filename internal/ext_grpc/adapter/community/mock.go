@@ -75,3 +75,43 @@ func (mr *MockCommunityServiceClientMockRecorder) GetHeader(ctx, in interface{},
 	varargs := append([]interface{}{ctx, in}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockCommunityServiceClient)(nil).GetHeader), varargs...)
 }
+
+// GetHeaders mocks base method.
+func (m *MockCommunityServiceClient) GetHeaders(ctx context.Context, in *community_api.GetHeadersRequest, opts ...grpc.CallOption) (*community_api.GetHeadersResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHeaders", varargs...)
+	ret0, _ := ret[0].(*community_api.GetHeadersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeaders indicates an expected call of GetHeaders.
+func (mr *MockCommunityServiceClientMockRecorder) GetHeaders(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeaders", reflect.TypeOf((*MockCommunityServiceClient)(nil).GetHeaders), varargs...)
+}
+
+// SearchCommunities mocks base method.
+func (m *MockCommunityServiceClient) SearchCommunities(ctx context.Context, in *community_api.SearchRequest, opts ...grpc.CallOption) (*community_api.SearchCommunitiesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SearchCommunities", varargs...)
+	ret0, _ := ret[0].(*community_api.SearchCommunitiesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCommunities indicates an expected call of SearchCommunities.
+func (mr *MockCommunityServiceClientMockRecorder) SearchCommunities(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCommunities", reflect.TypeOf((*MockCommunityServiceClient)(nil).SearchCommunities), varargs...)
+}
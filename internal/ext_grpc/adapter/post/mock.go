@@ -55,3 +55,23 @@ func (mr *MockPostServiceClientMockRecorder) GetAuthorsPosts(ctx, in interface{}
 	varargs := append([]interface{}{ctx, in}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorsPosts", reflect.TypeOf((*MockPostServiceClient)(nil).GetAuthorsPosts), varargs...)
 }
+
+// SearchPosts mocks base method.
+func (m *MockPostServiceClient) SearchPosts(ctx context.Context, in *post_api.SearchRequest, opts ...grpc.CallOption) (*post_api.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SearchPosts", varargs...)
+	ret0, _ := ret[0].(*post_api.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPosts indicates an expected call of SearchPosts.
+func (mr *MockPostServiceClientMockRecorder) SearchPosts(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPosts", reflect.TypeOf((*MockPostServiceClient)(nil).SearchPosts), varargs...)
+}
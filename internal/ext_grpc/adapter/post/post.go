@@ -30,4 +30,14 @@ func (g *GrpcSender) GetAuthorsPosts(ctx context.Context, header *models.Header,
 
 	res := post.UnmarshalResponse(resp)
 	return res, nil
-}
\ No newline at end of file
+}
+func (g *GrpcSender) SearchPosts(ctx context.Context, userID uint32, query string, cursor string) (*models.PostPage, error) {
+	req := post.NewSearchRequest(userID, query, cursor)
+	resp, err := g.client.SearchPosts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := post.UnmarshalSearchResponse(resp)
+	return res, nil
+}
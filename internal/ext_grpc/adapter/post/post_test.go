@@ -69,6 +69,10 @@ func TestGetAuthorsPost(t *testing.T) {
 						Header: models.Header{
 							AuthorID: 1,
 						},
+						Reactions: models.Reactions{
+							Counts: map[models.ReactionType]uint32{models.ReactionLaugh: 3},
+							My:     models.ReactionLaugh,
+						},
 					},
 				}, nil
 			},
@@ -87,6 +91,8 @@ func TestGetAuthorsPost(t *testing.T) {
 								Head: &post_api.Header{
 									AuthorID: 1,
 								},
+								Reactions:  map[string]uint32{"laugh": 3},
+								MyReaction: "laugh",
 							},
 						},
 					}, nil)
@@ -123,6 +129,94 @@ func TestGetAuthorsPost(t *testing.T) {
 	}
 }
 
+func TestSearchPosts(t *testing.T) {
+	tests := []TableTest[*models.PostPage, string]{
+		{
+			name: "1",
+			SetupInput: func() (string, error) {
+				return "", nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request string) (*models.PostPage, error) {
+				return implementation.SearchPosts(ctx, 1, "post", request)
+			},
+			ExpectedResult: func() (*models.PostPage, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request string, m *mocks) {
+				m.client.EXPECT().SearchPosts(gomock.Any(), gomock.Any()).Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (string, error) {
+				return "cursor", nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request string) (*models.PostPage, error) {
+				return implementation.SearchPosts(ctx, 1, "post", request)
+			},
+			ExpectedResult: func() (*models.PostPage, error) {
+				return &models.PostPage{
+					Posts: []*models.Post{
+						{
+							ID:          2,
+							Header:      models.Header{AuthorID: 1},
+							PostContent: models.Content{Text: "new post", CreatedAt: time.Unix(0, 0), UpdatedAt: time.Unix(0, 0)},
+							Reactions:   models.NewReactions(),
+							Snippet:     "new <mark>post</mark>",
+						},
+					},
+					Cursor: "next",
+				}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request string, m *mocks) {
+				m.client.EXPECT().SearchPosts(
+					gomock.Any(), &post_api.SearchRequest{UserID: 1, Query: "post", Cursor: request},
+				).Return(&post_api.SearchResponse{
+					Posts: []*post_api.Post{
+						{
+							ID:          2,
+							Head:        &post_api.Header{AuthorID: 1},
+							PostContent: &post_api.Content{Text: "new post"},
+							Snippet:     "new <mark>post</mark>",
+						},
+					},
+					Cursor: "next",
+				}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
 type TableTest[T, In any] struct {
 	name           string
 	SetupInput     func() (In, error)
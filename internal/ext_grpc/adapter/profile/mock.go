@@ -56,6 +56,26 @@ func (mr *MockProfileServiceClientMockRecorder) Create(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProfileServiceClient)(nil).Create), varargs...)
 }
 
+// DeleteTwoFactor mocks base method.
+func (m *MockProfileServiceClient) DeleteTwoFactor(ctx context.Context, in *profile_api.TwoFactorRequest, opts ...grpc.CallOption) (*profile_api.DeleteTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteTwoFactor", varargs...)
+	ret0, _ := ret[0].(*profile_api.DeleteTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTwoFactor indicates an expected call of DeleteTwoFactor.
+func (mr *MockProfileServiceClientMockRecorder) DeleteTwoFactor(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactor", reflect.TypeOf((*MockProfileServiceClient)(nil).DeleteTwoFactor), varargs...)
+}
+
 // GetFriendsID mocks base method.
 func (m *MockProfileServiceClient) GetFriendsID(ctx context.Context, in *profile_api.FriendsRequest, opts ...grpc.CallOption) (*profile_api.FriendsResponse, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +116,66 @@ func (mr *MockProfileServiceClientMockRecorder) GetHeader(ctx, in interface{}, o
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockProfileServiceClient)(nil).GetHeader), varargs...)
 }
 
+// GetHeaders mocks base method.
+func (m *MockProfileServiceClient) GetHeaders(ctx context.Context, in *profile_api.HeadersRequest, opts ...grpc.CallOption) (*profile_api.HeadersResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHeaders", varargs...)
+	ret0, _ := ret[0].(*profile_api.HeadersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeaders indicates an expected call of GetHeaders.
+func (mr *MockProfileServiceClientMockRecorder) GetHeaders(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeaders", reflect.TypeOf((*MockProfileServiceClient)(nil).GetHeaders), varargs...)
+}
+
+// GetShortProfiles mocks base method.
+func (m *MockProfileServiceClient) GetShortProfiles(ctx context.Context, in *profile_api.ShortProfilesRequest, opts ...grpc.CallOption) (*profile_api.ShortProfilesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetShortProfiles", varargs...)
+	ret0, _ := ret[0].(*profile_api.ShortProfilesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortProfiles indicates an expected call of GetShortProfiles.
+func (mr *MockProfileServiceClientMockRecorder) GetShortProfiles(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortProfiles", reflect.TypeOf((*MockProfileServiceClient)(nil).GetShortProfiles), varargs...)
+}
+
+// GetTwoFactor mocks base method.
+func (m *MockProfileServiceClient) GetTwoFactor(ctx context.Context, in *profile_api.TwoFactorRequest, opts ...grpc.CallOption) (*profile_api.TwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTwoFactor", varargs...)
+	ret0, _ := ret[0].(*profile_api.TwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactor indicates an expected call of GetTwoFactor.
+func (mr *MockProfileServiceClientMockRecorder) GetTwoFactor(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactor", reflect.TypeOf((*MockProfileServiceClient)(nil).GetTwoFactor), varargs...)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockProfileServiceClient) GetUserByEmail(ctx context.Context, in *profile_api.GetByEmailRequest, opts ...grpc.CallOption) (*profile_api.GetByEmailResponse, error) {
 	m.ctrl.T.Helper()
@@ -115,3 +195,123 @@ func (mr *MockProfileServiceClientMockRecorder) GetUserByEmail(ctx, in interface
 	varargs := append([]interface{}{ctx, in}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockProfileServiceClient)(nil).GetUserByEmail), varargs...)
 }
+
+// GetUserByID mocks base method.
+func (m *MockProfileServiceClient) GetUserByID(ctx context.Context, in *profile_api.GetByIDRequest, opts ...grpc.CallOption) (*profile_api.GetByIDResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUserByID", varargs...)
+	ret0, _ := ret[0].(*profile_api.GetByIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockProfileServiceClientMockRecorder) GetUserByID(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockProfileServiceClient)(nil).GetUserByID), varargs...)
+}
+
+// SaveTwoFactor mocks base method.
+func (m *MockProfileServiceClient) SaveTwoFactor(ctx context.Context, in *profile_api.SaveTwoFactorRequest, opts ...grpc.CallOption) (*profile_api.SaveTwoFactorResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SaveTwoFactor", varargs...)
+	ret0, _ := ret[0].(*profile_api.SaveTwoFactorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTwoFactor indicates an expected call of SaveTwoFactor.
+func (mr *MockProfileServiceClientMockRecorder) SaveTwoFactor(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTwoFactor", reflect.TypeOf((*MockProfileServiceClient)(nil).SaveTwoFactor), varargs...)
+}
+
+// SearchProfiles mocks base method.
+func (m *MockProfileServiceClient) SearchProfiles(ctx context.Context, in *profile_api.SearchRequest, opts ...grpc.CallOption) (*profile_api.SearchProfilesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SearchProfiles", varargs...)
+	ret0, _ := ret[0].(*profile_api.SearchProfilesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchProfiles indicates an expected call of SearchProfiles.
+func (mr *MockProfileServiceClientMockRecorder) SearchProfiles(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProfiles", reflect.TypeOf((*MockProfileServiceClient)(nil).SearchProfiles), varargs...)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockProfileServiceClient) UpdatePassword(ctx context.Context, in *profile_api.UpdatePasswordRequest, opts ...grpc.CallOption) (*profile_api.UpdatePasswordResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdatePassword", varargs...)
+	ret0, _ := ret[0].(*profile_api.UpdatePasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockProfileServiceClientMockRecorder) UpdatePassword(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockProfileServiceClient)(nil).UpdatePassword), varargs...)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockProfileServiceClient) UseRecoveryCode(ctx context.Context, in *profile_api.UseRecoveryCodeRequest, opts ...grpc.CallOption) (*profile_api.UseRecoveryCodeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UseRecoveryCode", varargs...)
+	ret0, _ := ret[0].(*profile_api.UseRecoveryCodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockProfileServiceClientMockRecorder) UseRecoveryCode(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockProfileServiceClient)(nil).UseRecoveryCode), varargs...)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockProfileServiceClient) VerifyEmail(ctx context.Context, in *profile_api.VerifyEmailRequest, opts ...grpc.CallOption) (*profile_api.VerifyEmailResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyEmail", varargs...)
+	ret0, _ := ret[0].(*profile_api.VerifyEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockProfileServiceClientMockRecorder) VerifyEmail(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockProfileServiceClient)(nil).VerifyEmail), varargs...)
+}
@@ -34,6 +34,17 @@ func (g *GrpcSender) GetHeader(ctx context.Context, userID uint32) (*models.Head
 	return res, nil
 }
 
+func (g *GrpcSender) GetHeaders(ctx context.Context, userIDs []uint32) ([]*models.Header, error) {
+	req := profile.NewGetHeadersRequest(userIDs)
+	resp, err := g.client.GetHeaders(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := profile.UnmarshallHeadersResponse(resp)
+	return res, nil
+}
+
 func (g *GrpcSender) GetFriendsID(ctx context.Context, userID uint32) ([]uint32, error) {
 	req := profile.NewGetFriendsIDRequest(userID)
 	resp, err := g.client.GetFriendsID(ctx, req)
@@ -66,3 +77,76 @@ func (g *GrpcSender) GetByEmail(ctx context.Context, email string) (*models.User
 	res := profile.UnmarshallGetUserByEmailRequest(resp)
 	return res, nil
 }
+
+func (g *GrpcSender) GetByID(ctx context.Context, userID uint32) (*models.User, error) {
+	req := profile.NewGetUserByIDRequest(userID)
+	resp, err := g.client.GetUserByID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := profile.UnmarshallGetUserByIDResponse(resp)
+	return res, nil
+}
+
+func (g *GrpcSender) UpdatePassword(ctx context.Context, userID uint32, password string) error {
+	req := profile.NewUpdatePasswordRequest(userID, password)
+	_, err := g.client.UpdatePassword(ctx, req)
+	return err
+}
+
+func (g *GrpcSender) VerifyEmail(ctx context.Context, userID uint32, email string) error {
+	req := profile.NewVerifyEmailRequest(userID, email)
+	_, err := g.client.VerifyEmail(ctx, req)
+	return err
+}
+
+func (g *GrpcSender) GetTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactor, error) {
+	req := profile.NewTwoFactorRequest(userID)
+	resp, err := g.client.GetTwoFactor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile.UnmarshallTwoFactorResponse(userID, resp), nil
+}
+
+func (g *GrpcSender) SaveTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error {
+	req := profile.NewSaveTwoFactorRequest(twoFactor)
+	_, err := g.client.SaveTwoFactor(ctx, req)
+	return err
+}
+
+func (g *GrpcSender) DeleteTwoFactor(ctx context.Context, userID uint32) error {
+	req := profile.NewTwoFactorRequest(userID)
+	_, err := g.client.DeleteTwoFactor(ctx, req)
+	return err
+}
+
+func (g *GrpcSender) UseRecoveryCode(ctx context.Context, userID uint32, codeHash string) error {
+	req := profile.NewUseRecoveryCodeRequest(userID, codeHash)
+	_, err := g.client.UseRecoveryCode(ctx, req)
+	return err
+}
+
+func (g *GrpcSender) GetShortProfiles(ctx context.Context, selfID uint32, ids []uint32) ([]*models.ShortProfile, error) {
+	req := profile.NewGetShortProfilesRequest(selfID, ids)
+	resp, err := g.client.GetShortProfiles(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := profile.UnmarshallGetShortProfilesResponse(resp)
+	return res, nil
+}
+
+func (g *GrpcSender) SearchProfiles(ctx context.Context, userID uint32, query string, cursor string) (*models.ProfilePage, error) {
+	req := profile.NewSearchRequest(userID, query, cursor)
+	resp, err := g.client.SearchProfiles(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := profile.UnmarshallSearchResponse(resp)
+	return res, nil
+}
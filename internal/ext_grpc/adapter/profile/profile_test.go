@@ -95,6 +95,79 @@ func TestGetHeader(t *testing.T) {
 	}
 }
 
+func TestGetHeaders(t *testing.T) {
+	tests := []TableTest[[]*models.Header, []uint32]{
+		{
+			name: "1",
+			SetupInput: func() (*[]uint32, error) {
+				return &[]uint32{1, 2}, nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request *[]uint32) ([]*models.Header, error) {
+				return implementation.GetHeaders(ctx, *request)
+			},
+			ExpectedResult: func() ([]*models.Header, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request *[]uint32, m *mocks) {
+				m.client.EXPECT().GetHeaders(gomock.Any(), gomock.Any()).
+					Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*[]uint32, error) {
+				return &[]uint32{1, 2}, nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request *[]uint32) ([]*models.Header, error) {
+				return implementation.GetHeaders(ctx, *request)
+			},
+			ExpectedResult: func() ([]*models.Header, error) {
+				return []*models.Header{
+					{AuthorID: 1, Author: "Alexey Zemliakov"},
+					{AuthorID: 2, Author: "Andrew Savvateev", Avatar: "/image"},
+				}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request *[]uint32, m *mocks) {
+				m.client.EXPECT().GetHeaders(gomock.Any(), &profile_api.HeadersRequest{UserIDs: *request}).
+					Return(&profile_api.HeadersResponse{Heads: []*profile_api.Header{
+						{AuthorID: 1, Author: "Alexey Zemliakov"},
+						{AuthorID: 2, Author: "Andrew Savvateev", Avatar: "/image"},
+					}}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
 func TestGetFriendsID(t *testing.T) {
 	tests := []TableTest[[]uint32, uint32]{
 		{
@@ -306,6 +379,149 @@ func TestGetByEmail(t *testing.T) {
 	}
 }
 
+func TestSearchProfiles(t *testing.T) {
+	tests := []TableTest[*models.ProfilePage, string]{
+		{
+			name: "1",
+			SetupInput: func() (*string, error) {
+				res := ""
+				return &res, nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request *string) (*models.ProfilePage, error) {
+				return implementation.SearchProfiles(ctx, 1, "user", *request)
+			},
+			ExpectedResult: func() (*models.ProfilePage, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request *string, m *mocks) {
+				m.client.EXPECT().SearchProfiles(gomock.Any(), gomock.Any()).Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*string, error) {
+				res := "cursor"
+				return &res, nil
+			},
+			Run: func(ctx context.Context, implementation *GrpcSender, request *string) (*models.ProfilePage, error) {
+				return implementation.SearchProfiles(ctx, 1, "user", *request)
+			},
+			ExpectedResult: func() (*models.ProfilePage, error) {
+				return &models.ProfilePage{
+					Profiles: []*models.ShortProfile{{ID: 5, FirstName: "user", IsFriend: true}},
+					Cursor:   "next",
+				}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request *string, m *mocks) {
+				m.client.EXPECT().SearchProfiles(
+					gomock.Any(), &profile_api.SearchRequest{UserID: 1, Query: "user", Cursor: *request},
+				).Return(&profile_api.SearchProfilesResponse{
+					Profiles: []*profile_api.ShortProfile{{ID: 5, FirstName: "user", IsFriend: true}},
+					Cursor:   "next",
+				}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adapter, mock := getAdapter(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, adapter, input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
+func TestPasswordOfUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapter, m := getAdapter(ctrl)
+	ctx := context.Background()
+
+	m.client.EXPECT().GetUserByID(gomock.Any(), &profile_api.GetByIDRequest{UserID: 1}).
+		Return(&profile_api.GetByIDResponse{User: &profile_api.User{ID: 1, Password: "hash"}}, nil)
+	user, err := adapter.GetByID(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.User{ID: 1, Password: "hash"}, user)
+	m.client.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).Return(nil, errMock)
+	_, err = adapter.GetByID(ctx, 2)
+	assert.ErrorIs(t, err, errMock)
+
+	m.client.EXPECT().UpdatePassword(gomock.Any(), &profile_api.UpdatePasswordRequest{UserID: 1, Password: "hash"}).
+		Return(&profile_api.UpdatePasswordResponse{}, nil)
+	assert.NoError(t, adapter.UpdatePassword(ctx, 1, "hash"))
+	m.client.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(nil, errMock)
+	assert.ErrorIs(t, adapter.UpdatePassword(ctx, 1, "hash"), errMock)
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapter, m := getAdapter(ctrl)
+	ctx := context.Background()
+
+	m.client.EXPECT().VerifyEmail(gomock.Any(), &profile_api.VerifyEmailRequest{UserID: 1, Email: "email@mail.ru"}).
+		Return(&profile_api.VerifyEmailResponse{}, nil)
+	assert.NoError(t, adapter.VerifyEmail(ctx, 1, "email@mail.ru"))
+	m.client.EXPECT().VerifyEmail(gomock.Any(), gomock.Any()).Return(nil, errMock)
+	assert.ErrorIs(t, adapter.VerifyEmail(ctx, 1, "email@mail.ru"), errMock)
+}
+
+func TestTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	adapter, m := getAdapter(ctrl)
+	ctx := context.Background()
+
+	m.client.EXPECT().GetTwoFactor(gomock.Any(), &profile_api.TwoFactorRequest{UserID: 1}).
+		Return(&profile_api.TwoFactorResponse{Secret: "SECRET", Enabled: true}, nil)
+	res, err := adapter.GetTwoFactor(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.TwoFactor{UserID: 1, Secret: "SECRET", Enabled: true}, res)
+	m.client.EXPECT().GetTwoFactor(gomock.Any(), gomock.Any()).Return(nil, errMock)
+	_, err = adapter.GetTwoFactor(ctx, 1)
+	assert.ErrorIs(t, err, errMock)
+
+	m.client.EXPECT().SaveTwoFactor(
+		gomock.Any(),
+		&profile_api.SaveTwoFactorRequest{UserID: 1, Secret: "SECRET", Enabled: true, RecoveryCodes: []string{"hash"}},
+	).Return(&profile_api.SaveTwoFactorResponse{}, nil)
+	err = adapter.SaveTwoFactor(
+		ctx, &models.TwoFactor{UserID: 1, Secret: "SECRET", Enabled: true, RecoveryCodes: []string{"hash"}},
+	)
+	assert.NoError(t, err)
+
+	m.client.EXPECT().DeleteTwoFactor(gomock.Any(), &profile_api.TwoFactorRequest{UserID: 1}).
+		Return(&profile_api.DeleteTwoFactorResponse{}, nil)
+	assert.NoError(t, adapter.DeleteTwoFactor(ctx, 1))
+
+	m.client.EXPECT().UseRecoveryCode(gomock.Any(), &profile_api.UseRecoveryCodeRequest{UserID: 1, CodeHash: "hash"}).
+		Return(nil, errMock)
+	assert.ErrorIs(t, adapter.UseRecoveryCode(ctx, 1, "hash"), errMock)
+}
+
 type TableTest[T, In any] struct {
 	name           string
 	SetupInput     func() (*In, error)
@@ -12,11 +12,7 @@ func NewSearchRequest(userID uint32) *auth_api.CreateRequest {
 }
 
 func UnmarshalCreateResponse(response *auth_api.CreateResponse) *models.Session {
-	return &models.Session{
-		ID:        response.Sess.ID,
-		UserID:    response.Sess.UserID,
-		CreatedAt: response.Sess.CreatedAt,
-	}
+	return unmarshalSession(response.Sess)
 }
 
 func NewCheckRequest(cookie string) *auth_api.CheckRequest {
@@ -26,13 +22,19 @@ func NewCheckRequest(cookie string) *auth_api.CheckRequest {
 }
 
 func UnmarshalCheckResponse(response *auth_api.CheckResponse) *models.Session {
-	return &models.Session{
-		ID:        response.Sess.ID,
-		UserID:    response.Sess.UserID,
-		CreatedAt: response.Sess.CreatedAt,
+	return unmarshalSession(response.Sess)
+}
+
+func NewRotateRequest(session *models.Session) *auth_api.RotateRequest {
+	return &auth_api.RotateRequest{
+		Sess: marshalSession(session),
 	}
 }
 
+func UnmarshalRotateResponse(response *auth_api.RotateResponse) *models.Session {
+	return unmarshalSession(response.Sess)
+}
+
 func NewDestroyRequest(session *models.Session) *auth_api.DestroyRequest {
 	return &auth_api.DestroyRequest{
 		Sess: &auth_api.Session{
@@ -42,3 +44,29 @@ func NewDestroyRequest(session *models.Session) *auth_api.DestroyRequest {
 		},
 	}
 }
+
+func marshalSession(session *models.Session) *auth_api.Session {
+	return &auth_api.Session{
+		ID:        session.ID,
+		UserID:    session.UserID,
+		CreatedAt: session.CreatedAt,
+		UserAgent: session.UserAgent,
+		IP:        session.IP,
+		LoginAt:   session.LoginAt,
+		LastSeen:  session.LastSeen,
+		Verified:  !session.Unverified,
+	}
+}
+
+func unmarshalSession(session *auth_api.Session) *models.Session {
+	return &models.Session{
+		ID:         session.ID,
+		UserID:     session.UserID,
+		CreatedAt:  session.CreatedAt,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		LoginAt:    session.LoginAt,
+		LastSeen:   session.LastSeen,
+		Unverified: !session.Verified,
+	}
+}
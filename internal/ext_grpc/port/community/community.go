@@ -30,3 +30,46 @@ func UnmarshallHeaderResponse(response *community_api.GetHeaderResponse) *models
 		Avatar:      models.Picture(response.Head.Avatar),
 	}
 }
+
+func NewHeadersRequest(communityIDs []uint32) *community_api.GetHeadersRequest {
+	return &community_api.GetHeadersRequest{
+		CommunityIDs: communityIDs,
+	}
+}
+
+func UnmarshallHeadersResponse(response *community_api.GetHeadersResponse) []*models.Header {
+	res := make([]*models.Header, 0, len(response.Heads))
+	for _, header := range response.Heads {
+		res = append(res, &models.Header{
+			AuthorID:    header.AuthorID,
+			CommunityID: header.CommunityID,
+			Author:      header.Author,
+			Avatar:      models.Picture(header.Avatar),
+		})
+	}
+
+	return res
+}
+
+func NewSearchRequest(userID uint32, query string, lastID uint32) *community_api.SearchRequest {
+	return &community_api.SearchRequest{
+		UserID: userID,
+		Query:  query,
+		LastID: lastID,
+	}
+}
+
+func UnmarshallSearchResponse(response *community_api.SearchCommunitiesResponse) []*models.CommunityCard {
+	res := make([]*models.CommunityCard, 0, len(response.Communities))
+	for _, card := range response.Communities {
+		res = append(res, &models.CommunityCard{
+			ID:         card.ID,
+			Name:       card.Name,
+			Avatar:     models.Picture(card.Avatar),
+			About:      card.About,
+			IsFollowed: card.IsFollowed,
+		})
+	}
+
+	return res
+}
@@ -20,8 +20,27 @@ func NewRequest(header *models.Header, userID uint32) *post_api.Request {
 }
 
 func UnmarshalResponse(response *post_api.Response) []*models.Post {
-	res := make([]*models.Post, 0, len(response.Posts))
-	for _, post := range response.Posts {
+	return unmarshalPosts(response.Posts)
+}
+
+func NewSearchRequest(userID uint32, query string, cursor string) *post_api.SearchRequest {
+	return &post_api.SearchRequest{
+		UserID: userID,
+		Query:  query,
+		Cursor: cursor,
+	}
+}
+
+func UnmarshalSearchResponse(response *post_api.SearchResponse) *models.PostPage {
+	return &models.PostPage{
+		Posts:  unmarshalPosts(response.Posts),
+		Cursor: response.Cursor,
+	}
+}
+
+func unmarshalPosts(posts []*post_api.Post) []*models.Post {
+	res := make([]*models.Post, 0, len(posts))
+	for _, post := range posts {
 		res = append(res, &models.Post{
 			ID: post.ID,
 			Header: models.Header{
@@ -36,10 +55,21 @@ func UnmarshalResponse(response *post_api.Response) []*models.Post {
 				CreatedAt: time.Unix(post.PostContent.CreatedAt, 0),
 				UpdatedAt: time.Unix(post.PostContent.UpdatedAt, 0),
 			},
-			IsLiked:    post.IsLiked,
-			LikesCount: post.LikesCount,
+			Reactions:    unmarshalReactions(post.Reactions, post.MyReaction),
+			CommentCount: post.CommentCount,
+			Snippet:      post.Snippet,
 		})
 	}
 
 	return res
 }
+
+func unmarshalReactions(counts map[string]uint32, my string) models.Reactions {
+	res := models.NewReactions()
+	for reaction, count := range counts {
+		res.Counts[models.ReactionType(reaction)] = count
+	}
+	res.My = models.ReactionType(my)
+
+	return res
+}
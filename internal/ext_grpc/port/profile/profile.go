@@ -20,6 +20,26 @@ func UnmarshallHeaderResponse(header *profile_api.HeaderResponse) *models.Header
 	}
 }
 
+func NewGetHeadersRequest(userIDs []uint32) *profile_api.HeadersRequest {
+	return &profile_api.HeadersRequest{
+		UserIDs: userIDs,
+	}
+}
+
+func UnmarshallHeadersResponse(headers *profile_api.HeadersResponse) []*models.Header {
+	res := make([]*models.Header, 0, len(headers.Heads))
+	for _, header := range headers.Heads {
+		res = append(res, &models.Header{
+			AuthorID:    header.AuthorID,
+			CommunityID: header.CommunityID,
+			Avatar:      models.Picture(header.Avatar),
+			Author:      header.Author,
+		})
+	}
+
+	return res
+}
+
 func NewGetFriendsIDRequest(userID uint32) *profile_api.FriendsRequest {
 	return &profile_api.FriendsRequest{
 		UserID: userID,
@@ -58,11 +78,114 @@ func NewGetUserByEmailRequest(email string) *profile_api.GetByEmailRequest {
 
 func UnmarshallGetUserByEmailRequest(response *profile_api.GetByEmailResponse) *models.User {
 	return &models.User{
-		ID:        response.User.ID,
-		Email:     response.User.Email,
-		FirstName: response.User.FirstName,
-		LastName:  response.User.LastName,
-		Password:  response.User.Password,
-		Avatar:    models.Picture(response.User.Avatar),
+		ID:            response.User.ID,
+		Email:         response.User.Email,
+		FirstName:     response.User.FirstName,
+		LastName:      response.User.LastName,
+		Password:      response.User.Password,
+		Avatar:        models.Picture(response.User.Avatar),
+		EmailVerified: response.User.EmailVerified,
+	}
+}
+
+func NewGetUserByIDRequest(userID uint32) *profile_api.GetByIDRequest {
+	return &profile_api.GetByIDRequest{
+		UserID: userID,
+	}
+}
+
+func UnmarshallGetUserByIDResponse(response *profile_api.GetByIDResponse) *models.User {
+	return &models.User{
+		ID:            response.User.ID,
+		Email:         response.User.Email,
+		FirstName:     response.User.FirstName,
+		LastName:      response.User.LastName,
+		Password:      response.User.Password,
+		Avatar:        models.Picture(response.User.Avatar),
+		EmailVerified: response.User.EmailVerified,
+	}
+}
+
+func NewUpdatePasswordRequest(userID uint32, password string) *profile_api.UpdatePasswordRequest {
+	return &profile_api.UpdatePasswordRequest{
+		UserID:   userID,
+		Password: password,
+	}
+}
+
+func NewVerifyEmailRequest(userID uint32, email string) *profile_api.VerifyEmailRequest {
+	return &profile_api.VerifyEmailRequest{
+		UserID: userID,
+		Email:  email,
+	}
+}
+
+func NewTwoFactorRequest(userID uint32) *profile_api.TwoFactorRequest {
+	return &profile_api.TwoFactorRequest{
+		UserID: userID,
+	}
+}
+
+func UnmarshallTwoFactorResponse(userID uint32, response *profile_api.TwoFactorResponse) *models.TwoFactor {
+	return &models.TwoFactor{
+		UserID:  userID,
+		Secret:  response.Secret,
+		Enabled: response.Enabled,
+	}
+}
+
+func NewSaveTwoFactorRequest(twoFactor *models.TwoFactor) *profile_api.SaveTwoFactorRequest {
+	return &profile_api.SaveTwoFactorRequest{
+		UserID:        twoFactor.UserID,
+		Secret:        twoFactor.Secret,
+		Enabled:       twoFactor.Enabled,
+		RecoveryCodes: twoFactor.RecoveryCodes,
+	}
+}
+
+func NewUseRecoveryCodeRequest(userID uint32, codeHash string) *profile_api.UseRecoveryCodeRequest {
+	return &profile_api.UseRecoveryCodeRequest{
+		UserID:   userID,
+		CodeHash: codeHash,
+	}
+}
+
+func NewGetShortProfilesRequest(selfID uint32, ids []uint32) *profile_api.ShortProfilesRequest {
+	return &profile_api.ShortProfilesRequest{
+		SelfID: selfID,
+		UserID: ids,
+	}
+}
+
+func UnmarshallGetShortProfilesResponse(response *profile_api.ShortProfilesResponse) []*models.ShortProfile {
+	res := make([]*models.ShortProfile, 0, len(response.Profiles))
+	for _, profile := range response.Profiles {
+		res = append(res, &models.ShortProfile{
+			ID:             profile.ID,
+			FirstName:      profile.FirstName,
+			LastName:       profile.LastName,
+			Avatar:         models.Picture(profile.Avatar),
+			IsAuthor:       profile.IsAuthor,
+			IsFriend:       profile.IsFriend,
+			IsSubscriber:   profile.IsSubscriber,
+			IsSubscription: profile.IsSubscription,
+		})
+	}
+
+	return res
+}
+
+func NewSearchRequest(userID uint32, query string, cursor string) *profile_api.SearchRequest {
+	return &profile_api.SearchRequest{
+		UserID: userID,
+		Query:  query,
+		Cursor: cursor,
+	}
+}
+
+func UnmarshallSearchResponse(response *profile_api.SearchProfilesResponse) *models.ProfilePage {
+	return &models.ProfilePage{
+		Profiles: UnmarshallGetShortProfilesResponse(&profile_api.ShortProfilesResponse{Profiles: response.Profiles}),
+		Cursor:   response.Cursor,
 	}
 }
@@ -4,25 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"mime"
 	"mime/multipart"
 	"net/http"
+	"path"
 
 	"github.com/gorilla/mux"
 
+	"github.com/2024_2_BetterCallFirewall/internal/models"
 	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
-var fileFormat = map[string]struct{}{
-	"image/jpeg": {},
-	"image/jpg":  {},
-	"image/png":  {},
-	"image/webp": {},
-}
+var fileFormat = models.ImageFormats
 
 //go:generate mockgen -destination=mock.go -source=$GOFILE -package=${GOPACKAGE}
 type fileService interface {
 	Upload(ctx context.Context, name string) ([]byte, error)
-	Download(ctx context.Context, file multipart.File) (string, error)
+	Download(ctx context.Context, file multipart.File, ext string) (string, error)
 }
 
 type responder interface {
@@ -67,35 +65,63 @@ func (fc *FileController) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the images are saved without the extension and their type is detected,
+	// the documents are not shown by the browser but saved
+	if format, ok := models.DocumentType(path.Ext(name)); ok {
+		w.Header().Set("Content-Type", format)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	}
 	fc.responder.OutputBytes(w, res, reqID)
 }
 
 func (fc *FileController) Download(w http.ResponseWriter, r *http.Request) {
+	fc.download(w, r, func(format string) (string, bool) {
+		_, ok := fileFormat[format]
+		return "", ok
+	})
+}
+
+// DownloadAttachment saves a file attached to a chat message, it may be an image or a document
+func (fc *FileController) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	fc.download(w, r, func(format string) (string, bool) {
+		if _, ok := fileFormat[format]; ok {
+			return "", true
+		}
+		ext, ok := models.DocumentFormats[format]
+		return ext, ok
+	})
+}
+
+// download saves the file if its format is allowed, allowed returns the extension the file is saved with
+func (fc *FileController) download(
+	w http.ResponseWriter, r *http.Request, allowed func(format string) (string, bool),
+) {
 	reqID, ok := r.Context().Value("requestID").(string)
 	if !ok {
 		fc.responder.LogError(my_err.ErrInvalidContext, "")
 	}
 
 	err := r.ParseMultipartForm(10 << 20) // 10Mbyte
-	defer r.MultipartForm.RemoveAll()
 	if err != nil {
 		fc.responder.ErrorBadRequest(w, my_err.ErrToLargeFile, reqID)
 		return
 	}
+	defer r.MultipartForm.RemoveAll()
 
 	file, header, err := r.FormFile("file")
 	if err != nil {
-		file = nil
-	} else {
-		format := header.Header.Get("Content-Type")
-		if _, ok := fileFormat[format]; !ok {
-			fc.responder.ErrorBadRequest(w, my_err.ErrWrongFiletype, reqID)
-			return
-		}
+		fc.responder.ErrorBadRequest(w, my_err.ErrWrongFile, reqID)
+		return
 	}
 	defer file.Close()
 
-	url, err := fc.fileService.Download(r.Context(), file)
+	ext, ok := allowed(header.Header.Get("Content-Type"))
+	if !ok {
+		fc.responder.ErrorBadRequest(w, my_err.ErrWrongFiletype, reqID)
+		return
+	}
+
+	url, err := fc.fileService.Download(r.Context(), file, ext)
 	if err != nil {
 		fc.responder.ErrorBadRequest(w, err, reqID)
 		return
@@ -1,15 +1,20 @@
 package controller
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
 type mocks struct {
@@ -147,6 +152,199 @@ func TestUpload(t *testing.T) {
 	}
 }
 
+func TestUploadDocument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	controller, m := getController(ctrl)
+
+	for name, want := range map[string]string{"file.pdf": "application/pdf", "default": ""} {
+		r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/image/"+name, nil), map[string]string{"name": name})
+		r = r.WithContext(context.WithValue(r.Context(), "requestID", "1"))
+		w := httptest.NewRecorder()
+		m.fileService.EXPECT().Upload(gomock.Any(), name).Return([]byte("data"), nil)
+		m.responder.EXPECT().OutputBytes(w, []byte("data"), "1")
+
+		controller.Upload(w, r)
+		assert.Equal(t, want, w.Header().Get("Content-Type"))
+		if want != "" {
+			assert.Equal(t, `attachment; filename=file.pdf`, w.Header().Get("Content-Disposition"))
+		} else {
+			assert.Empty(t, w.Header().Get("Content-Disposition"))
+		}
+	}
+}
+
+func attachmentRequest(contentType string) (*http.Request, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="file"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write([]byte("data")); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/attachment", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req, nil
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	tests := []TableTest[Response, Request]{
+		{
+			name: "no file",
+			SetupInput: func() (*Request, error) {
+				body := &bytes.Buffer{}
+				writer := multipart.NewWriter(body)
+				if err := writer.WriteField("name", "file"); err != nil {
+					return nil, err
+				}
+				if err := writer.Close(); err != nil {
+					return nil, err
+				}
+				req := httptest.NewRequest(http.MethodPost, "/attachment", body)
+				req.Header.Set("Content-Type", writer.FormDataContentType())
+				return &Request{r: req, w: httptest.NewRecorder()}, nil
+			},
+			Run: func(ctx context.Context, implementation *FileController, request Request) (Response, error) {
+				implementation.DownloadAttachment(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusBadRequest, Body: "bad request"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.responder.EXPECT().ErrorBadRequest(request.w, my_err.ErrWrongFile, gomock.Any()).Do(func(w, err, req any) {
+					request.w.WriteHeader(http.StatusBadRequest)
+					request.w.Write([]byte("bad request"))
+				})
+			},
+		},
+		{
+			name: "1",
+			SetupInput: func() (*Request, error) {
+				req, err := attachmentRequest("image/gif")
+				if err != nil {
+					return nil, err
+				}
+				res := &Request{r: req, w: httptest.NewRecorder()}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *FileController, request Request) (Response, error) {
+				implementation.DownloadAttachment(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusBadRequest, Body: "bad request"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+					request.w.WriteHeader(http.StatusBadRequest)
+					request.w.Write([]byte("bad request"))
+				})
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*Request, error) {
+				req, err := attachmentRequest("application/pdf")
+				if err != nil {
+					return nil, err
+				}
+				res := &Request{r: req, w: httptest.NewRecorder()}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *FileController, request Request) (Response, error) {
+				implementation.DownloadAttachment(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusOK, Body: "OK"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.fileService.EXPECT().Download(gomock.Any(), gomock.Any(), ".pdf").Return("/image/file.pdf", nil)
+				m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(func(w, data, req any) {
+					request.w.WriteHeader(http.StatusOK)
+					request.w.Write([]byte("OK"))
+				})
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*Request, error) {
+				req, err := attachmentRequest("image/png")
+				if err != nil {
+					return nil, err
+				}
+				res := &Request{r: req, w: httptest.NewRecorder()}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *FileController, request Request) (Response, error) {
+				implementation.DownloadAttachment(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusOK, Body: "OK"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.fileService.EXPECT().Download(gomock.Any(), gomock.Any(), "").Return("/image/file", nil)
+				m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(func(w, data, req any) {
+					request.w.WriteHeader(http.StatusOK)
+					request.w.Write([]byte("OK"))
+				})
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getController(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
 type Request struct {
 	w *httptest.ResponseRecorder
 	r *http.Request
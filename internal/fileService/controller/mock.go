@@ -37,18 +37,18 @@ func (m *MockfileService) EXPECT() *MockfileServiceMockRecorder {
 }
 
 // Download mocks base method.
-func (m *MockfileService) Download(ctx context.Context, file multipart.File) (string, error) {
+func (m *MockfileService) Download(ctx context.Context, file multipart.File, ext string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Download", ctx, file)
+	ret := m.ctrl.Call(m, "Download", ctx, file, ext)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Download indicates an expected call of Download.
-func (mr *MockfileServiceMockRecorder) Download(ctx, file interface{}) *gomock.Call {
+func (mr *MockfileServiceMockRecorder) Download(ctx, file, ext interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockfileService)(nil).Download), ctx, file)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockfileService)(nil).Download), ctx, file, ext)
 }
 
 // Upload mocks base method.
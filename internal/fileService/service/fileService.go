@@ -16,9 +16,10 @@ func NewFileService() *FileService {
 	return &FileService{}
 }
 
-func (f *FileService) Download(ctx context.Context, file multipart.File) (string, error) {
+// Download saves the file under a new name with the extension ext and returns its path
+func (f *FileService) Download(ctx context.Context, file multipart.File, ext string) (string, error) {
 	var (
-		fileName = uuid.New().String()
+		fileName = uuid.New().String() + ext
 		filePath = fmt.Sprintf("/image/%s", fileName)
 		dst, err = os.Create(filePath)
 	)
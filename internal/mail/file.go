@@ -0,0 +1,38 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+// FileSender writes the letters to a file instead of sending them, it stands in for a mail server on local runs
+type FileSender struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewFileSender(w io.Writer) *FileSender {
+	return &FileSender{
+		w: w,
+	}
+}
+
+func (s *FileSender) Send(_ context.Context, mail *models.Mail) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := fmt.Fprintf(
+		s.w, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC1123Z), mail.To, mail.Subject, mail.Body,
+	)
+	if err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+
+	return nil
+}
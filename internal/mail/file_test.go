@@ -0,0 +1,35 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+type brokenWriter struct{}
+
+var errWrite = errors.New("write error")
+
+func (brokenWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestFileSender(t *testing.T) {
+	var buf bytes.Buffer
+	sender := NewFileSender(&buf)
+
+	err := sender.Send(context.Background(), &models.Mail{To: "andrew@mail.ru", Subject: "Hello", Body: "World"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(buf.String(), "Date: "))
+	assert.Contains(t, buf.String(), "To: andrew@mail.ru\nSubject: Hello\n\nWorld\n")
+
+	err = NewFileSender(brokenWriter{}).Send(context.Background(), &models.Mail{})
+	assert.ErrorIs(t, err, errWrite)
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CacheMetrics counts the lookups of the caches, the cache label tells the kind of the cached values
+type CacheMetrics struct {
+	Hits        *prometheus.CounterVec
+	Misses      *prometheus.CounterVec
+	serviceName string
+}
+
+func NewCacheMetrics(serviceName string) (*CacheMetrics, error) {
+	var metrics CacheMetrics
+	metrics.Hits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_hits_total",
+			Help: "Number of values found in the cache.",
+		},
+		[]string{"service", "cache"},
+	)
+	if err := prometheus.Register(metrics.Hits); err != nil {
+		return nil, err
+	}
+
+	metrics.Misses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_misses_total",
+			Help: "Number of values loaded from the source missing in the cache.",
+		},
+		[]string{"service", "cache"},
+	)
+	if err := prometheus.Register(metrics.Misses); err != nil {
+		return nil, err
+	}
+
+	metrics.serviceName = serviceName
+	return &metrics, nil
+}
+
+func (m *CacheMetrics) Hit(cache string, count int) {
+	m.Hits.WithLabelValues(m.serviceName, cache).Add(float64(count))
+}
+
+func (m *CacheMetrics) Miss(cache string, count int) {
+	m.Misses.WithLabelValues(m.serviceName, cache).Add(float64(count))
+}
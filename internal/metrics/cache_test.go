@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCache(t *testing.T) {
+	m, err := NewCacheMetrics("post")
+	require.NoError(t, err)
+	require.NotNil(t, m)
+
+	m.Hit("page", 1)
+	m.Hit("header", 3)
+	m.Miss("header", 2)
+	m.Miss("header", 0)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.Hits.WithLabelValues("post", "page")))
+	assert.Equal(t, float64(3), testutil.ToFloat64(m.Hits.WithLabelValues("post", "header")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.Misses.WithLabelValues("post", "header")))
+
+	_, err = NewCacheMetrics("post")
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	directionIn  = "in"
+	directionOut = "out"
+)
+
+// ChatMetrics counts websocket connections of the chat and the frames passed through them
+type ChatMetrics struct {
+	Sockets     *prometheus.GaugeVec
+	Messages    *prometheus.CounterVec
+	Drops       *prometheus.CounterVec
+	Coalesced   *prometheus.CounterVec
+	serviceName string
+}
+
+func NewChatMetrics(serviceName string) (*ChatMetrics, error) {
+	var metrics ChatMetrics
+	metrics.Sockets = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "chat_active_sockets",
+			Help: "Number of open websocket connections.",
+		},
+		[]string{"service"},
+	)
+	if err := prometheus.Register(metrics.Sockets); err != nil {
+		return nil, err
+	}
+
+	metrics.Messages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "chat_messages_total",
+			Help: "Number of websocket frames read from and written to the clients.",
+		},
+		[]string{"service", "direction"},
+	)
+	if err := prometheus.Register(metrics.Messages); err != nil {
+		return nil, err
+	}
+
+	metrics.Drops = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "chat_drops_total",
+			Help: "Number of websocket connections closed by the server.",
+		},
+		[]string{"service", "reason"},
+	)
+	if err := prometheus.Register(metrics.Drops); err != nil {
+		return nil, err
+	}
+
+	metrics.Coalesced = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "chat_presence_coalesced_total",
+			Help: "Number of presence changes replaced by a later one before they were saved.",
+		},
+		[]string{"service"},
+	)
+	if err := prometheus.Register(metrics.Coalesced); err != nil {
+		return nil, err
+	}
+
+	metrics.serviceName = serviceName
+	return &metrics, nil
+}
+
+func (m *ChatMetrics) Connected() {
+	m.Sockets.WithLabelValues(m.serviceName).Inc()
+}
+
+func (m *ChatMetrics) Disconnected() {
+	m.Sockets.WithLabelValues(m.serviceName).Dec()
+}
+
+func (m *ChatMetrics) MessageIn() {
+	m.Messages.WithLabelValues(m.serviceName, directionIn).Inc()
+}
+
+func (m *ChatMetrics) MessageOut() {
+	m.Messages.WithLabelValues(m.serviceName, directionOut).Inc()
+}
+
+func (m *ChatMetrics) Dropped(reason string) {
+	m.Drops.WithLabelValues(m.serviceName, reason).Inc()
+}
+
+func (m *ChatMetrics) PresenceCoalesced() {
+	m.Coalesced.WithLabelValues(m.serviceName).Inc()
+}
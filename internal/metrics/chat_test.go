@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewChat(t *testing.T) {
+	m, err := NewChatMetrics("chat")
+	require.NoError(t, err)
+	require.NotNil(t, m)
+
+	m.Connected()
+	m.Connected()
+	m.Disconnected()
+	m.MessageIn()
+	m.MessageOut()
+	m.MessageOut()
+	m.Dropped("slow_consumer")
+	m.PresenceCoalesced()
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.Sockets.WithLabelValues("chat")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.Messages.WithLabelValues("chat", directionIn)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.Messages.WithLabelValues("chat", directionOut)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.Drops.WithLabelValues("chat", "slow_consumer")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.Coalesced.WithLabelValues("chat")))
+
+	_, err = NewChatMetrics("chat")
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// LimitMetrics counts the throttled requests and the lockouts. The scope label tells
+// if the address or the email is locked out, the reason label tells what the request is rejected by
+type LimitMetrics struct {
+	Lockouts    *prometheus.CounterVec
+	Rejections  *prometheus.CounterVec
+	serviceName string
+}
+
+func NewLimitMetrics(serviceName string) (*LimitMetrics, error) {
+	var metrics LimitMetrics
+	metrics.Lockouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "lockouts_total",
+			Help: "Number of lockouts started after the repeated wrong passwords.",
+		},
+		[]string{"service", "scope"},
+	)
+	if err := prometheus.Register(metrics.Lockouts); err != nil {
+		return nil, err
+	}
+
+	metrics.Rejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limit_rejections_total",
+			Help: "Number of requests rejected by the rate limits and the lockouts.",
+		},
+		[]string{"service", "action", "reason"},
+	)
+	if err := prometheus.Register(metrics.Rejections); err != nil {
+		return nil, err
+	}
+
+	metrics.serviceName = serviceName
+	return &metrics, nil
+}
+
+func (m *LimitMetrics) Lockout(scope string) {
+	m.Lockouts.WithLabelValues(m.serviceName, scope).Inc()
+}
+
+func (m *LimitMetrics) Reject(action, reason string) {
+	m.Rejections.WithLabelValues(m.serviceName, action, reason).Inc()
+}
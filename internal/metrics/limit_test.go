@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLimit(t *testing.T) {
+	m, err := NewLimitMetrics("auth")
+	require.NoError(t, err)
+	require.NotNil(t, m)
+
+	m.Lockout("email")
+	m.Lockout("email")
+	m.Lockout("ip")
+	m.Reject("login", "lockout")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.Lockouts.WithLabelValues("auth", "email")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.Lockouts.WithLabelValues("auth", "ip")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.Rejections.WithLabelValues("auth", "login", "lockout")))
+
+	_, err = NewLimitMetrics("auth")
+	assert.Error(t, err)
+}
@@ -16,7 +16,7 @@ var noAuthUrls = map[string]struct{}{
 
 type SessionManager interface {
 	Check(string) (*models.Session, error)
-	Create(userID uint32) (*models.Session, error)
+	Rotate(sess *models.Session) (*models.Session, error)
 	Destroy(sess *models.Session) error
 }
 
@@ -41,13 +41,7 @@ func Auth(sm SessionManager, next http.Handler) http.Handler {
 		}
 
 		if sess.CreatedAt <= time.Now().Add(-time.Hour).Unix() {
-			if err := sm.Destroy(sess); err != nil {
-				log.Println(r.Context().Value("requestID"), err)
-				internalErr(w)
-				return
-			}
-
-			sess, err = sm.Create(sess.UserID)
+			sess, err = sm.Rotate(sess)
 			if err != nil {
 				log.Println(r.Context().Value("requestID"), err)
 				internalErr(w)
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+type route struct {
+	method string
+	path   string
+}
+
+// limitedActions are the actions the users with the unverified email can be limited in,
+// every action is the routes doing it, the route without the method is limited for all the methods
+var limitedActions = map[string][]route{
+	"post":      {{http.MethodPost, "/api/v1/feed"}},
+	"comment":   {{http.MethodPost, "/api/v1/feed/{id}/comments"}},
+	"message":   {{"", "/api/v1/message/ws"}, {http.MethodPost, "/api/v1/messages/groups"}},
+	"community": {{http.MethodPost, "/api/v1/community"}},
+}
+
+// Limits are the routes closed for the users with the unverified email
+type Limits map[route]struct{}
+
+func NewLimits(actions []string) (Limits, error) {
+	limits := make(Limits)
+	for _, action := range actions {
+		routes, ok := limitedActions[action]
+		if !ok {
+			return nil, fmt.Errorf("unknown action to limit: %s", action)
+		}
+		for _, rt := range routes {
+			limits[rt] = struct{}{}
+		}
+	}
+
+	return limits, nil
+}
+
+func (l Limits) limited(r *http.Request) bool {
+	cur := mux.CurrentRoute(r)
+	if cur == nil {
+		return false
+	}
+	path, err := cur.GetPathTemplate()
+	if err != nil {
+		return false
+	}
+	if _, ok := l[route{path: path}]; ok {
+		return true
+	}
+	_, ok := l[route{method: r.Method, path: path}]
+
+	return ok
+}
+
+// Verified is used as the middleware of the mux router, it needs the route of the request to be matched
+func Verified(limits Limits) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(limits) == 0 || !limits.limited(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sess, err := models.SessionFromContext(r.Context())
+			if err != nil {
+				unauthorized(w, r, err)
+				return
+			}
+			if sess.Unverified {
+				forbidden(w, r, my_err.ErrEmailNotVerified)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func forbidden(w http.ResponseWriter, r *http.Request, err error) {
+	w.Header().Set("Content-Type", "application/json:charset=UTF-8")
+	w.Header().Set("Access-Control-Allow-Origin", "http://vilka.online")
+	w.Header().Set("Access-Control-Allow-Credentials", "true")
+	w.WriteHeader(http.StatusForbidden)
+
+	_, _ = w.Write([]byte(err.Error()))
+
+	log.Println(r.Context().Value("requestID"), err)
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+func TestNewLimits(t *testing.T) {
+	limits, err := NewLimits([]string{"post", "message"})
+	require.NoError(t, err)
+	assert.Len(t, limits, 3)
+
+	_, err = NewLimits([]string{"post", "dance"})
+	assert.Error(t, err)
+}
+
+func TestVerified(t *testing.T) {
+	limits, err := NewLimits([]string{"post", "message"})
+	require.NoError(t, err)
+
+	ok := func(w http.ResponseWriter, _ *http.Request) {}
+	router := mux.NewRouter()
+	router.Use(Verified(limits))
+	router.HandleFunc("/api/v1/feed", ok).Methods(http.MethodPost, http.MethodGet)
+	router.HandleFunc("/api/v1/feed/{id}/comments", ok).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/message/ws", ok)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		sess     *models.Session
+		wantCode int
+	}{
+		{name: "limited", method: http.MethodPost, path: "/api/v1/feed", sess: &models.Session{Unverified: true}, wantCode: http.StatusForbidden},
+		{name: "verified", method: http.MethodPost, path: "/api/v1/feed", sess: &models.Session{}, wantCode: http.StatusOK},
+		{name: "other method", method: http.MethodGet, path: "/api/v1/feed", sess: &models.Session{}, wantCode: http.StatusOK},
+		{name: "not limited", method: http.MethodPost, path: "/api/v1/feed/1/comments", sess: &models.Session{}, wantCode: http.StatusOK},
+		{name: "any method", method: http.MethodGet, path: "/api/v1/message/ws", sess: &models.Session{Unverified: true}, wantCode: http.StatusForbidden},
+		{name: "no session", method: http.MethodPost, path: "/api/v1/feed", wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.sess != nil {
+				r = r.WithContext(models.ContextWithSession(r.Context(), tt.sess))
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
@@ -4,15 +4,138 @@ import (
 	"time"
 )
 
+// Chat is an item of the chat list, Group is set for group chats and Receiver for direct ones.
+// LastFile is the file attached to the last message, the text of a message with a file only is its preview
 type Chat struct {
-	LastMessage string    `json:"last_message"`
-	LastDate    time.Time `json:"last_date"`
-	Receiver    Header    `json:"receiver"`
+	LastMessage string     `json:"last_message"`
+	LastFile    Picture    `json:"last_file,omitempty"`
+	LastDate    time.Time  `json:"last_date"`
+	Receiver    Header     `json:"receiver"`
+	Group       *GroupChat `json:"group,omitempty"`
+	UnreadCount uint32     `json:"unread_count"`
 }
 
+type GroupRole string
+
+const (
+	GroupRoleOwner  GroupRole = "owner"
+	GroupRoleAdmin  GroupRole = "admin"
+	GroupRoleMember GroupRole = "member"
+)
+
+// CanManage tells if the role allows changing the group and its members
+func (r GroupRole) CanManage() bool {
+	return r == GroupRoleOwner || r == GroupRoleAdmin
+}
+
+// GroupChat is a conversation of several users, its owner and admins manage the members
+type GroupChat struct {
+	ID      uint32        `json:"id"`
+	Title   string        `json:"title"`
+	Avatar  Picture       `json:"avatar"`
+	OwnerID uint32        `json:"owner_id"`
+	Members []GroupMember `json:"members,omitempty"`
+}
+
+type GroupMember struct {
+	Header
+	Role GroupRole `json:"role"`
+}
+
+// GroupRequest creates a group chat or changes its title and avatar, Members are ignored by the change
+type GroupRequest struct {
+	Title   string   `json:"title"`
+	Avatar  Picture  `json:"avatar"`
+	Members []uint32 `json:"members"`
+}
+
+// Message is a message of a direct or a group chat.
+// ClientID is the key the client sets to send the message once however many times it retries
 type Message struct {
-	Sender    uint32    `json:"sender"`
-	Receiver  uint32    `json:"receiver"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        uint32     `json:"id"`
+	ClientID  string     `json:"client_id,omitempty"`
+	Sender    uint32     `json:"sender"`
+	Receiver  uint32     `json:"receiver"`
+	GroupID   uint32     `json:"group_id,omitempty"`
+	Content   string     `json:"content"`
+	File      Picture    `json:"file,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	IsRead    bool       `json:"is_read"`
+	IsEdited  bool       `json:"is_edited"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	ReplyTo   *Quote     `json:"reply_to,omitempty"`
+	Reactions Reactions  `json:"reactions"`
+}
+
+// Quote is the message replied to, a client sets only its ID
+type Quote struct {
+	ID      uint32 `json:"id"`
+	Sender  uint32 `json:"sender"`
+	Content string `json:"content"`
+}
+
+// ReadReceipt tells that Reader has read the messages from Sender or in the group up to LastReadID
+type ReadReceipt struct {
+	Reader     uint32    `json:"reader"`
+	Sender     uint32    `json:"sender"`
+	GroupID    uint32    `json:"group_id,omitempty"`
+	LastReadID uint32    `json:"last_read_id"`
+	ReadAt     time.Time `json:"read_at"`
+}
+
+type UnreadCounter struct {
+	Count uint32 `json:"count"`
+}
+
+// Typing tells that Sender is typing a message to Receiver or to the group
+type Typing struct {
+	Sender   uint32 `json:"sender"`
+	Receiver uint32 `json:"receiver"`
+	GroupID  uint32 `json:"group_id,omitempty"`
+}
+
+// Presence is the online status of a user, LastSeen is zero while the user is online or was never seen
+type Presence struct {
+	UserID   uint32    `json:"user_id,omitempty"`
+	IsOnline bool      `json:"is_online"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+type ChatEventType string
+
+const (
+	ChatEventMessage  ChatEventType = "message"
+	ChatEventEdit     ChatEventType = "edit"
+	ChatEventDelete   ChatEventType = "delete"
+	ChatEventTyping   ChatEventType = "typing"
+	ChatEventRead     ChatEventType = "read"
+	ChatEventPresence ChatEventType = "presence"
+	ChatEventGroup    ChatEventType = "group"
+	ChatEventError    ChatEventType = "error"
+	ChatEventAck      ChatEventType = "ack"
+	ChatEventResume   ChatEventType = "resume"
+)
+
+// Resume asks to replay the messages after the last one the client has seen, by its id or its time.
+// The reply tells the last replayed message and if there are more of them to ask for
+type Resume struct {
+	LastID   uint32    `json:"last_id"`
+	LastTime time.Time `json:"last_time"`
+	HasMore  bool      `json:"has_more"`
+}
+
+// ChatEvent is a frame passed over the chat websocket, the payload matching Type is set.
+// Edit, delete and ack carry the message, a deleted message has only its ID and participants,
+// an error carries the text of the error and the message it is about if any.
+// Events of a group chat list the members they are delivered to, a group event tells the group has changed
+type ChatEvent struct {
+	Type     ChatEventType `json:"type"`
+	Message  *Message      `json:"message,omitempty"`
+	Typing   *Typing       `json:"typing,omitempty"`
+	Read     *ReadReceipt  `json:"read,omitempty"`
+	Presence *Presence     `json:"presence,omitempty"`
+	Group    *GroupChat    `json:"group,omitempty"`
+	Resume   *Resume       `json:"resume,omitempty"`
+	Members  []uint32      `json:"members,omitempty"`
+	Error    string        `json:"error,omitempty"`
 }
@@ -0,0 +1,13 @@
+package models
+
+type Comment struct {
+	ID         uint32  `json:"id"`
+	PostID     uint32  `json:"post_id"`
+	ParentID   uint32  `json:"parent_id"`
+	Depth      uint32  `json:"depth"`
+	IsDeleted  bool    `json:"is_deleted"`
+	Header     Header  `json:"header"`
+	Content    Content `json:"content"`
+	LikesCount uint32  `json:"likes_count"`
+	IsLiked    bool    `json:"is_liked"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+// Feed selects the ranked home feed of UserID: the posts of the friends, of the joined communities
+// and the popular ones. The posts are ordered by score and then by id
+type Feed struct {
+	UserID    uint32
+	FriendIDs []uint32
+	Cursor    FeedCursor
+}
+
+// FeedCursor points to the last post of the previous feed page, the zero cursor points to the beginning.
+// The feed is ranked as it was at AsOf, so the order of the next pages does not change with new posts and likes
+type FeedCursor struct {
+	AsOf  time.Time
+	Score float32
+	ID    uint32
+}
+
+// String encodes the cursor, clients pass it back as it is
+func (c FeedCursor) String() string {
+	if c.ID == 0 {
+		return ""
+	}
+
+	raw := fmt.Sprintf("%d:%d:%d", c.AsOf.UnixMicro(), math.Float32bits(c.Score), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseFeedCursor(s string) (FeedCursor, error) {
+	if s == "" {
+		return FeedCursor{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return FeedCursor{}, my_err.ErrWrongCursor
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 {
+		return FeedCursor{}, my_err.ErrWrongCursor
+	}
+	asOf, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || asOf <= 0 {
+		return FeedCursor{}, my_err.ErrWrongCursor
+	}
+	bits, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return FeedCursor{}, my_err.ErrWrongCursor
+	}
+	postID, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil || postID == 0 {
+		return FeedCursor{}, my_err.ErrWrongCursor
+	}
+
+	return FeedCursor{
+		AsOf:  time.UnixMicro(asOf).UTC(),
+		Score: math.Float32frombits(uint32(bits)),
+		ID:    uint32(postID),
+	}, nil
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"path"
+)
+
+// ImageFormats are the types of images users may upload
+var ImageFormats = map[string]struct{}{
+	"image/jpeg": {},
+	"image/jpg":  {},
+	"image/png":  {},
+	"image/webp": {},
+}
+
+// DocumentFormats are the types of other files which may be attached to chat messages.
+// Documents are saved with the extension, so the type of a saved file is seen from its path
+var DocumentFormats = map[string]string{
+	"application/pdf":    ".pdf",
+	"application/zip":    ".zip",
+	"application/msword": ".doc",
+	"text/plain":         ".txt",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": ".docx",
+}
+
+// DocumentType returns the type of the document saved with the extension
+func DocumentType(ext string) (string, bool) {
+	for format, e := range DocumentFormats {
+		if e == ext {
+			return format, true
+		}
+	}
+
+	return "", false
+}
+
+// IsDocument tells if the saved file is a document rather than an image
+func (p Picture) IsDocument() bool {
+	_, ok := DocumentType(path.Ext(string(p)))
+	return ok
+}
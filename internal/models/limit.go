@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Lockout is how the repeated failures lock the key out. After Threshold failures the key is locked for Base,
+// every next failure doubles the lock up to Max. The failures are forgotten after Memory without new ones
+type Lockout struct {
+	Threshold int
+	Base      time.Duration
+	Max       time.Duration
+	Memory    time.Duration
+}
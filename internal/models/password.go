@@ -0,0 +1,26 @@
+package models
+
+type PasswordChange struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email"`
+}
+
+type PasswordReset struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type EmailVerification struct {
+	Token string `json:"token"`
+}
+
+// Mail is a letter to a user
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -1,16 +1,24 @@
 package models
 
+import "math"
+
+// FirstPageLastID is the last id passed for the first page of posts, every post goes before it
+const FirstPageLastID = math.MaxInt32
+
 type Post struct {
-	ID          uint32  `json:"id"`
-	Header      Header  `json:"header"`
-	PostContent Content `json:"post_content"`
-	LikesCount  uint32  `json:"likes_count"`
-	IsLiked     bool    `json:"is_liked"`
+	ID           uint32    `json:"id"`
+	Header       Header    `json:"header"`
+	PostContent  Content   `json:"post_content"`
+	Reactions    Reactions `json:"reactions"`
+	CommentCount uint32    `json:"comment_count"`
+	// Snippet is the HTML escaped fragment of the text with the found words in <mark>, it is set by search only
+	Snippet string `json:"snippet,omitempty"`
 }
 
 type Header struct {
-	AuthorID    uint32  `json:"author_id"`
-	CommunityID uint32  `json:"community_id"`
-	Author      string  `json:"author"`
-	Avatar      Picture `json:"avatar"`
+	AuthorID    uint32    `json:"author_id"`
+	CommunityID uint32    `json:"community_id"`
+	Author      string    `json:"author"`
+	Avatar      Picture   `json:"avatar"`
+	Presence    *Presence `json:"presence,omitempty"`
 }
@@ -12,6 +12,7 @@ type FullProfile struct {
 	Avatar         Picture   `json:"avatar"`
 	Pics           []Picture `json:"pics"`
 	Posts          []*Post   `json:"posts"`
+	Presence       *Presence `json:"presence,omitempty"`
 }
 
 type ShortProfile struct {
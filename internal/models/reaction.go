@@ -0,0 +1,34 @@
+package models
+
+type ReactionType string
+
+const (
+	ReactionLike  ReactionType = "like"
+	ReactionLove  ReactionType = "love"
+	ReactionLaugh ReactionType = "laugh"
+	ReactionSad   ReactionType = "sad"
+	ReactionAngry ReactionType = "angry"
+)
+
+func (r ReactionType) IsValid() bool {
+	switch r {
+	case ReactionLike, ReactionLove, ReactionLaugh, ReactionSad, ReactionAngry:
+		return true
+	}
+
+	return false
+}
+
+// Reactions holds per-type counters and the reaction left by the current user, empty if none
+type Reactions struct {
+	Counts map[ReactionType]uint32 `json:"counts"`
+	My     ReactionType            `json:"my"`
+}
+
+func NewReactions() Reactions {
+	return Reactions{Counts: make(map[ReactionType]uint32)}
+}
+
+type Reaction struct {
+	Type ReactionType `json:"type"`
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+// sections of the post search, the empty one searches all posts
+const (
+	SearchSectionFriend    = "friend"
+	SearchSectionCommunity = "community"
+)
+
+// PostSearch selects the posts matching the query, the found posts are ordered by rank and then by id
+type PostSearch struct {
+	Query   string
+	Section string
+	// CommunityID limits the search to the posts of the community
+	CommunityID uint32
+	// AuthorIDs limits the search to the posts of the users, it is set for the friend section
+	AuthorIDs []uint32
+	// MemberID limits the search to the posts of the communities the user is subscribed to
+	MemberID uint32
+	Cursor   SearchCursor
+}
+
+// SearchCursor points to the last item of the previous page of a ranked search, the zero cursor points to the beginning
+type SearchCursor struct {
+	Rank float32
+	ID   uint32
+}
+
+// String encodes the cursor, clients pass it back as it is
+func (c SearchCursor) String() string {
+	if c.ID == 0 {
+		return ""
+	}
+
+	raw := fmt.Sprintf("%d:%d", math.Float32bits(c.Rank), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseSearchCursor(s string) (SearchCursor, error) {
+	if s == "" {
+		return SearchCursor{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return SearchCursor{}, my_err.ErrWrongCursor
+	}
+
+	rank, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return SearchCursor{}, my_err.ErrWrongCursor
+	}
+	bits, err := strconv.ParseUint(rank, 10, 32)
+	if err != nil {
+		return SearchCursor{}, my_err.ErrWrongCursor
+	}
+	itemID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil || itemID == 0 {
+		return SearchCursor{}, my_err.ErrWrongCursor
+	}
+
+	return SearchCursor{Rank: math.Float32frombits(uint32(bits)), ID: uint32(itemID)}, nil
+}
+
+// PostPage is a page of found posts, Cursor is empty on the last page
+type PostPage struct {
+	Posts  []*Post `json:"posts"`
+	Cursor string  `json:"cursor,omitempty"`
+}
+
+// ProfileSearch selects the profiles with names similar to the query as seen by SelfID,
+// the found profiles are ordered by rank and then by id
+type ProfileSearch struct {
+	Query  string
+	SelfID uint32
+	Cursor SearchCursor
+}
+
+// ProfilePage is a page of found profiles, Cursor is empty on the last page
+type ProfilePage struct {
+	Profiles []*ShortProfile `json:"profiles"`
+	Cursor   string          `json:"cursor,omitempty"`
+}
+
+// SearchSectionType is the type of items found by the global search
+type SearchSectionType string
+
+const (
+	SearchProfiles    SearchSectionType = "profiles"
+	SearchCommunities SearchSectionType = "communities"
+	SearchPosts       SearchSectionType = "posts"
+)
+
+func (t SearchSectionType) Valid() bool {
+	return t == SearchProfiles || t == SearchCommunities || t == SearchPosts
+}
+
+// GlobalSearch searches all sections at once, with Type it searches the only section continuing from its Cursor
+type GlobalSearch struct {
+	Query  string
+	UserID uint32
+	Type   SearchSectionType
+	Cursor string
+}
+
+// SearchSection holds the items of one type found by the global search, the most relevant go first.
+// Failed is set if the section could not be searched in time, the other sections are still returned
+type SearchSection struct {
+	Type        SearchSectionType `json:"type"`
+	Profiles    []*ShortProfile   `json:"profiles,omitempty"`
+	Communities []*CommunityCard  `json:"communities,omitempty"`
+	Posts       []*Post           `json:"posts,omitempty"`
+	Cursor      string            `json:"cursor,omitempty"`
+	Failed      bool              `json:"failed,omitempty"`
+}
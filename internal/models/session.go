@@ -3,16 +3,43 @@ package models
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
 )
 
+// Session is a login of a user. CreatedAt is when the session was issued, it is reissued every hour,
+// LoginAt is when the user logged in on the device, LastSeen is when the session was used last time.
+// Unverified tells the email of the user is not verified yet, the sessions are updated when it is.
+// It is set for the new users only, so the sessions issued before the verification are kept verified
 type Session struct {
-	ID        string
-	UserID    uint32
-	CreatedAt int64
+	ID         string
+	UserID     uint32
+	CreatedAt  int64
+	UserAgent  string
+	IP         string
+	LoginAt    int64
+	LastSeen   int64
+	Unverified bool
+}
+
+// Device describes the client a session is created for
+type Device struct {
+	UserAgent string
+	IP        string
+}
+
+// SessionInfo is the session as shown to its owner. ID is not the session id itself, which is a secret of the cookie
+type SessionInfo struct {
+	ID        string `json:"id"`
+	UserAgent string `json:"user_agent"`
+	IP        string `json:"ip"`
+	CreatedAt int64  `json:"created_at"`
+	LastSeen  int64  `json:"last_seen"`
+	Current   bool   `json:"current"`
 }
 
 func NewSession(userID uint32) (*Session, error) {
@@ -21,13 +48,34 @@ func NewSession(userID uint32) (*Session, error) {
 	if err != nil {
 		return nil, fmt.Errorf("new session: %w", err)
 	}
+	now := time.Now().Unix()
 	return &Session{
 		ID:        fmt.Sprintf("%x", randID),
 		UserID:    userID,
-		CreatedAt: time.Now().Unix(),
+		CreatedAt: now,
+		LoginAt:   now,
+		LastSeen:  now,
 	}, nil
 }
 
+// PublicID identifies the session to its owner without revealing the session id
+func (s *Session) PublicID() string {
+	sum := sha256.Sum256([]byte(s.ID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Info shows the session to its owner, current is the id of the session the owner uses now
+func (s *Session) Info(current string) *SessionInfo {
+	return &SessionInfo{
+		ID:        s.PublicID(),
+		UserAgent: s.UserAgent,
+		IP:        s.IP,
+		CreatedAt: s.LoginAt,
+		LastSeen:  s.LastSeen,
+		Current:   s.ID == current,
+	}
+}
+
 type sessKey string
 
 var SessionKey sessKey = "sessionKey"
@@ -0,0 +1,32 @@
+package models
+
+// TwoFactor is the TOTP second factor of the user. It is enabled once the user confirms it by a code,
+// RecoveryCodes are the hashes of the codes the user can log in by instead of the TOTP code
+type TwoFactor struct {
+	UserID        uint32
+	Secret        string
+	Enabled       bool
+	RecoveryCodes []string
+}
+
+// TwoFactorSetup is what the authenticator app is set up by, URI is shown to the user as the QR code
+type TwoFactorSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type TwoFactorCode struct {
+	Code string `json:"code"`
+}
+
+// TwoFactorChallenge is the answer to the login of the user with the second factor,
+// the login is finished by the token and the code
+type TwoFactorChallenge struct {
+	Required bool   `json:"two_factor_required"`
+	Token    string `json:"token"`
+}
+
+type TwoFactorLogin struct {
+	Token string `json:"token"`
+	Code  string `json:"code"`
+}
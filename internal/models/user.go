@@ -9,4 +9,6 @@ type User struct {
 	FirstName string  `json:"first_name"`
 	LastName  string  `json:"last_name"`
 	Avatar    Picture `json:"avatar"`
+	// EmailVerified is not read from the requests, the email is verified by the link from the letter
+	EmailVerified bool `json:"-"`
 }
@@ -0,0 +1,309 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+//go:generate mockgen -destination=mock_comment.go -source=$GOFILE -package=${GOPACKAGE}
+type CommentService interface {
+	Create(ctx context.Context, comment *models.Comment) (*models.Comment, error)
+	GetComments(ctx context.Context, postID, userID, lastID uint32) ([]*models.Comment, error)
+	Update(ctx context.Context, comment *models.Comment) error
+	Delete(ctx context.Context, postID, commentID uint32) error
+	GetCommentAuthorID(ctx context.Context, postID, commentID uint32) (uint32, error)
+
+	SetLikeToComment(ctx context.Context, postID, commentID, userID uint32) error
+	DeleteLikeFromComment(ctx context.Context, postID, commentID, userID uint32) error
+	CheckLikes(ctx context.Context, commentID, userID uint32) (bool, error)
+}
+
+type CommentController struct {
+	commentService CommentService
+	responder      Responder
+}
+
+func NewCommentController(service CommentService, responder Responder) *CommentController {
+	return &CommentController{
+		commentService: service,
+		responder:      responder,
+	}
+}
+
+func (cc *CommentController) Create(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	postID, err := getIDFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	comment, err := getCommentFromBody(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+	comment.PostID = postID
+
+	newComment, err := cc.commentService.Create(r.Context(), comment)
+	if err != nil {
+		if errors.Is(err, my_err.ErrPostNotFound) || errors.Is(err, my_err.ErrCommentNotFound) {
+			cc.responder.ErrorBadRequest(w, err, reqID)
+			return
+		}
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, newComment, reqID)
+}
+
+func (cc *CommentController) GetComments(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	postID, err := getIDFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	lastID, err := getLastID(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	comments, err := cc.commentService.GetComments(r.Context(), postID, sess.UserID, uint32(lastID))
+	if err != nil {
+		if errors.Is(err, my_err.ErrNoMoreContent) {
+			cc.responder.OutputNoMoreContentJSON(w, reqID)
+			return
+		}
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, comments, reqID)
+}
+
+func (cc *CommentController) Update(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	postID, commentID, err := getCommentIDsFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if !cc.checkAccess(r, postID, commentID) {
+		cc.responder.ErrorBadRequest(w, my_err.ErrAccessDenied, reqID)
+		return
+	}
+
+	comment, err := getCommentFromBody(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+	comment.ID = commentID
+	comment.PostID = postID
+
+	if err := cc.commentService.Update(r.Context(), comment); err != nil {
+		if errors.Is(err, my_err.ErrCommentNotFound) {
+			cc.responder.ErrorBadRequest(w, err, reqID)
+			return
+		}
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, comment, reqID)
+}
+
+func (cc *CommentController) Delete(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	postID, commentID, err := getCommentIDsFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if !cc.checkAccess(r, postID, commentID) {
+		cc.responder.ErrorBadRequest(w, my_err.ErrAccessDenied, reqID)
+		return
+	}
+
+	if err := cc.commentService.Delete(r.Context(), postID, commentID); err != nil {
+		if errors.Is(err, my_err.ErrCommentNotFound) {
+			cc.responder.ErrorBadRequest(w, err, reqID)
+			return
+		}
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, commentID, reqID)
+}
+
+func (cc *CommentController) SetLikeOnComment(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	postID, commentID, err := getCommentIDsFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	set, err := cc.commentService.CheckLikes(r.Context(), commentID, sess.UserID)
+	if err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	if set {
+		cc.responder.ErrorBadRequest(w, my_err.ErrLikeAlreadyExists, reqID)
+		return
+	}
+
+	if err := cc.commentService.SetLikeToComment(r.Context(), postID, commentID, sess.UserID); err != nil {
+		if errors.Is(err, my_err.ErrCommentNotFound) {
+			cc.responder.ErrorNotFound(w, err, reqID)
+			return
+		}
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, "like is set on comment", reqID)
+}
+
+func (cc *CommentController) DeleteLikeFromComment(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		cc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	postID, commentID, err := getCommentIDsFromURL(r)
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		cc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	set, err := cc.commentService.CheckLikes(r.Context(), commentID, sess.UserID)
+	if err != nil {
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	if !set {
+		cc.responder.ErrorBadRequest(w, my_err.ErrInvalidQuery, reqID)
+		return
+	}
+
+	if err := cc.commentService.DeleteLikeFromComment(r.Context(), postID, commentID, sess.UserID); err != nil {
+		if errors.Is(err, my_err.ErrCommentNotFound) {
+			cc.responder.ErrorNotFound(w, err, reqID)
+			return
+		}
+		cc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	cc.responder.OutputJSON(w, "like is unset from comment", reqID)
+}
+
+func (cc *CommentController) checkAccess(r *http.Request, postID, commentID uint32) bool {
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		return false
+	}
+
+	authorID, err := cc.commentService.GetCommentAuthorID(r.Context(), postID, commentID)
+	if err != nil {
+		return false
+	}
+
+	return authorID == sess.UserID
+}
+
+func getCommentFromBody(r *http.Request) (*models.Comment, error) {
+	var comment models.Comment
+
+	if err := json.NewDecoder(r.Body).Decode(&comment); err != nil {
+		return nil, err
+	}
+
+	if len(comment.Content.Text) > 499 {
+		return nil, my_err.ErrCommentTooLong
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	comment.Header.AuthorID = sess.UserID
+
+	return &comment, nil
+}
+
+func getCommentIDsFromURL(r *http.Request) (uint32, uint32, error) {
+	postID, err := getIDFromURL(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id := mux.Vars(r)["comment_id"]
+	if id == "" {
+		return 0, 0, errors.New("comment id is empty")
+	}
+
+	commentID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return postID, uint32(commentID), nil
+}
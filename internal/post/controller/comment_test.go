@@ -0,0 +1,415 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+type commentMocks struct {
+	commentService *MockCommentService
+	responder      *MockResponder
+}
+
+func getCommentController(ctrl *gomock.Controller) (*CommentController, *commentMocks) {
+	m := &commentMocks{
+		commentService: NewMockCommentService(ctrl),
+		responder:      NewMockResponder(ctrl),
+	}
+
+	return NewCommentController(m.commentService, m.responder), m
+}
+
+func TestNewCommentController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	handler, _ := getCommentController(ctrl)
+	assert.NotNil(t, handler)
+}
+
+func newCommentRequest(method, body string, vars map[string]string, withSession bool) *Request {
+	req := httptest.NewRequest(method, "/api/v1/feed/1/comments", bytes.NewBufferString(body))
+	req = mux.SetURLVars(req, vars)
+	if withSession {
+		req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+	}
+	req = req.WithContext(context.WithValue(req.Context(), "requestID", "1"))
+
+	return &Request{r: req, w: httptest.NewRecorder()}
+}
+
+func expectBadRequest(request Request, m *commentMocks) {
+	m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+		request.w.WriteHeader(http.StatusBadRequest)
+		request.w.Write([]byte("bad request"))
+	})
+}
+
+func expectInternal(request Request, m *commentMocks) {
+	m.responder.EXPECT().ErrorInternal(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+		request.w.WriteHeader(http.StatusInternalServerError)
+		request.w.Write([]byte("error"))
+	})
+}
+
+func expectNotFound(request Request, m *commentMocks) {
+	m.responder.EXPECT().ErrorNotFound(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+		request.w.WriteHeader(http.StatusNotFound)
+		request.w.Write([]byte("not found"))
+	})
+}
+
+func expectOK(request Request, m *commentMocks) {
+	m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(func(w, data, req any) {
+		request.w.WriteHeader(http.StatusOK)
+		request.w.Write([]byte("OK"))
+	})
+}
+
+var (
+	badRequest = Response{StatusCode: http.StatusBadRequest, Body: "bad request"}
+	internal   = Response{StatusCode: http.StatusInternalServerError, Body: "error"}
+	okResponse = Response{StatusCode: http.StatusOK, Body: "OK"}
+	notFound   = Response{StatusCode: http.StatusNotFound, Body: "not found"}
+	postVars   = map[string]string{"id": "1"}
+	allVars    = map[string]string{"id": "1", "comment_id": "2"}
+)
+
+func TestCommentCreate(t *testing.T) {
+	tests := []CommentTableTest{
+		{
+			name:     "1",
+			request:  newCommentRequest(http.MethodPost, `{}`, nil, true),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "2",
+			request:  newCommentRequest(http.MethodPost, `{`, postVars, true),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "3",
+			request:  newCommentRequest(http.MethodPost, `{"content":{"text":"hi"}}`, postVars, false),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "4",
+			request:  newCommentRequest(http.MethodPost, `{"content":{"text":"hi"}}`, postVars, true),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, my_err.ErrPostNotFound)
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "5",
+			request:  newCommentRequest(http.MethodPost, `{"content":{"text":"hi"}}`, postVars, true),
+			expected: internal,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				expectInternal(request, m)
+			},
+		},
+		{
+			name:     "6",
+			request:  newCommentRequest(http.MethodPost, `{"content":{"text":"hi"}}`, postVars, true),
+			expected: okResponse,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&models.Comment{ID: 1}, nil)
+				expectOK(request, m)
+			},
+		},
+	}
+
+	runCommentTests(t, tests, func(cc *CommentController, w http.ResponseWriter, r *http.Request) {
+		cc.Create(w, r)
+	})
+}
+
+func TestGetComments(t *testing.T) {
+	tests := []CommentTableTest{
+		{
+			name:     "1",
+			request:  newCommentRequest(http.MethodGet, "", nil, true),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "2",
+			request:  newCommentRequest(http.MethodGet, "", postVars, false),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "3",
+			request:  newCommentRequest(http.MethodGet, "", postVars, true),
+			expected: Response{StatusCode: http.StatusNoContent},
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().GetComments(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, my_err.ErrNoMoreContent)
+				m.responder.EXPECT().OutputNoMoreContentJSON(request.w, gomock.Any()).Do(func(w, req any) {
+					request.w.WriteHeader(http.StatusNoContent)
+				})
+			},
+		},
+		{
+			name:     "4",
+			request:  newCommentRequest(http.MethodGet, "", postVars, true),
+			expected: internal,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().GetComments(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("error"))
+				expectInternal(request, m)
+			},
+		},
+		{
+			name:     "5",
+			request:  newCommentRequest(http.MethodGet, "", postVars, true),
+			expected: okResponse,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().GetComments(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*models.Comment{{ID: 1}}, nil)
+				expectOK(request, m)
+			},
+		},
+	}
+
+	runCommentTests(t, tests, func(cc *CommentController, w http.ResponseWriter, r *http.Request) {
+		cc.GetComments(w, r)
+	})
+}
+
+func TestCommentUpdate(t *testing.T) {
+	tests := []CommentTableTest{
+		{
+			name:     "1",
+			request:  newCommentRequest(http.MethodPut, `{}`, postVars, true),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "2",
+			request:  newCommentRequest(http.MethodPut, `{}`, allVars, true),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().GetCommentAuthorID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(2), nil)
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "3",
+			request:  newCommentRequest(http.MethodPut, `{"content":{"text":"new"}}`, allVars, true),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().GetCommentAuthorID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(1), nil)
+				m.commentService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(my_err.ErrCommentNotFound)
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "4",
+			request:  newCommentRequest(http.MethodPut, `{"content":{"text":"new"}}`, allVars, true),
+			expected: okResponse,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().GetCommentAuthorID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(1), nil)
+				m.commentService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				expectOK(request, m)
+			},
+		},
+	}
+
+	runCommentTests(t, tests, func(cc *CommentController, w http.ResponseWriter, r *http.Request) {
+		cc.Update(w, r)
+	})
+}
+
+func TestCommentDelete(t *testing.T) {
+	tests := []CommentTableTest{
+		{
+			name:     "1",
+			request:  newCommentRequest(http.MethodDelete, "", allVars, false),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "2",
+			request:  newCommentRequest(http.MethodDelete, "", allVars, true),
+			expected: internal,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().GetCommentAuthorID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(1), nil)
+				m.commentService.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error"))
+				expectInternal(request, m)
+			},
+		},
+		{
+			name:     "3",
+			request:  newCommentRequest(http.MethodDelete, "", allVars, true),
+			expected: okResponse,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().GetCommentAuthorID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(1), nil)
+				m.commentService.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				expectOK(request, m)
+			},
+		},
+	}
+
+	runCommentTests(t, tests, func(cc *CommentController, w http.ResponseWriter, r *http.Request) {
+		cc.Delete(w, r)
+	})
+}
+
+func TestSetLikeOnComment(t *testing.T) {
+	tests := []CommentTableTest{
+		{
+			name:     "1",
+			request:  newCommentRequest(http.MethodPost, "", postVars, true),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "2",
+			request:  newCommentRequest(http.MethodPost, "", allVars, true),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "3",
+			request:  newCommentRequest(http.MethodPost, "", allVars, true),
+			expected: internal,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				m.commentService.EXPECT().SetLikeToComment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error"))
+				expectInternal(request, m)
+			},
+		},
+		{
+			name:     "5",
+			request:  newCommentRequest(http.MethodPost, "", allVars, true),
+			expected: notFound,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				m.commentService.EXPECT().SetLikeToComment(gomock.Any(), uint32(1), uint32(2), uint32(1)).
+					Return(my_err.ErrCommentNotFound)
+				expectNotFound(request, m)
+			},
+		},
+		{
+			name:     "4",
+			request:  newCommentRequest(http.MethodPost, "", allVars, true),
+			expected: okResponse,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				m.commentService.EXPECT().SetLikeToComment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				expectOK(request, m)
+			},
+		},
+	}
+
+	runCommentTests(t, tests, func(cc *CommentController, w http.ResponseWriter, r *http.Request) {
+		cc.SetLikeOnComment(w, r)
+	})
+}
+
+func TestDeleteLikeFromComment(t *testing.T) {
+	tests := []CommentTableTest{
+		{
+			name:     "1",
+			request:  newCommentRequest(http.MethodPost, "", allVars, false),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "2",
+			request:  newCommentRequest(http.MethodPost, "", allVars, true),
+			expected: badRequest,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name:     "3",
+			request:  newCommentRequest(http.MethodPost, "", allVars, true),
+			expected: okResponse,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.commentService.EXPECT().DeleteLikeFromComment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				expectOK(request, m)
+			},
+		},
+		{
+			name:     "4",
+			request:  newCommentRequest(http.MethodPost, "", allVars, true),
+			expected: notFound,
+			setupMock: func(request Request, m *commentMocks) {
+				m.commentService.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.commentService.EXPECT().DeleteLikeFromComment(gomock.Any(), uint32(1), uint32(2), uint32(1)).
+					Return(my_err.ErrCommentNotFound)
+				expectNotFound(request, m)
+			},
+		},
+	}
+
+	runCommentTests(t, tests, func(cc *CommentController, w http.ResponseWriter, r *http.Request) {
+		cc.DeleteLikeFromComment(w, r)
+	})
+}
+
+type CommentTableTest struct {
+	name      string
+	request   *Request
+	expected  Response
+	setupMock func(Request, *commentMocks)
+}
+
+func runCommentTests(
+	t *testing.T, tests []CommentTableTest, handler func(*CommentController, http.ResponseWriter, *http.Request),
+) {
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			contr, mock := getCommentController(ctrl)
+			v.setupMock(*v.request, mock)
+
+			handler(contr, v.request.w, v.request.r)
+			actual := Response{StatusCode: v.request.w.Code, Body: v.request.w.Body.String()}
+			assert.Equal(t, v.expected, actual)
+		})
+	}
+}
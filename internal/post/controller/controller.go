@@ -5,9 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/gorilla/mux"
 
@@ -22,6 +23,8 @@ type PostService interface {
 	Update(ctx context.Context, post *models.Post) error
 	Delete(ctx context.Context, postID uint32) error
 	GetBatch(ctx context.Context, lastID, userID uint32) ([]*models.Post, error)
+	GetFeed(ctx context.Context, userID uint32, cursor models.FeedCursor) (*models.PostPage, error)
+	GetJoinedCommunitiesPosts(ctx context.Context, userID uint32, cursor models.FeedCursor) (*models.PostPage, error)
 	GetBatchFromFriend(ctx context.Context, userID uint32, lastID uint32) ([]*models.Post, error)
 	GetPostAuthorID(ctx context.Context, postID uint32) (uint32, error)
 
@@ -29,17 +32,26 @@ type PostService interface {
 	CreateCommunityPost(ctx context.Context, post *models.Post) (uint32, error)
 	CheckAccessToCommunity(ctx context.Context, userID uint32, communityID uint32) bool
 
-	SetLikeToPost(ctx context.Context, postID uint32, userID uint32) error
-	DeleteLikeFromPost(ctx context.Context, postID uint32, userID uint32) error
+	SetReactionToPost(ctx context.Context, postID, userID uint32, reaction models.ReactionType) error
+	DeleteReactionFromPost(ctx context.Context, postID, userID uint32) error
 	CheckLikes(ctx context.Context, postID, userID uint32) (bool, error)
+	GetLikedBy(ctx context.Context, postID, userID, lastID uint32) ([]*models.ShortProfile, error)
+
+	Search(ctx context.Context, userID uint32, search *models.PostSearch) (*models.PostPage, error)
 }
 
+const (
+	searchQueryMinLen = 3
+	searchQueryMaxLen = 100
+)
+
 type Responder interface {
 	OutputJSON(w http.ResponseWriter, data any, requestId string)
 	OutputNoMoreContentJSON(w http.ResponseWriter, requestId string)
 
 	ErrorInternal(w http.ResponseWriter, err error, requestId string)
 	ErrorBadRequest(w http.ResponseWriter, err error, requestId string)
+	ErrorNotFound(w http.ResponseWriter, err error, requestId string)
 	LogError(err error, requestId string)
 }
 
@@ -273,6 +285,11 @@ func (pc *PostController) GetBatchPosts(w http.ResponseWriter, r *http.Request)
 		{
 			posts, err = pc.postService.GetBatchFromFriend(r.Context(), sess.UserID, uint32(intLastID))
 		}
+	case "community":
+		{
+			pc.getPage(w, r, sess.UserID, pc.postService.GetJoinedCommunitiesPosts)
+			return
+		}
 	case "":
 		{
 			if communityID != "" {
@@ -305,6 +322,109 @@ func (pc *PostController) GetBatchPosts(w http.ResponseWriter, r *http.Request)
 	pc.responder.OutputJSON(w, posts, reqID)
 }
 
+// GetFeed writes a page of the ranked home feed, the next page is asked with the cursor of the previous one
+func (pc *PostController) GetFeed(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		pc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		pc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	pc.getPage(w, r, sess.UserID, pc.postService.GetFeed)
+}
+
+// getPage writes a page of the feed section loaded by get, the next page is asked with the cursor of the previous one
+func (pc *PostController) getPage(
+	w http.ResponseWriter, r *http.Request, userID uint32,
+	get func(ctx context.Context, userID uint32, cursor models.FeedCursor) (*models.PostPage, error),
+) {
+	reqID, _ := r.Context().Value("requestID").(string)
+
+	cursor, err := models.ParseFeedCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		pc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	page, err := get(r.Context(), userID, cursor)
+	if errors.Is(err, my_err.ErrNoMoreContent) {
+		pc.responder.OutputNoMoreContentJSON(w, reqID)
+		return
+	}
+
+	if err != nil {
+		pc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	pc.responder.OutputJSON(w, page, reqID)
+}
+
+// SearchPosts finds the posts by the words of q in the section, the posts of a community with the community
+// parameter. The next page is asked with the cursor of the previous one
+func (pc *PostController) SearchPosts(w http.ResponseWriter, r *http.Request) {
+	var (
+		reqID, ok   = r.Context().Value("requestID").(string)
+		query       = strings.TrimSpace(r.URL.Query().Get("q"))
+		section     = r.URL.Query().Get("section")
+		communityID = r.URL.Query().Get("community")
+	)
+
+	if !ok {
+		pc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	if length := utf8.RuneCountInString(query); length < searchQueryMinLen || length > searchQueryMaxLen {
+		pc.responder.ErrorBadRequest(w, my_err.ErrInvalidQuery, reqID)
+		return
+	}
+
+	if section != "" && section != models.SearchSectionFriend && section != models.SearchSectionCommunity {
+		pc.responder.ErrorBadRequest(w, my_err.ErrInvalidQuery, reqID)
+		return
+	}
+
+	cursor, err := models.ParseSearchCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		pc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	search := &models.PostSearch{Query: query, Section: section, Cursor: cursor}
+	if communityID != "" {
+		id, err := strconv.ParseUint(communityID, 10, 32)
+		if err != nil {
+			pc.responder.ErrorBadRequest(w, my_err.ErrInvalidQuery, reqID)
+			return
+		}
+		search.CommunityID = uint32(id)
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		pc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	page, err := pc.postService.Search(r.Context(), sess.UserID, search)
+	if errors.Is(err, my_err.ErrNoMoreContent) {
+		pc.responder.OutputNoMoreContentJSON(w, reqID)
+		return
+	}
+
+	if err != nil {
+		pc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	pc.responder.OutputJSON(w, page, reqID)
+}
+
 func (pc *PostController) getPostFromBody(r *http.Request) (*models.Post, error) {
 	var newPost models.Post
 
@@ -342,7 +462,7 @@ func getLastID(r *http.Request) (uint64, error) {
 	lastID := r.URL.Query().Get("id")
 
 	if lastID == "" {
-		return math.MaxInt32, nil
+		return models.FirstPageLastID, nil
 	}
 
 	intLastID, err := strconv.ParseUint(lastID, 10, 32)
@@ -411,8 +531,12 @@ func (pc *PostController) SetLikeOnPost(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = pc.postService.SetLikeToPost(r.Context(), postID, sess.UserID)
+	err = pc.postService.SetReactionToPost(r.Context(), postID, sess.UserID, models.ReactionLike)
 	if err != nil {
+		if errors.Is(err, my_err.ErrPostNotFound) {
+			pc.responder.ErrorNotFound(w, err, reqID)
+			return
+		}
 		pc.responder.ErrorInternal(w, err, reqID)
 		return
 	}
@@ -448,7 +572,7 @@ func (pc *PostController) DeleteLikeFromPost(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	err = pc.postService.DeleteLikeFromPost(r.Context(), postID, sess.UserID)
+	err = pc.postService.DeleteReactionFromPost(r.Context(), postID, sess.UserID)
 	if err != nil {
 		pc.responder.ErrorInternal(w, err, reqID)
 		return
@@ -456,3 +580,108 @@ func (pc *PostController) DeleteLikeFromPost(w http.ResponseWriter, r *http.Requ
 
 	pc.responder.OutputJSON(w, "like is unset from post", reqID)
 }
+
+func (pc *PostController) SetReactionOnPost(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		pc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	postID, err := getIDFromURL(r)
+	if err != nil {
+		pc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	sess, errSession := models.SessionFromContext(r.Context())
+	if errSession != nil {
+		pc.responder.ErrorBadRequest(w, errSession, reqID)
+		return
+	}
+
+	var reaction models.Reaction
+	if err := json.NewDecoder(r.Body).Decode(&reaction); err != nil {
+		pc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	err = pc.postService.SetReactionToPost(r.Context(), postID, sess.UserID, reaction.Type)
+	if err != nil {
+		if errors.Is(err, my_err.ErrWrongReaction) {
+			pc.responder.ErrorBadRequest(w, err, reqID)
+			return
+		}
+		if errors.Is(err, my_err.ErrPostNotFound) {
+			pc.responder.ErrorNotFound(w, err, reqID)
+			return
+		}
+		pc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	pc.responder.OutputJSON(w, reaction, reqID)
+}
+
+func (pc *PostController) DeleteReactionFromPost(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		pc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	postID, err := getIDFromURL(r)
+	if err != nil {
+		pc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	sess, errSession := models.SessionFromContext(r.Context())
+	if errSession != nil {
+		pc.responder.ErrorBadRequest(w, errSession, reqID)
+		return
+	}
+
+	err = pc.postService.DeleteReactionFromPost(r.Context(), postID, sess.UserID)
+	if err != nil {
+		pc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	pc.responder.OutputJSON(w, "reaction is unset from post", reqID)
+}
+
+func (pc *PostController) GetLikedBy(w http.ResponseWriter, r *http.Request) {
+	reqID, ok := r.Context().Value("requestID").(string)
+	if !ok {
+		pc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	postID, err := getIDFromURL(r)
+	if err != nil {
+		pc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	lastID, err := getLastID(r)
+	if err != nil {
+		pc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	sess, errSession := models.SessionFromContext(r.Context())
+	if errSession != nil {
+		pc.responder.ErrorBadRequest(w, errSession, reqID)
+		return
+	}
+
+	profiles, err := pc.postService.GetLikedBy(r.Context(), postID, sess.UserID, uint32(lastID))
+	if err != nil {
+		if errors.Is(err, my_err.ErrNoMoreContent) {
+			pc.responder.OutputNoMoreContentJSON(w, reqID)
+			return
+		}
+		pc.responder.ErrorInternal(w, err, reqID)
+		return
+	}
+
+	pc.responder.OutputJSON(w, profiles, reqID)
+}
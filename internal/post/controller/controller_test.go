@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
@@ -1205,7 +1206,8 @@ func TestGetBatchPost(t *testing.T) {
 			ExpectedErr: nil,
 			SetupMock: func(request Request, m *mocks) {
 				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any()).Do(func(err, req any) {})
-				m.postService.EXPECT().GetBatch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, my_err.ErrNoMoreContent)
+				m.postService.EXPECT().GetBatch(gomock.Any(), uint32(models.FirstPageLastID), uint32(1)).
+					Return(nil, my_err.ErrNoMoreContent)
 				m.responder.EXPECT().OutputNoMoreContentJSON(request.w, gomock.Any()).Do(func(w, req any) {
 					request.w.WriteHeader(http.StatusNoContent)
 				})
@@ -1310,7 +1312,7 @@ func TestGetBatchPost(t *testing.T) {
 			ExpectedErr: nil,
 			SetupMock: func(request Request, m *mocks) {
 				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any()).Do(func(err, req any) {})
-				m.postService.EXPECT().GetBatchFromFriend(gomock.Any(), gomock.Any(), gomock.Any()).
+				m.postService.EXPECT().GetBatchFromFriend(gomock.Any(), uint32(1), uint32(models.FirstPageLastID)).
 					Return(nil, nil)
 				m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
 					request.w.WriteHeader(http.StatusOK)
@@ -1397,6 +1399,228 @@ func TestGetBatchPost(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "12",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/feed?section=community", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *PostController, request Request) (Response, error) {
+				implementation.GetBatchPosts(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusOK, Body: "OK"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any()).Do(func(err, req any) {})
+				m.postService.EXPECT().GetJoinedCommunitiesPosts(gomock.Any(), uint32(1), models.FeedCursor{}).
+					Return(&models.PostPage{Posts: []*models.Post{{ID: 9}}}, nil)
+				m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(func(w, data, req any) {
+					request.w.WriteHeader(http.StatusOK)
+					request.w.Write([]byte("OK"))
+				})
+			},
+		},
+		{
+			name: "13",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/feed?section=community", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *PostController, request Request) (Response, error) {
+				implementation.GetBatchPosts(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusNoContent}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any()).Do(func(err, req any) {})
+				m.postService.EXPECT().GetJoinedCommunitiesPosts(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, my_err.ErrNoMoreContent)
+				m.responder.EXPECT().OutputNoMoreContentJSON(request.w, gomock.Any()).Do(func(w, req any) {
+					request.w.WriteHeader(http.StatusNoContent)
+				})
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getController(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
+func TestGetFeed(t *testing.T) {
+	tests := []TableTest[Response, Request]{
+		{
+			name: "1",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/feed/ranked", nil)
+				w := httptest.NewRecorder()
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *PostController, request Request) (Response, error) {
+				implementation.GetFeed(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusBadRequest, Body: "bad request"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any()).Do(func(err, req any) {})
+				m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+					request.w.WriteHeader(http.StatusBadRequest)
+					request.w.Write([]byte("bad request"))
+				})
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/feed/ranked?cursor=wrong", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *PostController, request Request) (Response, error) {
+				implementation.GetFeed(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusBadRequest, Body: "bad request"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any()).Do(func(err, req any) {})
+				m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+					request.w.WriteHeader(http.StatusBadRequest)
+					request.w.Write([]byte("bad request"))
+				})
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/feed/ranked", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *PostController, request Request) (Response, error) {
+				implementation.GetFeed(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusNoContent}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any()).Do(func(err, req any) {})
+				m.postService.EXPECT().GetFeed(gomock.Any(), uint32(1), models.FeedCursor{}).Return(nil, my_err.ErrNoMoreContent)
+				m.responder.EXPECT().OutputNoMoreContentJSON(request.w, gomock.Any()).Do(func(w, req any) {
+					request.w.WriteHeader(http.StatusNoContent)
+				})
+			},
+		},
+		{
+			name: "4",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/feed/ranked", nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *PostController, request Request) (Response, error) {
+				implementation.GetFeed(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusInternalServerError, Body: "error"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any()).Do(func(err, req any) {})
+				m.postService.EXPECT().GetFeed(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				m.responder.EXPECT().ErrorInternal(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+					request.w.WriteHeader(http.StatusInternalServerError)
+					request.w.Write([]byte("error"))
+				})
+			},
+		},
+		{
+			name: "5",
+			SetupInput: func() (*Request, error) {
+				cursor := models.FeedCursor{AsOf: time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC), Score: 0.5, ID: 10}
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/feed/ranked?cursor="+cursor.String(), nil)
+				w := httptest.NewRecorder()
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *PostController, request Request) (Response, error) {
+				implementation.GetFeed(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusOK, Body: "OK"}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any()).Do(func(err, req any) {})
+				m.postService.EXPECT().GetFeed(gomock.Any(), uint32(1), models.FeedCursor{
+					AsOf: time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC), Score: 0.5, ID: 10,
+				}).Return(&models.PostPage{Posts: []*models.Post{{ID: 9}}}, nil)
+				m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(func(w, data, req any) {
+					request.w.WriteHeader(http.StatusOK)
+					request.w.Write([]byte("OK"))
+				})
+			},
+		},
 	}
 
 	for _, v := range tests {
@@ -1559,7 +1783,7 @@ func TestSetLikeOnPost(t *testing.T) {
 			SetupMock: func(request Request, m *mocks) {
 				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
 				m.postService.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
-				m.postService.EXPECT().SetLikeToPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error"))
+				m.postService.EXPECT().SetReactionToPost(gomock.Any(), gomock.Any(), gomock.Any(), models.ReactionLike).Return(errors.New("error"))
 				m.responder.EXPECT().ErrorInternal(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
 					request.w.WriteHeader(http.StatusInternalServerError)
 					request.w.Write([]byte("error"))
@@ -1588,7 +1812,7 @@ func TestSetLikeOnPost(t *testing.T) {
 			SetupMock: func(request Request, m *mocks) {
 				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
 				m.postService.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
-				m.postService.EXPECT().SetLikeToPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.postService.EXPECT().SetReactionToPost(gomock.Any(), gomock.Any(), gomock.Any(), models.ReactionLike).Return(nil)
 				m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(func(w, data, req any) {
 					request.w.WriteHeader(http.StatusOK)
 					request.w.Write([]byte("OK"))
@@ -1757,7 +1981,7 @@ func TestDeleteLikeFromPost(t *testing.T) {
 			SetupMock: func(request Request, m *mocks) {
 				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
 				m.postService.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
-				m.postService.EXPECT().DeleteLikeFromPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error"))
+				m.postService.EXPECT().DeleteReactionFromPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error"))
 				m.responder.EXPECT().ErrorInternal(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
 					request.w.WriteHeader(http.StatusInternalServerError)
 					request.w.Write([]byte("error"))
@@ -1786,7 +2010,7 @@ func TestDeleteLikeFromPost(t *testing.T) {
 			SetupMock: func(request Request, m *mocks) {
 				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
 				m.postService.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
-				m.postService.EXPECT().DeleteLikeFromPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.postService.EXPECT().DeleteReactionFromPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(func(w, data, req any) {
 					request.w.WriteHeader(http.StatusOK)
 					request.w.Write([]byte("OK"))
@@ -1847,3 +2071,302 @@ type TableTest[T, In any] struct {
 	ExpectedErr    error
 	SetupMock      func(In, *mocks)
 }
+
+func TestSetReactionOnPost(t *testing.T) {
+	newRequest := func(body string, vars map[string]string) (*Request, error) {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/feed/2/reaction", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		req = mux.SetURLVars(req, vars)
+		req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+		return &Request{r: req, w: w}, nil
+	}
+	run := func(ctx context.Context, implementation *PostController, request Request) (Response, error) {
+		implementation.SetReactionOnPost(request.w, request.r)
+		res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+		return res, nil
+	}
+	badRequest := func() (Response, error) {
+		return Response{StatusCode: http.StatusBadRequest, Body: "bad request"}, nil
+	}
+	expectBadRequest := func(request Request, m *mocks) {
+		m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+			request.w.WriteHeader(http.StatusBadRequest)
+			request.w.Write([]byte("bad request"))
+		})
+	}
+
+	tests := []TableTest[Response, Request]{
+		{
+			name: "1",
+			SetupInput: func() (*Request, error) {
+				return newRequest(`{"type":"love"}`, nil)
+			},
+			Run:            run,
+			ExpectedResult: badRequest,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*Request, error) {
+				return newRequest(`{"type":`, map[string]string{"id": "2"})
+			},
+			Run:            run,
+			ExpectedResult: badRequest,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*Request, error) {
+				return newRequest(`{"type":"wow"}`, map[string]string{"id": "2"})
+			},
+			Run:            run,
+			ExpectedResult: badRequest,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.postService.EXPECT().SetReactionToPost(gomock.Any(), uint32(2), uint32(1), models.ReactionType("wow")).
+					Return(my_err.ErrWrongReaction)
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name: "4",
+			SetupInput: func() (*Request, error) {
+				return newRequest(`{"type":"sad"}`, map[string]string{"id": "2"})
+			},
+			Run: run,
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusInternalServerError, Body: "error"}, nil
+			},
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.postService.EXPECT().SetReactionToPost(gomock.Any(), uint32(2), uint32(1), models.ReactionSad).
+					Return(errors.New("error"))
+				m.responder.EXPECT().ErrorInternal(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+					request.w.WriteHeader(http.StatusInternalServerError)
+					request.w.Write([]byte("error"))
+				})
+			},
+		},
+		{
+			name: "5",
+			SetupInput: func() (*Request, error) {
+				return newRequest(`{"type":"sad"}`, map[string]string{"id": "2"})
+			},
+			Run: run,
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusOK, Body: "OK"}, nil
+			},
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.postService.EXPECT().SetReactionToPost(gomock.Any(), uint32(2), uint32(1), models.ReactionSad).Return(nil)
+				m.responder.EXPECT().OutputJSON(request.w, models.Reaction{Type: models.ReactionSad}, gomock.Any()).
+					Do(func(w, data, req any) {
+						request.w.WriteHeader(http.StatusOK)
+						request.w.Write([]byte("OK"))
+					})
+			},
+		},
+		{
+			name: "6",
+			SetupInput: func() (*Request, error) {
+				return newRequest(`{"type":"sad"}`, map[string]string{"id": "2"})
+			},
+			Run: run,
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusNotFound, Body: "not found"}, nil
+			},
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.postService.EXPECT().SetReactionToPost(gomock.Any(), uint32(2), uint32(1), models.ReactionSad).
+					Return(my_err.ErrPostNotFound)
+				m.responder.EXPECT().ErrorNotFound(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+					request.w.WriteHeader(http.StatusNotFound)
+					request.w.Write([]byte("not found"))
+				})
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getController(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSearchPosts(t *testing.T) {
+	newRequest := func(query string) (*Request, error) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/feed/search?"+query, nil)
+		w := httptest.NewRecorder()
+		req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+		return &Request{r: req, w: w}, nil
+	}
+	run := func(ctx context.Context, implementation *PostController, request Request) (Response, error) {
+		implementation.SearchPosts(request.w, request.r)
+		res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+		return res, nil
+	}
+	badRequest := func() (Response, error) {
+		return Response{StatusCode: http.StatusBadRequest, Body: "bad request"}, nil
+	}
+	expectBadRequest := func(request Request, m *mocks) {
+		m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+		m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+			request.w.WriteHeader(http.StatusBadRequest)
+			request.w.Write([]byte("bad request"))
+		})
+	}
+	cursor := models.SearchCursor{Rank: 0.25, ID: 7}
+
+	tests := []TableTest[Response, Request]{
+		{
+			name: "1",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=ко")
+			},
+			Run:            run,
+			ExpectedResult: badRequest,
+			SetupMock:      expectBadRequest,
+		},
+		{
+			name: "2",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=cats&section=all")
+			},
+			Run:            run,
+			ExpectedResult: badRequest,
+			SetupMock:      expectBadRequest,
+		},
+		{
+			name: "3",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=cats&cursor=wrong")
+			},
+			Run:            run,
+			ExpectedResult: badRequest,
+			SetupMock:      expectBadRequest,
+		},
+		{
+			name: "4",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=cats&community=one")
+			},
+			Run:            run,
+			ExpectedResult: badRequest,
+			SetupMock:      expectBadRequest,
+		},
+		{
+			name: "5",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=cats")
+			},
+			Run: run,
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusNoContent}, nil
+			},
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.postService.EXPECT().Search(gomock.Any(), uint32(1), &models.PostSearch{Query: "cats"}).
+					Return(nil, my_err.ErrNoMoreContent)
+				m.responder.EXPECT().OutputNoMoreContentJSON(request.w, gomock.Any()).Do(func(w, req any) {
+					request.w.WriteHeader(http.StatusNoContent)
+				})
+			},
+		},
+		{
+			name: "6",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=cats")
+			},
+			Run: run,
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusInternalServerError, Body: "error"}, nil
+			},
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.postService.EXPECT().Search(gomock.Any(), uint32(1), gomock.Any()).Return(nil, errors.New("error"))
+				m.responder.EXPECT().ErrorInternal(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+					request.w.WriteHeader(http.StatusInternalServerError)
+					request.w.Write([]byte("error"))
+				})
+			},
+		},
+		{
+			name: "7",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=%20котики%20&section=community&community=4&cursor=" + cursor.String())
+			},
+			Run: run,
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusOK, Body: "OK"}, nil
+			},
+			SetupMock: func(request Request, m *mocks) {
+				page := &models.PostPage{Posts: []*models.Post{{ID: 3}}}
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.postService.EXPECT().Search(gomock.Any(), uint32(1), &models.PostSearch{
+					Query: "котики", Section: models.SearchSectionCommunity, CommunityID: 4, Cursor: cursor,
+				}).Return(page, nil)
+				m.responder.EXPECT().OutputJSON(request.w, page, gomock.Any()).Do(func(w, data, req any) {
+					request.w.WriteHeader(http.StatusOK)
+					request.w.Write([]byte("OK"))
+				})
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getController(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
@@ -109,18 +109,18 @@ func (mr *MockPostServiceMockRecorder) Delete(ctx, postID interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPostService)(nil).Delete), ctx, postID)
 }
 
-// DeleteLikeFromPost mocks base method.
-func (m *MockPostService) DeleteLikeFromPost(ctx context.Context, postID, userID uint32) error {
+// DeleteReactionFromPost mocks base method.
+func (m *MockPostService) DeleteReactionFromPost(ctx context.Context, postID, userID uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLikeFromPost", ctx, postID, userID)
+	ret := m.ctrl.Call(m, "DeleteReactionFromPost", ctx, postID, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteLikeFromPost indicates an expected call of DeleteLikeFromPost.
-func (mr *MockPostServiceMockRecorder) DeleteLikeFromPost(ctx, postID, userID interface{}) *gomock.Call {
+// DeleteReactionFromPost indicates an expected call of DeleteReactionFromPost.
+func (mr *MockPostServiceMockRecorder) DeleteReactionFromPost(ctx, postID, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikeFromPost", reflect.TypeOf((*MockPostService)(nil).DeleteLikeFromPost), ctx, postID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReactionFromPost", reflect.TypeOf((*MockPostService)(nil).DeleteReactionFromPost), ctx, postID, userID)
 }
 
 // Get mocks base method.
@@ -183,6 +183,51 @@ func (mr *MockPostServiceMockRecorder) GetCommunityPost(ctx, communityID, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommunityPost", reflect.TypeOf((*MockPostService)(nil).GetCommunityPost), ctx, communityID, userID, lastID)
 }
 
+// GetFeed mocks base method.
+func (m *MockPostService) GetFeed(ctx context.Context, userID uint32, cursor models.FeedCursor) (*models.PostPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, userID, cursor)
+	ret0, _ := ret[0].(*models.PostPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockPostServiceMockRecorder) GetFeed(ctx, userID, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockPostService)(nil).GetFeed), ctx, userID, cursor)
+}
+
+// GetJoinedCommunitiesPosts mocks base method.
+func (m *MockPostService) GetJoinedCommunitiesPosts(ctx context.Context, userID uint32, cursor models.FeedCursor) (*models.PostPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJoinedCommunitiesPosts", ctx, userID, cursor)
+	ret0, _ := ret[0].(*models.PostPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJoinedCommunitiesPosts indicates an expected call of GetJoinedCommunitiesPosts.
+func (mr *MockPostServiceMockRecorder) GetJoinedCommunitiesPosts(ctx, userID, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJoinedCommunitiesPosts", reflect.TypeOf((*MockPostService)(nil).GetJoinedCommunitiesPosts), ctx, userID, cursor)
+}
+
+// GetLikedBy mocks base method.
+func (m *MockPostService) GetLikedBy(ctx context.Context, postID, userID, lastID uint32) ([]*models.ShortProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikedBy", ctx, postID, userID, lastID)
+	ret0, _ := ret[0].([]*models.ShortProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikedBy indicates an expected call of GetLikedBy.
+func (mr *MockPostServiceMockRecorder) GetLikedBy(ctx, postID, userID, lastID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikedBy", reflect.TypeOf((*MockPostService)(nil).GetLikedBy), ctx, postID, userID, lastID)
+}
+
 // GetPostAuthorID mocks base method.
 func (m *MockPostService) GetPostAuthorID(ctx context.Context, postID uint32) (uint32, error) {
 	m.ctrl.T.Helper()
@@ -198,18 +243,33 @@ func (mr *MockPostServiceMockRecorder) GetPostAuthorID(ctx, postID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostAuthorID", reflect.TypeOf((*MockPostService)(nil).GetPostAuthorID), ctx, postID)
 }
 
-// SetLikeToPost mocks base method.
-func (m *MockPostService) SetLikeToPost(ctx context.Context, postID, userID uint32) error {
+// Search mocks base method.
+func (m *MockPostService) Search(ctx context.Context, userID uint32, search *models.PostSearch) (*models.PostPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetLikeToPost", ctx, postID, userID)
+	ret := m.ctrl.Call(m, "Search", ctx, userID, search)
+	ret0, _ := ret[0].(*models.PostPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockPostServiceMockRecorder) Search(ctx, userID, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPostService)(nil).Search), ctx, userID, search)
+}
+
+// SetReactionToPost mocks base method.
+func (m *MockPostService) SetReactionToPost(ctx context.Context, postID, userID uint32, reaction models.ReactionType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReactionToPost", ctx, postID, userID, reaction)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SetLikeToPost indicates an expected call of SetLikeToPost.
-func (mr *MockPostServiceMockRecorder) SetLikeToPost(ctx, postID, userID interface{}) *gomock.Call {
+// SetReactionToPost indicates an expected call of SetReactionToPost.
+func (mr *MockPostServiceMockRecorder) SetReactionToPost(ctx, postID, userID, reaction interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLikeToPost", reflect.TypeOf((*MockPostService)(nil).SetLikeToPost), ctx, postID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReactionToPost", reflect.TypeOf((*MockPostService)(nil).SetReactionToPost), ctx, postID, userID, reaction)
 }
 
 // Update mocks base method.
@@ -273,6 +333,18 @@ func (mr *MockResponderMockRecorder) ErrorInternal(w, err, requestId interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErrorInternal", reflect.TypeOf((*MockResponder)(nil).ErrorInternal), w, err, requestId)
 }
 
+// ErrorNotFound mocks base method.
+func (m *MockResponder) ErrorNotFound(w http.ResponseWriter, err error, requestId string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ErrorNotFound", w, err, requestId)
+}
+
+// ErrorNotFound indicates an expected call of ErrorNotFound.
+func (mr *MockResponderMockRecorder) ErrorNotFound(w, err, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErrorNotFound", reflect.TypeOf((*MockResponder)(nil).ErrorNotFound), w, err, requestId)
+}
+
 // LogError mocks base method.
 func (m *MockResponder) LogError(err error, requestId string) {
 	m.ctrl.T.Helper()
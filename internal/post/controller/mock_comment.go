@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment.go
+
+// Package controller is a generated GoMock package.
+package controller
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/2024_2_BetterCallFirewall/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCommentService is a mock of CommentService interface.
+type MockCommentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentServiceMockRecorder
+}
+
+// MockCommentServiceMockRecorder is the mock recorder for MockCommentService.
+type MockCommentServiceMockRecorder struct {
+	mock *MockCommentService
+}
+
+// NewMockCommentService creates a new mock instance.
+func NewMockCommentService(ctrl *gomock.Controller) *MockCommentService {
+	mock := &MockCommentService{ctrl: ctrl}
+	mock.recorder = &MockCommentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentService) EXPECT() *MockCommentServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckLikes mocks base method.
+func (m *MockCommentService) CheckLikes(ctx context.Context, commentID, userID uint32) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLikes", ctx, commentID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLikes indicates an expected call of CheckLikes.
+func (mr *MockCommentServiceMockRecorder) CheckLikes(ctx, commentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLikes", reflect.TypeOf((*MockCommentService)(nil).CheckLikes), ctx, commentID, userID)
+}
+
+// Create mocks base method.
+func (m *MockCommentService) Create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comment)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentServiceMockRecorder) Create(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentService)(nil).Create), ctx, comment)
+}
+
+// Delete mocks base method.
+func (m *MockCommentService) Delete(ctx context.Context, postID, commentID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, postID, commentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentServiceMockRecorder) Delete(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentService)(nil).Delete), ctx, postID, commentID)
+}
+
+// DeleteLikeFromComment mocks base method.
+func (m *MockCommentService) DeleteLikeFromComment(ctx context.Context, postID, commentID, userID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLikeFromComment", ctx, postID, commentID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLikeFromComment indicates an expected call of DeleteLikeFromComment.
+func (mr *MockCommentServiceMockRecorder) DeleteLikeFromComment(ctx, postID, commentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikeFromComment", reflect.TypeOf((*MockCommentService)(nil).DeleteLikeFromComment), ctx, postID, commentID, userID)
+}
+
+// GetCommentAuthorID mocks base method.
+func (m *MockCommentService) GetCommentAuthorID(ctx context.Context, postID, commentID uint32) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentAuthorID", ctx, postID, commentID)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentAuthorID indicates an expected call of GetCommentAuthorID.
+func (mr *MockCommentServiceMockRecorder) GetCommentAuthorID(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentAuthorID", reflect.TypeOf((*MockCommentService)(nil).GetCommentAuthorID), ctx, postID, commentID)
+}
+
+// GetComments mocks base method.
+func (m *MockCommentService) GetComments(ctx context.Context, postID, userID, lastID uint32) ([]*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, postID, userID, lastID)
+	ret0, _ := ret[0].([]*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentServiceMockRecorder) GetComments(ctx, postID, userID, lastID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockCommentService)(nil).GetComments), ctx, postID, userID, lastID)
+}
+
+// SetLikeToComment mocks base method.
+func (m *MockCommentService) SetLikeToComment(ctx context.Context, postID, commentID, userID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLikeToComment", ctx, postID, commentID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLikeToComment indicates an expected call of SetLikeToComment.
+func (mr *MockCommentServiceMockRecorder) SetLikeToComment(ctx, postID, commentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLikeToComment", reflect.TypeOf((*MockCommentService)(nil).SetLikeToComment), ctx, postID, commentID, userID)
+}
+
+// Update mocks base method.
+func (m *MockCommentService) Update(ctx context.Context, comment *models.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentServiceMockRecorder) Update(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommentService)(nil).Update), ctx, comment)
+}
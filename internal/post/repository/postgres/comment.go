@@ -0,0 +1,250 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+const (
+	createComment = `INSERT INTO comment (user_id, post_id, content, parent_id)
+SELECT $1, $2, $3, NULLIF($4, 0)
+WHERE EXISTS (SELECT 1 FROM post WHERE id = $2)
+  AND ($4 = 0 OR EXISTS (SELECT 1 FROM comment WHERE id = $4 AND post_id = $2 AND NOT is_deleted))
+RETURNING id, created_at;`
+
+	// getComments returns a page of root comments together with all of their replies,
+	// flattened in thread order: newest roots first, replies oldest first under their parent.
+	getComments = `WITH RECURSIVE roots AS (
+    SELECT id FROM comment WHERE post_id = $1 AND parent_id IS NULL AND id < $2 ORDER BY id DESC LIMIT 10
+), thread AS (
+    SELECT c.id, c.parent_id, c.user_id, c.post_id, c.content, c.is_deleted, c.created_at, c.updated_at,
+           0 AS depth, ARRAY[-c.id] AS path
+    FROM comment c JOIN roots r ON c.id = r.id
+    UNION ALL
+    SELECT c.id, c.parent_id, c.user_id, c.post_id, c.content, c.is_deleted, c.created_at, c.updated_at,
+           t.depth + 1, t.path || c.id
+    FROM comment c JOIN thread t ON c.parent_id = t.id
+)
+SELECT id, COALESCE(parent_id, 0), user_id, post_id, content, is_deleted, created_at, updated_at, depth
+FROM thread
+ORDER BY path;`
+
+	updateComment    = `UPDATE comment SET content = $1, updated_at = NOW() WHERE id = $2 AND post_id = $3 AND NOT is_deleted;`
+	getCommentAuthor = `SELECT user_id FROM comment WHERE id = $1 AND post_id = $2 AND NOT is_deleted;`
+	getCommentCount  = `SELECT COUNT(*) FROM comment WHERE post_id = $1 AND NOT is_deleted;`
+	getCommentCounts = `SELECT post_id, COUNT(*) FROM comment WHERE post_id = ANY($1::int[]) AND NOT is_deleted GROUP BY post_id;`
+
+	// deleteComment leaves a tombstone in place of a comment that still has replies
+	// so the thread does not collapse, and removes the row otherwise.
+	deleteComment = `WITH target AS (
+    SELECT c.id, EXISTS (SELECT 1 FROM comment r WHERE r.parent_id = c.id) AS has_replies
+    FROM comment c WHERE c.id = $1 AND c.post_id = $2
+), tombstone AS (
+    UPDATE comment SET is_deleted = TRUE, content = '', updated_at = NOW()
+    WHERE id IN (SELECT id FROM target WHERE has_replies) RETURNING id
+), removed AS (
+    DELETE FROM comment WHERE id IN (SELECT id FROM target WHERE NOT has_replies) RETURNING id
+)
+SELECT (SELECT COUNT(*) FROM tombstone) + (SELECT COUNT(*) FROM removed);`
+
+	addLikeToComment = `INSERT INTO reaction (comment_id, user_id)
+SELECT $1, $3
+WHERE EXISTS (SELECT 1 FROM comment WHERE id = $1 AND post_id = $2 AND NOT is_deleted);`
+	deleteLikeFromComment = `DELETE FROM reaction
+WHERE comment_id = $1 AND user_id = $3
+  AND EXISTS (SELECT 1 FROM comment WHERE id = $1 AND post_id = $2);`
+	getLikesOnComment = `SELECT COUNT(*) FROM reaction WHERE comment_id = $1;`
+	checkCommentLike  = `SELECT COUNT(*) FROM reaction WHERE comment_id = $1 AND user_id = $2;`
+)
+
+func (a *Adapter) CreateComment(ctx context.Context, comment *models.Comment) (uint32, error) {
+	var commentID uint32
+
+	if err := a.db.QueryRowContext(
+		ctx, createComment, comment.Header.AuthorID, comment.PostID, comment.Content.Text, comment.ParentID,
+	).Scan(&commentID, &comment.Content.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if comment.ParentID != 0 {
+				return 0, my_err.ErrCommentNotFound
+			}
+			return 0, my_err.ErrPostNotFound
+		}
+		return 0, fmt.Errorf("postgres create comment: %w", err)
+	}
+
+	return commentID, nil
+}
+
+func (a *Adapter) GetComments(ctx context.Context, postID, lastID uint32) ([]*models.Comment, error) {
+	rows, err := a.db.QueryContext(ctx, getComments, postID, lastID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, my_err.ErrNoMoreContent
+		}
+		return nil, fmt.Errorf("postgres get comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*models.Comment
+
+	for rows.Next() {
+		comment := &models.Comment{}
+		if err := rows.Scan(
+			&comment.ID, &comment.ParentID, &comment.Header.AuthorID, &comment.PostID, &comment.Content.Text,
+			&comment.IsDeleted, &comment.Content.CreatedAt, &comment.Content.UpdatedAt, &comment.Depth,
+		); err != nil {
+			return nil, fmt.Errorf("postgres scan comments: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	if len(comments) == 0 {
+		return nil, my_err.ErrNoMoreContent
+	}
+
+	return comments, nil
+}
+
+func (a *Adapter) UpdateComment(ctx context.Context, comment *models.Comment) error {
+	res, err := a.db.ExecContext(ctx, updateComment, comment.Content.Text, comment.ID, comment.PostID)
+	if err != nil {
+		return fmt.Errorf("postgres update comment: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("postgres update comment: %w", err)
+	}
+
+	if affected == 0 {
+		return my_err.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func (a *Adapter) DeleteComment(ctx context.Context, postID, commentID uint32) error {
+	var affected int64
+
+	if err := a.db.QueryRowContext(ctx, deleteComment, commentID, postID).Scan(&affected); err != nil {
+		return fmt.Errorf("postgres delete comment: %w", err)
+	}
+
+	if affected == 0 {
+		return my_err.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func (a *Adapter) GetCommentAuthor(ctx context.Context, postID, commentID uint32) (uint32, error) {
+	var authorID uint32
+
+	if err := a.db.QueryRowContext(ctx, getCommentAuthor, commentID, postID).Scan(&authorID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, my_err.ErrCommentNotFound
+		}
+		return 0, fmt.Errorf("postgres get comment author: %w", err)
+	}
+
+	return authorID, nil
+}
+
+func (a *Adapter) GetCommentCount(ctx context.Context, postID uint32) (uint32, error) {
+	var count uint32
+
+	if err := a.db.QueryRowContext(ctx, getCommentCount, postID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("postgres get comment count: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetCommentCounts returns the number of comments on every post of postIDs
+func (a *Adapter) GetCommentCounts(ctx context.Context, postIDs []uint32) (map[uint32]uint32, error) {
+	res := make(map[uint32]uint32, len(postIDs))
+	for _, id := range postIDs {
+		res[id] = 0
+	}
+
+	rows, err := a.db.QueryContext(ctx, getCommentCounts, convertSliceToString(postIDs))
+	if err != nil {
+		return nil, fmt.Errorf("postgres get comment counts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID, count uint32
+		if err := rows.Scan(&postID, &count); err != nil {
+			return nil, fmt.Errorf("postgres get comment counts: %w", err)
+		}
+		res[postID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres get comment counts: %w", err)
+	}
+
+	return res, nil
+}
+
+// SetLikeToComment likes the comment of the post, it returns ErrCommentNotFound if the post has no such comment
+func (a *Adapter) SetLikeToComment(ctx context.Context, postID, commentID, userID uint32) error {
+	res, err := a.db.ExecContext(ctx, addLikeToComment, commentID, postID, userID)
+	if err != nil {
+		return fmt.Errorf("postgres set like to comment: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("postgres set like to comment: %w", err)
+	}
+	if affected == 0 {
+		return my_err.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// DeleteLikeFromComment unlikes the comment of the post, it returns ErrCommentNotFound
+// if the post has no such comment or the comment is not liked
+func (a *Adapter) DeleteLikeFromComment(ctx context.Context, postID, commentID, userID uint32) error {
+	res, err := a.db.ExecContext(ctx, deleteLikeFromComment, commentID, postID, userID)
+	if err != nil {
+		return fmt.Errorf("postgres delete like from comment: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("postgres delete like from comment: %w", err)
+	}
+	if affected == 0 {
+		return my_err.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func (a *Adapter) GetLikesOnComment(ctx context.Context, commentID uint32) (uint32, error) {
+	var likes uint32
+
+	if err := a.db.QueryRowContext(ctx, getLikesOnComment, commentID).Scan(&likes); err != nil {
+		return 0, fmt.Errorf("postgres get likes on comment: %w", err)
+	}
+
+	return likes, nil
+}
+
+func (a *Adapter) CheckCommentLikes(ctx context.Context, commentID, userID uint32) (bool, error) {
+	var likes uint32
+
+	if err := a.db.QueryRowContext(ctx, checkCommentLike, commentID, userID).Scan(&likes); err != nil {
+		return false, fmt.Errorf("postgres check likes on comment %d: %w", commentID, err)
+	}
+
+	return likes != 0, nil
+}
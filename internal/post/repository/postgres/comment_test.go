@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+func TestCreateComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewAdapter(db)
+	createTime := time.Now()
+
+	tests := []struct {
+		comment *models.Comment
+		wantID  uint32
+		wantErr error
+		dbErr   error
+	}{
+		{comment: &models.Comment{PostID: 1, Header: models.Header{AuthorID: 1}, Content: models.Content{Text: "hi"}}, wantID: 1},
+		{comment: &models.Comment{PostID: 1, ParentID: 1, Header: models.Header{AuthorID: 1}, Content: models.Content{Text: "reply"}}, wantID: 2},
+		{comment: &models.Comment{PostID: 100, Header: models.Header{AuthorID: 1}}, wantErr: my_err.ErrPostNotFound, dbErr: sql.ErrNoRows},
+		{comment: &models.Comment{PostID: 1, ParentID: 100, Header: models.Header{AuthorID: 1}}, wantErr: my_err.ErrCommentNotFound, dbErr: sql.ErrNoRows},
+		{comment: &models.Comment{PostID: 1, Header: models.Header{AuthorID: 1}}, wantErr: errMockDB, dbErr: errMockDB},
+	}
+
+	for _, test := range tests {
+		mock.ExpectQuery(regexp.QuoteMeta(createComment)).
+			WithArgs(test.comment.Header.AuthorID, test.comment.PostID, test.comment.Content.Text, test.comment.ParentID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(test.wantID, createTime)).
+			WillReturnError(test.dbErr)
+
+		id, err := repo.CreateComment(context.Background(), test.comment)
+		assert.Equal(t, test.wantID, id)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("unexpected err:\n want:%v\n got:%v", test.wantErr, err)
+		}
+	}
+}
+
+func TestGetComments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewAdapter(db)
+	createTime := time.Now()
+	expect := []*models.Comment{
+		{ID: 2, PostID: 1, Header: models.Header{AuthorID: 1}, Content: models.Content{Text: "second", CreatedAt: createTime, UpdatedAt: createTime}},
+		{ID: 1, PostID: 1, IsDeleted: true, Content: models.Content{CreatedAt: createTime, UpdatedAt: createTime}},
+		{ID: 3, PostID: 1, ParentID: 1, Depth: 1, Header: models.Header{AuthorID: 2}, Content: models.Content{Text: "reply", CreatedAt: createTime, UpdatedAt: createTime}},
+		{ID: 4, PostID: 1, ParentID: 3, Depth: 2, Header: models.Header{AuthorID: 1}, Content: models.Content{Text: "reply to reply", CreatedAt: createTime, UpdatedAt: createTime}},
+	}
+
+	tests := []struct {
+		want    []*models.Comment
+		wantErr error
+		dbErr   error
+	}{
+		{want: nil, wantErr: my_err.ErrNoMoreContent, dbErr: sql.ErrNoRows},
+		{want: nil, wantErr: errMockDB, dbErr: errMockDB},
+		{want: nil, wantErr: my_err.ErrNoMoreContent},
+		{want: expect},
+	}
+
+	for _, test := range tests {
+		rows := sqlmock.NewRows([]string{"id", "parent_id", "user_id", "post_id", "content", "is_deleted", "created_at", "updated_at", "depth"})
+		for _, c := range test.want {
+			rows.AddRow(
+				c.ID, c.ParentID, c.Header.AuthorID, c.PostID, c.Content.Text, c.IsDeleted,
+				c.Content.CreatedAt, c.Content.UpdatedAt, c.Depth,
+			)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(getComments)).
+			WithArgs(uint32(1), uint32(10)).
+			WillReturnRows(rows).
+			WillReturnError(test.dbErr)
+
+		comments, err := repo.GetComments(context.Background(), 1, 10)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("unexpected error: got:%v\nwant:%v\n", err, test.wantErr)
+		}
+		assert.Equal(t, test.want, comments)
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewAdapter(db)
+
+	tests := []struct {
+		affected int64
+		wantErr  error
+		dbErr    error
+	}{
+		{affected: 1},
+		{affected: 0, wantErr: my_err.ErrCommentNotFound},
+		{wantErr: errMockDB, dbErr: errMockDB},
+	}
+
+	for _, test := range tests {
+		mock.ExpectQuery(regexp.QuoteMeta(deleteComment)).
+			WithArgs(uint32(2), uint32(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(test.affected)).
+			WillReturnError(test.dbErr)
+
+		err := repo.DeleteComment(context.Background(), 1, 2)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("unexpected error: got:%v\nwant:%v\n", err, test.wantErr)
+		}
+	}
+}
+
+func TestCommentLikes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewAdapter(db)
+
+	tests := []struct {
+		affected int64
+		wantErr  error
+		dbErr    error
+	}{
+		{affected: 1},
+		// the comment is not on the post from the route
+		{affected: 0, wantErr: my_err.ErrCommentNotFound},
+		{wantErr: errMockDB, dbErr: errMockDB},
+	}
+
+	for _, test := range tests {
+		mock.ExpectExec(regexp.QuoteMeta(addLikeToComment)).
+			WithArgs(uint32(2), uint32(1), uint32(3)).
+			WillReturnResult(sqlmock.NewResult(0, test.affected)).
+			WillReturnError(test.dbErr)
+		err := repo.SetLikeToComment(context.Background(), 1, 2, 3)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("unexpected error: got:%v\nwant:%v\n", err, test.wantErr)
+		}
+
+		mock.ExpectExec(regexp.QuoteMeta(deleteLikeFromComment)).
+			WithArgs(uint32(2), uint32(1), uint32(3)).
+			WillReturnResult(sqlmock.NewResult(0, test.affected)).
+			WillReturnError(test.dbErr)
+		err = repo.DeleteLikeFromComment(context.Background(), 1, 2, 3)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("unexpected error: got:%v\nwant:%v\n", err, test.wantErr)
+		}
+	}
+}
+
+func TestGetCommentAuthor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewAdapter(db)
+
+	tests := []struct {
+		want    uint32
+		wantErr error
+		dbErr   error
+	}{
+		{want: 3},
+		{wantErr: my_err.ErrCommentNotFound, dbErr: sql.ErrNoRows},
+		{wantErr: errMockDB, dbErr: errMockDB},
+	}
+
+	for _, test := range tests {
+		mock.ExpectQuery(regexp.QuoteMeta(getCommentAuthor)).
+			WithArgs(uint32(2), uint32(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(test.want)).
+			WillReturnError(test.dbErr)
+
+		id, err := repo.GetCommentAuthor(context.Background(), 1, 2)
+		assert.Equal(t, test.want, id)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("unexpected error: got:%v\nwant:%v\n", err, test.wantErr)
+		}
+	}
+}
+
+func TestGetCommentCounts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewAdapter(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(getCommentCounts)).
+		WithArgs("{1, 2}").
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "count"}).AddRow(2, 4))
+
+	res, err := repo.GetCommentCounts(context.Background(), []uint32{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint32{1: 0, 2: 4}, res)
+
+	mock.ExpectQuery(regexp.QuoteMeta(getCommentCounts)).
+		WithArgs("{1, 2}").
+		WillReturnError(errMockDB)
+
+	_, err = repo.GetCommentCounts(context.Background(), []uint32{1, 2})
+	assert.ErrorIs(t, err, errMockDB)
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+const feedLimit = 10
+
+// getFeed ranks the posts of the friends ($2), of the communities the user ($1) joined and the most liked posts
+// of the last week. Everything is counted as it was at $3, so the score of a post does not change between pages.
+// The score grows with likes, comments and affinity to the author: the messages with them and the likes given
+// to their posts earlier, and falls with the age of the post. If there is nothing of it, as for a new user
+// without friends, the feed falls back to the latest posts
+const getFeed = `WITH joined AS (
+	SELECT community_id FROM community_profile WHERE profile_id = $1
+), popular AS (
+	SELECT r.post_id FROM reaction r
+	WHERE r.post_id IS NOT NULL AND r.created_at <= $3::timestamptz AND r.created_at > $3::timestamptz - interval '7 days'
+	GROUP BY r.post_id
+	ORDER BY count(*) DESC
+	LIMIT 100
+), chosen AS (
+	SELECT p.id, COALESCE(p.author_id, 0) AS author_id, COALESCE(p.community_id, 0) AS community_id,
+		p.content, p.file_path, p.created_at,
+		CASE
+			WHEN p.author_id = ANY($2::int[]) THEN 1.0
+			WHEN p.community_id IN (SELECT community_id FROM joined) THEN 0.8
+			ELSE 0.5
+		END AS source
+	FROM post p
+	WHERE p.created_at <= $3::timestamptz AND p.created_at > $3::timestamptz - interval '30 days'
+		AND p.author_id IS DISTINCT FROM $1
+		AND (p.author_id = ANY($2::int[])
+			OR p.community_id IN (SELECT community_id FROM joined)
+			OR p.id IN (SELECT post_id FROM popular))
+), latest AS (
+	SELECT p.id, COALESCE(p.author_id, 0) AS author_id, COALESCE(p.community_id, 0) AS community_id,
+		p.content, p.file_path, p.created_at, 0.5 AS source
+	FROM post p
+	WHERE p.created_at <= $3::timestamptz AND p.author_id IS DISTINCT FROM $1 AND NOT EXISTS (SELECT 1 FROM chosen)
+	ORDER BY p.created_at DESC, p.id DESC
+	LIMIT 100
+), candidate AS (
+	SELECT * FROM chosen
+	UNION ALL
+	SELECT * FROM latest
+), counted AS (
+	SELECT c.*,
+		(SELECT count(*) FROM reaction r WHERE r.post_id = c.id AND r.created_at <= $3::timestamptz) AS likes,
+		(SELECT count(*) FROM comment cm
+			WHERE cm.post_id = c.id AND NOT cm.is_deleted AND cm.created_at <= $3::timestamptz
+		) AS comments,
+		(SELECT count(*) FROM message m
+			WHERE m.group_id IS NULL AND m.created_at <= $3::timestamptz AND m.created_at > $3::timestamptz - interval '30 days'
+				AND ((m.sender = $1 AND m.receiver = c.author_id) OR (m.sender = c.author_id AND m.receiver = $1))
+		) AS messages,
+		(SELECT count(*) FROM reaction r JOIN post lp ON lp.id = r.post_id
+			WHERE r.user_id = $1 AND r.created_at <= $3::timestamptz AND lp.id <> c.id
+				AND CASE WHEN c.community_id = 0 THEN lp.community_id IS NULL AND lp.author_id = c.author_id
+					ELSE lp.community_id = c.community_id END
+		) AS liked
+	FROM candidate c
+), ranked AS (
+	SELECT id, author_id, community_id, content, file_path, created_at,
+		(source * (1 + ln(1 + likes) + 0.5 * ln(1 + comments)) * (1 + 0.3 * ln(1 + messages) + 0.3 * ln(1 + liked))
+			/ power(extract(epoch FROM $3::timestamptz - created_at) / 3600 + 2, 1.5))::real AS score
+	FROM counted
+)
+SELECT id, author_id, community_id, content, file_path, created_at, score
+FROM ranked
+WHERE $5 = 0 OR (score, id) < ($4::real, $5)
+ORDER BY score DESC, id DESC
+LIMIT $6;`
+
+// getJoinedCommunitiesPosts returns the posts of the communities the user ($1) joined, which were there at $2
+const getJoinedCommunitiesPosts = `SELECT id, community_id, content, file_path, created_at FROM post
+WHERE community_id IN (SELECT community_id FROM community_profile WHERE profile_id = $1)
+	AND created_at <= $2 AND ($3 = 0 OR id < $3)
+ORDER BY id DESC
+LIMIT $4;`
+
+// GetFeed returns a page of the ranked feed, the cursor of the page is set if there are more
+func (a *Adapter) GetFeed(ctx context.Context, feed *models.Feed) (*models.PostPage, error) {
+	rows, err := a.db.QueryContext(
+		ctx, getFeed, feed.UserID, convertSliceToString(feed.FriendIDs), feed.Cursor.AsOf,
+		feed.Cursor.Score, feed.Cursor.ID, feedLimit+1,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("postgres get feed: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		page = &models.PostPage{}
+		last = models.FeedCursor{AsOf: feed.Cursor.AsOf}
+	)
+	for rows.Next() {
+		var (
+			post  = &models.Post{}
+			score float32
+		)
+		if err := rows.Scan(
+			&post.ID, &post.Header.AuthorID, &post.Header.CommunityID, &post.PostContent.Text,
+			&post.PostContent.File, &post.PostContent.CreatedAt, &score,
+		); err != nil {
+			return nil, fmt.Errorf("postgres get feed: %w", err)
+		}
+
+		if len(page.Posts) == feedLimit {
+			page.Cursor = last.String()
+			break
+		}
+		page.Posts = append(page.Posts, post)
+		last.Score, last.ID = score, post.ID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres get feed: %w", err)
+	}
+
+	if len(page.Posts) == 0 {
+		return nil, my_err.ErrNoMoreContent
+	}
+
+	return page, nil
+}
+
+// GetJoinedCommunitiesPosts returns a page of the posts of the communities userID joined, the newest go first.
+// The cursor of the page is set if there are more
+func (a *Adapter) GetJoinedCommunitiesPosts(
+	ctx context.Context, userID uint32, cursor models.FeedCursor,
+) (*models.PostPage, error) {
+	rows, err := a.db.QueryContext(ctx, getJoinedCommunitiesPosts, userID, cursor.AsOf, cursor.ID, feedLimit+1)
+	if err != nil {
+		return nil, fmt.Errorf("postgres get joined communities posts: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		page = &models.PostPage{}
+		last = models.FeedCursor{AsOf: cursor.AsOf}
+	)
+	for rows.Next() {
+		post := &models.Post{}
+		if err := rows.Scan(
+			&post.ID, &post.Header.CommunityID, &post.PostContent.Text, &post.PostContent.File,
+			&post.PostContent.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("postgres get joined communities posts: %w", err)
+		}
+
+		if len(page.Posts) == feedLimit {
+			page.Cursor = last.String()
+			break
+		}
+		page.Posts = append(page.Posts, post)
+		last.ID = post.ID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres get joined communities posts: %w", err)
+	}
+
+	if len(page.Posts) == 0 {
+		return nil, my_err.ErrNoMoreContent
+	}
+
+	return page, nil
+}
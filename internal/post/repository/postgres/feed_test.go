@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+func TestGetFeed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAdapter(db)
+	createTime := time.Now()
+	asOf := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "author_id", "community_id", "content", "file_path", "created_at", "score"}
+	feed := &models.Feed{
+		UserID: 1, FriendIDs: []uint32{2, 3}, Cursor: models.FeedCursor{AsOf: asOf, Score: 0.5, ID: 100},
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(getFeed)).
+		WithArgs(1, "{2, 3}", asOf, 0.5, 100, feedLimit+1).
+		WillReturnError(errMockDB)
+	_, err = repo.GetFeed(context.Background(), feed)
+	assert.ErrorIs(t, err, errMockDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(getFeed)).
+		WillReturnRows(sqlmock.NewRows(columns))
+	_, err = repo.GetFeed(context.Background(), feed)
+	assert.ErrorIs(t, err, my_err.ErrNoMoreContent)
+
+	rows := sqlmock.NewRows(columns)
+	for id := 20; id > 20-feedLimit-1; id-- {
+		rows.AddRow(id, 2, 0, "post", "", createTime, float32(id)/100)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(getFeed)).WillReturnRows(rows)
+	page, err := repo.GetFeed(context.Background(), feed)
+	require.NoError(t, err)
+	require.Len(t, page.Posts, feedLimit)
+	assert.Equal(t, &models.Post{
+		ID:          20,
+		Header:      models.Header{AuthorID: 2},
+		PostContent: models.Content{Text: "post", CreatedAt: createTime},
+	}, page.Posts[0])
+
+	cursor, err := models.ParseFeedCursor(page.Cursor)
+	require.NoError(t, err)
+	assert.Equal(t, models.FeedCursor{AsOf: asOf, Score: float32(11) / 100, ID: 11}, cursor)
+
+	rows = sqlmock.NewRows(columns).AddRow(1, 0, 4, "post", "", createTime, float32(0.1))
+	mock.ExpectQuery(regexp.QuoteMeta(getFeed)).WillReturnRows(rows)
+	page, err = repo.GetFeed(context.Background(), feed)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4), page.Posts[0].Header.CommunityID)
+	assert.Empty(t, page.Cursor)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetJoinedCommunitiesPosts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAdapter(db)
+	createTime := time.Now()
+	asOf := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "community_id", "content", "file_path", "created_at"}
+	cursor := models.FeedCursor{AsOf: asOf, ID: 100}
+
+	mock.ExpectQuery(regexp.QuoteMeta(getJoinedCommunitiesPosts)).
+		WithArgs(1, asOf, 100, feedLimit+1).
+		WillReturnError(errMockDB)
+	_, err = repo.GetJoinedCommunitiesPosts(context.Background(), 1, cursor)
+	assert.ErrorIs(t, err, errMockDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(getJoinedCommunitiesPosts)).
+		WillReturnRows(sqlmock.NewRows(columns))
+	_, err = repo.GetJoinedCommunitiesPosts(context.Background(), 1, cursor)
+	assert.ErrorIs(t, err, my_err.ErrNoMoreContent)
+
+	rows := sqlmock.NewRows(columns)
+	for id := 20; id > 20-feedLimit-1; id-- {
+		rows.AddRow(id, id%3+1, "post", "", createTime)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(getJoinedCommunitiesPosts)).WillReturnRows(rows)
+	page, err := repo.GetJoinedCommunitiesPosts(context.Background(), 1, cursor)
+	require.NoError(t, err)
+	require.Len(t, page.Posts, feedLimit)
+	assert.Equal(t, &models.Post{
+		ID:          20,
+		Header:      models.Header{CommunityID: 3},
+		PostContent: models.Content{Text: "post", CreatedAt: createTime},
+	}, page.Posts[0])
+
+	next, err := models.ParseFeedCursor(page.Cursor)
+	require.NoError(t, err)
+	assert.Equal(t, models.FeedCursor{AsOf: asOf, ID: 11}, next)
+
+	rows = sqlmock.NewRows(columns).AddRow(1, 4, "post", "", createTime)
+	mock.ExpectQuery(regexp.QuoteMeta(getJoinedCommunitiesPosts)).WillReturnRows(rows)
+	page, err = repo.GetJoinedCommunitiesPosts(context.Background(), 1, cursor)
+	require.NoError(t, err)
+	assert.Len(t, page.Posts, 1)
+	assert.Empty(t, page.Cursor)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -24,10 +24,12 @@ const (
 	createCommunityPost = `INSERT INTO post (community_id, content, file_path) VALUES ($1, $2, $3) RETURNING id;`
 	getCommunityPosts   = `SELECT id, community_id, content, file_path, created_at FROM post WHERE community_id = $1 AND id < $2 ORDER BY id DESC LIMIT 10;`
 
-	AddLikeToPost      = `INSERT INTO reaction (post_id, user_id) VALUES ($1, $2);`
-	DeleteLikeFromPost = `DELETE FROM reaction WHERE post_id = $1 AND user_id = $2;`
-	GetLikesOnPost     = `SELECT COUNT(*) FROM reaction WHERE post_id = $1;`
-	CheckLike          = `SELECT COUNT(*) FROM reaction WHERE post_id = $1 AND user_id=$2;`
+	SetReactionToPost      = `INSERT INTO reaction (post_id, user_id, type) SELECT $1, $2, $3 WHERE EXISTS (SELECT 1 FROM post WHERE id = $1) ON CONFLICT (post_id, user_id) DO UPDATE SET type = EXCLUDED.type, updated_at = NOW();`
+	DeleteReactionFromPost = `DELETE FROM reaction WHERE post_id = $1 AND user_id = $2;`
+	GetReactionsOnPost     = `SELECT type, COUNT(*), BOOL_OR(user_id = $2) FROM reaction WHERE post_id = $1 GROUP BY type;`
+	GetReactionsOnPosts    = `SELECT post_id, type, COUNT(*), BOOL_OR(user_id = $2) FROM reaction WHERE post_id = ANY($1::int[]) GROUP BY post_id, type;`
+	CheckLike              = `SELECT COUNT(*) FROM reaction WHERE post_id = $1 AND user_id=$2;`
+	GetReactionAuthors     = `SELECT user_id FROM reaction WHERE post_id = $1 AND user_id < $2 ORDER BY user_id DESC LIMIT 20;`
 )
 
 type Adapter struct {
@@ -253,35 +255,101 @@ func (a *Adapter) GetCommunityPosts(ctx context.Context, communityID, id uint32)
 	return posts, nil
 }
 
-func (a *Adapter) SetLikeToPost(ctx context.Context, postID uint32, userID uint32) error {
-	res, err := a.db.ExecContext(ctx, AddLikeToPost, postID, userID)
-	if num, err := res.RowsAffected(); err == nil && num == 0 {
-		return my_err.ErrLikeAlreadyExists
+// SetReactionToPost sets or replaces the reaction of the user, it returns ErrPostNotFound if there is no such post
+func (a *Adapter) SetReactionToPost(ctx context.Context, postID, userID uint32, reaction models.ReactionType) error {
+	res, err := a.db.ExecContext(ctx, SetReactionToPost, postID, userID, reaction)
+	if err != nil {
+		return fmt.Errorf("postgres set reaction to post: %w", err)
 	}
+
+	affected, err := res.RowsAffected()
 	if err != nil {
-		return err
+		return fmt.Errorf("postgres set reaction to post: %w", err)
+	}
+	if affected == 0 {
+		return my_err.ErrPostNotFound
 	}
+
 	return nil
 }
 
-func (a *Adapter) DeleteLikeFromPost(ctx context.Context, postID uint32, userID uint32) error {
-	_, err := a.db.ExecContext(ctx, DeleteLikeFromPost, postID, userID)
+func (a *Adapter) DeleteReactionFromPost(ctx context.Context, postID, userID uint32) error {
+	_, err := a.db.ExecContext(ctx, DeleteReactionFromPost, postID, userID)
 	if err != nil {
-		return err
+		return fmt.Errorf("postgres delete reaction from post: %w", err)
 	}
+
 	return nil
 }
 
-func (a *Adapter) GetLikesOnPost(ctx context.Context, postID uint32) (uint32, error) {
-	var likes uint32
-	err := a.db.QueryRowContext(ctx, GetLikesOnPost, postID).Scan(&likes)
+func (a *Adapter) GetReactionsOnPost(ctx context.Context, postID, userID uint32) (models.Reactions, error) {
+	res := models.NewReactions()
+
+	rows, err := a.db.QueryContext(ctx, GetReactionsOnPost, postID, userID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return 0, my_err.ErrWrongPost
+		return res, fmt.Errorf("postgres get reactions on post: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			reaction models.ReactionType
+			count    uint32
+			my       bool
+		)
+		if err := rows.Scan(&reaction, &count, &my); err != nil {
+			return res, fmt.Errorf("postgres scan reactions on post: %w", err)
+		}
+		res.Counts[reaction] = count
+		if my {
+			res.My = reaction
 		}
-		return 0, err
 	}
-	return likes, nil
+
+	return res, nil
+}
+
+// GetReactionsOnPosts returns the reactions on every post of postIDs as seen by userID
+func (a *Adapter) GetReactionsOnPosts(ctx context.Context, postIDs []uint32, userID uint32) (
+	map[uint32]models.Reactions, error,
+) {
+	res := make(map[uint32]models.Reactions, len(postIDs))
+	for _, id := range postIDs {
+		res[id] = models.NewReactions()
+	}
+
+	rows, err := a.db.QueryContext(ctx, GetReactionsOnPosts, convertSliceToString(postIDs), userID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres get reactions on posts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			postID   uint32
+			reaction models.ReactionType
+			count    uint32
+			my       bool
+		)
+		if err := rows.Scan(&postID, &reaction, &count, &my); err != nil {
+			return nil, fmt.Errorf("postgres scan reactions on posts: %w", err)
+		}
+
+		reactions, ok := res[postID]
+		if !ok {
+			continue
+		}
+		reactions.Counts[reaction] = count
+		if my {
+			reactions.My = reaction
+		}
+		res[postID] = reactions
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres get reactions on posts: %w", err)
+	}
+
+	return res, nil
 }
 
 func (a *Adapter) CheckLikes(ctx context.Context, postID, userID uint32) (bool, error) {
@@ -297,3 +365,26 @@ func (a *Adapter) CheckLikes(ctx context.Context, postID, userID uint32) (bool,
 
 	return true, nil
 }
+
+func (a *Adapter) GetReactionAuthors(ctx context.Context, postID, lastID uint32) ([]uint32, error) {
+	rows, err := a.db.QueryContext(ctx, GetReactionAuthors, postID, lastID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres get reaction authors: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uint32
+	for rows.Next() {
+		var id uint32
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("postgres get reaction authors: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return nil, my_err.ErrNoMoreContent
+	}
+
+	return ids, nil
+}
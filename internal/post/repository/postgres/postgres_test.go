@@ -430,3 +430,141 @@ func TestGetFriendsPosts(t *testing.T) {
 		assert.Equalf(t, posts, test.wantPost, "result dont match\nwant: %v\ngot:%v", test.wantPost, posts)
 	}
 }
+
+func TestSetReactionToPost(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewAdapter(db)
+
+	tests := []struct {
+		affected int64
+		wantErr  error
+		dbErr    error
+	}{
+		{affected: 1},
+		{affected: 0, wantErr: my_err.ErrPostNotFound},
+		{wantErr: errMockDB, dbErr: errMockDB},
+	}
+
+	for _, test := range tests {
+		mock.ExpectExec(regexp.QuoteMeta(SetReactionToPost)).
+			WithArgs(uint32(1), uint32(2), models.ReactionLove).
+			WillReturnResult(sqlmock.NewResult(0, test.affected)).
+			WillReturnError(test.dbErr)
+
+		err := repo.SetReactionToPost(context.Background(), 1, 2, models.ReactionLove)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("unexpected error: got:%v\nwant:%v\n", err, test.wantErr)
+		}
+	}
+}
+
+func TestGetReactionsOnPost(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewAdapter(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetReactionsOnPost)).
+		WithArgs(uint32(1), uint32(2)).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"type", "count", "my"}).
+				AddRow("like", 3, false).
+				AddRow("love", 1, true),
+		)
+
+	res, err := repo.GetReactionsOnPost(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Reactions{
+		Counts: map[models.ReactionType]uint32{models.ReactionLike: 3, models.ReactionLove: 1},
+		My:     models.ReactionLove,
+	}, res)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetReactionsOnPost)).
+		WithArgs(uint32(1), uint32(2)).
+		WillReturnError(errMockDB)
+
+	_, err = repo.GetReactionsOnPost(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, errMockDB)
+}
+
+func TestGetReactionsOnPosts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewAdapter(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetReactionsOnPosts)).
+		WithArgs("{1, 2, 3}", uint32(5)).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"post_id", "type", "count", "my"}).
+				AddRow(1, "like", 3, false).
+				AddRow(1, "love", 1, true).
+				AddRow(3, "sad", 2, false),
+		)
+
+	res, err := repo.GetReactionsOnPosts(context.Background(), []uint32{1, 2, 3}, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]models.Reactions{
+		1: {
+			Counts: map[models.ReactionType]uint32{models.ReactionLike: 3, models.ReactionLove: 1},
+			My:     models.ReactionLove,
+		},
+		2: models.NewReactions(),
+		3: {Counts: map[models.ReactionType]uint32{models.ReactionSad: 2}},
+	}, res)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetReactionsOnPosts)).
+		WithArgs("{1}", uint32(5)).
+		WillReturnError(errMockDB)
+
+	_, err = repo.GetReactionsOnPosts(context.Background(), []uint32{1}, 5)
+	assert.ErrorIs(t, err, errMockDB)
+}
+
+func TestGetReactionAuthors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewAdapter(db)
+
+	tests := []struct {
+		want    []uint32
+		wantErr error
+		dbErr   error
+	}{
+		{want: []uint32{5, 3}},
+		{wantErr: my_err.ErrNoMoreContent},
+		{wantErr: errMockDB, dbErr: errMockDB},
+	}
+
+	for _, test := range tests {
+		rows := sqlmock.NewRows([]string{"user_id"})
+		for _, id := range test.want {
+			rows.AddRow(id)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(GetReactionAuthors)).
+			WithArgs(uint32(1), uint32(10)).
+			WillReturnRows(rows).
+			WillReturnError(test.dbErr)
+
+		ids, err := repo.GetReactionAuthors(context.Background(), 1, 10)
+		assert.Equal(t, test.want, ids)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("unexpected error: got:%v\nwant:%v\n", err, test.wantErr)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+const searchLimit = 10
+
+// searchPosts matches the query with both russian and english stemming. The page is selected first,
+// so snippets are built for its posts only. The text is escaped before the found words are marked
+const searchPosts = `WITH q AS (
+	SELECT websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1) AS query
+), page AS (
+	SELECT p.id, COALESCE(p.author_id, 0) AS author_id, COALESCE(p.community_id, 0) AS community_id,
+		p.content, p.file_path, p.created_at, ts_rank(p.search, q.query) AS rank, q.query
+	FROM post p, q
+	WHERE p.search @@ q.query
+		AND ($2 = 0 OR p.community_id = $2)
+		AND (cardinality($3::int[]) = 0 OR p.author_id = ANY($3::int[]))
+		AND ($4 = 0 OR p.community_id IN (SELECT community_id FROM community_profile WHERE profile_id = $4))
+		AND ($6 = 0 OR (ts_rank(p.search, q.query), p.id) < ($5::real, $6))
+	ORDER BY rank DESC, p.id DESC
+	LIMIT $7
+)
+SELECT id, author_id, community_id, content, file_path, created_at, rank,
+	ts_headline('russian', replace(replace(replace(content, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), query,
+		'StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2')
+FROM page
+ORDER BY rank DESC, id DESC;`
+
+// SearchPosts returns a page of the posts matching the search, the cursor of the page is set if there are more
+func (a *Adapter) SearchPosts(ctx context.Context, search *models.PostSearch) (*models.PostPage, error) {
+	rows, err := a.db.QueryContext(
+		ctx, searchPosts, search.Query, search.CommunityID, convertSliceToString(search.AuthorIDs), search.MemberID,
+		search.Cursor.Rank, search.Cursor.ID, searchLimit+1,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("postgres search posts: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		page = &models.PostPage{}
+		last models.SearchCursor
+	)
+	for rows.Next() {
+		var (
+			post = &models.Post{}
+			rank float32
+		)
+		if err := rows.Scan(
+			&post.ID, &post.Header.AuthorID, &post.Header.CommunityID, &post.PostContent.Text,
+			&post.PostContent.File, &post.PostContent.CreatedAt, &rank, &post.Snippet,
+		); err != nil {
+			return nil, fmt.Errorf("postgres search posts: %w", err)
+		}
+
+		if len(page.Posts) == searchLimit {
+			page.Cursor = last.String()
+			break
+		}
+		page.Posts = append(page.Posts, post)
+		last = models.SearchCursor{Rank: rank, ID: post.ID}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres search posts: %w", err)
+	}
+
+	if len(page.Posts) == 0 {
+		return nil, my_err.ErrNoMoreContent
+	}
+
+	return page, nil
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+func TestSearchPosts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAdapter(db)
+	createTime := time.Now()
+	columns := []string{"id", "author_id", "community_id", "content", "file_path", "created_at", "rank", "snippet"}
+	search := &models.PostSearch{
+		Query: "cats", AuthorIDs: []uint32{2, 3}, Cursor: models.SearchCursor{Rank: 0.5, ID: 100},
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(searchPosts)).
+		WithArgs("cats", 0, "{2, 3}", 0, 0.5, 100, searchLimit+1).
+		WillReturnError(errMockDB)
+	_, err = repo.SearchPosts(context.Background(), search)
+	assert.ErrorIs(t, err, errMockDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta(searchPosts)).
+		WillReturnRows(sqlmock.NewRows(columns))
+	_, err = repo.SearchPosts(context.Background(), search)
+	assert.ErrorIs(t, err, my_err.ErrNoMoreContent)
+
+	rows := sqlmock.NewRows(columns)
+	for id := 20; id > 20-searchLimit-1; id-- {
+		rows.AddRow(id, 2, 0, "cats", "", createTime, float32(id)/100, "<mark>cats</mark>")
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(searchPosts)).WillReturnRows(rows)
+	page, err := repo.SearchPosts(context.Background(), search)
+	require.NoError(t, err)
+	require.Len(t, page.Posts, searchLimit)
+	assert.Equal(t, &models.Post{
+		ID:          20,
+		Header:      models.Header{AuthorID: 2},
+		PostContent: models.Content{Text: "cats", CreatedAt: createTime},
+		Snippet:     "<mark>cats</mark>",
+	}, page.Posts[0])
+
+	cursor, err := models.ParseSearchCursor(page.Cursor)
+	require.NoError(t, err)
+	assert.Equal(t, models.SearchCursor{Rank: float32(11) / 100, ID: 11}, cursor)
+
+	rows = sqlmock.NewRows(columns).AddRow(1, 0, 4, "cats", "", createTime, float32(0.1), "<mark>cats</mark>")
+	mock.ExpectQuery(regexp.QuoteMeta(searchPosts)).WillReturnRows(rows)
+	page, err = repo.SearchPosts(context.Background(), search)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4), page.Posts[0].Header.CommunityID)
+	assert.Empty(t, page.Cursor)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,421 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+const (
+	// PageTTL bounds the life of a page which missed an invalidation, e.g. because redis was not available
+	PageTTL = 30 * time.Second
+	// HeaderTTL bounds the life of the headers, the names and the avatars are changed by the other services
+	HeaderTTL = 5 * time.Minute
+
+	// lockTTL frees the lock of a loader which is gone, lockWait is how long the other requests wait
+	// for the page being loaded before loading it themselves
+	lockTTL      = 2 * time.Second
+	lockWait     = 500 * time.Millisecond
+	pollInterval = 20 * time.Millisecond
+
+	pageCache   = "page"
+	headerCache = "header"
+
+	generationKey   = "posts:generation"
+	invalidationKey = "posts:invalidation"
+)
+
+// invalidate deletes the pages listed in the set KEYS[1] and the set itself at once,
+// so a page added to the set meanwhile is not lost. The post is stamped in KEYS[2] with the next
+// number of the invalidation KEYS[3] for ARGV[1] ms, a page loaded before it is not cached then
+var invalidate = redis.NewScript(3, `
+local invalidation = redis.call('INCR', KEYS[3])
+redis.call('SET', KEYS[2], invalidation, 'PX', ARGV[1])
+local pages = redis.call('SMEMBERS', KEYS[1])
+for i = 1, #pages, 500 do
+	redis.call('DEL', unpack(pages, i, math.min(i + 499, #pages)))
+end
+return redis.call('DEL', KEYS[1])
+`)
+
+// setPage caches the page ARGV[1] in KEYS[1] for ARGV[2] ms and adds it to the sets of pages of its posts,
+// the stamps and the sets of the posts go in pairs after it. The page is not cached if any of its posts
+// was invalidated after the invalidation ARGV[3] seen before the load
+var setPage = redis.NewScript(-1, `
+for i = 2, #KEYS, 2 do
+	if tonumber(redis.call('GET', KEYS[i]) or '0') > tonumber(ARGV[3]) then
+		return 0
+	end
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+for i = 3, #KEYS, 2 do
+	redis.call('SADD', KEYS[i], KEYS[1])
+	redis.call('PEXPIRE', KEYS[i], ARGV[2])
+end
+return 1
+`)
+
+// unlock deletes the lock KEYS[1] only if it is still held with the token ARGV[1]
+var unlock = redis.NewScript(1, `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+type cacheMetrics interface {
+	Hit(cache string, count int)
+	Miss(cache string, count int)
+}
+
+// Cache keeps the pages of posts as seen by a user and the headers of the posts.
+// Every post has the set post:pages:<id> of the pages it is on, the writes to a post delete them.
+// A page is not cached if one of its posts was invalidated while the page was loaded.
+// The first pages are also keyed with the generation of the posts, a new post bumps it:
+// the next pages are not affected as they end before the moment the first page was loaded
+type Cache struct {
+	db      *redis.Pool
+	metrics cacheMetrics
+}
+
+func NewCache(db *redis.Pool, metrics cacheMetrics) *Cache {
+	return &Cache{
+		db:      db,
+		metrics: metrics,
+	}
+}
+
+func postPagesKey(postID uint32) string {
+	return "post:pages:" + strconv.FormatUint(uint64(postID), 10)
+}
+
+func postInvalidationKey(postID uint32) string {
+	return "post:invalidation:" + strconv.FormatUint(uint64(postID), 10)
+}
+
+func headerKey(kind string, id uint32) string {
+	return "header:" + kind + ":" + strconv.FormatUint(uint64(id), 10)
+}
+
+func lockKey(key string) string {
+	return "lock:" + key
+}
+
+// GetPage returns the cached page of the section for userID, the page is loaded and cached on a miss.
+// Only one request loads a missing page, the others wait for it. The cache is skipped if redis is not available
+func (c *Cache) GetPage(
+	ctx context.Context, section string, userID uint32, cursor string,
+	load func(ctx context.Context) (*models.PostPage, error),
+) (*models.PostPage, error) {
+	key, err := c.pageKey(ctx, section, userID, cursor)
+	if err != nil {
+		return load(ctx)
+	}
+
+	page, err := c.getPage(ctx, key)
+	if err == nil {
+		c.metrics.Hit(pageCache, 1)
+		return page, nil
+	}
+	c.metrics.Miss(pageCache, 1)
+	if !errors.Is(err, redis.ErrNil) {
+		return load(ctx)
+	}
+
+	token := uuid.NewString()
+	locked, err := c.lock(ctx, key, token)
+	if err != nil {
+		return load(ctx)
+	}
+	if !locked {
+		if page, ok := c.waitPage(ctx, key); ok {
+			return page, nil
+		}
+		return load(ctx)
+	}
+	defer c.unlock(key, token)
+
+	seen, err := c.lastInvalidation(ctx)
+	if err != nil {
+		return load(ctx)
+	}
+	page, err = load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the page is loaded anyway, the next request will try to cache it again
+	_ = c.setPage(ctx, key, page, seen)
+
+	return page, nil
+}
+
+func (c *Cache) pageKey(ctx context.Context, section string, userID uint32, cursor string) (string, error) {
+	if cursor == "" {
+		conn, err := c.db.GetContext(ctx)
+		if err != nil {
+			return "", fmt.Errorf("get generation: %w", err)
+		}
+		defer conn.Close()
+
+		generation, err := redis.Int64(conn.Do("GET", generationKey))
+		if err != nil && !errors.Is(err, redis.ErrNil) {
+			return "", fmt.Errorf("get generation: %w", err)
+		}
+		cursor = "first:" + strconv.FormatInt(generation, 10)
+	}
+
+	return fmt.Sprintf("posts:page:%s:%d:%s", section, userID, cursor), nil
+}
+
+func (c *Cache) getPage(ctx context.Context, key string) (*models.PostPage, error) {
+	conn, err := c.db.GetContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get page: %w", err)
+	}
+	defer conn.Close()
+
+	data, err := redis.Bytes(conn.Do("GET", key))
+	if err != nil {
+		return nil, fmt.Errorf("get page: %w", err)
+	}
+
+	page := &models.PostPage{}
+	if err := json.Unmarshal(data, page); err != nil {
+		return nil, fmt.Errorf("get page: %w", err)
+	}
+
+	return page, nil
+}
+
+// lastInvalidation returns the number of the last invalidation of a post
+func (c *Cache) lastInvalidation(ctx context.Context) (int64, error) {
+	conn, err := c.db.GetContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get invalidation: %w", err)
+	}
+	defer conn.Close()
+
+	invalidation, err := redis.Int64(conn.Do("GET", invalidationKey))
+	if err != nil && !errors.Is(err, redis.ErrNil) {
+		return 0, fmt.Errorf("get invalidation: %w", err)
+	}
+
+	return invalidation, nil
+}
+
+// setPage caches the page unless one of its posts was invalidated after the invalidation seen before the load,
+// the page could miss the write then
+func (c *Cache) setPage(ctx context.Context, key string, page *models.PostPage, seen int64) error {
+	data, err := json.Marshal(page)
+	if err != nil {
+		return fmt.Errorf("set page: %w", err)
+	}
+
+	conn, err := c.db.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("set page: %w", err)
+	}
+	defer conn.Close()
+
+	args := make([]any, 0, 2*len(page.Posts)+5)
+	args = append(args, 2*len(page.Posts)+1, key)
+	for _, post := range page.Posts {
+		args = append(args, postInvalidationKey(post.ID), postPagesKey(post.ID))
+	}
+	args = append(args, data, PageTTL.Milliseconds(), seen)
+	if _, err := setPage.Do(conn, args...); err != nil {
+		return fmt.Errorf("set page: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Cache) lock(ctx context.Context, key, token string) (bool, error) {
+	conn, err := c.db.GetContext(ctx)
+	if err != nil {
+		return false, fmt.Errorf("lock page: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = redis.String(conn.Do("SET", lockKey(key), token, "NX", "PX", lockTTL.Milliseconds()))
+	if errors.Is(err, redis.ErrNil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("lock page: %w", err)
+	}
+
+	return true, nil
+}
+
+func (c *Cache) unlock(key, token string) {
+	conn := c.db.Get()
+	defer conn.Close()
+
+	// the lock expires by itself if it is not deleted
+	_, _ = unlock.Do(conn, lockKey(key), token)
+}
+
+// waitPage waits for the page loaded by the holder of the lock
+func (c *Cache) waitPage(ctx context.Context, key string) (*models.PostPage, bool) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	timeout := time.NewTimer(lockWait)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-timeout.C:
+			return nil, false
+		case <-ticker.C:
+			page, err := c.getPage(ctx, key)
+			if err == nil {
+				return page, true
+			}
+			if !errors.Is(err, redis.ErrNil) {
+				return nil, false
+			}
+		}
+	}
+}
+
+// InvalidatePost deletes the cached pages with the post
+func (c *Cache) InvalidatePost(ctx context.Context, postID uint32) error {
+	conn, err := c.db.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("invalidate post: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = invalidate.Do(
+		conn, postPagesKey(postID), postInvalidationKey(postID), invalidationKey, PageTTL.Milliseconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("invalidate post: %w", err)
+	}
+
+	return nil
+}
+
+// InvalidateFirstPages makes the cached first pages outdated, a new post can be on any of them
+func (c *Cache) InvalidateFirstPages(ctx context.Context) error {
+	conn, err := c.db.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("invalidate first pages: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Do("INCR", generationKey); err != nil {
+		return fmt.Errorf("invalidate first pages: %w", err)
+	}
+
+	return nil
+}
+
+// GetHeaders returns the headers of ids of the kind, only the missing ones are loaded.
+// A header is keyed with its community id if it is set and with the author id otherwise
+func (c *Cache) GetHeaders(
+	ctx context.Context, kind string, ids []uint32,
+	load func(ctx context.Context, ids []uint32) ([]*models.Header, error),
+) ([]*models.Header, error) {
+	if len(ids) == 0 {
+		return load(ctx, ids)
+	}
+
+	headers, missing, err := c.getHeaders(ctx, kind, ids)
+	if err != nil {
+		return load(ctx, ids)
+	}
+	c.metrics.Hit(headerCache, len(headers))
+	c.metrics.Miss(headerCache, len(missing))
+	if len(missing) == 0 {
+		return headers, nil
+	}
+
+	loaded, err := load(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	// the headers are loaded anyway, the next request will try to cache them again
+	_ = c.setHeaders(ctx, kind, loaded)
+
+	return append(headers, loaded...), nil
+}
+
+func (c *Cache) getHeaders(ctx context.Context, kind string, ids []uint32) ([]*models.Header, []uint32, error) {
+	conn, err := c.db.GetContext(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get headers: %w", err)
+	}
+	defer conn.Close()
+
+	keys := make([]any, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, headerKey(kind, id))
+	}
+	values, err := redis.ByteSlices(conn.Do("MGET", keys...))
+	if err != nil {
+		return nil, nil, fmt.Errorf("get headers: %w", err)
+	}
+
+	var (
+		headers = make([]*models.Header, 0, len(ids))
+		missing []uint32
+	)
+	for i, value := range values {
+		if value == nil {
+			missing = append(missing, ids[i])
+			continue
+		}
+
+		header := &models.Header{}
+		if err := json.Unmarshal(value, header); err != nil {
+			return nil, nil, fmt.Errorf("get headers: %w", err)
+		}
+		headers = append(headers, header)
+	}
+
+	return headers, missing, nil
+}
+
+func (c *Cache) setHeaders(ctx context.Context, kind string, headers []*models.Header) error {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	conn, err := c.db.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("set headers: %w", err)
+	}
+	defer conn.Close()
+
+	for _, header := range headers {
+		data, err := json.Marshal(header)
+		if err != nil {
+			return fmt.Errorf("set headers: %w", err)
+		}
+
+		id := header.AuthorID
+		if header.CommunityID != 0 {
+			id = header.CommunityID
+		}
+		if err := conn.Send("SET", headerKey(kind, id), data, "PX", HeaderTTL.Milliseconds()); err != nil {
+			return fmt.Errorf("set headers: %w", err)
+		}
+	}
+	if _, err := conn.Do(""); err != nil {
+		return fmt.Errorf("set headers: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+var errMock = errors.New("mock error")
+
+type fakeMetrics struct {
+	mu     sync.Mutex
+	hits   map[string]int
+	misses map[string]int
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{hits: make(map[string]int), misses: make(map[string]int)}
+}
+
+func (f *fakeMetrics) Hit(cache string, count int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hits[cache] += count
+}
+
+func (f *fakeMetrics) Miss(cache string, count int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.misses[cache] += count
+}
+
+func getPool(t *testing.T) (*redis.Pool, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", addr)
+		},
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	return pool, mr
+}
+
+// loader counts the loads of the page with the posts
+func loader(calls *atomic.Int32, ids ...uint32) func(ctx context.Context) (*models.PostPage, error) {
+	return func(ctx context.Context) (*models.PostPage, error) {
+		calls.Add(1)
+		page := &models.PostPage{Cursor: "next"}
+		for _, id := range ids {
+			page.Posts = append(page.Posts, &models.Post{
+				ID:        id,
+				Header:    models.Header{AuthorID: 1, Author: "Alexey Zemliakov"},
+				Reactions: models.Reactions{Counts: map[models.ReactionType]uint32{"like": 2}},
+			})
+		}
+		return page, nil
+	}
+}
+
+func TestGetPage(t *testing.T) {
+	pool, mr := getPool(t)
+	metrics := newFakeMetrics()
+	cache := NewCache(pool, metrics)
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	page, err := cache.GetPage(ctx, "feed", 1, "", loader(&calls, 1, 2))
+	require.NoError(t, err)
+	cached, err := cache.GetPage(ctx, "feed", 1, "", loader(&calls, 1, 2))
+	require.NoError(t, err)
+	assert.Equal(t, page, cached)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, 1, metrics.hits[pageCache])
+	assert.Equal(t, 1, metrics.misses[pageCache])
+
+	// the pages of the other users and sections are cached apart
+	_, err = cache.GetPage(ctx, "feed", 2, "", loader(&calls, 1))
+	require.NoError(t, err)
+	_, err = cache.GetPage(ctx, "feed", 1, "cursor", loader(&calls, 3))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+
+	// a like drops the pages with the post only
+	require.NoError(t, cache.InvalidatePost(ctx, 2))
+	_, err = cache.GetPage(ctx, "feed", 1, "", loader(&calls, 1, 2))
+	require.NoError(t, err)
+	_, err = cache.GetPage(ctx, "feed", 2, "", loader(&calls, 1))
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), calls.Load())
+	assert.False(t, mr.Exists(postPagesKey(4)))
+
+	// a new post drops the first pages only
+	require.NoError(t, cache.InvalidateFirstPages(ctx))
+	_, err = cache.GetPage(ctx, "feed", 2, "", loader(&calls, 1))
+	require.NoError(t, err)
+	_, err = cache.GetPage(ctx, "feed", 1, "cursor", loader(&calls, 3))
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), calls.Load())
+
+	mr.FastForward(PageTTL + time.Second)
+	_, err = cache.GetPage(ctx, "feed", 1, "cursor", loader(&calls, 3))
+	require.NoError(t, err)
+	assert.Equal(t, int32(6), calls.Load())
+
+	// the errors are not cached
+	_, err = cache.GetPage(ctx, "joined", 1, "", func(ctx context.Context) (*models.PostPage, error) {
+		return nil, errMock
+	})
+	assert.ErrorIs(t, err, errMock)
+	_, err = cache.GetPage(ctx, "joined", 1, "", loader(&calls))
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), calls.Load())
+
+	// the pages are loaded without the cache if redis is gone
+	mr.Close()
+	_, err = cache.GetPage(ctx, "feed", 1, "cursor", loader(&calls, 3))
+	require.NoError(t, err)
+	_, err = cache.GetPage(ctx, "feed", 1, "", loader(&calls, 3))
+	require.NoError(t, err)
+	assert.Equal(t, int32(9), calls.Load())
+	assert.Error(t, cache.InvalidatePost(ctx, 1))
+	assert.Error(t, cache.InvalidateFirstPages(ctx))
+}
+
+func TestGetPageStampede(t *testing.T) {
+	pool, _ := getPool(t)
+	cache := NewCache(pool, newFakeMetrics())
+
+	var (
+		calls atomic.Int32
+		wg    sync.WaitGroup
+		load  = loader(&calls, 1)
+	)
+	slowLoad := func(ctx context.Context) (*models.PostPage, error) {
+		time.Sleep(5 * pollInterval)
+		return load(ctx)
+	}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			page, err := cache.GetPage(context.Background(), "feed", 1, "", slowLoad)
+			assert.NoError(t, err)
+			assert.Len(t, page.Posts, 1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestGetPageInvalidatedDuringLoad(t *testing.T) {
+	pool, _ := getPool(t)
+	cache := NewCache(pool, newFakeMetrics())
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	load := loader(&calls, 1, 2)
+	invalidatingLoad := func(ctx context.Context) (*models.PostPage, error) {
+		page, err := load(ctx)
+		require.NoError(t, cache.InvalidatePost(ctx, 2))
+		return page, err
+	}
+
+	// the page read before the write is returned but not cached
+	_, err := cache.GetPage(ctx, "feed", 1, "", invalidatingLoad)
+	require.NoError(t, err)
+	_, err = cache.GetPage(ctx, "feed", 1, "", load)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// the page loaded after the write is cached
+	_, err = cache.GetPage(ctx, "feed", 1, "", load)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// the invalidation of the other posts does not matter
+	invalidatingLoad = func(ctx context.Context) (*models.PostPage, error) {
+		page, err := load(ctx)
+		require.NoError(t, cache.InvalidatePost(ctx, 3))
+		return page, err
+	}
+	_, err = cache.GetPage(ctx, "feed", 2, "", invalidatingLoad)
+	require.NoError(t, err)
+	_, err = cache.GetPage(ctx, "feed", 2, "", load)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestGetHeaders(t *testing.T) {
+	pool, mr := getPool(t)
+	metrics := newFakeMetrics()
+	cache := NewCache(pool, metrics)
+	ctx := context.Background()
+
+	var loaded [][]uint32
+	load := func(ctx context.Context, ids []uint32) ([]*models.Header, error) {
+		loaded = append(loaded, ids)
+		res := make([]*models.Header, 0, len(ids))
+		for _, id := range ids {
+			res = append(res, &models.Header{CommunityID: id, Author: "community", Avatar: "/avatar"})
+		}
+		return res, nil
+	}
+
+	res, err := cache.GetHeaders(ctx, "community", []uint32{1, 2}, load)
+	require.NoError(t, err)
+	assert.Len(t, res, 2)
+
+	res, err = cache.GetHeaders(ctx, "community", []uint32{2, 3}, load)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*models.Header{
+		{CommunityID: 2, Author: "community", Avatar: "/avatar"},
+		{CommunityID: 3, Author: "community", Avatar: "/avatar"},
+	}, res)
+	assert.Equal(t, [][]uint32{{1, 2}, {3}}, loaded)
+	assert.Equal(t, 1, metrics.hits[headerCache])
+	assert.Equal(t, 3, metrics.misses[headerCache])
+
+	// the kinds do not share the ids
+	_, err = cache.GetHeaders(ctx, "profile", []uint32{1}, load)
+	require.NoError(t, err)
+	assert.Len(t, loaded, 3)
+
+	_, err = cache.GetHeaders(ctx, "community", []uint32{4}, func(ctx context.Context, ids []uint32) ([]*models.Header, error) {
+		return nil, errMock
+	})
+	assert.ErrorIs(t, err, errMock)
+
+	mr.FastForward(HeaderTTL + time.Second)
+	_, err = cache.GetHeaders(ctx, "community", []uint32{1}, load)
+	require.NoError(t, err)
+	assert.Len(t, loaded, 4)
+
+	mr.Close()
+	res, err = cache.GetHeaders(ctx, "community", []uint32{1}, load)
+	require.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Len(t, loaded, 5)
+}
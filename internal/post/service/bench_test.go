@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/2024_2_BetterCallFirewall/internal/api/grpc/community_api"
+	"github.com/2024_2_BetterCallFirewall/internal/api/grpc/profile_api"
+	"github.com/2024_2_BetterCallFirewall/internal/ext_grpc/adapter/community"
+	"github.com/2024_2_BetterCallFirewall/internal/ext_grpc/adapter/profile"
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+// dbLatency stands for a round trip to postgres, the grpc calls make their own round trips over bufconn
+const dbLatency = 200 * time.Microsecond
+
+type fakeProfileServer struct {
+	profile_api.UnimplementedProfileServiceServer
+	calls *atomic.Int64
+}
+
+func (f *fakeProfileServer) GetHeader(
+	_ context.Context, req *profile_api.HeaderRequest,
+) (*profile_api.HeaderResponse, error) {
+	f.calls.Add(1)
+	return &profile_api.HeaderResponse{Head: &profile_api.Header{AuthorID: req.UserID, Author: "author"}}, nil
+}
+
+func (f *fakeProfileServer) GetHeaders(
+	_ context.Context, req *profile_api.HeadersRequest,
+) (*profile_api.HeadersResponse, error) {
+	f.calls.Add(1)
+	res := &profile_api.HeadersResponse{}
+	for _, id := range req.UserIDs {
+		res.Heads = append(res.Heads, &profile_api.Header{AuthorID: id, Author: "author"})
+	}
+	return res, nil
+}
+
+type fakeCommunityServer struct {
+	community_api.UnimplementedCommunityServiceServer
+	calls *atomic.Int64
+}
+
+func (f *fakeCommunityServer) GetHeader(
+	_ context.Context, req *community_api.GetHeaderRequest,
+) (*community_api.GetHeaderResponse, error) {
+	f.calls.Add(1)
+	return &community_api.GetHeaderResponse{
+		Head: &community_api.Header{CommunityID: req.CommunityID, Author: "community"},
+	}, nil
+}
+
+func (f *fakeCommunityServer) GetHeaders(
+	_ context.Context, req *community_api.GetHeadersRequest,
+) (*community_api.GetHeadersResponse, error) {
+	f.calls.Add(1)
+	res := &community_api.GetHeadersResponse{}
+	for _, id := range req.CommunityIDs {
+		res.Heads = append(res.Heads, &community_api.Header{CommunityID: id, Author: "community"})
+	}
+	return res, nil
+}
+
+// fakeDB implements only the queries needed to fill a page
+type fakeDB struct {
+	DB
+	calls *atomic.Int64
+}
+
+func (f *fakeDB) GetReactionsOnPost(context.Context, uint32, uint32) (models.Reactions, error) {
+	f.calls.Add(1)
+	time.Sleep(dbLatency)
+	return models.NewReactions(), nil
+}
+
+func (f *fakeDB) GetReactionsOnPosts(_ context.Context, postIDs []uint32, _ uint32) (map[uint32]models.Reactions, error) {
+	f.calls.Add(1)
+	time.Sleep(dbLatency)
+	res := make(map[uint32]models.Reactions, len(postIDs))
+	for _, id := range postIDs {
+		res[id] = models.NewReactions()
+	}
+	return res, nil
+}
+
+func (f *fakeDB) GetCommentCount(context.Context, uint32) (uint32, error) {
+	f.calls.Add(1)
+	time.Sleep(dbLatency)
+	return 0, nil
+}
+
+func (f *fakeDB) GetCommentCounts(_ context.Context, postIDs []uint32) (map[uint32]uint32, error) {
+	f.calls.Add(1)
+	time.Sleep(dbLatency)
+	res := make(map[uint32]uint32, len(postIDs))
+	for _, id := range postIDs {
+		res[id] = 0
+	}
+	return res, nil
+}
+
+func setupBench(b *testing.B) (*PostServiceImpl, *atomic.Int64) {
+	b.Helper()
+
+	calls := &atomic.Int64{}
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	profile_api.RegisterProfileServiceServer(server, &fakeProfileServer{calls: calls})
+	community_api.RegisterCommunityServiceServer(server, &fakeCommunityServer{calls: calls})
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	b.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return NewPostServiceImpl(&fakeDB{calls: calls}, profile.New(conn), community.New(conn)), calls
+}
+
+const feedPageSize = 10
+
+// benchPage is a feed page: the posts of 2 friends and of 3 communities
+func benchPage() []*models.Post {
+	posts := make([]*models.Post, 0, feedPageSize)
+	for i := uint32(1); i <= feedPageSize; i++ {
+		post := &models.Post{ID: i}
+		if i%2 == 0 {
+			post.Header.AuthorID = i%4 + 1
+		} else {
+			post.Header.CommunityID = i%3 + 1
+		}
+		posts = append(posts, post)
+	}
+	return posts
+}
+
+func BenchmarkSetPostFieldsPerPost(b *testing.B) {
+	s, calls := setupBench(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, post := range benchPage() {
+			if err := s.setPostFields(ctx, post, 1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.ReportMetric(float64(calls.Load())/float64(b.N), "calls/op")
+}
+
+func BenchmarkSetPostsFieldsBatch(b *testing.B) {
+	s, calls := setupBench(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := s.setPostsFields(ctx, benchPage(), 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(calls.Load())/float64(b.N), "calls/op")
+}
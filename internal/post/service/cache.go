@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+const (
+	latestSection    = "latest"
+	feedSection      = "feed"
+	joinedSection    = "joined"
+	friendSection    = "friend"
+	communitySection = "community"
+
+	profileHeaders   = "profile"
+	communityHeaders = "community"
+)
+
+//go:generate mockgen -destination=mock_cache.go -source=$GOFILE -package=${GOPACKAGE}
+type PostCache interface {
+	GetPage(
+		ctx context.Context, section string, userID uint32, cursor string,
+		load func(ctx context.Context) (*models.PostPage, error),
+	) (*models.PostPage, error)
+	GetHeaders(
+		ctx context.Context, kind string, ids []uint32,
+		load func(ctx context.Context, ids []uint32) ([]*models.Header, error),
+	) ([]*models.Header, error)
+	InvalidatePost(ctx context.Context, postID uint32) error
+	InvalidateFirstPages(ctx context.Context) error
+}
+
+// CachedPostService serves the pages of posts and their headers from the cache,
+// the writes to the posts drop the cached pages they change
+type CachedPostService struct {
+	*PostServiceImpl
+	cache PostCache
+}
+
+func NewCachedPostService(
+	db DB, profileRepo ProfileRepo, communityRepo CommunityRepo, cache PostCache,
+) *CachedPostService {
+	return &CachedPostService{
+		PostServiceImpl: NewPostServiceImpl(
+			db,
+			&cachedProfileRepo{ProfileRepo: profileRepo, cache: cache},
+			&cachedCommunityRepo{CommunityRepo: communityRepo, cache: cache},
+		),
+		cache: cache,
+	}
+}
+
+func (s *CachedPostService) GetBatch(ctx context.Context, lastID, userID uint32) ([]*models.Post, error) {
+	page, err := s.cache.GetPage(ctx, latestSection, userID, lastIDCursor(lastID),
+		func(ctx context.Context) (*models.PostPage, error) {
+			posts, err := s.PostServiceImpl.GetBatch(ctx, lastID, userID)
+			if err != nil {
+				return nil, err
+			}
+			return &models.PostPage{Posts: posts}, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Posts, nil
+}
+
+func (s *CachedPostService) GetFeed(
+	ctx context.Context, userID uint32, cursor models.FeedCursor,
+) (*models.PostPage, error) {
+	return s.cache.GetPage(ctx, feedSection, userID, cursor.String(), func(ctx context.Context) (*models.PostPage, error) {
+		return s.PostServiceImpl.GetFeed(ctx, userID, cursor)
+	})
+}
+
+func (s *CachedPostService) GetJoinedCommunitiesPosts(
+	ctx context.Context, userID uint32, cursor models.FeedCursor,
+) (*models.PostPage, error) {
+	return s.cache.GetPage(ctx, joinedSection, userID, cursor.String(), func(ctx context.Context) (*models.PostPage, error) {
+		return s.PostServiceImpl.GetJoinedCommunitiesPosts(ctx, userID, cursor)
+	})
+}
+
+func (s *CachedPostService) GetBatchFromFriend(
+	ctx context.Context, userID uint32, lastID uint32,
+) ([]*models.Post, error) {
+	page, err := s.cache.GetPage(ctx, friendSection, userID, lastIDCursor(lastID),
+		func(ctx context.Context) (*models.PostPage, error) {
+			posts, err := s.PostServiceImpl.GetBatchFromFriend(ctx, userID, lastID)
+			if err != nil {
+				return nil, err
+			}
+			return &models.PostPage{Posts: posts}, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Posts, nil
+}
+
+func (s *CachedPostService) GetCommunityPost(
+	ctx context.Context, communityID, userID, lastID uint32,
+) ([]*models.Post, error) {
+	section := fmt.Sprintf("%s:%d", communitySection, communityID)
+	page, err := s.cache.GetPage(ctx, section, userID, lastIDCursor(lastID),
+		func(ctx context.Context) (*models.PostPage, error) {
+			posts, err := s.PostServiceImpl.GetCommunityPost(ctx, communityID, userID, lastID)
+			if err != nil {
+				return nil, err
+			}
+			return &models.PostPage{Posts: posts}, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Posts, nil
+}
+
+func (s *CachedPostService) Create(ctx context.Context, post *models.Post) (uint32, error) {
+	id, err := s.PostServiceImpl.Create(ctx, post)
+	if err != nil {
+		return 0, err
+	}
+	s.invalidateFirstPages(ctx)
+
+	return id, nil
+}
+
+func (s *CachedPostService) CreateCommunityPost(ctx context.Context, post *models.Post) (uint32, error) {
+	id, err := s.PostServiceImpl.CreateCommunityPost(ctx, post)
+	if err != nil {
+		return 0, err
+	}
+	s.invalidateFirstPages(ctx)
+
+	return id, nil
+}
+
+func (s *CachedPostService) Update(ctx context.Context, post *models.Post) error {
+	if err := s.PostServiceImpl.Update(ctx, post); err != nil {
+		return err
+	}
+	s.invalidatePost(ctx, post.ID)
+
+	return nil
+}
+
+func (s *CachedPostService) Delete(ctx context.Context, postID uint32) error {
+	if err := s.PostServiceImpl.Delete(ctx, postID); err != nil {
+		return err
+	}
+	s.invalidatePost(ctx, postID)
+
+	return nil
+}
+
+func (s *CachedPostService) SetReactionToPost(
+	ctx context.Context, postID, userID uint32, reaction models.ReactionType,
+) error {
+	if err := s.PostServiceImpl.SetReactionToPost(ctx, postID, userID, reaction); err != nil {
+		return err
+	}
+	s.invalidatePost(ctx, postID)
+
+	return nil
+}
+
+func (s *CachedPostService) DeleteReactionFromPost(ctx context.Context, postID, userID uint32) error {
+	if err := s.PostServiceImpl.DeleteReactionFromPost(ctx, postID, userID); err != nil {
+		return err
+	}
+	s.invalidatePost(ctx, postID)
+
+	return nil
+}
+
+// The write is done already when the cache is invalidated, so a failure is not reported to the client:
+// the stale pages expire by themselves
+func (s *CachedPostService) invalidatePost(ctx context.Context, postID uint32) {
+	_ = s.cache.InvalidatePost(ctx, postID)
+}
+
+func (s *CachedPostService) invalidateFirstPages(ctx context.Context) {
+	_ = s.cache.InvalidateFirstPages(ctx)
+}
+
+// lastIDCursor keys the page by its last id, the first page is keyed as the first pages of the other sections
+// to be dropped with them by a new post
+func lastIDCursor(lastID uint32) string {
+	if lastID == 0 || lastID == models.FirstPageLastID {
+		return ""
+	}
+
+	return strconv.FormatUint(uint64(lastID), 10)
+}
+
+// CachedCommentService drops the cached pages of the post on the writes changing its comment count
+type CachedCommentService struct {
+	*CommentServiceImpl
+	cache PostCache
+}
+
+func NewCachedCommentService(db CommentDB, profileRepo ProfileRepo, cache PostCache) *CachedCommentService {
+	return &CachedCommentService{
+		CommentServiceImpl: NewCommentServiceImpl(db, profileRepo),
+		cache:              cache,
+	}
+}
+
+func (s *CachedCommentService) Create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	newComment, err := s.CommentServiceImpl.Create(ctx, comment)
+	// the id is set once the comment is saved, even if its header is not got then
+	if comment.ID != 0 {
+		_ = s.cache.InvalidatePost(ctx, comment.PostID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newComment, nil
+}
+
+func (s *CachedCommentService) Delete(ctx context.Context, postID, commentID uint32) error {
+	if err := s.CommentServiceImpl.Delete(ctx, postID, commentID); err != nil {
+		return err
+	}
+	_ = s.cache.InvalidatePost(ctx, postID)
+
+	return nil
+}
+
+type cachedProfileRepo struct {
+	ProfileRepo
+	cache PostCache
+}
+
+func (r *cachedProfileRepo) GetHeaders(ctx context.Context, userIDs []uint32) ([]*models.Header, error) {
+	return r.cache.GetHeaders(ctx, profileHeaders, userIDs, r.ProfileRepo.GetHeaders)
+}
+
+type cachedCommunityRepo struct {
+	CommunityRepo
+	cache PostCache
+}
+
+func (r *cachedCommunityRepo) GetHeaders(ctx context.Context, communityIDs []uint32) ([]*models.Header, error) {
+	return r.cache.GetHeaders(ctx, communityHeaders, communityIDs, r.CommunityRepo.GetHeaders)
+}
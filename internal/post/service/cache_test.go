@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+func getCachedService(ctrl *gomock.Controller) (*CachedPostService, *mocks, *MockPostCache) {
+	m := &mocks{
+		postRepo:      NewMockDB(ctrl),
+		communityRepo: NewMockCommunityRepo(ctrl),
+		profileRepo:   NewMockProfileRepo(ctrl),
+	}
+	cache := NewMockPostCache(ctrl)
+
+	return NewCachedPostService(m.postRepo, m.profileRepo, m.communityRepo, cache), m, cache
+}
+
+// loadPage makes the cache mock miss and load the page
+func loadPage(
+	_ context.Context, _ string, _ uint32, _ string, load func(ctx context.Context) (*models.PostPage, error),
+) (*models.PostPage, error) {
+	return load(context.Background())
+}
+
+// loadHeaders makes the cache mock miss and load the headers
+func loadHeaders(
+	_ context.Context, _ string, ids []uint32, load func(ctx context.Context, ids []uint32) ([]*models.Header, error),
+) ([]*models.Header, error) {
+	return load(context.Background(), ids)
+}
+
+func TestCachedGetFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, m, cache := getCachedService(ctrl)
+	ctx := context.Background()
+	cursor := models.FeedCursor{AsOf: time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC), Score: 0.5, ID: 10}
+
+	cache.EXPECT().GetPage(gomock.Any(), feedSection, uint32(1), cursor.String(), gomock.Any()).
+		DoAndReturn(loadPage)
+	m.profileRepo.EXPECT().GetFriendsID(gomock.Any(), uint32(1)).Return([]uint32{2}, nil)
+	m.postRepo.EXPECT().GetFeed(gomock.Any(), gomock.Any()).Return(
+		&models.PostPage{Posts: []*models.Post{{ID: 3, Header: models.Header{AuthorID: 2}}}}, nil,
+	)
+	cache.EXPECT().GetHeaders(gomock.Any(), profileHeaders, []uint32{2}, gomock.Any()).DoAndReturn(loadHeaders)
+	m.profileRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{2}).
+		Return([]*models.Header{{AuthorID: 2, Author: "Andrew Savvateev"}}, nil)
+	m.postRepo.EXPECT().GetReactionsOnPosts(gomock.Any(), []uint32{3}, uint32(1)).
+		Return(map[uint32]models.Reactions{3: likeReactions()}, nil)
+	m.postRepo.EXPECT().GetCommentCounts(gomock.Any(), []uint32{3}).Return(map[uint32]uint32{3: 1}, nil)
+
+	page, err := service.GetFeed(ctx, 1, cursor)
+	require.NoError(t, err)
+	assert.Equal(t, &models.PostPage{Posts: []*models.Post{{
+		ID:           3,
+		Header:       models.Header{AuthorID: 2, Author: "Andrew Savvateev"},
+		Reactions:    likeReactions(),
+		CommentCount: 1,
+	}}}, page)
+
+	cached := &models.PostPage{Posts: []*models.Post{{ID: 4}}, Cursor: "next"}
+	cache.EXPECT().GetPage(gomock.Any(), feedSection, uint32(1), "", gomock.Any()).Return(cached, nil)
+	page, err = service.GetFeed(ctx, 1, models.FeedCursor{})
+	require.NoError(t, err)
+	assert.Equal(t, cached, page)
+
+	cache.EXPECT().GetPage(gomock.Any(), joinedSection, uint32(1), "", gomock.Any()).
+		DoAndReturn(loadPage)
+	m.postRepo.EXPECT().GetJoinedCommunitiesPosts(gomock.Any(), uint32(1), gomock.Any()).
+		Return(nil, my_err.ErrNoMoreContent)
+	_, err = service.GetJoinedCommunitiesPosts(ctx, 1, models.FeedCursor{})
+	assert.ErrorIs(t, err, my_err.ErrNoMoreContent)
+}
+
+func TestCachedGetPosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, m, cache := getCachedService(ctrl)
+	ctx := context.Background()
+
+	cache.EXPECT().GetPage(gomock.Any(), friendSection, uint32(1), "5", gomock.Any()).
+		Return(&models.PostPage{Posts: []*models.Post{{ID: 4}}}, nil)
+	posts, err := service.GetBatchFromFriend(ctx, 1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Post{{ID: 4}}, posts)
+
+	cache.EXPECT().GetPage(gomock.Any(), friendSection, uint32(1), "", gomock.Any()).
+		DoAndReturn(loadPage)
+	m.profileRepo.EXPECT().GetFriendsID(gomock.Any(), uint32(1)).Return(nil, errMock)
+	_, err = service.GetBatchFromFriend(ctx, 1, 0)
+	assert.ErrorIs(t, err, errMock)
+
+	cache.EXPECT().GetPage(gomock.Any(), "community:2", uint32(1), "", gomock.Any()).
+		DoAndReturn(loadPage)
+	m.postRepo.EXPECT().GetCommunityPosts(gomock.Any(), uint32(2), uint32(0)).
+		Return([]*models.Post{{ID: 6, Header: models.Header{CommunityID: 2}}}, nil)
+	cache.EXPECT().GetHeaders(gomock.Any(), communityHeaders, []uint32{2}, gomock.Any()).
+		DoAndReturn(loadHeaders)
+	m.communityRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{2}).
+		Return([]*models.Header{{CommunityID: 2, Author: "Community"}}, nil)
+	m.postRepo.EXPECT().GetReactionsOnPosts(gomock.Any(), []uint32{6}, uint32(1)).Return(nil, nil)
+	m.postRepo.EXPECT().GetCommentCounts(gomock.Any(), []uint32{6}).Return(nil, nil)
+	posts, err = service.GetCommunityPost(ctx, 2, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Post{{
+		ID:        6,
+		Header:    models.Header{CommunityID: 2, Author: "Community"},
+		Reactions: models.NewReactions(),
+	}}, posts)
+
+	cache.EXPECT().GetPage(gomock.Any(), "community:2", uint32(1), "6", gomock.Any()).Return(nil, errMock)
+	_, err = service.GetCommunityPost(ctx, 2, 1, 6)
+	assert.ErrorIs(t, err, errMock)
+
+	// the controllers ask the first page with the last id no post has
+	cache.EXPECT().GetPage(gomock.Any(), friendSection, uint32(1), "", gomock.Any()).Return(&models.PostPage{}, nil)
+	_, err = service.GetBatchFromFriend(ctx, 1, models.FirstPageLastID)
+	require.NoError(t, err)
+	cache.EXPECT().GetPage(gomock.Any(), "community:2", uint32(1), "", gomock.Any()).Return(&models.PostPage{}, nil)
+	_, err = service.GetCommunityPost(ctx, 2, 1, models.FirstPageLastID)
+	require.NoError(t, err)
+
+	cache.EXPECT().GetPage(gomock.Any(), latestSection, uint32(1), "", gomock.Any()).DoAndReturn(loadPage)
+	m.postRepo.EXPECT().GetPosts(gomock.Any(), uint32(models.FirstPageLastID)).Return(nil, my_err.ErrNoMoreContent)
+	_, err = service.GetBatch(ctx, models.FirstPageLastID, 1)
+	assert.ErrorIs(t, err, my_err.ErrNoMoreContent)
+	cache.EXPECT().GetPage(gomock.Any(), latestSection, uint32(1), "7", gomock.Any()).
+		Return(&models.PostPage{Posts: []*models.Post{{ID: 6}}}, nil)
+	posts, err = service.GetBatch(ctx, 7, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Post{{ID: 6}}, posts)
+}
+
+func TestCachedWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, m, cache := getCachedService(ctrl)
+	ctx := context.Background()
+
+	m.postRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
+	cache.EXPECT().InvalidateFirstPages(gomock.Any()).Return(nil)
+	id, err := service.Create(ctx, &models.Post{})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), id)
+
+	// the post is created even if the cache is not available
+	m.postRepo.EXPECT().CreateCommunityPost(gomock.Any(), gomock.Any(), uint32(2)).Return(uint32(3), nil)
+	cache.EXPECT().InvalidateFirstPages(gomock.Any()).Return(errMock)
+	id, err = service.CreateCommunityPost(ctx, &models.Post{Header: models.Header{CommunityID: 2}})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), id)
+
+	m.postRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(uint32(0), errMock)
+	_, err = service.Create(ctx, &models.Post{})
+	assert.ErrorIs(t, err, errMock)
+	m.postRepo.EXPECT().CreateCommunityPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint32(0), errMock)
+	_, err = service.CreateCommunityPost(ctx, &models.Post{})
+	assert.ErrorIs(t, err, errMock)
+
+	m.postRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	cache.EXPECT().InvalidatePost(gomock.Any(), uint32(1)).Return(nil)
+	require.NoError(t, service.Update(ctx, &models.Post{ID: 1}))
+	m.postRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errMock)
+	assert.ErrorIs(t, service.Update(ctx, &models.Post{ID: 1}), errMock)
+
+	m.postRepo.EXPECT().Delete(gomock.Any(), uint32(1)).Return(nil)
+	cache.EXPECT().InvalidatePost(gomock.Any(), uint32(1)).Return(errMock)
+	require.NoError(t, service.Delete(ctx, 1))
+	m.postRepo.EXPECT().Delete(gomock.Any(), uint32(1)).Return(errMock)
+	assert.ErrorIs(t, service.Delete(ctx, 1), errMock)
+
+	m.postRepo.EXPECT().SetReactionToPost(gomock.Any(), uint32(1), uint32(2), models.ReactionLike).Return(nil)
+	cache.EXPECT().InvalidatePost(gomock.Any(), uint32(1)).Return(nil)
+	require.NoError(t, service.SetReactionToPost(ctx, 1, 2, models.ReactionLike))
+	assert.ErrorIs(t, service.SetReactionToPost(ctx, 1, 2, "boo"), my_err.ErrWrongReaction)
+
+	m.postRepo.EXPECT().DeleteReactionFromPost(gomock.Any(), uint32(1), uint32(2)).Return(nil)
+	cache.EXPECT().InvalidatePost(gomock.Any(), uint32(1)).Return(nil)
+	require.NoError(t, service.DeleteReactionFromPost(ctx, 1, 2))
+	m.postRepo.EXPECT().DeleteReactionFromPost(gomock.Any(), uint32(1), uint32(2)).Return(errMock)
+	assert.ErrorIs(t, service.DeleteReactionFromPost(ctx, 1, 2), errMock)
+}
+
+func TestCachedCommentWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := &commentMocks{
+		repo:        NewMockCommentDB(ctrl),
+		profileRepo: NewMockProfileRepo(ctrl),
+	}
+	cache := NewMockPostCache(ctrl)
+	service := NewCachedCommentService(m.repo, m.profileRepo, cache)
+	ctx := context.Background()
+
+	m.repo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(uint32(2), nil)
+	m.profileRepo.EXPECT().GetHeader(gomock.Any(), uint32(3)).Return(&models.Header{AuthorID: 3}, nil)
+	cache.EXPECT().InvalidatePost(gomock.Any(), uint32(1)).Return(nil)
+	comment, err := service.Create(ctx, &models.Comment{PostID: 1, Header: models.Header{AuthorID: 3}})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), comment.ID)
+
+	// the comment is saved, so the count changes even if its header is not got
+	m.repo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(uint32(2), nil)
+	m.profileRepo.EXPECT().GetHeader(gomock.Any(), uint32(3)).Return(nil, errMock)
+	cache.EXPECT().InvalidatePost(gomock.Any(), uint32(1)).Return(errMock)
+	_, err = service.Create(ctx, &models.Comment{PostID: 1, Header: models.Header{AuthorID: 3}})
+	assert.ErrorIs(t, err, errMock)
+
+	m.repo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(uint32(0), my_err.ErrPostNotFound)
+	_, err = service.Create(ctx, &models.Comment{PostID: 1})
+	assert.ErrorIs(t, err, my_err.ErrPostNotFound)
+
+	m.repo.EXPECT().DeleteComment(gomock.Any(), uint32(1), uint32(2)).Return(nil)
+	cache.EXPECT().InvalidatePost(gomock.Any(), uint32(1)).Return(nil)
+	require.NoError(t, service.Delete(ctx, 1, 2))
+	m.repo.EXPECT().DeleteComment(gomock.Any(), uint32(1), uint32(2)).Return(errMock)
+	assert.ErrorIs(t, service.Delete(ctx, 1, 2), errMock)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+//go:generate mockgen -destination=mock_comment.go -source=$GOFILE -package=${GOPACKAGE}
+type CommentDB interface {
+	CreateComment(ctx context.Context, comment *models.Comment) (uint32, error)
+	GetComments(ctx context.Context, postID, lastID uint32) ([]*models.Comment, error)
+	UpdateComment(ctx context.Context, comment *models.Comment) error
+	DeleteComment(ctx context.Context, postID, commentID uint32) error
+	GetCommentAuthor(ctx context.Context, postID, commentID uint32) (uint32, error)
+
+	SetLikeToComment(ctx context.Context, postID, commentID, userID uint32) error
+	DeleteLikeFromComment(ctx context.Context, postID, commentID, userID uint32) error
+	GetLikesOnComment(ctx context.Context, commentID uint32) (uint32, error)
+	CheckCommentLikes(ctx context.Context, commentID, userID uint32) (bool, error)
+}
+
+type CommentServiceImpl struct {
+	db          CommentDB
+	profileRepo ProfileRepo
+}
+
+func NewCommentServiceImpl(db CommentDB, profileRepo ProfileRepo) *CommentServiceImpl {
+	return &CommentServiceImpl{
+		db:          db,
+		profileRepo: profileRepo,
+	}
+}
+
+func (s *CommentServiceImpl) Create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	id, err := s.db.CreateComment(ctx, comment)
+	if err != nil {
+		return nil, fmt.Errorf("create comment: %w", err)
+	}
+	comment.ID = id
+
+	header, err := s.profileRepo.GetHeader(ctx, comment.Header.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("get header: %w", err)
+	}
+	comment.Header = *header
+	comment.Content.CreatedAt = convertTime(comment.Content.CreatedAt)
+
+	return comment, nil
+}
+
+func (s *CommentServiceImpl) GetComments(ctx context.Context, postID, userID, lastID uint32) ([]*models.Comment, error) {
+	comments, err := s.db.GetComments(ctx, postID, lastID)
+	if err != nil {
+		return nil, fmt.Errorf("get comments: %w", err)
+	}
+
+	for _, comment := range comments {
+		if err := s.setCommentFields(ctx, comment, userID); err != nil {
+			return nil, fmt.Errorf("set comment fields: %w", err)
+		}
+	}
+
+	return comments, nil
+}
+
+func (s *CommentServiceImpl) Update(ctx context.Context, comment *models.Comment) error {
+	if err := s.db.UpdateComment(ctx, comment); err != nil {
+		return fmt.Errorf("update comment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CommentServiceImpl) Delete(ctx context.Context, postID, commentID uint32) error {
+	if err := s.db.DeleteComment(ctx, postID, commentID); err != nil {
+		return fmt.Errorf("delete comment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CommentServiceImpl) GetCommentAuthorID(ctx context.Context, postID, commentID uint32) (uint32, error) {
+	id, err := s.db.GetCommentAuthor(ctx, postID, commentID)
+	if err != nil {
+		return 0, fmt.Errorf("get comment author: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *CommentServiceImpl) SetLikeToComment(ctx context.Context, postID, commentID, userID uint32) error {
+	if err := s.db.SetLikeToComment(ctx, postID, commentID, userID); err != nil {
+		return fmt.Errorf("set like to comment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CommentServiceImpl) DeleteLikeFromComment(ctx context.Context, postID, commentID, userID uint32) error {
+	if err := s.db.DeleteLikeFromComment(ctx, postID, commentID, userID); err != nil {
+		return fmt.Errorf("delete like from comment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CommentServiceImpl) CheckLikes(ctx context.Context, commentID, userID uint32) (bool, error) {
+	res, err := s.db.CheckCommentLikes(ctx, commentID, userID)
+	if err != nil {
+		return false, fmt.Errorf("check comment likes: %w", err)
+	}
+
+	return res, nil
+}
+
+func (s *CommentServiceImpl) setCommentFields(ctx context.Context, comment *models.Comment, userID uint32) error {
+	comment.Content.CreatedAt = convertTime(comment.Content.CreatedAt)
+	comment.Content.UpdatedAt = convertTime(comment.Content.UpdatedAt)
+
+	if comment.IsDeleted {
+		comment.Header = models.Header{}
+		return nil
+	}
+
+	header, err := s.profileRepo.GetHeader(ctx, comment.Header.AuthorID)
+	if err != nil {
+		return fmt.Errorf("get header: %w", err)
+	}
+	comment.Header = *header
+
+	likes, err := s.db.GetLikesOnComment(ctx, comment.ID)
+	if err != nil {
+		return fmt.Errorf("get likes: %w", err)
+	}
+	comment.LikesCount = likes
+
+	liked, err := s.db.CheckCommentLikes(ctx, comment.ID, userID)
+	if err != nil {
+		return fmt.Errorf("check likes: %w", err)
+	}
+	comment.IsLiked = liked
+
+	return nil
+}
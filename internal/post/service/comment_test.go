@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+type commentMocks struct {
+	repo        *MockCommentDB
+	profileRepo *MockProfileRepo
+}
+
+func getCommentService(ctrl *gomock.Controller) (*CommentServiceImpl, *commentMocks) {
+	m := &commentMocks{
+		repo:        NewMockCommentDB(ctrl),
+		profileRepo: NewMockProfileRepo(ctrl),
+	}
+
+	return NewCommentServiceImpl(m.repo, m.profileRepo), m
+}
+
+func TestNewCommentService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, _ := getCommentService(ctrl)
+	assert.NotNil(t, service)
+}
+
+func TestCreateComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		comment   *models.Comment
+		want      *models.Comment
+		wantErr   error
+		setupMock func(m *commentMocks)
+	}{
+		{
+			name:    "1",
+			comment: &models.Comment{PostID: 1, Header: models.Header{AuthorID: 1}},
+			wantErr: my_err.ErrPostNotFound,
+			setupMock: func(m *commentMocks) {
+				m.repo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(uint32(0), my_err.ErrPostNotFound)
+			},
+		},
+		{
+			name:    "2",
+			comment: &models.Comment{PostID: 1, Header: models.Header{AuthorID: 1}},
+			wantErr: errMock,
+			setupMock: func(m *commentMocks) {
+				m.repo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
+				m.profileRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).Return(nil, errMock)
+			},
+		},
+		{
+			name:    "3",
+			comment: &models.Comment{PostID: 1, Header: models.Header{AuthorID: 1}},
+			want: &models.Comment{
+				ID:     1,
+				PostID: 1,
+				Header: models.Header{AuthorID: 1, Author: "user"},
+			},
+			setupMock: func(m *commentMocks) {
+				m.repo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
+				m.profileRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).
+					Return(&models.Header{AuthorID: 1, Author: "user"}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getCommentService(ctrl)
+			v.setupMock(mock)
+
+			res, err := serv.Create(context.Background(), v.comment)
+			if !errors.Is(err, v.wantErr) {
+				t.Errorf("expect %v, got %v", v.wantErr, err)
+			}
+			if v.want != nil {
+				v.want.Content.CreatedAt = res.Content.CreatedAt
+			}
+			assert.Equal(t, v.want, res)
+		})
+	}
+}
+
+func TestGetComments(t *testing.T) {
+	tests := []struct {
+		name      string
+		want      []*models.Comment
+		wantErr   error
+		setupMock func(m *commentMocks)
+	}{
+		{
+			name:    "1",
+			wantErr: my_err.ErrNoMoreContent,
+			setupMock: func(m *commentMocks) {
+				m.repo.EXPECT().GetComments(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, my_err.ErrNoMoreContent)
+			},
+		},
+		{
+			name:    "2",
+			wantErr: errMock,
+			setupMock: func(m *commentMocks) {
+				m.repo.EXPECT().GetComments(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*models.Comment{{ID: 1}}, nil)
+				m.profileRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).Return(nil, errMock)
+			},
+		},
+		{
+			name:    "3",
+			wantErr: errMock,
+			setupMock: func(m *commentMocks) {
+				m.repo.EXPECT().GetComments(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*models.Comment{{ID: 1}}, nil)
+				m.profileRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).Return(&models.Header{}, nil)
+				m.repo.EXPECT().GetLikesOnComment(gomock.Any(), gomock.Any()).Return(uint32(0), errMock)
+			},
+		},
+		{
+			name:    "4",
+			wantErr: errMock,
+			setupMock: func(m *commentMocks) {
+				m.repo.EXPECT().GetComments(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*models.Comment{{ID: 1}}, nil)
+				m.profileRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).Return(&models.Header{}, nil)
+				m.repo.EXPECT().GetLikesOnComment(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
+				m.repo.EXPECT().CheckCommentLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, errMock)
+			},
+		},
+		{
+			name: "5",
+			want: []*models.Comment{
+				{ID: 1, Header: models.Header{AuthorID: 1, Author: "user"}, LikesCount: 1, IsLiked: true},
+			},
+			setupMock: func(m *commentMocks) {
+				m.repo.EXPECT().GetComments(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*models.Comment{{ID: 1, Header: models.Header{AuthorID: 1}}}, nil)
+				m.profileRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).
+					Return(&models.Header{AuthorID: 1, Author: "user"}, nil)
+				m.repo.EXPECT().GetLikesOnComment(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
+				m.repo.EXPECT().CheckCommentLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name: "6",
+			want: []*models.Comment{
+				{ID: 1, IsDeleted: true},
+				{ID: 2, ParentID: 1, Depth: 1, Header: models.Header{AuthorID: 2, Author: "user"}},
+			},
+			setupMock: func(m *commentMocks) {
+				m.repo.EXPECT().GetComments(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					[]*models.Comment{
+						{ID: 1, IsDeleted: true, Header: models.Header{AuthorID: 1}},
+						{ID: 2, ParentID: 1, Depth: 1, Header: models.Header{AuthorID: 2}},
+					}, nil,
+				)
+				m.profileRepo.EXPECT().GetHeader(gomock.Any(), uint32(2)).
+					Return(&models.Header{AuthorID: 2, Author: "user"}, nil)
+				m.repo.EXPECT().GetLikesOnComment(gomock.Any(), uint32(2)).Return(uint32(0), nil)
+				m.repo.EXPECT().CheckCommentLikes(gomock.Any(), uint32(2), gomock.Any()).Return(false, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getCommentService(ctrl)
+			v.setupMock(mock)
+
+			res, err := serv.GetComments(context.Background(), 1, 1, 0)
+			if !errors.Is(err, v.wantErr) {
+				t.Errorf("expect %v, got %v", v.wantErr, err)
+			}
+			for i := range v.want {
+				v.want[i].Content = res[i].Content
+			}
+			if v.want == nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, v.want, res)
+		})
+	}
+}
+
+func TestCommentWrappers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serv, m := getCommentService(ctrl)
+	ctx := context.Background()
+
+	m.repo.EXPECT().UpdateComment(gomock.Any(), gomock.Any()).Return(my_err.ErrCommentNotFound)
+	assert.ErrorIs(t, serv.Update(ctx, &models.Comment{ID: 1}), my_err.ErrCommentNotFound)
+
+	m.repo.EXPECT().DeleteComment(gomock.Any(), uint32(1), uint32(2)).Return(nil)
+	assert.NoError(t, serv.Delete(ctx, 1, 2))
+
+	m.repo.EXPECT().GetCommentAuthor(gomock.Any(), uint32(1), uint32(2)).Return(uint32(3), nil)
+	id, err := serv.GetCommentAuthorID(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), id)
+
+	m.repo.EXPECT().SetLikeToComment(gomock.Any(), uint32(1), uint32(2), uint32(3)).Return(errMock)
+	assert.ErrorIs(t, serv.SetLikeToComment(ctx, 1, 2, 3), errMock)
+
+	m.repo.EXPECT().DeleteLikeFromComment(gomock.Any(), uint32(1), uint32(2), uint32(3)).Return(nil)
+	assert.NoError(t, serv.DeleteLikeFromComment(ctx, 1, 2, 3))
+
+	m.repo.EXPECT().CheckCommentLikes(gomock.Any(), uint32(2), uint32(3)).Return(true, nil)
+	liked, err := serv.CheckLikes(ctx, 2, 3)
+	assert.NoError(t, err)
+	assert.True(t, liked)
+}
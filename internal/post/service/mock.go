@@ -94,18 +94,18 @@ func (mr *MockDBMockRecorder) Delete(ctx, postID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDB)(nil).Delete), ctx, postID)
 }
 
-// DeleteLikeFromPost mocks base method.
-func (m *MockDB) DeleteLikeFromPost(ctx context.Context, postID, userID uint32) error {
+// DeleteReactionFromPost mocks base method.
+func (m *MockDB) DeleteReactionFromPost(ctx context.Context, postID, userID uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLikeFromPost", ctx, postID, userID)
+	ret := m.ctrl.Call(m, "DeleteReactionFromPost", ctx, postID, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteLikeFromPost indicates an expected call of DeleteLikeFromPost.
-func (mr *MockDBMockRecorder) DeleteLikeFromPost(ctx, postID, userID interface{}) *gomock.Call {
+// DeleteReactionFromPost indicates an expected call of DeleteReactionFromPost.
+func (mr *MockDBMockRecorder) DeleteReactionFromPost(ctx, postID, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikeFromPost", reflect.TypeOf((*MockDB)(nil).DeleteLikeFromPost), ctx, postID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReactionFromPost", reflect.TypeOf((*MockDB)(nil).DeleteReactionFromPost), ctx, postID, userID)
 }
 
 // Get mocks base method.
@@ -123,6 +123,36 @@ func (mr *MockDBMockRecorder) Get(ctx, postID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDB)(nil).Get), ctx, postID)
 }
 
+// GetCommentCount mocks base method.
+func (m *MockDB) GetCommentCount(ctx context.Context, postID uint32) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentCount", ctx, postID)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentCount indicates an expected call of GetCommentCount.
+func (mr *MockDBMockRecorder) GetCommentCount(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentCount", reflect.TypeOf((*MockDB)(nil).GetCommentCount), ctx, postID)
+}
+
+// GetCommentCounts mocks base method.
+func (m *MockDB) GetCommentCounts(ctx context.Context, postIDs []uint32) (map[uint32]uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentCounts", ctx, postIDs)
+	ret0, _ := ret[0].(map[uint32]uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentCounts indicates an expected call of GetCommentCounts.
+func (mr *MockDBMockRecorder) GetCommentCounts(ctx, postIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentCounts", reflect.TypeOf((*MockDB)(nil).GetCommentCounts), ctx, postIDs)
+}
+
 // GetCommunityPosts mocks base method.
 func (m *MockDB) GetCommunityPosts(ctx context.Context, communityID, lastID uint32) ([]*models.Post, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +168,21 @@ func (mr *MockDBMockRecorder) GetCommunityPosts(ctx, communityID, lastID interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommunityPosts", reflect.TypeOf((*MockDB)(nil).GetCommunityPosts), ctx, communityID, lastID)
 }
 
+// GetFeed mocks base method.
+func (m *MockDB) GetFeed(ctx context.Context, feed *models.Feed) (*models.PostPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, feed)
+	ret0, _ := ret[0].(*models.PostPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockDBMockRecorder) GetFeed(ctx, feed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockDB)(nil).GetFeed), ctx, feed)
+}
+
 // GetFriendsPosts mocks base method.
 func (m *MockDB) GetFriendsPosts(ctx context.Context, friendsID []uint32, lastID uint32) ([]*models.Post, error) {
 	m.ctrl.T.Helper()
@@ -153,19 +198,19 @@ func (mr *MockDBMockRecorder) GetFriendsPosts(ctx, friendsID, lastID interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriendsPosts", reflect.TypeOf((*MockDB)(nil).GetFriendsPosts), ctx, friendsID, lastID)
 }
 
-// GetLikesOnPost mocks base method.
-func (m *MockDB) GetLikesOnPost(ctx context.Context, postID uint32) (uint32, error) {
+// GetJoinedCommunitiesPosts mocks base method.
+func (m *MockDB) GetJoinedCommunitiesPosts(ctx context.Context, userID uint32, cursor models.FeedCursor) (*models.PostPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLikesOnPost", ctx, postID)
-	ret0, _ := ret[0].(uint32)
+	ret := m.ctrl.Call(m, "GetJoinedCommunitiesPosts", ctx, userID, cursor)
+	ret0, _ := ret[0].(*models.PostPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLikesOnPost indicates an expected call of GetLikesOnPost.
-func (mr *MockDBMockRecorder) GetLikesOnPost(ctx, postID interface{}) *gomock.Call {
+// GetJoinedCommunitiesPosts indicates an expected call of GetJoinedCommunitiesPosts.
+func (mr *MockDBMockRecorder) GetJoinedCommunitiesPosts(ctx, userID, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikesOnPost", reflect.TypeOf((*MockDB)(nil).GetLikesOnPost), ctx, postID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJoinedCommunitiesPosts", reflect.TypeOf((*MockDB)(nil).GetJoinedCommunitiesPosts), ctx, userID, cursor)
 }
 
 // GetPostAuthor mocks base method.
@@ -198,18 +243,78 @@ func (mr *MockDBMockRecorder) GetPosts(ctx, lastID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosts", reflect.TypeOf((*MockDB)(nil).GetPosts), ctx, lastID)
 }
 
-// SetLikeToPost mocks base method.
-func (m *MockDB) SetLikeToPost(ctx context.Context, postID, userID uint32) error {
+// GetReactionAuthors mocks base method.
+func (m *MockDB) GetReactionAuthors(ctx context.Context, postID, lastID uint32) ([]uint32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetLikeToPost", ctx, postID, userID)
+	ret := m.ctrl.Call(m, "GetReactionAuthors", ctx, postID, lastID)
+	ret0, _ := ret[0].([]uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactionAuthors indicates an expected call of GetReactionAuthors.
+func (mr *MockDBMockRecorder) GetReactionAuthors(ctx, postID, lastID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactionAuthors", reflect.TypeOf((*MockDB)(nil).GetReactionAuthors), ctx, postID, lastID)
+}
+
+// GetReactionsOnPost mocks base method.
+func (m *MockDB) GetReactionsOnPost(ctx context.Context, postID, userID uint32) (models.Reactions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactionsOnPost", ctx, postID, userID)
+	ret0, _ := ret[0].(models.Reactions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactionsOnPost indicates an expected call of GetReactionsOnPost.
+func (mr *MockDBMockRecorder) GetReactionsOnPost(ctx, postID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactionsOnPost", reflect.TypeOf((*MockDB)(nil).GetReactionsOnPost), ctx, postID, userID)
+}
+
+// GetReactionsOnPosts mocks base method.
+func (m *MockDB) GetReactionsOnPosts(ctx context.Context, postIDs []uint32, userID uint32) (map[uint32]models.Reactions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactionsOnPosts", ctx, postIDs, userID)
+	ret0, _ := ret[0].(map[uint32]models.Reactions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactionsOnPosts indicates an expected call of GetReactionsOnPosts.
+func (mr *MockDBMockRecorder) GetReactionsOnPosts(ctx, postIDs, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactionsOnPosts", reflect.TypeOf((*MockDB)(nil).GetReactionsOnPosts), ctx, postIDs, userID)
+}
+
+// SearchPosts mocks base method.
+func (m *MockDB) SearchPosts(ctx context.Context, search *models.PostSearch) (*models.PostPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPosts", ctx, search)
+	ret0, _ := ret[0].(*models.PostPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPosts indicates an expected call of SearchPosts.
+func (mr *MockDBMockRecorder) SearchPosts(ctx, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPosts", reflect.TypeOf((*MockDB)(nil).SearchPosts), ctx, search)
+}
+
+// SetReactionToPost mocks base method.
+func (m *MockDB) SetReactionToPost(ctx context.Context, postID, userID uint32, reaction models.ReactionType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReactionToPost", ctx, postID, userID, reaction)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SetLikeToPost indicates an expected call of SetLikeToPost.
-func (mr *MockDBMockRecorder) SetLikeToPost(ctx, postID, userID interface{}) *gomock.Call {
+// SetReactionToPost indicates an expected call of SetReactionToPost.
+func (mr *MockDBMockRecorder) SetReactionToPost(ctx, postID, userID, reaction interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLikeToPost", reflect.TypeOf((*MockDB)(nil).SetLikeToPost), ctx, postID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReactionToPost", reflect.TypeOf((*MockDB)(nil).SetReactionToPost), ctx, postID, userID, reaction)
 }
 
 // Update mocks base method.
@@ -279,6 +384,36 @@ func (mr *MockProfileRepoMockRecorder) GetHeader(ctx, userID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockProfileRepo)(nil).GetHeader), ctx, userID)
 }
 
+// GetHeaders mocks base method.
+func (m *MockProfileRepo) GetHeaders(ctx context.Context, userIDs []uint32) ([]*models.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeaders", ctx, userIDs)
+	ret0, _ := ret[0].([]*models.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeaders indicates an expected call of GetHeaders.
+func (mr *MockProfileRepoMockRecorder) GetHeaders(ctx, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeaders", reflect.TypeOf((*MockProfileRepo)(nil).GetHeaders), ctx, userIDs)
+}
+
+// GetShortProfiles mocks base method.
+func (m *MockProfileRepo) GetShortProfiles(ctx context.Context, selfID uint32, ids []uint32) ([]*models.ShortProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortProfiles", ctx, selfID, ids)
+	ret0, _ := ret[0].([]*models.ShortProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortProfiles indicates an expected call of GetShortProfiles.
+func (mr *MockProfileRepoMockRecorder) GetShortProfiles(ctx, selfID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortProfiles", reflect.TypeOf((*MockProfileRepo)(nil).GetShortProfiles), ctx, selfID, ids)
+}
+
 // MockCommunityRepo is a mock of CommunityRepo interface.
 type MockCommunityRepo struct {
 	ctrl     *gomock.Controller
@@ -330,3 +465,18 @@ func (mr *MockCommunityRepoMockRecorder) GetHeader(ctx, communityID interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockCommunityRepo)(nil).GetHeader), ctx, communityID)
 }
+
+// GetHeaders mocks base method.
+func (m *MockCommunityRepo) GetHeaders(ctx context.Context, communityIDs []uint32) ([]*models.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeaders", ctx, communityIDs)
+	ret0, _ := ret[0].([]*models.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeaders indicates an expected call of GetHeaders.
+func (mr *MockCommunityRepoMockRecorder) GetHeaders(ctx, communityIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeaders", reflect.TypeOf((*MockCommunityRepo)(nil).GetHeaders), ctx, communityIDs)
+}
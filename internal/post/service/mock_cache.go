@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cache.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/2024_2_BetterCallFirewall/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPostCache is a mock of PostCache interface.
+type MockPostCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostCacheMockRecorder
+}
+
+// MockPostCacheMockRecorder is the mock recorder for MockPostCache.
+type MockPostCacheMockRecorder struct {
+	mock *MockPostCache
+}
+
+// NewMockPostCache creates a new mock instance.
+func NewMockPostCache(ctrl *gomock.Controller) *MockPostCache {
+	mock := &MockPostCache{ctrl: ctrl}
+	mock.recorder = &MockPostCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostCache) EXPECT() *MockPostCacheMockRecorder {
+	return m.recorder
+}
+
+// GetHeaders mocks base method.
+func (m *MockPostCache) GetHeaders(ctx context.Context, kind string, ids []uint32, load func(context.Context, []uint32) ([]*models.Header, error)) ([]*models.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeaders", ctx, kind, ids, load)
+	ret0, _ := ret[0].([]*models.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeaders indicates an expected call of GetHeaders.
+func (mr *MockPostCacheMockRecorder) GetHeaders(ctx, kind, ids, load interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeaders", reflect.TypeOf((*MockPostCache)(nil).GetHeaders), ctx, kind, ids, load)
+}
+
+// GetPage mocks base method.
+func (m *MockPostCache) GetPage(ctx context.Context, section string, userID uint32, cursor string, load func(context.Context) (*models.PostPage, error)) (*models.PostPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, section, userID, cursor, load)
+	ret0, _ := ret[0].(*models.PostPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockPostCacheMockRecorder) GetPage(ctx, section, userID, cursor, load interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockPostCache)(nil).GetPage), ctx, section, userID, cursor, load)
+}
+
+// InvalidateFirstPages mocks base method.
+func (m *MockPostCache) InvalidateFirstPages(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateFirstPages", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateFirstPages indicates an expected call of InvalidateFirstPages.
+func (mr *MockPostCacheMockRecorder) InvalidateFirstPages(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateFirstPages", reflect.TypeOf((*MockPostCache)(nil).InvalidateFirstPages), ctx)
+}
+
+// InvalidatePost mocks base method.
+func (m *MockPostCache) InvalidatePost(ctx context.Context, postID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidatePost", ctx, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidatePost indicates an expected call of InvalidatePost.
+func (mr *MockPostCacheMockRecorder) InvalidatePost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidatePost", reflect.TypeOf((*MockPostCache)(nil).InvalidatePost), ctx, postID)
+}
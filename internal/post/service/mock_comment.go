@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/2024_2_BetterCallFirewall/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCommentDB is a mock of CommentDB interface.
+type MockCommentDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentDBMockRecorder
+}
+
+// MockCommentDBMockRecorder is the mock recorder for MockCommentDB.
+type MockCommentDBMockRecorder struct {
+	mock *MockCommentDB
+}
+
+// NewMockCommentDB creates a new mock instance.
+func NewMockCommentDB(ctrl *gomock.Controller) *MockCommentDB {
+	mock := &MockCommentDB{ctrl: ctrl}
+	mock.recorder = &MockCommentDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentDB) EXPECT() *MockCommentDBMockRecorder {
+	return m.recorder
+}
+
+// CheckCommentLikes mocks base method.
+func (m *MockCommentDB) CheckCommentLikes(ctx context.Context, commentID, userID uint32) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCommentLikes", ctx, commentID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckCommentLikes indicates an expected call of CheckCommentLikes.
+func (mr *MockCommentDBMockRecorder) CheckCommentLikes(ctx, commentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCommentLikes", reflect.TypeOf((*MockCommentDB)(nil).CheckCommentLikes), ctx, commentID, userID)
+}
+
+// CreateComment mocks base method.
+func (m *MockCommentDB) CreateComment(ctx context.Context, comment *models.Comment) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, comment)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentDBMockRecorder) CreateComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockCommentDB)(nil).CreateComment), ctx, comment)
+}
+
+// DeleteComment mocks base method.
+func (m *MockCommentDB) DeleteComment(ctx context.Context, postID, commentID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, postID, commentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentDBMockRecorder) DeleteComment(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentDB)(nil).DeleteComment), ctx, postID, commentID)
+}
+
+// DeleteLikeFromComment mocks base method.
+func (m *MockCommentDB) DeleteLikeFromComment(ctx context.Context, postID, commentID, userID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLikeFromComment", ctx, postID, commentID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLikeFromComment indicates an expected call of DeleteLikeFromComment.
+func (mr *MockCommentDBMockRecorder) DeleteLikeFromComment(ctx, postID, commentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikeFromComment", reflect.TypeOf((*MockCommentDB)(nil).DeleteLikeFromComment), ctx, postID, commentID, userID)
+}
+
+// GetCommentAuthor mocks base method.
+func (m *MockCommentDB) GetCommentAuthor(ctx context.Context, postID, commentID uint32) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentAuthor", ctx, postID, commentID)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentAuthor indicates an expected call of GetCommentAuthor.
+func (mr *MockCommentDBMockRecorder) GetCommentAuthor(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentAuthor", reflect.TypeOf((*MockCommentDB)(nil).GetCommentAuthor), ctx, postID, commentID)
+}
+
+// GetComments mocks base method.
+func (m *MockCommentDB) GetComments(ctx context.Context, postID, lastID uint32) ([]*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, postID, lastID)
+	ret0, _ := ret[0].([]*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentDBMockRecorder) GetComments(ctx, postID, lastID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockCommentDB)(nil).GetComments), ctx, postID, lastID)
+}
+
+// GetLikesOnComment mocks base method.
+func (m *MockCommentDB) GetLikesOnComment(ctx context.Context, commentID uint32) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikesOnComment", ctx, commentID)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikesOnComment indicates an expected call of GetLikesOnComment.
+func (mr *MockCommentDBMockRecorder) GetLikesOnComment(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikesOnComment", reflect.TypeOf((*MockCommentDB)(nil).GetLikesOnComment), ctx, commentID)
+}
+
+// SetLikeToComment mocks base method.
+func (m *MockCommentDB) SetLikeToComment(ctx context.Context, postID, commentID, userID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLikeToComment", ctx, postID, commentID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLikeToComment indicates an expected call of SetLikeToComment.
+func (mr *MockCommentDBMockRecorder) SetLikeToComment(ctx, postID, commentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLikeToComment", reflect.TypeOf((*MockCommentDB)(nil).SetLikeToComment), ctx, postID, commentID, userID)
+}
+
+// UpdateComment mocks base method.
+func (m *MockCommentDB) UpdateComment(ctx context.Context, comment *models.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockCommentDBMockRecorder) UpdateComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockCommentDB)(nil).UpdateComment), ctx, comment)
+}
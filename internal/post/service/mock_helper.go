@@ -35,47 +35,47 @@ func (m *MockPostProfileDB) EXPECT() *MockPostProfileDBMockRecorder {
 	return m.recorder
 }
 
-// CheckLikes mocks base method.
-func (m *MockPostProfileDB) CheckLikes(ctx context.Context, postID, userID uint32) (bool, error) {
+// GetAuthorPosts mocks base method.
+func (m *MockPostProfileDB) GetAuthorPosts(ctx context.Context, header *models.Header) ([]*models.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckLikes", ctx, postID, userID)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "GetAuthorPosts", ctx, header)
+	ret0, _ := ret[0].([]*models.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CheckLikes indicates an expected call of CheckLikes.
-func (mr *MockPostProfileDBMockRecorder) CheckLikes(ctx, postID, userID interface{}) *gomock.Call {
+// GetAuthorPosts indicates an expected call of GetAuthorPosts.
+func (mr *MockPostProfileDBMockRecorder) GetAuthorPosts(ctx, header interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLikes", reflect.TypeOf((*MockPostProfileDB)(nil).CheckLikes), ctx, postID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorPosts", reflect.TypeOf((*MockPostProfileDB)(nil).GetAuthorPosts), ctx, header)
 }
 
-// GetAuthorPosts mocks base method.
-func (m *MockPostProfileDB) GetAuthorPosts(ctx context.Context, header *models.Header) ([]*models.Post, error) {
+// GetCommentCounts mocks base method.
+func (m *MockPostProfileDB) GetCommentCounts(ctx context.Context, postIDs []uint32) (map[uint32]uint32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAuthorPosts", ctx, header)
-	ret0, _ := ret[0].([]*models.Post)
+	ret := m.ctrl.Call(m, "GetCommentCounts", ctx, postIDs)
+	ret0, _ := ret[0].(map[uint32]uint32)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAuthorPosts indicates an expected call of GetAuthorPosts.
-func (mr *MockPostProfileDBMockRecorder) GetAuthorPosts(ctx, header interface{}) *gomock.Call {
+// GetCommentCounts indicates an expected call of GetCommentCounts.
+func (mr *MockPostProfileDBMockRecorder) GetCommentCounts(ctx, postIDs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorPosts", reflect.TypeOf((*MockPostProfileDB)(nil).GetAuthorPosts), ctx, header)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentCounts", reflect.TypeOf((*MockPostProfileDB)(nil).GetCommentCounts), ctx, postIDs)
 }
 
-// GetLikesOnPost mocks base method.
-func (m *MockPostProfileDB) GetLikesOnPost(ctx context.Context, postID uint32) (uint32, error) {
+// GetReactionsOnPosts mocks base method.
+func (m *MockPostProfileDB) GetReactionsOnPosts(ctx context.Context, postIDs []uint32, userID uint32) (map[uint32]models.Reactions, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLikesOnPost", ctx, postID)
-	ret0, _ := ret[0].(uint32)
+	ret := m.ctrl.Call(m, "GetReactionsOnPosts", ctx, postIDs, userID)
+	ret0, _ := ret[0].(map[uint32]models.Reactions)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLikesOnPost indicates an expected call of GetLikesOnPost.
-func (mr *MockPostProfileDBMockRecorder) GetLikesOnPost(ctx, postID interface{}) *gomock.Call {
+// GetReactionsOnPosts indicates an expected call of GetReactionsOnPosts.
+func (mr *MockPostProfileDBMockRecorder) GetReactionsOnPosts(ctx, postIDs, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikesOnPost", reflect.TypeOf((*MockPostProfileDB)(nil).GetLikesOnPost), ctx, postID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactionsOnPosts", reflect.TypeOf((*MockPostProfileDB)(nil).GetReactionsOnPosts), ctx, postIDs, userID)
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
@@ -16,26 +17,38 @@ type DB interface {
 	Update(ctx context.Context, post *models.Post) error
 	Delete(ctx context.Context, postID uint32) error
 	GetPosts(ctx context.Context, lastID uint32) ([]*models.Post, error)
+	GetFeed(ctx context.Context, feed *models.Feed) (*models.PostPage, error)
+	GetJoinedCommunitiesPosts(ctx context.Context, userID uint32, cursor models.FeedCursor) (*models.PostPage, error)
 	GetFriendsPosts(ctx context.Context, friendsID []uint32, lastID uint32) ([]*models.Post, error)
 	GetPostAuthor(ctx context.Context, postID uint32) (uint32, error)
 
 	CreateCommunityPost(ctx context.Context, post *models.Post, communityID uint32) (uint32, error)
 	GetCommunityPosts(ctx context.Context, communityID uint32, lastID uint32) ([]*models.Post, error)
 
-	SetLikeToPost(ctx context.Context, postID uint32, userID uint32) error
-	DeleteLikeFromPost(ctx context.Context, postID uint32, userID uint32) error
-	GetLikesOnPost(ctx context.Context, postID uint32) (uint32, error)
+	SetReactionToPost(ctx context.Context, postID, userID uint32, reaction models.ReactionType) error
+	DeleteReactionFromPost(ctx context.Context, postID, userID uint32) error
+	GetReactionsOnPost(ctx context.Context, postID, userID uint32) (models.Reactions, error)
+	GetReactionsOnPosts(ctx context.Context, postIDs []uint32, userID uint32) (map[uint32]models.Reactions, error)
 	CheckLikes(ctx context.Context, postID, userID uint32) (bool, error)
+	GetReactionAuthors(ctx context.Context, postID, lastID uint32) ([]uint32, error)
+
+	GetCommentCount(ctx context.Context, postID uint32) (uint32, error)
+	GetCommentCounts(ctx context.Context, postIDs []uint32) (map[uint32]uint32, error)
+
+	SearchPosts(ctx context.Context, search *models.PostSearch) (*models.PostPage, error)
 }
 
 type ProfileRepo interface {
 	GetHeader(ctx context.Context, userID uint32) (*models.Header, error)
+	GetHeaders(ctx context.Context, userIDs []uint32) ([]*models.Header, error)
 	GetFriendsID(ctx context.Context, userID uint32) ([]uint32, error)
+	GetShortProfiles(ctx context.Context, selfID uint32, ids []uint32) ([]*models.ShortProfile, error)
 }
 
 type CommunityRepo interface {
 	CheckAccess(ctx context.Context, communityID, userID uint32) bool
 	GetHeader(ctx context.Context, communityID uint32) (*models.Header, error)
+	GetHeaders(ctx context.Context, communityIDs []uint32) ([]*models.Header, error)
 }
 
 type PostServiceImpl struct {
@@ -100,15 +113,57 @@ func (s *PostServiceImpl) GetBatch(ctx context.Context, lastID, userID uint32) (
 		return nil, fmt.Errorf("get posts: %w", err)
 	}
 
-	for _, post := range posts {
-		if err := s.setPostFields(ctx, post, userID); err != nil {
-			return nil, fmt.Errorf("set post fields: %w", err)
-		}
+	if err := s.setPostsFields(ctx, posts, userID); err != nil {
+		return nil, fmt.Errorf("set posts fields: %w", err)
 	}
 
 	return posts, nil
 }
 
+// GetFeed returns a page of the ranked feed of userID. The first page fixes the moment the feed is ranked at,
+// the next pages keep it in their cursors
+func (s *PostServiceImpl) GetFeed(ctx context.Context, userID uint32, cursor models.FeedCursor) (*models.PostPage, error) {
+	friends, err := s.profileRepo.GetFriendsID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get friends: %w", err)
+	}
+
+	if cursor.ID == 0 {
+		cursor.AsOf = time.Now().UTC()
+	}
+
+	page, err := s.db.GetFeed(ctx, &models.Feed{UserID: userID, FriendIDs: friends, Cursor: cursor})
+	if err != nil {
+		return nil, fmt.Errorf("get feed: %w", err)
+	}
+
+	if err := s.setPostsFields(ctx, page.Posts, userID); err != nil {
+		return nil, fmt.Errorf("set posts fields: %w", err)
+	}
+
+	return page, nil
+}
+
+// GetJoinedCommunitiesPosts returns a page of the posts of all communities userID joined
+func (s *PostServiceImpl) GetJoinedCommunitiesPosts(
+	ctx context.Context, userID uint32, cursor models.FeedCursor,
+) (*models.PostPage, error) {
+	if cursor.ID == 0 {
+		cursor.AsOf = time.Now().UTC()
+	}
+
+	page, err := s.db.GetJoinedCommunitiesPosts(ctx, userID, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("get joined communities posts: %w", err)
+	}
+
+	if err := s.setPostsFields(ctx, page.Posts, userID); err != nil {
+		return nil, fmt.Errorf("set posts fields: %w", err)
+	}
+
+	return page, nil
+}
+
 func (s *PostServiceImpl) GetBatchFromFriend(ctx context.Context, userID uint32, lastID uint32) ([]*models.Post, error) {
 	friends, err := s.profileRepo.GetFriendsID(ctx, userID)
 	if err != nil {
@@ -124,10 +179,8 @@ func (s *PostServiceImpl) GetBatchFromFriend(ctx context.Context, userID uint32,
 		return nil, fmt.Errorf("get posts: %w", err)
 	}
 
-	for _, post := range posts {
-		if err := s.setPostFields(ctx, post, userID); err != nil {
-			return nil, fmt.Errorf("set post fields: %w", err)
-		}
+	if err := s.setPostsFields(ctx, posts, userID); err != nil {
+		return nil, fmt.Errorf("set posts fields: %w", err)
 	}
 
 	return posts, err
@@ -157,10 +210,8 @@ func (s *PostServiceImpl) GetCommunityPost(ctx context.Context, communityID, use
 		return nil, fmt.Errorf("get posts: %w", err)
 	}
 
-	for _, post := range posts {
-		if err := s.setPostFields(ctx, post, userID); err != nil {
-			return nil, fmt.Errorf("set post fields: %w", err)
-		}
+	if err := s.setPostsFields(ctx, posts, userID); err != nil {
+		return nil, fmt.Errorf("set posts fields: %w", err)
 	}
 
 	return posts, nil
@@ -174,16 +225,20 @@ func convertTime(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
 }
 
-func (s *PostServiceImpl) SetLikeToPost(ctx context.Context, postID uint32, userID uint32) error {
-	err := s.db.SetLikeToPost(ctx, postID, userID)
+func (s *PostServiceImpl) SetReactionToPost(ctx context.Context, postID, userID uint32, reaction models.ReactionType) error {
+	if !reaction.IsValid() {
+		return my_err.ErrWrongReaction
+	}
+
+	err := s.db.SetReactionToPost(ctx, postID, userID, reaction)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *PostServiceImpl) DeleteLikeFromPost(ctx context.Context, postID uint32, userID uint32) error {
-	err := s.db.DeleteLikeFromPost(ctx, postID, userID)
+func (s *PostServiceImpl) DeleteReactionFromPost(ctx context.Context, postID, userID uint32) error {
+	err := s.db.DeleteReactionFromPost(ctx, postID, userID)
 	if err != nil {
 		return err
 	}
@@ -199,6 +254,48 @@ func (s *PostServiceImpl) CheckLikes(ctx context.Context, postID, userID uint32)
 	return res, nil
 }
 
+func (s *PostServiceImpl) GetLikedBy(ctx context.Context, postID, userID, lastID uint32) ([]*models.ShortProfile, error) {
+	ids, err := s.db.GetReactionAuthors(ctx, postID, lastID)
+	if err != nil {
+		return nil, fmt.Errorf("get reaction authors: %w", err)
+	}
+
+	profiles, err := s.profileRepo.GetShortProfiles(ctx, userID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get short profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// Search finds the posts in the section of the search, a section is resolved for userID
+func (s *PostServiceImpl) Search(ctx context.Context, userID uint32, search *models.PostSearch) (*models.PostPage, error) {
+	switch search.Section {
+	case models.SearchSectionFriend:
+		friends, err := s.profileRepo.GetFriendsID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("get friends: %w", err)
+		}
+		if len(friends) == 0 {
+			return nil, my_err.ErrNoMoreContent
+		}
+		search.AuthorIDs = friends
+	case models.SearchSectionCommunity:
+		search.MemberID = userID
+	}
+
+	page, err := s.db.SearchPosts(ctx, search)
+	if err != nil {
+		return nil, fmt.Errorf("search posts: %w", err)
+	}
+
+	if err := s.setPostsFields(ctx, page.Posts, userID); err != nil {
+		return nil, fmt.Errorf("set posts fields: %w", err)
+	}
+
+	return page, nil
+}
+
 func (s *PostServiceImpl) setPostFields(ctx context.Context, post *models.Post, userID uint32) error {
 	var (
 		header *models.Header
@@ -218,19 +315,100 @@ func (s *PostServiceImpl) setPostFields(ctx context.Context, post *models.Post,
 	}
 	post.Header = *header
 
-	likes, err := s.db.GetLikesOnPost(ctx, post.ID)
+	reactions, err := s.db.GetReactionsOnPost(ctx, post.ID, userID)
 	if err != nil {
-		return fmt.Errorf("get likes: %w", err)
+		return fmt.Errorf("get reactions: %w", err)
 	}
-	post.LikesCount = likes
+	post.Reactions = reactions
 
-	liked, err := s.db.CheckLikes(ctx, post.ID, userID)
+	comments, err := s.db.GetCommentCount(ctx, post.ID)
 	if err != nil {
-		return fmt.Errorf("check likes: %w", err)
+		return fmt.Errorf("get comment count: %w", err)
 	}
-	post.IsLiked = liked
+	post.CommentCount = comments
 
 	post.PostContent.CreatedAt = convertTime(post.PostContent.CreatedAt)
 
 	return nil
 }
+
+// setPostsFields fills the posts of a page with a constant number of calls whatever the size of the page:
+// one for the headers of the authors, one for the headers of the communities, one for the reactions
+// and one for the comment counts
+func (s *PostServiceImpl) setPostsFields(ctx context.Context, posts []*models.Post, userID uint32) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	var (
+		postIDs      = make([]uint32, 0, len(posts))
+		authorIDs    []uint32
+		communityIDs []uint32
+	)
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+		if post.Header.CommunityID == 0 {
+			if !slices.Contains(authorIDs, post.Header.AuthorID) {
+				authorIDs = append(authorIDs, post.Header.AuthorID)
+			}
+		} else if !slices.Contains(communityIDs, post.Header.CommunityID) {
+			communityIDs = append(communityIDs, post.Header.CommunityID)
+		}
+	}
+
+	var (
+		authors     = make(map[uint32]*models.Header, len(authorIDs))
+		communities = make(map[uint32]*models.Header, len(communityIDs))
+	)
+	if len(authorIDs) != 0 {
+		headers, err := s.profileRepo.GetHeaders(ctx, authorIDs)
+		if err != nil {
+			return fmt.Errorf("get headers: %w", err)
+		}
+		for _, header := range headers {
+			authors[header.AuthorID] = header
+		}
+	}
+	if len(communityIDs) != 0 {
+		headers, err := s.communityRepo.GetHeaders(ctx, communityIDs)
+		if err != nil {
+			return fmt.Errorf("get community headers: %w", err)
+		}
+		for _, header := range headers {
+			communities[header.CommunityID] = header
+		}
+	}
+
+	reactions, err := s.db.GetReactionsOnPosts(ctx, postIDs, userID)
+	if err != nil {
+		return fmt.Errorf("get reactions: %w", err)
+	}
+	comments, err := s.db.GetCommentCounts(ctx, postIDs)
+	if err != nil {
+		return fmt.Errorf("get comment counts: %w", err)
+	}
+
+	for _, post := range posts {
+		var (
+			header *models.Header
+			ok     bool
+		)
+		if post.Header.CommunityID == 0 {
+			if header, ok = authors[post.Header.AuthorID]; !ok {
+				return fmt.Errorf("get header %d: %w", post.Header.AuthorID, my_err.ErrProfileNotFound)
+			}
+		} else if header, ok = communities[post.Header.CommunityID]; !ok {
+			return fmt.Errorf("get community header %d: %w", post.Header.CommunityID, my_err.ErrWrongCommunity)
+		}
+		post.Header = *header
+
+		post.Reactions, ok = reactions[post.ID]
+		if !ok {
+			post.Reactions = models.NewReactions()
+		}
+		post.CommentCount = comments[post.ID]
+		post.PostContent.CreatedAt = convertTime(post.PostContent.CreatedAt)
+	}
+
+	return nil
+}
@@ -10,8 +10,8 @@ import (
 //go:generate mockgen -destination=mock_helper.go -source=$GOFILE -package=${GOPACKAGE}
 type PostProfileDB interface {
 	GetAuthorPosts(ctx context.Context, header *models.Header) ([]*models.Post, error)
-	GetLikesOnPost(ctx context.Context, postID uint32) (uint32, error)
-	CheckLikes(ctx context.Context, postID, userID uint32) (bool, error)
+	GetReactionsOnPosts(ctx context.Context, postIDs []uint32, userID uint32) (map[uint32]models.Reactions, error)
+	GetCommentCounts(ctx context.Context, postIDs []uint32) (map[uint32]uint32, error)
 }
 
 type PostProfileImpl struct {
@@ -30,19 +30,31 @@ func (p *PostProfileImpl) GetAuthorsPosts(ctx context.Context, header *models.He
 		return nil, err
 	}
 
-	for i, post := range posts {
-		likes, err := p.db.GetLikesOnPost(ctx, post.ID)
-		if err != nil {
-			return nil, fmt.Errorf("get likes: %w", err)
-		}
-		posts[i].LikesCount = likes
+	if len(posts) == 0 {
+		return posts, nil
+	}
+
+	postIDs := make([]uint32, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+
+	reactions, err := p.db.GetReactionsOnPosts(ctx, postIDs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get reactions: %w", err)
+	}
+	comments, err := p.db.GetCommentCounts(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get comment counts: %w", err)
+	}
 
-		liked, err := p.db.CheckLikes(ctx, post.ID, userID)
-		if err != nil {
-			return nil, fmt.Errorf("check likes: %w", err)
+	for _, post := range posts {
+		var ok bool
+		if post.Reactions, ok = reactions[post.ID]; !ok {
+			post.Reactions = models.NewReactions()
 		}
-		posts[i].IsLiked = liked
-		posts[i].PostContent.CreatedAt = convertTime(post.PostContent.CreatedAt)
+		post.CommentCount = comments[post.ID]
+		post.PostContent.CreatedAt = convertTime(post.PostContent.CreatedAt)
 	}
 
 	return posts, nil
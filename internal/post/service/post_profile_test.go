@@ -65,7 +65,7 @@ func TestGetAuthorsPost(t *testing.T) {
 							ID: 1,
 						},
 					}, nil)
-				m.repo.EXPECT().GetLikesOnPost(gomock.Any(), gomock.Any()).Return(uint32(0), errMock)
+				m.repo.EXPECT().GetReactionsOnPosts(gomock.Any(), []uint32{1}, gomock.Any()).Return(nil, errMock)
 			},
 		},
 		{
@@ -87,8 +87,9 @@ func TestGetAuthorsPost(t *testing.T) {
 							ID: 1,
 						},
 					}, nil)
-				m.repo.EXPECT().GetLikesOnPost(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
-				m.repo.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, errMock)
+				m.repo.EXPECT().GetReactionsOnPosts(gomock.Any(), []uint32{1}, gomock.Any()).
+					Return(map[uint32]models.Reactions{1: likeReactions()}, nil)
+				m.repo.EXPECT().GetCommentCounts(gomock.Any(), []uint32{1}).Return(nil, errMock)
 			},
 		},
 		{
@@ -102,9 +103,8 @@ func TestGetAuthorsPost(t *testing.T) {
 			ExpectedResult: func() ([]*models.Post, error) {
 				return []*models.Post{
 					{
-						ID:         1,
-						IsLiked:    true,
-						LikesCount: 1,
+						ID:        1,
+						Reactions: likeReactions(),
 					},
 				}, nil
 			},
@@ -116,8 +116,9 @@ func TestGetAuthorsPost(t *testing.T) {
 							ID: 1,
 						},
 					}, nil)
-				m.repo.EXPECT().GetLikesOnPost(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
-				m.repo.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.repo.EXPECT().GetReactionsOnPosts(gomock.Any(), []uint32{1}, gomock.Any()).
+					Return(map[uint32]models.Reactions{1: likeReactions()}, nil)
+				m.repo.EXPECT().GetCommentCounts(gomock.Any(), []uint32{1}).Return(map[uint32]uint32{1: 0}, nil)
 			},
 		},
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -37,6 +38,13 @@ func TestNewPostService(t *testing.T) {
 
 var errMock = errors.New("mock error")
 
+func likeReactions() models.Reactions {
+	return models.Reactions{
+		Counts: map[models.ReactionType]uint32{models.ReactionLike: 1},
+		My:     models.ReactionLike,
+	}
+}
+
 func TestCreate(t *testing.T) {
 	tests := []TableTest[uint32, models.Post]{
 		{
@@ -199,7 +207,7 @@ func TestGet(t *testing.T) {
 					AuthorID:    1,
 					Author:      "user",
 				}, nil)
-				m.postRepo.EXPECT().GetLikesOnPost(gomock.Any(), gomock.Any()).Return(uint32(0), errMock)
+				m.postRepo.EXPECT().GetReactionsOnPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.Reactions{}, errMock)
 			},
 		},
 		{
@@ -228,8 +236,8 @@ func TestGet(t *testing.T) {
 					AuthorID:    0,
 					Author:      "community",
 				}, nil)
-				m.postRepo.EXPECT().GetLikesOnPost(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
-				m.postRepo.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, errMock)
+				m.postRepo.EXPECT().GetReactionsOnPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(likeReactions(), nil)
+				m.postRepo.EXPECT().GetCommentCount(gomock.Any(), gomock.Any()).Return(uint32(0), errMock)
 			},
 		},
 		{
@@ -247,8 +255,7 @@ func TestGet(t *testing.T) {
 						AuthorID:    0,
 						Author:      "community",
 					},
-					LikesCount: 1,
-					IsLiked:    true,
+					Reactions: likeReactions(),
 				}, nil
 			},
 			ExpectedErr: nil,
@@ -266,8 +273,8 @@ func TestGet(t *testing.T) {
 					AuthorID:    0,
 					Author:      "community",
 				}, nil)
-				m.postRepo.EXPECT().GetLikesOnPost(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
-				m.postRepo.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.postRepo.EXPECT().GetReactionsOnPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(likeReactions(), nil)
+				m.postRepo.EXPECT().GetCommentCount(gomock.Any(), gomock.Any()).Return(uint32(0), nil)
 			},
 		},
 	}
@@ -437,21 +444,139 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestGetJoinedCommunitiesPosts(t *testing.T) {
+	run := func(ctx context.Context, implementation *PostServiceImpl, request models.FeedCursor) (*models.PostPage, error) {
+		return implementation.GetJoinedCommunitiesPosts(ctx, 1, request)
+	}
+	cursor := models.FeedCursor{AsOf: time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC), ID: 10}
+
+	tests := []TableTest[*models.PostPage, models.FeedCursor]{
+		{
+			name: "1",
+			SetupInput: func() (*models.FeedCursor, error) {
+				return &models.FeedCursor{}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*models.PostPage, error) {
+				return nil, nil
+			},
+			ExpectedErr: my_err.ErrNoMoreContent,
+			SetupMock: func(request models.FeedCursor, m *mocks) {
+				m.postRepo.EXPECT().GetJoinedCommunitiesPosts(gomock.Any(), uint32(1), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, userID uint32, cursor models.FeedCursor) (*models.PostPage, error) {
+						// the first page is taken at the current moment
+						if cursor.AsOf.IsZero() {
+							return nil, errMock
+						}
+						return nil, my_err.ErrNoMoreContent
+					},
+				)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*models.FeedCursor, error) {
+				return &cursor, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*models.PostPage, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request models.FeedCursor, m *mocks) {
+				m.postRepo.EXPECT().GetJoinedCommunitiesPosts(gomock.Any(), uint32(1), cursor).Return(
+					&models.PostPage{Posts: []*models.Post{{ID: 9, Header: models.Header{CommunityID: 1}}}}, nil,
+				)
+				m.communityRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{1}).Return(nil, errMock)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*models.FeedCursor, error) {
+				return &cursor, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*models.PostPage, error) {
+				return &models.PostPage{
+					Posts: []*models.Post{
+						{ID: 9, Header: models.Header{CommunityID: 1, Author: "cats"}, Reactions: likeReactions()},
+						{ID: 8, Header: models.Header{CommunityID: 2, Author: "dogs"}, Reactions: likeReactions()},
+						{ID: 7, Header: models.Header{CommunityID: 1, Author: "cats"}, Reactions: likeReactions()},
+					},
+					Cursor: "next",
+				}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request models.FeedCursor, m *mocks) {
+				m.postRepo.EXPECT().GetJoinedCommunitiesPosts(gomock.Any(), uint32(1), cursor).Return(
+					&models.PostPage{
+						Posts: []*models.Post{
+							{ID: 9, Header: models.Header{CommunityID: 1}},
+							{ID: 8, Header: models.Header{CommunityID: 2}},
+							{ID: 7, Header: models.Header{CommunityID: 1}},
+						},
+						Cursor: "next",
+					}, nil,
+				)
+				// every community is asked once
+				m.communityRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{1, 2}).Return([]*models.Header{
+					{CommunityID: 2, Author: "dogs"},
+					{CommunityID: 1, Author: "cats"},
+				}, nil)
+				m.postRepo.EXPECT().GetReactionsOnPosts(gomock.Any(), []uint32{9, 8, 7}, uint32(1)).
+					Return(map[uint32]models.Reactions{9: likeReactions(), 8: likeReactions(), 7: likeReactions()}, nil)
+				m.postRepo.EXPECT().GetCommentCounts(gomock.Any(), []uint32{9, 8, 7}).
+					Return(map[uint32]uint32{9: 0, 8: 0, 7: 0}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getService(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
 type userAndLastIDs struct {
 	UserID uint32
 	LastId uint32
 }
 
 func TestGetBatch(t *testing.T) {
+	run := func(ctx context.Context, implementation *PostServiceImpl, request userAndLastIDs) ([]*models.Post, error) {
+		return implementation.GetBatch(ctx, request.LastId, request.UserID)
+	}
+
 	tests := []TableTest[[]*models.Post, userAndLastIDs]{
 		{
 			name: "1",
 			SetupInput: func() (*userAndLastIDs, error) {
 				return &userAndLastIDs{}, nil
 			},
-			Run: func(ctx context.Context, implementation *PostServiceImpl, request userAndLastIDs) ([]*models.Post, error) {
-				return implementation.GetBatch(ctx, request.LastId, request.UserID)
-			},
+			Run: run,
 			ExpectedResult: func() ([]*models.Post, error) {
 				return nil, nil
 			},
@@ -465,19 +590,17 @@ func TestGetBatch(t *testing.T) {
 			SetupInput: func() (*userAndLastIDs, error) {
 				return &userAndLastIDs{UserID: 1, LastId: 2}, nil
 			},
-			Run: func(ctx context.Context, implementation *PostServiceImpl, request userAndLastIDs) ([]*models.Post, error) {
-				return implementation.GetBatch(ctx, request.LastId, request.UserID)
-			},
+			Run: run,
 			ExpectedResult: func() ([]*models.Post, error) {
 				return nil, nil
 			},
 			ExpectedErr: errMock,
 			SetupMock: func(request userAndLastIDs, m *mocks) {
-				m.postRepo.EXPECT().GetPosts(gomock.Any(), gomock.Any()).Return(
+				m.postRepo.EXPECT().GetPosts(gomock.Any(), uint32(2)).Return(
 					[]*models.Post{
 						{ID: 1, Header: models.Header{CommunityID: 1}},
 					}, nil)
-				m.communityRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).Return(nil, errMock)
+				m.communityRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{1}).Return(nil, errMock)
 			},
 		},
 		{
@@ -485,28 +608,168 @@ func TestGetBatch(t *testing.T) {
 			SetupInput: func() (*userAndLastIDs, error) {
 				return &userAndLastIDs{UserID: 1, LastId: 2}, nil
 			},
-			Run: func(ctx context.Context, implementation *PostServiceImpl, request userAndLastIDs) ([]*models.Post, error) {
-				return implementation.GetBatch(ctx, request.LastId, request.UserID)
-			},
+			Run: run,
 			ExpectedResult: func() ([]*models.Post, error) {
 				return []*models.Post{
 					{
-						ID:         1,
-						Header:     models.Header{AuthorID: 1},
-						IsLiked:    true,
-						LikesCount: 1,
+						ID:        1,
+						Header:    models.Header{AuthorID: 1},
+						Reactions: likeReactions(),
 					},
 				}, nil
 			},
 			ExpectedErr: nil,
 			SetupMock: func(request userAndLastIDs, m *mocks) {
-				m.postRepo.EXPECT().GetPosts(gomock.Any(), gomock.Any()).Return(
+				m.postRepo.EXPECT().GetPosts(gomock.Any(), uint32(2)).Return(
 					[]*models.Post{
 						{ID: 1, Header: models.Header{AuthorID: 1}},
 					}, nil)
-				m.profileRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).Return(&models.Header{AuthorID: 1}, nil)
-				m.postRepo.EXPECT().GetLikesOnPost(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
-				m.postRepo.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.profileRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{1}).Return([]*models.Header{{AuthorID: 1}}, nil)
+				m.postRepo.EXPECT().GetReactionsOnPosts(gomock.Any(), []uint32{1}, uint32(1)).
+					Return(map[uint32]models.Reactions{1: likeReactions()}, nil)
+				m.postRepo.EXPECT().GetCommentCounts(gomock.Any(), []uint32{1}).Return(map[uint32]uint32{1: 0}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getService(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
+func TestGetFeed(t *testing.T) {
+	run := func(ctx context.Context, implementation *PostServiceImpl, request models.FeedCursor) (*models.PostPage, error) {
+		return implementation.GetFeed(ctx, 1, request)
+	}
+	cursor := models.FeedCursor{AsOf: time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC), Score: 0.5, ID: 10}
+
+	tests := []TableTest[*models.PostPage, models.FeedCursor]{
+		{
+			name: "1",
+			SetupInput: func() (*models.FeedCursor, error) {
+				return &models.FeedCursor{}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*models.PostPage, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request models.FeedCursor, m *mocks) {
+				m.profileRepo.EXPECT().GetFriendsID(gomock.Any(), uint32(1)).Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*models.FeedCursor, error) {
+				return &models.FeedCursor{}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*models.PostPage, error) {
+				return nil, nil
+			},
+			ExpectedErr: my_err.ErrNoMoreContent,
+			SetupMock: func(request models.FeedCursor, m *mocks) {
+				m.profileRepo.EXPECT().GetFriendsID(gomock.Any(), uint32(1)).Return(nil, nil)
+				m.postRepo.EXPECT().GetFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, feed *models.Feed) (*models.PostPage, error) {
+						// the first page is ranked at the current moment
+						if feed.Cursor.AsOf.IsZero() || feed.Cursor.ID != 0 {
+							return nil, errMock
+						}
+						return nil, my_err.ErrNoMoreContent
+					},
+				)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*models.FeedCursor, error) {
+				return &cursor, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*models.PostPage, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request models.FeedCursor, m *mocks) {
+				m.profileRepo.EXPECT().GetFriendsID(gomock.Any(), gomock.Any()).Return([]uint32{2}, nil)
+				m.postRepo.EXPECT().GetFeed(gomock.Any(), gomock.Any()).Return(
+					&models.PostPage{Posts: []*models.Post{{ID: 1, Header: models.Header{CommunityID: 1}}}}, nil,
+				)
+				m.communityRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{1}).Return(nil, errMock)
+			},
+		},
+		{
+			name: "4",
+			SetupInput: func() (*models.FeedCursor, error) {
+				return &cursor, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*models.PostPage, error) {
+				return &models.PostPage{
+					Posts: []*models.Post{
+						{
+							ID:        1,
+							Header:    models.Header{AuthorID: 2},
+							Reactions: likeReactions(),
+						},
+					},
+					Cursor: "next",
+				}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request models.FeedCursor, m *mocks) {
+				m.profileRepo.EXPECT().GetFriendsID(gomock.Any(), gomock.Any()).Return([]uint32{2}, nil)
+				m.postRepo.EXPECT().GetFeed(gomock.Any(), &models.Feed{UserID: 1, FriendIDs: []uint32{2}, Cursor: cursor}).
+					Return(&models.PostPage{Posts: []*models.Post{{ID: 1, Header: models.Header{AuthorID: 2}}}, Cursor: "next"}, nil)
+				m.profileRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{2}).Return([]*models.Header{{AuthorID: 2}}, nil)
+				m.postRepo.EXPECT().GetReactionsOnPosts(gomock.Any(), []uint32{1}, gomock.Any()).
+					Return(map[uint32]models.Reactions{1: likeReactions()}, nil)
+				m.postRepo.EXPECT().GetCommentCounts(gomock.Any(), []uint32{1}).Return(map[uint32]uint32{1: 0}, nil)
+			},
+		},
+		{
+			name: "5",
+			SetupInput: func() (*models.FeedCursor, error) {
+				return &cursor, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*models.PostPage, error) {
+				return nil, nil
+			},
+			ExpectedErr: my_err.ErrProfileNotFound,
+			SetupMock: func(request models.FeedCursor, m *mocks) {
+				m.profileRepo.EXPECT().GetFriendsID(gomock.Any(), gomock.Any()).Return([]uint32{2}, nil)
+				m.postRepo.EXPECT().GetFeed(gomock.Any(), gomock.Any()).
+					Return(&models.PostPage{Posts: []*models.Post{{ID: 1, Header: models.Header{AuthorID: 2}}}}, nil)
+				m.profileRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{2}).Return(nil, nil)
+				m.postRepo.EXPECT().GetReactionsOnPosts(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(map[uint32]models.Reactions{}, nil)
+				m.postRepo.EXPECT().GetCommentCounts(gomock.Any(), gomock.Any()).Return(map[uint32]uint32{}, nil)
 			},
 		},
 	}
@@ -609,7 +872,7 @@ func TestGetBatchFromFriend(t *testing.T) {
 					[]*models.Post{
 						{ID: 1, Header: models.Header{CommunityID: 1}},
 					}, nil)
-				m.communityRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).Return(nil, errMock)
+				m.communityRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{1}).Return(nil, errMock)
 			},
 		},
 		{
@@ -623,10 +886,9 @@ func TestGetBatchFromFriend(t *testing.T) {
 			ExpectedResult: func() ([]*models.Post, error) {
 				return []*models.Post{
 					{
-						ID:         1,
-						Header:     models.Header{AuthorID: 1},
-						IsLiked:    true,
-						LikesCount: 1,
+						ID:        1,
+						Header:    models.Header{AuthorID: 1},
+						Reactions: likeReactions(),
 					},
 				}, nil
 			},
@@ -637,9 +899,10 @@ func TestGetBatchFromFriend(t *testing.T) {
 					[]*models.Post{
 						{ID: 1, Header: models.Header{AuthorID: 1}},
 					}, nil)
-				m.profileRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).Return(&models.Header{AuthorID: 1}, nil)
-				m.postRepo.EXPECT().GetLikesOnPost(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
-				m.postRepo.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.profileRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{1}).Return([]*models.Header{{AuthorID: 1}}, nil)
+				m.postRepo.EXPECT().GetReactionsOnPosts(gomock.Any(), []uint32{1}, gomock.Any()).
+					Return(map[uint32]models.Reactions{1: likeReactions()}, nil)
+				m.postRepo.EXPECT().GetCommentCounts(gomock.Any(), []uint32{1}).Return(map[uint32]uint32{1: 0}, nil)
 			},
 		},
 	}
@@ -846,7 +1109,7 @@ func TestGetCommunityPost(t *testing.T) {
 					[]*models.Post{
 						{ID: 1, Header: models.Header{CommunityID: 1}},
 					}, nil)
-				m.communityRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).Return(nil, errMock)
+				m.communityRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{1}).Return(nil, errMock)
 			},
 		},
 		{
@@ -860,10 +1123,9 @@ func TestGetCommunityPost(t *testing.T) {
 			ExpectedResult: func() ([]*models.Post, error) {
 				return []*models.Post{
 					{
-						ID:         1,
-						Header:     models.Header{AuthorID: 1},
-						IsLiked:    true,
-						LikesCount: 1,
+						ID:        1,
+						Header:    models.Header{AuthorID: 1},
+						Reactions: likeReactions(),
 					},
 				}, nil
 			},
@@ -873,9 +1135,10 @@ func TestGetCommunityPost(t *testing.T) {
 					[]*models.Post{
 						{ID: 1, Header: models.Header{AuthorID: 1}},
 					}, nil)
-				m.profileRepo.EXPECT().GetHeader(gomock.Any(), gomock.Any()).Return(&models.Header{AuthorID: 1}, nil)
-				m.postRepo.EXPECT().GetLikesOnPost(gomock.Any(), gomock.Any()).Return(uint32(1), nil)
-				m.postRepo.EXPECT().CheckLikes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.profileRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{1}).Return([]*models.Header{{AuthorID: 1}}, nil)
+				m.postRepo.EXPECT().GetReactionsOnPosts(gomock.Any(), []uint32{1}, gomock.Any()).
+					Return(map[uint32]models.Reactions{1: likeReactions()}, nil)
+				m.postRepo.EXPECT().GetCommentCounts(gomock.Any(), []uint32{1}).Return(map[uint32]uint32{1: 0}, nil)
 			},
 		},
 	}
@@ -981,15 +1244,30 @@ func TestCheckAccessToCommunity(t *testing.T) {
 	}
 }
 
-func TestSetLikeOnPost(t *testing.T) {
+func TestSetReactionToPost(t *testing.T) {
 	tests := []TableTest[struct{}, userAndPostIDs]{
+		{
+			name: "0",
+			SetupInput: func() (*userAndPostIDs, error) {
+				return &userAndPostIDs{}, nil
+			},
+			Run: func(ctx context.Context, implementation *PostServiceImpl, request userAndPostIDs) (struct{}, error) {
+				err := implementation.SetReactionToPost(ctx, request.postId, request.userID, "wow")
+				return struct{}{}, err
+			},
+			ExpectedResult: func() (struct{}, error) {
+				return struct{}{}, nil
+			},
+			ExpectedErr: my_err.ErrWrongReaction,
+			SetupMock:   func(request userAndPostIDs, m *mocks) {},
+		},
 		{
 			name: "1",
 			SetupInput: func() (*userAndPostIDs, error) {
 				return &userAndPostIDs{}, nil
 			},
 			Run: func(ctx context.Context, implementation *PostServiceImpl, request userAndPostIDs) (struct{}, error) {
-				err := implementation.SetLikeToPost(ctx, request.postId, request.userID)
+				err := implementation.SetReactionToPost(ctx, request.postId, request.userID, models.ReactionLove)
 				return struct{}{}, err
 			},
 			ExpectedResult: func() (struct{}, error) {
@@ -997,7 +1275,7 @@ func TestSetLikeOnPost(t *testing.T) {
 			},
 			ExpectedErr: errMock,
 			SetupMock: func(request userAndPostIDs, m *mocks) {
-				m.postRepo.EXPECT().SetLikeToPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(errMock)
+				m.postRepo.EXPECT().SetReactionToPost(gomock.Any(), gomock.Any(), gomock.Any(), models.ReactionLove).Return(errMock)
 			},
 		},
 		{
@@ -1006,7 +1284,7 @@ func TestSetLikeOnPost(t *testing.T) {
 				return &userAndPostIDs{}, nil
 			},
 			Run: func(ctx context.Context, implementation *PostServiceImpl, request userAndPostIDs) (struct{}, error) {
-				err := implementation.SetLikeToPost(ctx, request.postId, request.userID)
+				err := implementation.SetReactionToPost(ctx, request.postId, request.userID, models.ReactionLove)
 				return struct{}{}, err
 			},
 			ExpectedResult: func() (struct{}, error) {
@@ -1014,7 +1292,7 @@ func TestSetLikeOnPost(t *testing.T) {
 			},
 			ExpectedErr: nil,
 			SetupMock: func(request userAndPostIDs, m *mocks) {
-				m.postRepo.EXPECT().SetLikeToPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.postRepo.EXPECT().SetReactionToPost(gomock.Any(), gomock.Any(), gomock.Any(), models.ReactionLove).Return(nil)
 			},
 		},
 	}
@@ -1048,7 +1326,7 @@ func TestSetLikeOnPost(t *testing.T) {
 	}
 }
 
-func TestDeleteLikeFromPost(t *testing.T) {
+func TestDeleteReactionFromPost(t *testing.T) {
 	tests := []TableTest[struct{}, userAndPostIDs]{
 		{
 			name: "1",
@@ -1056,7 +1334,7 @@ func TestDeleteLikeFromPost(t *testing.T) {
 				return &userAndPostIDs{}, nil
 			},
 			Run: func(ctx context.Context, implementation *PostServiceImpl, request userAndPostIDs) (struct{}, error) {
-				err := implementation.DeleteLikeFromPost(ctx, request.postId, request.userID)
+				err := implementation.DeleteReactionFromPost(ctx, request.postId, request.userID)
 				return struct{}{}, err
 			},
 			ExpectedResult: func() (struct{}, error) {
@@ -1064,7 +1342,7 @@ func TestDeleteLikeFromPost(t *testing.T) {
 			},
 			ExpectedErr: errMock,
 			SetupMock: func(request userAndPostIDs, m *mocks) {
-				m.postRepo.EXPECT().DeleteLikeFromPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(errMock)
+				m.postRepo.EXPECT().DeleteReactionFromPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(errMock)
 			},
 		},
 		{
@@ -1073,7 +1351,7 @@ func TestDeleteLikeFromPost(t *testing.T) {
 				return &userAndPostIDs{}, nil
 			},
 			Run: func(ctx context.Context, implementation *PostServiceImpl, request userAndPostIDs) (struct{}, error) {
-				err := implementation.DeleteLikeFromPost(ctx, request.postId, request.userID)
+				err := implementation.DeleteReactionFromPost(ctx, request.postId, request.userID)
 				return struct{}{}, err
 			},
 			ExpectedResult: func() (struct{}, error) {
@@ -1081,7 +1359,7 @@ func TestDeleteLikeFromPost(t *testing.T) {
 			},
 			ExpectedErr: nil,
 			SetupMock: func(request userAndPostIDs, m *mocks) {
-				m.postRepo.EXPECT().DeleteLikeFromPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.postRepo.EXPECT().DeleteReactionFromPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
 	}
@@ -1188,3 +1466,193 @@ type TableTest[T, In any] struct {
 	ExpectedErr    error
 	SetupMock      func(In, *mocks)
 }
+
+func TestGetLikedBy(t *testing.T) {
+	tests := []TableTest[[]*models.ShortProfile, userAndPostIDs]{
+		{
+			name: "1",
+			SetupInput: func() (*userAndPostIDs, error) {
+				return &userAndPostIDs{postId: 1, userID: 1}, nil
+			},
+			Run: func(ctx context.Context, implementation *PostServiceImpl, request userAndPostIDs) ([]*models.ShortProfile, error) {
+				return implementation.GetLikedBy(ctx, request.postId, request.userID, 0)
+			},
+			ExpectedResult: func() ([]*models.ShortProfile, error) {
+				return nil, nil
+			},
+			ExpectedErr: my_err.ErrNoMoreContent,
+			SetupMock: func(request userAndPostIDs, m *mocks) {
+				m.postRepo.EXPECT().GetReactionAuthors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, my_err.ErrNoMoreContent)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*userAndPostIDs, error) {
+				return &userAndPostIDs{postId: 1, userID: 1}, nil
+			},
+			Run: func(ctx context.Context, implementation *PostServiceImpl, request userAndPostIDs) ([]*models.ShortProfile, error) {
+				return implementation.GetLikedBy(ctx, request.postId, request.userID, 0)
+			},
+			ExpectedResult: func() ([]*models.ShortProfile, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request userAndPostIDs, m *mocks) {
+				m.postRepo.EXPECT().GetReactionAuthors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]uint32{3, 2}, nil)
+				m.profileRepo.EXPECT().GetShortProfiles(gomock.Any(), uint32(1), []uint32{3, 2}).Return(nil, errMock)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*userAndPostIDs, error) {
+				return &userAndPostIDs{postId: 1, userID: 1}, nil
+			},
+			Run: func(ctx context.Context, implementation *PostServiceImpl, request userAndPostIDs) ([]*models.ShortProfile, error) {
+				return implementation.GetLikedBy(ctx, request.postId, request.userID, 0)
+			},
+			ExpectedResult: func() ([]*models.ShortProfile, error) {
+				return []*models.ShortProfile{{ID: 3, IsFriend: true}, {ID: 2}}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request userAndPostIDs, m *mocks) {
+				m.postRepo.EXPECT().GetReactionAuthors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]uint32{3, 2}, nil)
+				m.profileRepo.EXPECT().GetShortProfiles(gomock.Any(), uint32(1), []uint32{3, 2}).
+					Return([]*models.ShortProfile{{ID: 3, IsFriend: true}, {ID: 2}}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getService(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	run := func(ctx context.Context, implementation *PostServiceImpl, request models.PostSearch) (*models.PostPage, error) {
+		return implementation.Search(ctx, 1, &request)
+	}
+	tests := []TableTest[*models.PostPage, models.PostSearch]{
+		{
+			name: "1",
+			SetupInput: func() (*models.PostSearch, error) {
+				return &models.PostSearch{Query: "cats", Section: models.SearchSectionFriend}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*models.PostPage, error) {
+				return nil, nil
+			},
+			ExpectedErr: my_err.ErrNoMoreContent,
+			SetupMock: func(request models.PostSearch, m *mocks) {
+				m.profileRepo.EXPECT().GetFriendsID(gomock.Any(), uint32(1)).Return(nil, nil)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*models.PostSearch, error) {
+				return &models.PostSearch{Query: "cats", Section: models.SearchSectionFriend}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*models.PostPage, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request models.PostSearch, m *mocks) {
+				m.profileRepo.EXPECT().GetFriendsID(gomock.Any(), uint32(1)).Return([]uint32{2, 3}, nil)
+				m.postRepo.EXPECT().SearchPosts(
+					gomock.Any(),
+					&models.PostSearch{Query: "cats", Section: models.SearchSectionFriend, AuthorIDs: []uint32{2, 3}},
+				).Return(nil, errMock)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*models.PostSearch, error) {
+				return &models.PostSearch{Query: "cats", Section: models.SearchSectionCommunity}, nil
+			},
+			Run: run,
+			ExpectedResult: func() (*models.PostPage, error) {
+				return &models.PostPage{
+					Posts: []*models.Post{
+						{
+							ID:        1,
+							Header:    models.Header{CommunityID: 4, Author: "community"},
+							Reactions: likeReactions(),
+							Snippet:   "<mark>cats</mark>",
+						},
+					},
+					Cursor: "next",
+				}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request models.PostSearch, m *mocks) {
+				m.postRepo.EXPECT().SearchPosts(
+					gomock.Any(),
+					&models.PostSearch{Query: "cats", Section: models.SearchSectionCommunity, MemberID: 1},
+				).Return(&models.PostPage{
+					Posts:  []*models.Post{{ID: 1, Header: models.Header{CommunityID: 4}, Snippet: "<mark>cats</mark>"}},
+					Cursor: "next",
+				}, nil)
+				m.communityRepo.EXPECT().GetHeaders(gomock.Any(), []uint32{4}).
+					Return([]*models.Header{{CommunityID: 4, Author: "community"}}, nil)
+				m.postRepo.EXPECT().GetReactionsOnPosts(gomock.Any(), []uint32{1}, uint32(1)).
+					Return(map[uint32]models.Reactions{1: likeReactions()}, nil)
+				m.postRepo.EXPECT().GetCommentCounts(gomock.Any(), []uint32{1}).Return(map[uint32]uint32{1: 0}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getService(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+// PresenceTTL is how long an instance keeps a user online without refreshing it,
+// so users of a crashed instance go offline by themselves
+const PresenceTTL = 90 * time.Second
+
+// PresenceRepository keeps online status shared by all chat instances, the other services read it.
+// Every instance holding connections of a user is a member of the presence:<user> sorted set
+// scored with the time its record expires, the user is online while any record is alive
+type PresenceRepository struct {
+	db         *redis.Pool
+	instanceID string
+}
+
+func NewPresenceRepository(db *redis.Pool) *PresenceRepository {
+	return &PresenceRepository{
+		db:         db,
+		instanceID: uuid.NewString(),
+	}
+}
+
+// goOnline adds the record of the instance ARGV[3] expiring at ARGV[2] to the presence KEYS[1]
+// and returns how many other instances hold the user at ARGV[1]
+var goOnline = redis.NewScript(1, `
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+local others = redis.call('ZCOUNT', KEYS[1], ARGV[1], '+inf')
+if redis.call('ZSCORE', KEYS[1], ARGV[3]) then
+	others = others - 1
+end
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[3])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return others
+`)
+
+// goOffline removes the record of the instance ARGV[2] from the presence KEYS[1] and returns
+// how many other instances hold the user at ARGV[1]. The user is seen last at ARGV[3] if there are none
+var goOffline = redis.NewScript(2, `
+redis.call('ZREM', KEYS[1], ARGV[2])
+local others = redis.call('ZCOUNT', KEYS[1], ARGV[1], '+inf')
+if others == 0 then
+	redis.call('SET', KEYS[2], ARGV[3])
+end
+return others
+`)
+
+func presenceKey(userID uint32) string {
+	return "presence:" + strconv.FormatUint(uint64(userID), 10)
+}
+
+func lastSeenKey(userID uint32) string {
+	return "last_seen:" + strconv.FormatUint(uint64(userID), 10)
+}
+
+// Online adds the record of this instance, it returns true if the user has not been online on the other instances
+func (p *PresenceRepository) Online(userID uint32) (bool, error) {
+	conn := p.db.Get()
+	defer conn.Close()
+
+	now := time.Now()
+	others, err := redis.Int(goOnline.Do(
+		conn, presenceKey(userID), now.UnixMilli(), now.Add(PresenceTTL).UnixMilli(), p.instanceID,
+		PresenceTTL.Milliseconds(),
+	))
+	if err != nil {
+		return false, fmt.Errorf("set online: %w", err)
+	}
+
+	return others == 0, nil
+}
+
+// Refresh prolongs the records of the users connected to this instance
+func (p *PresenceRepository) Refresh(userIDs []uint32) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	conn := p.db.Get()
+	defer conn.Close()
+
+	now := time.Now()
+	expire := now.Add(PresenceTTL)
+	for _, id := range userIDs {
+		key := presenceKey(id)
+		// records of crashed instances are not needed anymore
+		if err := conn.Send("ZREMRANGEBYSCORE", key, "-inf", now.UnixMilli()); err != nil {
+			return fmt.Errorf("refresh presence: %w", err)
+		}
+		if err := conn.Send("ZADD", key, expire.UnixMilli(), p.instanceID); err != nil {
+			return fmt.Errorf("refresh presence: %w", err)
+		}
+		if err := conn.Send("PEXPIRE", key, PresenceTTL.Milliseconds()); err != nil {
+			return fmt.Errorf("refresh presence: %w", err)
+		}
+	}
+
+	if _, err := conn.Do(""); err != nil {
+		return fmt.Errorf("refresh presence: %w", err)
+	}
+
+	return nil
+}
+
+// Offline removes the record of this instance, it returns true if the user is not online on the other instances
+func (p *PresenceRepository) Offline(userID uint32, lastSeen time.Time) (bool, error) {
+	conn := p.db.Get()
+	defer conn.Close()
+
+	others, err := redis.Int(goOffline.Do(
+		conn, presenceKey(userID), lastSeenKey(userID), time.Now().UnixMilli(), p.instanceID, lastSeen.Unix(),
+	))
+	if err != nil {
+		return false, fmt.Errorf("set offline: %w", err)
+	}
+
+	return others == 0, nil
+}
+
+func (p *PresenceRepository) GetPresence(userIDs []uint32) (map[uint32]models.Presence, error) {
+	res := make(map[uint32]models.Presence, len(userIDs))
+	if len(userIDs) == 0 {
+		return res, nil
+	}
+
+	conn := p.db.Get()
+	defer conn.Close()
+
+	now := time.Now().UnixMilli()
+	for _, id := range userIDs {
+		if err := conn.Send("ZCOUNT", presenceKey(id), now, "+inf"); err != nil {
+			return nil, fmt.Errorf("get presence: %w", err)
+		}
+		if err := conn.Send("GET", lastSeenKey(id)); err != nil {
+			return nil, fmt.Errorf("get presence: %w", err)
+		}
+	}
+
+	values, err := redis.Values(conn.Do(""))
+	if err != nil {
+		return nil, fmt.Errorf("get presence: %w", err)
+	}
+
+	for i, id := range userIDs {
+		online, err := redis.Int(values[2*i], nil)
+		if err != nil {
+			return nil, fmt.Errorf("get presence: %w", err)
+		}
+
+		presence := models.Presence{UserID: id, IsOnline: online > 0}
+		if !presence.IsOnline && values[2*i+1] != nil {
+			seen, err := redis.Int64(values[2*i+1], nil)
+			if err != nil {
+				return nil, fmt.Errorf("get presence: %w", err)
+			}
+			presence.LastSeen = time.Unix(seen, 0).UTC()
+		}
+		res[id] = presence
+	}
+
+	return res, nil
+}
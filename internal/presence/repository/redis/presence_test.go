@@ -0,0 +1,88 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+)
+
+func getPool(t *testing.T) (*redis.Pool, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", addr)
+		},
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	return pool, mr
+}
+
+func TestPresenceRepository(t *testing.T) {
+	pool, mr := getPool(t)
+	first := NewPresenceRepository(pool)
+	second := NewPresenceRepository(pool)
+
+	changed, err := first.Online(1)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	// the record of the instance itself is not another instance
+	changed, err = first.Online(1)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	changed, err = second.Online(1)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	require.NoError(t, second.Refresh([]uint32{2}))
+	require.NoError(t, second.Refresh(nil))
+
+	res, err := first.GetPresence([]uint32{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32]models.Presence{
+		1: {UserID: 1, IsOnline: true},
+		2: {UserID: 2, IsOnline: true},
+		3: {UserID: 3},
+	}, res)
+
+	// the user is still connected to the second instance
+	seen := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	changed, err = first.Offline(1, seen)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	res, err = first.GetPresence([]uint32{1})
+	require.NoError(t, err)
+	assert.True(t, res[1].IsOnline)
+	assert.False(t, mr.Exists("last_seen:1"))
+
+	changed, err = second.Offline(1, seen)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	res, err = first.GetPresence([]uint32{1})
+	require.NoError(t, err)
+	assert.Equal(t, models.Presence{UserID: 1, LastSeen: seen}, res[1])
+
+	// the instance holding the user is gone and does not refresh the record anymore
+	mr.FastForward(PresenceTTL + time.Second)
+	res, err = first.GetPresence([]uint32{2})
+	require.NoError(t, err)
+	assert.False(t, res[2].IsOnline)
+
+	res, err = first.GetPresence(nil)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	mr.Close()
+	_, err = first.GetPresence([]uint32{1})
+	assert.Error(t, err)
+	_, err = first.Online(1)
+	assert.Error(t, err)
+	_, err = first.Offline(1, seen)
+	assert.Error(t, err)
+}
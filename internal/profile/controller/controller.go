@@ -7,6 +7,7 @@ import (
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 
@@ -484,13 +485,12 @@ func (h *ProfileHandlerImplementation) GetCommunitySubs(w http.ResponseWriter, r
 	h.Responder.OutputJSON(w, subs, reqID)
 }
 
+// SearchProfile finds the profiles with names similar to q, also written in the other alphabet.
+// The next page is asked with the cursor of the previous one
 func (h *ProfileHandlerImplementation) SearchProfile(w http.ResponseWriter, r *http.Request) {
 	var (
 		reqID, ok = r.Context().Value("requestID").(string)
-		subStr    = r.URL.Query().Get("q")
-		lastID    = r.URL.Query().Get("id")
-		id        uint64
-		err       error
+		subStr    = strings.TrimSpace(r.URL.Query().Get("q"))
 	)
 
 	if !ok {
@@ -502,17 +502,25 @@ func (h *ProfileHandlerImplementation) SearchProfile(w http.ResponseWriter, r *h
 		return
 	}
 
-	if lastID == "" {
-		id = math.MaxInt32
-	} else {
-		id, err = strconv.ParseUint(lastID, 10, 32)
-		if err != nil {
-			h.Responder.ErrorBadRequest(w, my_err.ErrInvalidQuery, reqID)
-			return
-		}
+	cursor, err := models.ParseSearchCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		h.Responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		h.Responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	search := &models.ProfileSearch{Query: subStr, SelfID: sess.UserID, Cursor: cursor}
+	page, err := h.ProfileManager.Search(r.Context(), search)
+	if errors.Is(err, my_err.ErrNoMoreContent) {
+		h.Responder.OutputNoMoreContentJSON(w, reqID)
+		return
 	}
 
-	profiles, err := h.ProfileManager.Search(r.Context(), subStr, uint32(id))
 	if err != nil {
 		if errors.Is(err, my_err.ErrSessionNotFound) {
 			h.Responder.ErrorBadRequest(w, err, reqID)
@@ -523,5 +531,5 @@ func (h *ProfileHandlerImplementation) SearchProfile(w http.ResponseWriter, r *h
 		return
 	}
 
-	h.Responder.OutputJSON(w, profiles, reqID)
+	h.Responder.OutputJSON(w, page, reqID)
 }
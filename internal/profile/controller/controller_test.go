@@ -2205,6 +2205,7 @@ func TestSearch(t *testing.T) {
 			name: "2",
 			SetupInput: func() (*Request, error) {
 				req := httptest.NewRequest(http.MethodGet, "/api/v1/profile/search?q=waha", nil)
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
 				w := httptest.NewRecorder()
 				res := &Request{r: req, w: w}
 				return res, nil
@@ -2220,7 +2221,8 @@ func TestSearch(t *testing.T) {
 			ExpectedErr: nil,
 			SetupMock: func(request Request, m *mocks) {
 				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
-				m.profileManager.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				m.profileManager.EXPECT().Search(gomock.Any(), &models.ProfileSearch{Query: "waha", SelfID: 1}).
+					Return(nil, errors.New("error"))
 				m.responder.EXPECT().ErrorInternal(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
 					request.w.WriteHeader(http.StatusInternalServerError)
 					request.w.Write([]byte("error"))
@@ -2230,7 +2232,7 @@ func TestSearch(t *testing.T) {
 		{
 			name: "3",
 			SetupInput: func() (*Request, error) {
-				req := httptest.NewRequest(http.MethodGet, "/api/v1/profile/search?q=waha&id=lkjlhg", nil)
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/profile/search?q=waha&cursor=lkjlhg", nil)
 				w := httptest.NewRecorder()
 				res := &Request{r: req, w: w}
 				return res, nil
@@ -2255,7 +2257,10 @@ func TestSearch(t *testing.T) {
 		{
 			name: "4",
 			SetupInput: func() (*Request, error) {
-				req := httptest.NewRequest(http.MethodGet, "/api/v1/profile/search?q=waha&id=100", nil)
+				req := httptest.NewRequest(
+					http.MethodGet, "/api/v1/profile/search?q=waha&cursor="+models.SearchCursor{Rank: 0.5, ID: 100}.String(), nil,
+				)
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
 				w := httptest.NewRecorder()
 				res := &Request{r: req, w: w}
 				return res, nil
@@ -2271,7 +2276,9 @@ func TestSearch(t *testing.T) {
 			ExpectedErr: nil,
 			SetupMock: func(request Request, m *mocks) {
 				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
-				m.profileManager.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				m.profileManager.EXPECT().Search(gomock.Any(), &models.ProfileSearch{
+					Query: "waha", SelfID: 1, Cursor: models.SearchCursor{Rank: 0.5, ID: 100},
+				}).Return(&models.ProfilePage{}, nil)
 				m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(func(w, data, req any) {
 					request.w.WriteHeader(http.StatusOK)
 					request.w.Write([]byte("OK"))
@@ -2297,13 +2304,39 @@ func TestSearch(t *testing.T) {
 			ExpectedErr: nil,
 			SetupMock: func(request Request, m *mocks) {
 				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
-				m.profileManager.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, my_err.ErrSessionNotFound)
 				m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
 					request.w.WriteHeader(http.StatusBadRequest)
 					request.w.Write([]byte("bad request"))
 				})
 			},
 		},
+		{
+			name: "6",
+			SetupInput: func() (*Request, error) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/profile/search?q=%D0%B8%D0%B2%D0%B0", nil)
+				req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+				w := httptest.NewRecorder()
+				res := &Request{r: req, w: w}
+				return res, nil
+			},
+			Run: func(ctx context.Context, implementation *ProfileHandlerImplementation, request Request) (Response, error) {
+				implementation.SearchProfile(request.w, request.r)
+				res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+				return res, nil
+			},
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusNoContent}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.profileManager.EXPECT().Search(gomock.Any(), &models.ProfileSearch{Query: "ива", SelfID: 1}).
+					Return(nil, my_err.ErrNoMoreContent)
+				m.responder.EXPECT().OutputNoMoreContentJSON(request.w, gomock.Any()).Do(func(w, req any) {
+					request.w.WriteHeader(http.StatusNoContent)
+				})
+			},
+		},
 	}
 
 	for _, v := range tests {
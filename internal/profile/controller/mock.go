@@ -184,18 +184,18 @@ func (mr *MockProfileUsecaseMockRecorder) RemoveFromFriends(who, whose interface
 }
 
 // Search mocks base method.
-func (m *MockProfileUsecase) Search(ctx context.Context, subStr string, lastId uint32) ([]*models.ShortProfile, error) {
+func (m *MockProfileUsecase) Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, subStr, lastId)
-	ret0, _ := ret[0].([]*models.ShortProfile)
+	ret := m.ctrl.Call(m, "Search", ctx, search)
+	ret0, _ := ret[0].(*models.ProfilePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockProfileUsecaseMockRecorder) Search(ctx, subStr, lastId interface{}) *gomock.Call {
+func (mr *MockProfileUsecaseMockRecorder) Search(ctx, search interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockProfileUsecase)(nil).Search), ctx, subStr, lastId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockProfileUsecase)(nil).Search), ctx, search)
 }
 
 // SendFriendReq mocks base method.
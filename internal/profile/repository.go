@@ -13,7 +13,7 @@ type Repository interface {
 	UpdateProfile(context.Context, *models.FullProfile) error
 	UpdateWithAvatar(context.Context, *models.FullProfile) error
 	DeleteProfile(uint32) error
-	Search(ctx context.Context, subStr string, lastId uint32) ([]*models.ShortProfile, error)
+	Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error)
 
 	CheckFriendship(context.Context, uint32, uint32) (bool, error)
 	AddFriendsReq(receiver uint32, sender uint32) error
@@ -35,3 +35,8 @@ type Repository interface {
 type PostGetter interface {
 	GetAuthorsPosts(ctx context.Context, header *models.Header, userID uint32) ([]*models.Post, error)
 }
+
+// PresenceGetter tells who of the users is online right now
+type PresenceGetter interface {
+	GetPresence(userIDs []uint32) (map[uint32]models.Presence, error)
+}
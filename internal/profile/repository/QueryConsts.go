@@ -2,7 +2,16 @@ package repository
 
 const (
 	CreateUser     = `INSERT INTO profile (first_name, last_name, email, hashed_password) VALUES ($1, $2, $3, $4) ON CONFLICT (email) DO NOTHING RETURNING id;`
-	GetUserByEmail = `SELECT id, first_name, last_name, email, hashed_password FROM profile WHERE email = $1 LIMIT 1;`
+	GetUserByEmail = `SELECT id, first_name, last_name, email, hashed_password, email_verified FROM profile WHERE email = $1 LIMIT 1;`
+	GetUserByID    = `SELECT id, first_name, last_name, email, hashed_password, email_verified FROM profile WHERE id = $1 LIMIT 1;`
+	UpdatePassword = `UPDATE profile SET hashed_password = $1 WHERE id = $2;`
+	VerifyEmail    = `UPDATE profile SET email_verified = TRUE WHERE id = $1 AND email = $2;`
+
+	GetTwoFactor = `SELECT secret, enabled FROM two_factor WHERE profile_id = $1;`
+	// SaveTwoFactor replaces the recovery codes along with the second factor, so they are never left from the old one
+	SaveTwoFactor   = `WITH factor AS (INSERT INTO two_factor (profile_id, secret, enabled) VALUES ($1, $2, $3) ON CONFLICT (profile_id) DO UPDATE SET secret = EXCLUDED.secret, enabled = EXCLUDED.enabled, updated_at = NOW() RETURNING profile_id), removed AS (DELETE FROM recovery_code WHERE profile_id = $1) INSERT INTO recovery_code (profile_id, code_hash) SELECT factor.profile_id, UNNEST($4::TEXT[]) FROM factor;`
+	DeleteTwoFactor = `DELETE FROM two_factor WHERE profile_id = $1;`
+	UseRecoveryCode = `DELETE FROM recovery_code WHERE profile_id = $1 AND code_hash = $2;`
 
 	GetProfileByID      = "SELECT profile.id, first_name, last_name, bio, avatar FROM profile WHERE profile.id = $1 LIMIT 1;"
 	GetStatus           = "SELECT status FROM friend WHERE (sender = $1 AND receiver = $2) LIMIT 1"
@@ -25,15 +34,39 @@ const (
 	GetSubscriptionsID = "SELECT sender AS subscription FROM friend WHERE (receiver = $1 AND status = -1) UNION SELECT receiver AS subscriber FROM friend WHERE (sender = $1 AND status = 1)"
 	GetAllStatuses     = "WITH friends AS (\n    SELECT sender AS friend\n    FROM friend\n    WHERE (receiver = $1 AND status = 0)\n    UNION\n    SELECT receiver AS friend\n    FROM friend\n    WHERE (sender = $1 AND status = 0)\n), subscriptions AS (\n    SELECT sender AS subscription FROM friend WHERE (receiver = $1 AND status = -1) UNION SELECT receiver AS subscriber FROM friend WHERE (sender = $1 AND status = 1)\n), subscribers AS (\n    SELECT sender AS subscriber FROM friend WHERE (receiver = $1 AND status = 1) UNION SELECT receiver AS subscriber FROM friend WHERE (sender = $1 AND status = -1)) SELECT (SELECT json_agg(friend) FROM friends) AS friends, (SELECT json_agg(subscriber) FROM subscribers) AS subscribers, (SELECT json_agg(subscription) FROM subscriptions) AS subscriptions;"
 	GetShortProfile    = "SELECT first_name || ' ' || last_name AS name, avatar FROM profile WHERE profile.id = $1 LIMIT 1;"
+	GetHeaders         = "SELECT id, first_name || ' ' || last_name AS name, avatar FROM profile WHERE id = ANY($1::int[]);"
+	GetShortProfiles   = "SELECT id, first_name, last_name, avatar FROM profile WHERE id = ANY($1::int[]);"
 
 	GetCommunitySubs = `WITH subs AS (SELECT profile_id AS id FROM community_profile WHERE community_id = $1) SELECT p.id, first_name, last_name, avatar FROM profile p JOIN subs ON p.id = subs.id WHERE id > $2 ORDER BY id LIMIT $3;`
 
+	// SetSearchThreshold sets the word similarity threshold of the %> operator until the end of the transaction
+	SetSearchThreshold = `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true);`
+	// Search ranks the profiles by the word similarity of their names to the closest spelling of the query,
+	// friends and then friends of friends of the user go higher. The candidates come from profile_name_trgm_idx,
+	// so the query runs after SetSearchThreshold in the same transaction
 	Search = `
-SELECT id, first_name, last_name, avatar
-FROM profile
-WHERE 
-    (first_name || ' ' || last_name ILIKE '%' || $1 || '%' OR last_name || ' ' || first_name  ILIKE '%' || $1 || '%')
-	AND id < $2
-ORDER BY first_name ASC
-LIMIT $3;`
+WITH friends AS (
+    SELECT receiver AS id FROM friend WHERE sender = $1 AND status = 0
+    UNION SELECT sender FROM friend WHERE receiver = $1 AND status = 0
+), friends_of_friends AS (
+    SELECT f.receiver AS id FROM friend f JOIN friends ON f.sender = friends.id WHERE f.status = 0 AND f.receiver <> $1
+    UNION SELECT f.sender FROM friend f JOIN friends ON f.receiver = friends.id WHERE f.status = 0 AND f.sender <> $1
+), found AS (
+    SELECT p.id, p.first_name, p.last_name, p.avatar,
+        (SELECT max(word_similarity(q, p.first_name || ' ' || p.last_name)) FROM unnest($2::text[]) AS q) AS similarity
+    FROM profile p
+    WHERE (p.first_name || ' ' || p.last_name) %> ANY($2::text[])
+), ranked AS (
+    SELECT id, first_name, last_name, avatar, (similarity + CASE
+        WHEN id IN (SELECT id FROM friends) THEN 0.3
+        WHEN id IN (SELECT id FROM friends_of_friends) THEN 0.15
+        ELSE 0 END)::real AS rank
+    FROM found
+    WHERE similarity >= $3
+)
+SELECT id, first_name, last_name, avatar, rank
+FROM ranked
+WHERE $5 = 0 OR (rank, id) < ($4::real, $5)
+ORDER BY rank DESC, id DESC
+LIMIT $6;`
 )
@@ -8,13 +8,18 @@ import (
 	"fmt"
 
 	_ "github.com/jackc/pgx"
+	"github.com/lib/pq"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
 	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+	"github.com/2024_2_BetterCallFirewall/pkg/translit"
 )
 
 const LIMIT = 20
 
+// searchThreshold is the least word similarity of a found name to the query
+const searchThreshold = 0.5
+
 type ProfileRepo struct {
 	DB *sql.DB
 }
@@ -41,7 +46,9 @@ func (p *ProfileRepo) Create(user *models.User, ctx context.Context) (uint32, er
 
 func (p *ProfileRepo) GetByEmail(email string, ctx context.Context) (*models.User, error) {
 	user := &models.User{}
-	err := p.DB.QueryRowContext(ctx, GetUserByEmail, email).Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Password)
+	err := p.DB.QueryRowContext(ctx, GetUserByEmail, email).Scan(
+		&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Password, &user.EmailVerified,
+	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("postgres get user: %w", my_err.ErrUserNotFound)
@@ -52,6 +59,110 @@ func (p *ProfileRepo) GetByEmail(email string, ctx context.Context) (*models.Use
 	return user, nil
 }
 
+func (p *ProfileRepo) GetByID(ctx context.Context, id uint32) (*models.User, error) {
+	user := &models.User{}
+	err := p.DB.QueryRowContext(ctx, GetUserByID, id).Scan(
+		&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Password, &user.EmailVerified,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("postgres get user by id: %w", my_err.ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("postgres get user by id: %w", err)
+	}
+
+	return user, nil
+}
+
+// UpdatePassword sets the hashed password of the user
+func (p *ProfileRepo) UpdatePassword(ctx context.Context, id uint32, password string) error {
+	res, err := p.DB.ExecContext(ctx, UpdatePassword, password, id)
+	if err != nil {
+		return fmt.Errorf("postgres update password: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("postgres update password: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("postgres update password: %w", my_err.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// VerifyEmail marks the email of the user verified if it is still the email of the user
+func (p *ProfileRepo) VerifyEmail(ctx context.Context, id uint32, email string) error {
+	res, err := p.DB.ExecContext(ctx, VerifyEmail, id, email)
+	if err != nil {
+		return fmt.Errorf("postgres verify email: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("postgres verify email: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("postgres verify email: %w", my_err.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func (p *ProfileRepo) GetTwoFactor(ctx context.Context, id uint32) (*models.TwoFactor, error) {
+	res := &models.TwoFactor{UserID: id}
+	err := p.DB.QueryRowContext(ctx, GetTwoFactor, id).Scan(&res.Secret, &res.Enabled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("postgres get two factor: %w", my_err.ErrTwoFactorNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("postgres get two factor: %w", err)
+	}
+
+	return res, nil
+}
+
+// SaveTwoFactor creates or replaces the second factor of the user with its recovery codes
+func (p *ProfileRepo) SaveTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error {
+	codes := twoFactor.RecoveryCodes
+	if codes == nil {
+		codes = []string{}
+	}
+	_, err := p.DB.ExecContext(
+		ctx, SaveTwoFactor, twoFactor.UserID, twoFactor.Secret, twoFactor.Enabled, pq.Array(codes),
+	)
+	if err != nil {
+		return fmt.Errorf("postgres save two factor: %w", err)
+	}
+
+	return nil
+}
+
+func (p *ProfileRepo) DeleteTwoFactor(ctx context.Context, id uint32) error {
+	_, err := p.DB.ExecContext(ctx, DeleteTwoFactor, id)
+	if err != nil {
+		return fmt.Errorf("postgres delete two factor: %w", err)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode deletes the recovery code of the user by its hash, so it can be used once
+func (p *ProfileRepo) UseRecoveryCode(ctx context.Context, id uint32, codeHash string) error {
+	res, err := p.DB.ExecContext(ctx, UseRecoveryCode, id, codeHash)
+	if err != nil {
+		return fmt.Errorf("postgres use recovery code: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("postgres use recovery code: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("postgres use recovery code: %w", my_err.ErrWrongTwoFactorCode)
+	}
+
+	return nil
+}
+
 func (p *ProfileRepo) GetProfileById(ctx context.Context, id uint32) (*models.FullProfile, error) {
 	res := &models.FullProfile{}
 	err := p.DB.QueryRowContext(ctx, GetProfileByID, id).Scan(&res.ID, &res.FirstName, &res.LastName, &res.Bio, &res.Avatar)
@@ -313,6 +424,47 @@ func (p *ProfileRepo) GetHeader(ctx context.Context, u uint32) (*models.Header,
 	return profile, nil
 }
 
+// GetHeaders returns the headers of the existing profiles of ids in no particular order
+func (p *ProfileRepo) GetHeaders(ctx context.Context, ids []uint32) ([]*models.Header, error) {
+	rows, err := p.DB.QueryContext(ctx, GetHeaders, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("get headers db: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.Header, 0, len(ids))
+	for rows.Next() {
+		header := &models.Header{}
+		if err := rows.Scan(&header.AuthorID, &header.Author, &header.Avatar); err != nil {
+			return nil, fmt.Errorf("get headers db: %w", err)
+		}
+		res = append(res, header)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get headers db: %w", err)
+	}
+
+	return res, nil
+}
+
+func (p *ProfileRepo) GetShortProfiles(ctx context.Context, ids []uint32) ([]*models.ShortProfile, error) {
+	res := make([]*models.ShortProfile, 0, len(ids))
+	rows, err := p.DB.QueryContext(ctx, GetShortProfiles, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("get short profiles db: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		profile := &models.ShortProfile{}
+		err = rows.Scan(&profile.ID, &profile.FirstName, &profile.LastName, &profile.Avatar)
+		if err != nil {
+			return nil, fmt.Errorf("get short profiles db: %w", err)
+		}
+		res = append(res, profile)
+	}
+	return res, nil
+}
+
 func (p *ProfileRepo) GetCommunitySubs(ctx context.Context, communityID uint32, lastInsertId uint32) ([]*models.ShortProfile, error) {
 	var subs []*models.ShortProfile
 	rows, err := p.DB.QueryContext(ctx, GetCommunitySubs, communityID, lastInsertId, LIMIT)
@@ -331,26 +483,55 @@ func (p *ProfileRepo) GetCommunitySubs(ctx context.Context, communityID uint32,
 	return subs, nil
 }
 
-func (p *ProfileRepo) Search(ctx context.Context, query string, lastID uint32) ([]*models.ShortProfile, error) {
-	res := make([]*models.ShortProfile, 0)
+// Search returns a page of the profiles with names similar to the query or to its transliteration,
+// the cursor of the page is set if there are more
+func (p *ProfileRepo) Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error) {
+	tx, err := p.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("search profile: %w", err)
+	}
+	// nothing is written, so ending the transaction with a rollback only drops the threshold
+	defer tx.Rollback()
 
-	rows, err := p.DB.QueryContext(ctx, Search, query, lastID, LIMIT)
+	if _, err := tx.ExecContext(ctx, SetSearchThreshold, searchThreshold); err != nil {
+		return nil, fmt.Errorf("search profile: %w", err)
+	}
+	rows, err := tx.QueryContext(
+		ctx, Search, search.SelfID, pq.Array(translit.Variants(search.Query)), searchThreshold,
+		search.Cursor.Rank, search.Cursor.ID, LIMIT+1,
+	)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, my_err.ErrNoMoreContent
-		}
-		return nil, fmt.Errorf("search community: %w", err)
+		return nil, fmt.Errorf("search profile: %w", err)
 	}
 	defer rows.Close()
+
+	var (
+		page = &models.ProfilePage{}
+		last models.SearchCursor
+	)
 	for rows.Next() {
-		profile := &models.ShortProfile{}
-		err = rows.Scan(&profile.ID, &profile.FirstName, &profile.LastName, &profile.Avatar)
-		if err != nil {
-			return nil, fmt.Errorf("search community: %w", err)
+		var (
+			profile = &models.ShortProfile{}
+			rank    float32
+		)
+		if err := rows.Scan(&profile.ID, &profile.FirstName, &profile.LastName, &profile.Avatar, &rank); err != nil {
+			return nil, fmt.Errorf("search profile: %w", err)
 		}
 
-		res = append(res, profile)
+		if len(page.Profiles) == LIMIT {
+			page.Cursor = last.String()
+			break
+		}
+		page.Profiles = append(page.Profiles, profile)
+		last = models.SearchCursor{Rank: rank, ID: profile.ID}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search profile: %w", err)
 	}
 
-	return res, nil
+	if len(page.Profiles) == 0 {
+		return nil, my_err.ErrNoMoreContent
+	}
+
+	return page, nil
 }
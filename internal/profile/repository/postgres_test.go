@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
@@ -806,3 +807,338 @@ func TestGetHeader(t *testing.T) {
 		}
 	}
 }
+
+func TestGetHeaders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	ids := []uint32{1, 3}
+	expect := []*models.Header{
+		{AuthorID: 1, Author: "Alexey Zemliakov", Avatar: "/image"},
+		{AuthorID: 3, Author: "Andrew Savvateev", Avatar: "/default"},
+	}
+	ProfileManager := NewProfileRepo(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "avatar"})
+	for _, item := range expect {
+		rows = rows.AddRow(item.AuthorID, item.Author, item.Avatar)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(GetHeaders)).WithArgs(pq.Array(ids)).WillReturnRows(rows)
+	res, err := ProfileManager.GetHeaders(context.Background(), ids)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, res)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetHeaders)).WithArgs(pq.Array(ids)).WillReturnError(errMockDb)
+	_, err = ProfileManager.GetHeaders(context.Background(), ids)
+	assert.ErrorIs(t, err, errMockDb)
+
+	errRows := sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "Andrew Savvateev")
+	mock.ExpectQuery(regexp.QuoteMeta(GetHeaders)).WithArgs(pq.Array(ids)).WillReturnRows(errRows)
+	_, err = ProfileManager.GetHeaders(context.Background(), ids)
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"id", "first_name", "last_name", "avatar", "rank"}
+	fullRows := sqlmock.NewRows(columns)
+	var expectFull []*models.ShortProfile
+	for i := LIMIT + 1; i > 0; i-- {
+		fullRows = fullRows.AddRow(i, "Ivan", "Ivanov", "/default", 0.9)
+		if i > 1 {
+			expectFull = append(expectFull, &models.ShortProfile{
+				ID: uint32(i), FirstName: "Ivan", LastName: "Ivanov", Avatar: "/default",
+			})
+		}
+	}
+
+	tests := []struct {
+		search      *models.ProfileSearch
+		rows        *sqlmock.Rows
+		dbError     error
+		resPage     *models.ProfilePage
+		expectedErr error
+	}{
+		{
+			search: &models.ProfileSearch{Query: "Ivan", SelfID: 1},
+			rows:   fullRows,
+			resPage: &models.ProfilePage{
+				Profiles: expectFull,
+				Cursor:   models.SearchCursor{Rank: 0.9, ID: 2}.String(),
+			},
+		},
+		{
+			search: &models.ProfileSearch{Query: "иван", SelfID: 1, Cursor: models.SearchCursor{Rank: 0.9, ID: 2}},
+			rows:   sqlmock.NewRows(columns).AddRow(1, "Ivan", "Ivanov", "/default", 0.9),
+			resPage: &models.ProfilePage{
+				Profiles: []*models.ShortProfile{{ID: 1, FirstName: "Ivan", LastName: "Ivanov", Avatar: "/default"}},
+			},
+		},
+		{
+			search:      &models.ProfileSearch{Query: "nobody", SelfID: 1},
+			rows:        sqlmock.NewRows(columns),
+			expectedErr: my_err.ErrNoMoreContent,
+		},
+		{
+			search:      &models.ProfileSearch{Query: "Ivan", SelfID: 1},
+			rows:        sqlmock.NewRows(columns),
+			dbError:     errMockDb,
+			expectedErr: errMockDb,
+		},
+	}
+
+	ProfileManager := NewProfileRepo(db)
+	for casenum, test := range tests {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(SetSearchThreshold)).
+			WithArgs(searchThreshold).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(Search)).
+			WithArgs(
+				test.search.SelfID, sqlmock.AnyArg(), searchThreshold,
+				test.search.Cursor.Rank, test.search.Cursor.ID, LIMIT+1,
+			).
+			WillReturnRows(test.rows).WillReturnError(test.dbError)
+		mock.ExpectRollback()
+
+		page, err := ProfileManager.Search(context.Background(), test.search)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("case [%d]: there were unfulfilled expectations: %v", casenum, err)
+		}
+		assert.Equalf(t, test.resPage, page, "case [%d]: results must match", casenum)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("case [%d]: errors must match, have %v, want %v", casenum, err, test.expectedErr)
+		}
+	}
+}
+
+func TestUpdatePassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	tests := []Test{
+		{
+			inputID:    1,
+			execResult: sqlmock.NewResult(0, 1),
+		},
+		{
+			inputID:     2,
+			execResult:  sqlmock.NewResult(0, 0),
+			expectedErr: my_err.ErrUserNotFound,
+		},
+		{
+			inputID:     3,
+			expectedErr: errMockDb,
+			dbError:     errMockDb,
+		},
+	}
+
+	ProfileManager := NewProfileRepo(db)
+	for casenum, test := range tests {
+		mock.ExpectExec(regexp.QuoteMeta(UpdatePassword)).
+			WithArgs("hash", test.inputID).
+			WillReturnResult(test.execResult).
+			WillReturnError(test.dbError)
+		err := ProfileManager.UpdatePassword(context.Background(), test.inputID, "hash")
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("case [%d]: errors must match, have %v, want %v", casenum, err, test.expectedErr)
+		}
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("case [%d]: there were unfulfilled expectations: %v", casenum, err)
+		}
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	ProfileManager := NewProfileRepo(db)
+	columns := []string{"id", "first_name", "last_name", "email", "hashed_password", "email_verified"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetUserByID)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Andrew", "Savvateev", "andrew@mail.ru", "hash", true))
+	user, err := ProfileManager.GetByID(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.User{
+		ID: 1, FirstName: "Andrew", LastName: "Savvateev", Email: "andrew@mail.ru", Password: "hash", EmailVerified: true,
+	}, user)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetUserByID)).WithArgs(2).WillReturnRows(sqlmock.NewRows(columns))
+	_, err = ProfileManager.GetByID(context.Background(), 2)
+	assert.ErrorIs(t, err, my_err.ErrUserNotFound)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetUserByID)).WithArgs(3).WillReturnError(errMockDb)
+	_, err = ProfileManager.GetByID(context.Background(), 3)
+	assert.ErrorIs(t, err, errMockDb)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVerifyEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	tests := []Test{
+		{
+			inputID:    1,
+			execResult: sqlmock.NewResult(0, 1),
+		},
+		{
+			inputID:     2,
+			execResult:  sqlmock.NewResult(0, 0),
+			expectedErr: my_err.ErrUserNotFound,
+		},
+		{
+			inputID:     3,
+			expectedErr: errMockDb,
+			dbError:     errMockDb,
+		},
+	}
+
+	ProfileManager := NewProfileRepo(db)
+	for casenum, test := range tests {
+		mock.ExpectExec(regexp.QuoteMeta(VerifyEmail)).
+			WithArgs(test.inputID, "andrew@mail.ru").
+			WillReturnResult(test.execResult).
+			WillReturnError(test.dbError)
+		err := ProfileManager.VerifyEmail(context.Background(), test.inputID, "andrew@mail.ru")
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("case [%d]: errors must match, have %v, want %v", casenum, err, test.expectedErr)
+		}
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("case [%d]: there were unfulfilled expectations: %v", casenum, err)
+		}
+	}
+}
+
+func TestGetTwoFactor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	repo := NewProfileRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetTwoFactor)).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"secret", "enabled"}).AddRow("SECRET", true))
+	res, err := repo.GetTwoFactor(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.TwoFactor{UserID: 1, Secret: "SECRET", Enabled: true}, res)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetTwoFactor)).WithArgs(2).WillReturnError(sql.ErrNoRows)
+	_, err = repo.GetTwoFactor(context.Background(), 2)
+	assert.ErrorIs(t, err, my_err.ErrTwoFactorNotFound)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetTwoFactor)).WithArgs(3).WillReturnError(errMockDb)
+	_, err = repo.GetTwoFactor(context.Background(), 3)
+	assert.ErrorIs(t, err, errMockDb)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveTwoFactor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	repo := NewProfileRepo(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(SaveTwoFactor)).
+		WithArgs(1, "SECRET", true, pq.Array([]string{"a", "b"})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	err = repo.SaveTwoFactor(
+		context.Background(), &models.TwoFactor{UserID: 1, Secret: "SECRET", Enabled: true, RecoveryCodes: []string{"a", "b"}},
+	)
+	assert.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta(SaveTwoFactor)).
+		WithArgs(2, "SECRET", false, pq.Array([]string{})).
+		WillReturnError(errMockDb)
+	err = repo.SaveTwoFactor(context.Background(), &models.TwoFactor{UserID: 2, Secret: "SECRET"})
+	assert.ErrorIs(t, err, errMockDb)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTwoFactor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	repo := NewProfileRepo(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(DeleteTwoFactor)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.DeleteTwoFactor(context.Background(), 1))
+
+	mock.ExpectExec(regexp.QuoteMeta(DeleteTwoFactor)).WithArgs(2).WillReturnError(errMockDb)
+	assert.ErrorIs(t, repo.DeleteTwoFactor(context.Background(), 2), errMockDb)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	tests := []Test{
+		{
+			inputID:    1,
+			execResult: sqlmock.NewResult(0, 1),
+		},
+		{
+			inputID:     2,
+			execResult:  sqlmock.NewResult(0, 0),
+			expectedErr: my_err.ErrWrongTwoFactorCode,
+		},
+		{
+			inputID:     3,
+			expectedErr: errMockDb,
+			dbError:     errMockDb,
+		},
+	}
+
+	repo := NewProfileRepo(db)
+	for casenum, test := range tests {
+		mock.ExpectExec(regexp.QuoteMeta(UseRecoveryCode)).
+			WithArgs(test.inputID, "hash").
+			WillReturnResult(test.execResult).
+			WillReturnError(test.dbError)
+		err := repo.UseRecoveryCode(context.Background(), test.inputID, "hash")
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("case [%d]: errors must match, have %v, want %v", casenum, err, test.expectedErr)
+		}
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("case [%d]: there were unfulfilled expectations: %v", casenum, err)
+		}
+	}
+}
@@ -50,6 +50,20 @@ func (mr *MockrepositoryMockRecorder) Create(user, ctx interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockrepository)(nil).Create), user, ctx)
 }
 
+// DeleteTwoFactor mocks base method.
+func (m *Mockrepository) DeleteTwoFactor(ctx context.Context, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTwoFactor", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTwoFactor indicates an expected call of DeleteTwoFactor.
+func (mr *MockrepositoryMockRecorder) DeleteTwoFactor(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactor", reflect.TypeOf((*Mockrepository)(nil).DeleteTwoFactor), ctx, id)
+}
+
 // GetByEmail mocks base method.
 func (m *Mockrepository) GetByEmail(email string, ctx context.Context) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +79,21 @@ func (mr *MockrepositoryMockRecorder) GetByEmail(email, ctx interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*Mockrepository)(nil).GetByEmail), email, ctx)
 }
 
+// GetByID mocks base method.
+func (m *Mockrepository) GetByID(ctx context.Context, id uint32) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockrepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*Mockrepository)(nil).GetByID), ctx, id)
+}
+
 // GetFriendsID mocks base method.
 func (m *Mockrepository) GetFriendsID(arg0 context.Context, arg1 uint32) ([]uint32, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +123,136 @@ func (mr *MockrepositoryMockRecorder) GetHeader(arg0, arg1 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*Mockrepository)(nil).GetHeader), arg0, arg1)
 }
+
+// GetHeaders mocks base method.
+func (m *Mockrepository) GetHeaders(arg0 context.Context, arg1 []uint32) ([]*models.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeaders", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeaders indicates an expected call of GetHeaders.
+func (mr *MockrepositoryMockRecorder) GetHeaders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeaders", reflect.TypeOf((*Mockrepository)(nil).GetHeaders), arg0, arg1)
+}
+
+// GetShortProfiles mocks base method.
+func (m *Mockrepository) GetShortProfiles(arg0 context.Context, arg1 []uint32) ([]*models.ShortProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortProfiles", arg0, arg1)
+	ret0, _ := ret[0].([]*models.ShortProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortProfiles indicates an expected call of GetShortProfiles.
+func (mr *MockrepositoryMockRecorder) GetShortProfiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortProfiles", reflect.TypeOf((*Mockrepository)(nil).GetShortProfiles), arg0, arg1)
+}
+
+// GetStatuses mocks base method.
+func (m *Mockrepository) GetStatuses(arg0 context.Context, arg1 uint32) ([]uint32, []uint32, []uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatuses", arg0, arg1)
+	ret0, _ := ret[0].([]uint32)
+	ret1, _ := ret[1].([]uint32)
+	ret2, _ := ret[2].([]uint32)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetStatuses indicates an expected call of GetStatuses.
+func (mr *MockrepositoryMockRecorder) GetStatuses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatuses", reflect.TypeOf((*Mockrepository)(nil).GetStatuses), arg0, arg1)
+}
+
+// GetTwoFactor mocks base method.
+func (m *Mockrepository) GetTwoFactor(ctx context.Context, id uint32) (*models.TwoFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwoFactor", ctx, id)
+	ret0, _ := ret[0].(*models.TwoFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactor indicates an expected call of GetTwoFactor.
+func (mr *MockrepositoryMockRecorder) GetTwoFactor(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactor", reflect.TypeOf((*Mockrepository)(nil).GetTwoFactor), ctx, id)
+}
+
+// SaveTwoFactor mocks base method.
+func (m *Mockrepository) SaveTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTwoFactor", ctx, twoFactor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTwoFactor indicates an expected call of SaveTwoFactor.
+func (mr *MockrepositoryMockRecorder) SaveTwoFactor(ctx, twoFactor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTwoFactor", reflect.TypeOf((*Mockrepository)(nil).SaveTwoFactor), ctx, twoFactor)
+}
+
+// Search mocks base method.
+func (m *Mockrepository) Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search)
+	ret0, _ := ret[0].(*models.ProfilePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockrepositoryMockRecorder) Search(ctx, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*Mockrepository)(nil).Search), ctx, search)
+}
+
+// UpdatePassword mocks base method.
+func (m *Mockrepository) UpdatePassword(ctx context.Context, id uint32, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockrepositoryMockRecorder) UpdatePassword(ctx, id, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*Mockrepository)(nil).UpdatePassword), ctx, id, password)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *Mockrepository) UseRecoveryCode(ctx context.Context, id uint32, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, id, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockrepositoryMockRecorder) UseRecoveryCode(ctx, id, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*Mockrepository)(nil).UseRecoveryCode), ctx, id, codeHash)
+}
+
+// VerifyEmail mocks base method.
+func (m *Mockrepository) VerifyEmail(ctx context.Context, id uint32, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, id, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockrepositoryMockRecorder) VerifyEmail(ctx, id, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*Mockrepository)(nil).VerifyEmail), ctx, id, email)
+}
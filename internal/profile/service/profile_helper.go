@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
 )
@@ -11,8 +12,19 @@ import (
 type repository interface {
 	Create(user *models.User, ctx context.Context) (uint32, error)
 	GetByEmail(email string, ctx context.Context) (*models.User, error)
+	GetByID(ctx context.Context, id uint32) (*models.User, error)
+	UpdatePassword(ctx context.Context, id uint32, password string) error
+	VerifyEmail(ctx context.Context, id uint32, email string) error
+	GetTwoFactor(ctx context.Context, id uint32) (*models.TwoFactor, error)
+	SaveTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error
+	DeleteTwoFactor(ctx context.Context, id uint32) error
+	UseRecoveryCode(ctx context.Context, id uint32, codeHash string) error
 	GetFriendsID(context.Context, uint32) ([]uint32, error)
 	GetHeader(context.Context, uint32) (*models.Header, error)
+	GetHeaders(context.Context, []uint32) ([]*models.Header, error)
+	GetShortProfiles(context.Context, []uint32) ([]*models.ShortProfile, error)
+	GetStatuses(context.Context, uint32) ([]uint32, []uint32, []uint32, error)
+	Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error)
 }
 
 type ProfileHelper struct {
@@ -41,6 +53,64 @@ func (p ProfileHelper) GetByEmail(ctx context.Context, email string) (*models.Us
 	return user, nil
 }
 
+func (p ProfileHelper) GetByID(ctx context.Context, userID uint32) (*models.User, error) {
+	user, err := p.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user by id usecase: %w", err)
+	}
+
+	return user, nil
+}
+
+func (p ProfileHelper) UpdatePassword(ctx context.Context, userID uint32, password string) error {
+	if err := p.repo.UpdatePassword(ctx, userID, password); err != nil {
+		return fmt.Errorf("update password usecase: %w", err)
+	}
+
+	return nil
+}
+
+func (p ProfileHelper) VerifyEmail(ctx context.Context, userID uint32, email string) error {
+	if err := p.repo.VerifyEmail(ctx, userID, email); err != nil {
+		return fmt.Errorf("verify email usecase: %w", err)
+	}
+
+	return nil
+}
+
+func (p ProfileHelper) GetTwoFactor(ctx context.Context, userID uint32) (*models.TwoFactor, error) {
+	twoFactor, err := p.repo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get two factor usecase: %w", err)
+	}
+
+	return twoFactor, nil
+}
+
+func (p ProfileHelper) SaveTwoFactor(ctx context.Context, twoFactor *models.TwoFactor) error {
+	if err := p.repo.SaveTwoFactor(ctx, twoFactor); err != nil {
+		return fmt.Errorf("save two factor usecase: %w", err)
+	}
+
+	return nil
+}
+
+func (p ProfileHelper) DeleteTwoFactor(ctx context.Context, userID uint32) error {
+	if err := p.repo.DeleteTwoFactor(ctx, userID); err != nil {
+		return fmt.Errorf("delete two factor usecase: %w", err)
+	}
+
+	return nil
+}
+
+func (p ProfileHelper) UseRecoveryCode(ctx context.Context, userID uint32, codeHash string) error {
+	if err := p.repo.UseRecoveryCode(ctx, userID, codeHash); err != nil {
+		return fmt.Errorf("use recovery code usecase: %w", err)
+	}
+
+	return nil
+}
+
 func (p ProfileHelper) GetHeader(ctx context.Context, userID uint32) (*models.Header, error) {
 	header, err := p.repo.GetHeader(ctx, userID)
 	if err != nil {
@@ -50,6 +120,15 @@ func (p ProfileHelper) GetHeader(ctx context.Context, userID uint32) (*models.He
 	return header, nil
 }
 
+func (p ProfileHelper) GetHeaders(ctx context.Context, userIDs []uint32) ([]*models.Header, error) {
+	headers, err := p.repo.GetHeaders(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get headers usecase: %w", err)
+	}
+
+	return headers, nil
+}
+
 func (p ProfileHelper) GetFriendsID(ctx context.Context, userID uint32) ([]uint32, error) {
 	res, err := p.repo.GetFriendsID(ctx, userID)
 	if err != nil {
@@ -58,3 +137,59 @@ func (p ProfileHelper) GetFriendsID(ctx context.Context, userID uint32) ([]uint3
 
 	return res, nil
 }
+
+// GetShortProfiles returns profiles in the order of ids, with relation flags as seen by selfID
+func (p ProfileHelper) GetShortProfiles(ctx context.Context, selfID uint32, ids []uint32) ([]*models.ShortProfile, error) {
+	profiles, err := p.repo.GetShortProfiles(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get short profiles usecase: %w", err)
+	}
+
+	if err := p.setStatuses(ctx, selfID, profiles); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint32]*models.ShortProfile, len(profiles))
+	for _, profile := range profiles {
+		byID[profile.ID] = profile
+	}
+
+	res := make([]*models.ShortProfile, 0, len(profiles))
+	for _, id := range ids {
+		if profile, ok := byID[id]; ok {
+			res = append(res, profile)
+		}
+	}
+
+	return res, nil
+}
+
+// Search returns a page of profiles with names similar to the query, with relation flags as seen by SelfID
+func (p ProfileHelper) Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error) {
+	page, err := p.repo.Search(ctx, search)
+	if err != nil {
+		return nil, fmt.Errorf("search usecase: %w", err)
+	}
+
+	if err := p.setStatuses(ctx, search.SelfID, page.Profiles); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (p ProfileHelper) setStatuses(ctx context.Context, selfID uint32, profiles []*models.ShortProfile) error {
+	friends, subs, subscriptions, err := p.repo.GetStatuses(ctx, selfID)
+	if err != nil {
+		return fmt.Errorf("get status usecase: %w", err)
+	}
+
+	for _, profile := range profiles {
+		profile.IsFriend = slices.Contains(friends, profile.ID)
+		profile.IsSubscriber = slices.Contains(subs, profile.ID)
+		profile.IsSubscription = slices.Contains(subscriptions, profile.ID)
+		profile.IsAuthor = profile.ID == selfID
+	}
+
+	return nil
+}
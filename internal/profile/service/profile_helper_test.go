@@ -304,6 +304,237 @@ func TestGetFriendsID(t *testing.T) {
 	}
 }
 
+func TestGetShortProfiles(t *testing.T) {
+	tests := []TableTest[[]*models.ShortProfile, []uint32]{
+		{
+			name: "1",
+			SetupInput: func() (*[]uint32, error) {
+				return &[]uint32{3, 2}, nil
+			},
+			Run: func(ctx context.Context, implementation *ProfileHelper, request []uint32) ([]*models.ShortProfile, error) {
+				return implementation.GetShortProfiles(ctx, 1, request)
+			},
+			ExpectedResult: func() ([]*models.ShortProfile, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request []uint32, m *mocksHelper) {
+				m.repo.EXPECT().GetShortProfiles(gomock.Any(), request).Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*[]uint32, error) {
+				return &[]uint32{3, 2}, nil
+			},
+			Run: func(ctx context.Context, implementation *ProfileHelper, request []uint32) ([]*models.ShortProfile, error) {
+				return implementation.GetShortProfiles(ctx, 1, request)
+			},
+			ExpectedResult: func() ([]*models.ShortProfile, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request []uint32, m *mocksHelper) {
+				m.repo.EXPECT().GetShortProfiles(gomock.Any(), request).Return([]*models.ShortProfile{{ID: 2}}, nil)
+				m.repo.EXPECT().GetStatuses(gomock.Any(), uint32(1)).Return(nil, nil, nil, errMock)
+			},
+		},
+		{
+			name: "3",
+			SetupInput: func() (*[]uint32, error) {
+				return &[]uint32{3, 1, 2, 4}, nil
+			},
+			Run: func(ctx context.Context, implementation *ProfileHelper, request []uint32) ([]*models.ShortProfile, error) {
+				return implementation.GetShortProfiles(ctx, 1, request)
+			},
+			ExpectedResult: func() ([]*models.ShortProfile, error) {
+				return []*models.ShortProfile{
+					{ID: 3, IsSubscription: true},
+					{ID: 1, IsAuthor: true},
+					{ID: 2, IsFriend: true},
+				}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request []uint32, m *mocksHelper) {
+				m.repo.EXPECT().GetShortProfiles(gomock.Any(), request).
+					Return([]*models.ShortProfile{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+				m.repo.EXPECT().GetStatuses(gomock.Any(), uint32(1)).Return([]uint32{2}, []uint32{5}, []uint32{3}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getServiceHelper(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSearchHelper(t *testing.T) {
+	tests := []TableTest[*models.ProfilePage, models.ProfileSearch]{
+		{
+			name: "1",
+			SetupInput: func() (*models.ProfileSearch, error) {
+				return &models.ProfileSearch{Query: "ivan", SelfID: 1}, nil
+			},
+			Run: func(ctx context.Context, implementation *ProfileHelper, request models.ProfileSearch) (*models.ProfilePage, error) {
+				return implementation.Search(ctx, &request)
+			},
+			ExpectedResult: func() (*models.ProfilePage, error) {
+				return nil, nil
+			},
+			ExpectedErr: errMock,
+			SetupMock: func(request models.ProfileSearch, m *mocksHelper) {
+				m.repo.EXPECT().Search(gomock.Any(), &request).Return(nil, errMock)
+			},
+		},
+		{
+			name: "2",
+			SetupInput: func() (*models.ProfileSearch, error) {
+				return &models.ProfileSearch{Query: "ivan", SelfID: 1, Cursor: models.SearchCursor{Rank: 0.5, ID: 10}}, nil
+			},
+			Run: func(ctx context.Context, implementation *ProfileHelper, request models.ProfileSearch) (*models.ProfilePage, error) {
+				return implementation.Search(ctx, &request)
+			},
+			ExpectedResult: func() (*models.ProfilePage, error) {
+				return &models.ProfilePage{
+					Profiles: []*models.ShortProfile{
+						{ID: 1, FirstName: "Ivan", IsAuthor: true},
+						{ID: 2, FirstName: "Ivan", IsFriend: true},
+						{ID: 3, FirstName: "Ivan", IsSubscription: true},
+					},
+					Cursor: "next",
+				}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request models.ProfileSearch, m *mocksHelper) {
+				m.repo.EXPECT().Search(gomock.Any(), &request).Return(&models.ProfilePage{
+					Profiles: []*models.ShortProfile{
+						{ID: 1, FirstName: "Ivan"},
+						{ID: 2, FirstName: "Ivan"},
+						{ID: 3, FirstName: "Ivan"},
+					},
+					Cursor: "next",
+				}, nil)
+				m.repo.EXPECT().GetStatuses(gomock.Any(), uint32(1)).Return([]uint32{2}, []uint32{5}, []uint32{3}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getServiceHelper(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
+func TestPasswordOfUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	serv, m := getServiceHelper(ctrl)
+	ctx := context.Background()
+
+	m.repo.EXPECT().GetByID(gomock.Any(), uint32(1)).Return(&models.User{ID: 1, Password: "hash"}, nil)
+	user, err := serv.GetByID(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.User{ID: 1, Password: "hash"}, user)
+	m.repo.EXPECT().GetByID(gomock.Any(), uint32(2)).Return(nil, errMock)
+	_, err = serv.GetByID(ctx, 2)
+	assert.ErrorIs(t, err, errMock)
+
+	m.repo.EXPECT().UpdatePassword(gomock.Any(), uint32(1), "new hash").Return(nil)
+	assert.NoError(t, serv.UpdatePassword(ctx, 1, "new hash"))
+	m.repo.EXPECT().UpdatePassword(gomock.Any(), uint32(2), "new hash").Return(errMock)
+	assert.ErrorIs(t, serv.UpdatePassword(ctx, 2, "new hash"), errMock)
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	serv, m := getServiceHelper(ctrl)
+	ctx := context.Background()
+
+	m.repo.EXPECT().VerifyEmail(gomock.Any(), uint32(1), "email@mail.ru").Return(nil)
+	assert.NoError(t, serv.VerifyEmail(ctx, 1, "email@mail.ru"))
+	m.repo.EXPECT().VerifyEmail(gomock.Any(), uint32(2), "email@mail.ru").Return(errMock)
+	assert.ErrorIs(t, serv.VerifyEmail(ctx, 2, "email@mail.ru"), errMock)
+}
+
+func TestTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	serv, m := getServiceHelper(ctrl)
+	ctx := context.Background()
+
+	twoFactor := &models.TwoFactor{UserID: 1, Secret: "SECRET", Enabled: true}
+	m.repo.EXPECT().GetTwoFactor(gomock.Any(), uint32(1)).Return(twoFactor, nil)
+	res, err := serv.GetTwoFactor(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, twoFactor, res)
+	m.repo.EXPECT().GetTwoFactor(gomock.Any(), uint32(2)).Return(nil, errMock)
+	_, err = serv.GetTwoFactor(ctx, 2)
+	assert.ErrorIs(t, err, errMock)
+
+	m.repo.EXPECT().SaveTwoFactor(gomock.Any(), twoFactor).Return(nil)
+	assert.NoError(t, serv.SaveTwoFactor(ctx, twoFactor))
+	m.repo.EXPECT().SaveTwoFactor(gomock.Any(), twoFactor).Return(errMock)
+	assert.ErrorIs(t, serv.SaveTwoFactor(ctx, twoFactor), errMock)
+
+	m.repo.EXPECT().DeleteTwoFactor(gomock.Any(), uint32(1)).Return(nil)
+	assert.NoError(t, serv.DeleteTwoFactor(ctx, 1))
+	m.repo.EXPECT().DeleteTwoFactor(gomock.Any(), uint32(2)).Return(errMock)
+	assert.ErrorIs(t, serv.DeleteTwoFactor(ctx, 2), errMock)
+
+	m.repo.EXPECT().UseRecoveryCode(gomock.Any(), uint32(1), "hash").Return(nil)
+	assert.NoError(t, serv.UseRecoveryCode(ctx, 1, "hash"))
+	m.repo.EXPECT().UseRecoveryCode(gomock.Any(), uint32(2), "hash").Return(errMock)
+	assert.ErrorIs(t, serv.UseRecoveryCode(ctx, 2, "hash"), errMock)
+}
+
 type TableTest[T, In any] struct {
 	name           string
 	SetupInput     func() (*In, error)
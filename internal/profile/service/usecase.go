@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/2024_2_BetterCallFirewall/internal/models"
 	"github.com/2024_2_BetterCallFirewall/internal/profile"
 	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
@@ -15,10 +17,20 @@ import (
 type ProfileUsecaseImplementation struct {
 	repo        profile.Repository
 	postManager profile.PostGetter
+	presence    profile.PresenceGetter
+	logger      *logrus.Logger
 }
 
-func NewProfileUsecase(profileRepo profile.Repository, postRepo profile.PostGetter) *ProfileUsecaseImplementation {
-	return &ProfileUsecaseImplementation{repo: profileRepo, postManager: postRepo}
+func NewProfileUsecase(
+	profileRepo profile.Repository, postRepo profile.PostGetter, presence profile.PresenceGetter,
+	logger *logrus.Logger,
+) *ProfileUsecaseImplementation {
+	return &ProfileUsecaseImplementation{
+		repo:        profileRepo,
+		postManager: postRepo,
+		presence:    presence,
+		logger:      logger,
+	}
 }
 
 func (p ProfileUsecaseImplementation) GetProfileById(ctx context.Context, u uint32) (*models.FullProfile, error) {
@@ -61,6 +73,8 @@ func (p ProfileUsecaseImplementation) GetProfileById(ctx context.Context, u uint
 	}
 
 	profile.Posts = posts
+	profile.Presence = p.getPresence(u)
+
 	return profile, nil
 }
 
@@ -223,9 +237,25 @@ func (p ProfileUsecaseImplementation) GetHeader(ctx context.Context, userID uint
 		return nil, fmt.Errorf("get header usecase: %w", err)
 	}
 
+	header.Presence = p.getPresence(userID)
+
 	return header, nil
 }
 
+// getPresence is best-effort: presence is decoration, so an unavailable store
+// leaves it unknown instead of failing the whole request.
+func (p ProfileUsecaseImplementation) getPresence(userID uint32) *models.Presence {
+	presence, err := p.presence.GetPresence([]uint32{userID})
+	if err != nil {
+		p.logger.Warnf("get presence of user %d: %v", userID, err)
+		return nil
+	}
+
+	res := presence[userID]
+	res.UserID = userID
+	return &res
+}
+
 func (p ProfileUsecaseImplementation) GetCommunitySubs(ctx context.Context, communityID, lastId uint32) ([]*models.ShortProfile, error) {
 	subs, err := p.repo.GetCommunitySubs(ctx, communityID, lastId)
 	if err != nil {
@@ -235,16 +265,16 @@ func (p ProfileUsecaseImplementation) GetCommunitySubs(ctx context.Context, comm
 	return subs, nil
 }
 
-func (p ProfileUsecaseImplementation) Search(ctx context.Context, subStr string, lastId uint32) ([]*models.ShortProfile, error) {
-	profiles, err := p.repo.Search(ctx, subStr, lastId)
+func (p ProfileUsecaseImplementation) Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error) {
+	page, err := p.repo.Search(ctx, search)
 	if err != nil {
 		return nil, fmt.Errorf("search: %w", err)
 	}
 
-	err = p.setStatuses(ctx, profiles)
+	err = p.setStatuses(ctx, page.Profiles)
 	if err != nil {
 		return nil, fmt.Errorf("search: %w", err)
 	}
 
-	return profiles, nil
+	return page, nil
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/2024_2_BetterCallFirewall/internal/models"
@@ -24,18 +25,33 @@ func (m MockProfileDB) GetCommunitySubs(ctx context.Context, communityID uint32,
 	return nil, nil
 }
 
-func (m MockProfileDB) Search(ctx context.Context, search string, id uint32) ([]*models.ShortProfile, error) {
-	if search == "" {
+func (m MockProfileDB) Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error) {
+	if search.Query == "" {
 		return nil, ErrExec
 	}
 
-	return nil, nil
+	return &models.ProfilePage{Profiles: []*models.ShortProfile{{ID: search.SelfID}}}, nil
 }
 
 type MockPostDB struct {
 	Storage struct{}
 }
 
+type MockPresence struct{}
+
+func (m MockPresence) GetPresence(userIDs []uint32) (map[uint32]models.Presence, error) {
+	res := make(map[uint32]models.Presence)
+	for _, id := range userIDs {
+		if id == 3 {
+			return nil, ErrExec
+		}
+		if id == 1 {
+			res[id] = models.Presence{UserID: id, IsOnline: true}
+		}
+	}
+	return res, nil
+}
+
 type Test struct {
 	ctx              context.Context
 	userID           uint32
@@ -51,7 +67,7 @@ type Test struct {
 var (
 	profileDB = MockProfileDB{}
 	postDB    = MockPostDB{}
-	pu        = NewProfileUsecase(profileDB, postDB)
+	pu        = NewProfileUsecase(profileDB, postDB, MockPresence{}, logrus.New())
 
 	examplePost = &models.Post{
 		ID:          1,
@@ -176,7 +192,7 @@ func (m MockProfileDB) GetFriendsID(ctx context.Context, u uint32) ([]uint32, er
 }
 
 func (m MockProfileDB) GetHeader(ctx context.Context, u uint32) (*models.Header, error) {
-	if u == 1 {
+	if u == 1 || u == 3 {
 		return &models.Header{AuthorID: u, Author: "Andrew Savvateev"}, nil
 	}
 	return nil, sql.ErrNoRows
@@ -591,10 +607,20 @@ func TestGetAllSubscriptions(t *testing.T) {
 
 func TestGetHeader(t *testing.T) {
 	tests := []Test{
+		{
+			ctx:    context.Background(),
+			userID: 1,
+			resHeader: &models.Header{
+				AuthorID: 1,
+				Author:   "Andrew Savvateev",
+				Presence: &models.Presence{UserID: 1, IsOnline: true},
+			},
+			err: nil,
+		},
 		{
 			ctx:       context.Background(),
-			userID:    1,
-			resHeader: &models.Header{AuthorID: 1, Author: "Andrew Savvateev"},
+			userID:    3,
+			resHeader: &models.Header{AuthorID: 3, Author: "Andrew Savvateev"},
 			err:       nil,
 		},
 		{
@@ -624,7 +650,7 @@ type TestSearchInput struct {
 	ID  uint32
 	ctx context.Context
 
-	want []*models.ShortProfile
+	want *models.ProfilePage
 	err  error
 }
 
@@ -633,11 +659,11 @@ func TestSearch(t *testing.T) {
 		{str: "", ID: 0, ctx: context.Background(), want: nil, err: ErrExec},
 		{str: "alexey", ID: 1, ctx: context.Background(), want: nil, err: my_err.ErrSessionNotFound},
 		{str: "alexey", ID: 10, ctx: models.ContextWithSession(context.Background(), &models.Session{ID: "1", UserID: 10}),
-			want: nil, err: nil},
+			want: &models.ProfilePage{Profiles: []*models.ShortProfile{{ID: 10, IsAuthor: true}}}, err: nil},
 	}
 
 	for caseNum, test := range tests {
-		res, err := pu.Search(test.ctx, test.str, test.ID)
+		res, err := pu.Search(test.ctx, &models.ProfileSearch{Query: test.str, SelfID: test.ID})
 		if !errors.Is(err, test.err) {
 			t.Errorf("[%d] wrong error, expected: %#v, got: %#v", caseNum, test.err, err)
 		}
@@ -11,7 +11,7 @@ type ProfileUsecase interface {
 	GetAll(ctx context.Context, self uint32, lastId uint32) ([]*models.ShortProfile, error)
 	UpdateProfile(context.Context, *models.FullProfile) error
 	DeleteProfile(uint32) error
-	Search(ctx context.Context, subStr string, lastId uint32) ([]*models.ShortProfile, error)
+	Search(ctx context.Context, search *models.ProfileSearch) (*models.ProfilePage, error)
 
 	SendFriendReq(receiver uint32, sender uint32) error
 	AcceptFriendReq(who uint32, whose uint32) error
@@ -14,7 +14,7 @@ import (
 
 type SessionManager interface {
 	Check(string) (*models.Session, error)
-	Create(userID uint32) (*models.Session, error)
+	Rotate(sess *models.Session) (*models.Session, error)
 	Destroy(sess *models.Session) error
 }
 
@@ -22,6 +22,18 @@ type AuthController interface {
 	Register(w http.ResponseWriter, r *http.Request)
 	Auth(w http.ResponseWriter, r *http.Request)
 	Logout(w http.ResponseWriter, r *http.Request)
+	ListSessions(w http.ResponseWriter, r *http.Request)
+	RevokeSession(w http.ResponseWriter, r *http.Request)
+	RevokeOtherSessions(w http.ResponseWriter, r *http.Request)
+	ChangePassword(w http.ResponseWriter, r *http.Request)
+	ForgotPassword(w http.ResponseWriter, r *http.Request)
+	ResetPassword(w http.ResponseWriter, r *http.Request)
+	VerifyEmail(w http.ResponseWriter, r *http.Request)
+	ResendVerification(w http.ResponseWriter, r *http.Request)
+	LoginTwoFactor(w http.ResponseWriter, r *http.Request)
+	EnrollTwoFactor(w http.ResponseWriter, r *http.Request)
+	ConfirmTwoFactor(w http.ResponseWriter, r *http.Request)
+	DisableTwoFactor(w http.ResponseWriter, r *http.Request)
 }
 
 func NewRouter(
@@ -30,7 +42,33 @@ func NewRouter(
 	router := mux.NewRouter()
 	router.HandleFunc("/api/v1/auth/register", authControl.Register).Methods(http.MethodPost, http.MethodOptions)
 	router.HandleFunc("/api/v1/auth/login", authControl.Auth).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/api/v1/auth/login/2fa", authControl.LoginTwoFactor).Methods(
+		http.MethodPost, http.MethodOptions,
+	)
 	router.HandleFunc("/api/v1/auth/logout", authControl.Logout).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/api/v1/auth/sessions", authControl.ListSessions).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/api/v1/auth/sessions", authControl.RevokeOtherSessions).Methods(
+		http.MethodDelete, http.MethodOptions,
+	)
+	router.HandleFunc("/api/v1/auth/sessions/{id}", authControl.RevokeSession).Methods(
+		http.MethodDelete, http.MethodOptions,
+	)
+	router.HandleFunc("/api/v1/auth/password", authControl.ChangePassword).Methods(http.MethodPut, http.MethodOptions)
+	router.HandleFunc("/api/v1/auth/password/forgot", authControl.ForgotPassword).Methods(
+		http.MethodPost, http.MethodOptions,
+	)
+	router.HandleFunc("/api/v1/auth/password/reset", authControl.ResetPassword).Methods(
+		http.MethodPost, http.MethodOptions,
+	)
+	router.HandleFunc("/api/v1/auth/verify", authControl.VerifyEmail).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/api/v1/auth/verify/resend", authControl.ResendVerification).Methods(
+		http.MethodPost, http.MethodOptions,
+	)
+	router.HandleFunc("/api/v1/auth/2fa", authControl.EnrollTwoFactor).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/api/v1/auth/2fa", authControl.DisableTwoFactor).Methods(http.MethodDelete, http.MethodOptions)
+	router.HandleFunc("/api/v1/auth/2fa/confirm", authControl.ConfirmTwoFactor).Methods(
+		http.MethodPost, http.MethodOptions,
+	)
 
 	router.Handle("/api/v1/metrics", promhttp.Handler())
 	router.Handle(
@@ -19,11 +19,35 @@ func (m mockController) Auth(w http.ResponseWriter, r *http.Request) {}
 
 func (m mockController) Logout(w http.ResponseWriter, r *http.Request) {}
 
+func (m mockController) ListSessions(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockController) RevokeSession(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockController) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockController) ChangePassword(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockController) ForgotPassword(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockController) ResetPassword(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockController) VerifyEmail(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockController) ResendVerification(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockController) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockController) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockController) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockController) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {}
+
 type mockMiddleware struct{}
 
 func (m mockMiddleware) Check(str string) (*models.Session, error) { return nil, nil }
 
-func (m mockMiddleware) Create(userID uint32) (*models.Session, error) { return nil, nil }
+func (m mockMiddleware) Rotate(sess *models.Session) (*models.Session, error) { return nil, nil }
 
 func (m mockMiddleware) Destroy(sess *models.Session) error { return nil }
 
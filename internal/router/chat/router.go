@@ -16,21 +16,56 @@ type ChatController interface {
 	SetConnection(w http.ResponseWriter, r *http.Request)
 	GetAllChats(w http.ResponseWriter, r *http.Request)
 	GetChat(w http.ResponseWriter, r *http.Request)
+	ReadChat(w http.ResponseWriter, r *http.Request)
+	GetUnreadCount(w http.ResponseWriter, r *http.Request)
+	EditMessage(w http.ResponseWriter, r *http.Request)
+	DeleteMessage(w http.ResponseWriter, r *http.Request)
+	SetReactionOnMessage(w http.ResponseWriter, r *http.Request)
+	DeleteReactionFromMessage(w http.ResponseWriter, r *http.Request)
+
+	CreateGroup(w http.ResponseWriter, r *http.Request)
+	GetGroup(w http.ResponseWriter, r *http.Request)
+	UpdateGroup(w http.ResponseWriter, r *http.Request)
+	GetGroupChat(w http.ResponseWriter, r *http.Request)
+	ReadGroup(w http.ResponseWriter, r *http.Request)
+	AddGroupMembers(w http.ResponseWriter, r *http.Request)
+	KickGroupMember(w http.ResponseWriter, r *http.Request)
+	LeaveGroup(w http.ResponseWriter, r *http.Request)
+	SetGroupAdmin(w http.ResponseWriter, r *http.Request)
+	UnsetGroupAdmin(w http.ResponseWriter, r *http.Request)
 }
 
 type SessionManager interface {
 	Check(string) (*models.Session, error)
-	Create(userID uint32) (*models.Session, error)
+	Rotate(sess *models.Session) (*models.Session, error)
 	Destroy(sess *models.Session) error
 }
 
 func NewRouter(
-	cc ChatController, sm SessionManager, logger *logrus.Logger, chatMetrics *metrics.HttpMetrics,
+	cc ChatController, sm SessionManager, limits middleware.Limits, logger *logrus.Logger,
+	chatMetrics *metrics.HttpMetrics,
 ) http.Handler {
 	router := mux.NewRouter()
+	router.Use(middleware.Verified(limits))
 
 	router.HandleFunc("/api/v1/messages/chats", cc.GetAllChats).Methods(http.MethodGet, http.MethodOptions)
 	router.HandleFunc("/api/v1/messages/chat/{id}", cc.GetChat).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/chat/{id}/read", cc.ReadChat).Methods(http.MethodPut, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/unread", cc.GetUnreadCount).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/{id}", cc.EditMessage).Methods(http.MethodPut, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/{id}", cc.DeleteMessage).Methods(http.MethodDelete, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/{id}/reaction", cc.SetReactionOnMessage).Methods(http.MethodPut, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/{id}/reaction", cc.DeleteReactionFromMessage).Methods(http.MethodDelete, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/groups", cc.CreateGroup).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/groups/{id}", cc.GetGroup).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/groups/{id}", cc.UpdateGroup).Methods(http.MethodPut, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/groups/{id}/messages", cc.GetGroupChat).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/groups/{id}/read", cc.ReadGroup).Methods(http.MethodPut, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/groups/{id}/members", cc.AddGroupMembers).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/groups/{id}/members", cc.LeaveGroup).Methods(http.MethodDelete, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/groups/{id}/members/{user}", cc.KickGroupMember).Methods(http.MethodDelete, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/groups/{id}/admins/{user}", cc.SetGroupAdmin).Methods(http.MethodPut, http.MethodOptions)
+	router.HandleFunc("/api/v1/messages/groups/{id}/admins/{user}", cc.UnsetGroupAdmin).Methods(http.MethodDelete, http.MethodOptions)
 	router.HandleFunc("/api/v1/message/ws", cc.SetConnection)
 
 	router.Handle("/api/v1/metrics", promhttp.Handler())
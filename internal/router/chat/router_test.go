@@ -17,7 +17,7 @@ func (m mockSessionManager) Check(s string) (*models.Session, error) {
 	return nil, nil
 }
 
-func (m mockSessionManager) Create(userID uint32) (*models.Session, error) {
+func (m mockSessionManager) Rotate(sess *models.Session) (*models.Session, error) {
 	return nil, nil
 }
 
@@ -33,7 +33,39 @@ func (m mockChatController) GetAllChats(w http.ResponseWriter, r *http.Request)
 
 func (m mockChatController) GetChat(w http.ResponseWriter, r *http.Request) {}
 
+func (m mockChatController) ReadChat(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) GetUnreadCount(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) EditMessage(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) DeleteMessage(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) SetReactionOnMessage(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) DeleteReactionFromMessage(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) CreateGroup(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) GetGroup(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) UpdateGroup(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) GetGroupChat(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) ReadGroup(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) AddGroupMembers(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) KickGroupMember(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) LeaveGroup(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) SetGroupAdmin(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockChatController) UnsetGroupAdmin(w http.ResponseWriter, r *http.Request) {}
+
 func TestNewRouter(t *testing.T) {
-	r := NewRouter(mockChatController{}, mockSessionManager{}, logrus.New(), &metrics.HttpMetrics{})
+	r := NewRouter(mockChatController{}, mockSessionManager{}, nil, logrus.New(), &metrics.HttpMetrics{})
 	assert.NotNil(t, r)
 }
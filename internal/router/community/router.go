@@ -26,15 +26,16 @@ type CommunityController interface {
 
 type SessionManager interface {
 	Check(string) (*models.Session, error)
-	Create(userID uint32) (*models.Session, error)
+	Rotate(sess *models.Session) (*models.Session, error)
 	Destroy(sess *models.Session) error
 }
 
 func NewRouter(
-	communityController CommunityController, sm SessionManager, logger *logrus.Logger,
+	communityController CommunityController, sm SessionManager, limits middleware.Limits, logger *logrus.Logger,
 	communityMetrics *metrics.HttpMetrics,
 ) http.Handler {
 	router := mux.NewRouter()
+	router.Use(middleware.Verified(limits))
 
 	router.HandleFunc("/api/v1/community", communityController.Create).Methods(http.MethodPost, http.MethodOptions)
 	router.HandleFunc("/api/v1/community/{id}", communityController.GetOne).Methods(http.MethodGet, http.MethodOptions)
@@ -17,7 +17,7 @@ func (m mockSessionManager) Check(s string) (*models.Session, error) {
 	return nil, nil
 }
 
-func (m mockSessionManager) Create(userID uint32) (*models.Session, error) {
+func (m mockSessionManager) Rotate(sess *models.Session) (*models.Session, error) {
 	return nil, nil
 }
 
@@ -46,6 +46,6 @@ func (m mockCommunityController) Create(w http.ResponseWriter, r *http.Request)
 func (m mockCommunityController) SearchCommunity(w http.ResponseWriter, r *http.Request) {}
 
 func TestNewRouter(t *testing.T) {
-	r := NewRouter(mockCommunityController{}, mockSessionManager{}, logrus.New(), &metrics.HttpMetrics{})
+	r := NewRouter(mockCommunityController{}, mockSessionManager{}, nil, logrus.New(), &metrics.HttpMetrics{})
 	assert.NotNil(t, r)
 }
@@ -14,13 +14,14 @@ import (
 
 type SessionManager interface {
 	Check(string) (*models.Session, error)
-	Create(userID uint32) (*models.Session, error)
+	Rotate(sess *models.Session) (*models.Session, error)
 	Destroy(sess *models.Session) error
 }
 
 type FileController interface {
 	Upload(w http.ResponseWriter, r *http.Request)
 	Download(w http.ResponseWriter, r *http.Request)
+	DownloadAttachment(w http.ResponseWriter, r *http.Request)
 }
 
 func NewRouter(
@@ -30,6 +31,7 @@ func NewRouter(
 
 	router.HandleFunc("/image/{name}", fc.Upload).Methods(http.MethodGet, http.MethodOptions)
 	router.HandleFunc("/image", fc.Download).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/attachment", fc.DownloadAttachment).Methods(http.MethodPost, http.MethodOptions)
 
 	router.Handle("/api/v1/metrics", promhttp.Handler())
 	router.Handle(
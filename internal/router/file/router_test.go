@@ -17,7 +17,7 @@ func (m mockSessionManager) Check(s string) (*models.Session, error) {
 	return nil, nil
 }
 
-func (m mockSessionManager) Create(userID uint32) (*models.Session, error) {
+func (m mockSessionManager) Rotate(sess *models.Session) (*models.Session, error) {
 	return nil, nil
 }
 
@@ -31,6 +31,8 @@ func (m mockFileController) Upload(w http.ResponseWriter, r *http.Request) {}
 
 func (m mockFileController) Download(w http.ResponseWriter, r *http.Request) {}
 
+func (m mockFileController) DownloadAttachment(w http.ResponseWriter, r *http.Request) {}
+
 func TestNewRouter(t *testing.T) {
 	r := NewRouter(mockFileController{}, mockSessionManager{}, logrus.New(), &metrics.FileMetrics{})
 	assert.NotNil(t, r)
@@ -14,7 +14,7 @@ import (
 
 type SessionManager interface {
 	Check(string) (*models.Session, error)
-	Create(userID uint32) (*models.Session, error)
+	Rotate(sess *models.Session) (*models.Session, error)
 	Destroy(sess *models.Session) error
 }
 
@@ -24,16 +24,39 @@ type Controller interface {
 	Update(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
 	GetBatchPosts(w http.ResponseWriter, r *http.Request)
+	SearchPosts(w http.ResponseWriter, r *http.Request)
+	GetFeed(w http.ResponseWriter, r *http.Request)
 
 	SetLikeOnPost(w http.ResponseWriter, r *http.Request)
 	DeleteLikeFromPost(w http.ResponseWriter, r *http.Request)
+	SetReactionOnPost(w http.ResponseWriter, r *http.Request)
+	DeleteReactionFromPost(w http.ResponseWriter, r *http.Request)
+	GetLikedBy(w http.ResponseWriter, r *http.Request)
+}
+
+type CommentController interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	GetComments(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+
+	SetLikeOnComment(w http.ResponseWriter, r *http.Request)
+	DeleteLikeFromComment(w http.ResponseWriter, r *http.Request)
+}
+
+type SearchController interface {
+	Search(w http.ResponseWriter, r *http.Request)
 }
 
 func NewRouter(
-	contr Controller, sm SessionManager, logger *logrus.Logger, postMetric *metrics.HttpMetrics,
+	contr Controller, commentContr CommentController, searchContr SearchController, sm SessionManager,
+	limits middleware.Limits, logger *logrus.Logger, postMetric *metrics.HttpMetrics,
 ) http.Handler {
 	router := mux.NewRouter()
+	router.Use(middleware.Verified(limits))
 	router.HandleFunc("/api/v1/feed", contr.Create).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/api/v1/feed/search", contr.SearchPosts).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/api/v1/feed/ranked", contr.GetFeed).Methods(http.MethodGet, http.MethodOptions)
 	router.HandleFunc("/api/v1/feed/{id}", contr.GetOne).Methods(http.MethodGet, http.MethodOptions)
 	router.HandleFunc("/api/v1/feed/{id}", contr.Update).Methods(http.MethodPut, http.MethodOptions)
 	router.HandleFunc("/api/v1/feed/{id}", contr.Delete).Methods(http.MethodDelete, http.MethodOptions)
@@ -41,6 +64,18 @@ func NewRouter(
 
 	router.HandleFunc("/api/v1/feed/{id}/like", contr.SetLikeOnPost).Methods(http.MethodPost, http.MethodOptions)
 	router.HandleFunc("/api/v1/feed/{id}/unlike", contr.DeleteLikeFromPost).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/api/v1/feed/{id}/reaction", contr.SetReactionOnPost).Methods(http.MethodPut, http.MethodOptions)
+	router.HandleFunc("/api/v1/feed/{id}/reaction", contr.DeleteReactionFromPost).Methods(http.MethodDelete, http.MethodOptions)
+	router.HandleFunc("/api/v1/feed/{id}/likes", contr.GetLikedBy).Methods(http.MethodGet, http.MethodOptions)
+
+	router.HandleFunc("/api/v1/feed/{id}/comments", commentContr.Create).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/api/v1/feed/{id}/comments", commentContr.GetComments).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/api/v1/feed/{id}/comments/{comment_id}", commentContr.Update).Methods(http.MethodPut, http.MethodOptions)
+	router.HandleFunc("/api/v1/feed/{id}/comments/{comment_id}", commentContr.Delete).Methods(http.MethodDelete, http.MethodOptions)
+	router.HandleFunc("/api/v1/feed/{id}/comments/{comment_id}/like", commentContr.SetLikeOnComment).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/api/v1/feed/{id}/comments/{comment_id}/unlike", commentContr.DeleteLikeFromComment).Methods(http.MethodPost, http.MethodOptions)
+
+	router.HandleFunc("/api/v1/search", searchContr.Search).Methods(http.MethodGet, http.MethodOptions)
 
 	router.Handle("/api/v1/metrics", promhttp.Handler())
 	router.Handle(
@@ -17,7 +17,7 @@ func (m mockSessionManager) Check(s string) (*models.Session, error) {
 	return nil, nil
 }
 
-func (m mockSessionManager) Create(userID uint32) (*models.Session, error) {
+func (m mockSessionManager) Rotate(sess *models.Session) (*models.Session, error) {
 	return nil, nil
 }
 
@@ -33,17 +33,48 @@ func (m mockPostController) DeleteLikeFromPost(w http.ResponseWriter, r *http.Re
 
 func (m mockPostController) GetLikesOnPost(w http.ResponseWriter, r *http.Request) {}
 
+func (m mockPostController) SetReactionOnPost(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockPostController) DeleteReactionFromPost(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockPostController) GetLikedBy(w http.ResponseWriter, r *http.Request) {}
+
 func (m mockPostController) Create(w http.ResponseWriter, r *http.Request) {}
 
 func (m mockPostController) GetOne(w http.ResponseWriter, r *http.Request) {}
 
+func (m mockPostController) SearchPosts(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockPostController) GetFeed(w http.ResponseWriter, r *http.Request) {}
+
 func (m mockPostController) Update(w http.ResponseWriter, r *http.Request) {}
 
 func (m mockPostController) Delete(w http.ResponseWriter, r *http.Request) {}
 
 func (m mockPostController) GetBatchPosts(w http.ResponseWriter, r *http.Request) {}
 
+type mockCommentController struct{}
+
+func (m mockCommentController) Create(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockCommentController) GetComments(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockCommentController) Update(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockCommentController) Delete(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockCommentController) SetLikeOnComment(w http.ResponseWriter, r *http.Request) {}
+
+func (m mockCommentController) DeleteLikeFromComment(w http.ResponseWriter, r *http.Request) {}
+
+type mockSearchController struct{}
+
+func (m mockSearchController) Search(w http.ResponseWriter, r *http.Request) {}
+
 func TestNewRouter(t *testing.T) {
-	r := NewRouter(mockPostController{}, mockSessionManager{}, logrus.New(), &metrics.HttpMetrics{})
+	r := NewRouter(
+		mockPostController{}, mockCommentController{}, mockSearchController{}, mockSessionManager{}, nil, logrus.New(),
+		&metrics.HttpMetrics{},
+	)
 	assert.NotNil(t, r)
 }
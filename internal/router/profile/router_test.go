@@ -17,7 +17,7 @@ func (m mockSessionManager) Check(s string) (*models.Session, error) {
 	return nil, nil
 }
 
-func (m mockSessionManager) Create(userID uint32) (*models.Session, error) {
+func (m mockSessionManager) Rotate(sess *models.Session) (*models.Session, error) {
 	return nil, nil
 }
 
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -59,8 +61,12 @@ func (r *Respond) OutputNoMoreContentJSON(w http.ResponseWriter, requestID strin
 	r.logger.Infof("req: %s: success request", requestID)
 }
 
+// OutputBytes writes the file, its type is detected from the data unless it is set already
 func (r *Respond) OutputBytes(w http.ResponseWriter, data []byte, requestID string) {
-	w.Header().Set("Content-Type", "image/avif,image/webp")
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", http.DetectContentType(data))
+	}
+	w.Header().Set("X-Content-Type-Options", "nosniff")
 	w.Header().Set("Access-Control-Allow-Origin", "http://185.241.194.197:8000")
 	w.Header().Set("Access-Control-Allow-Credentials", "true")
 	w.WriteHeader(http.StatusOK)
@@ -80,6 +86,29 @@ func (r *Respond) ErrorBadRequest(w http.ResponseWriter, err error, requestID st
 	}
 }
 
+func (r *Respond) ErrorNotFound(w http.ResponseWriter, err error, requestID string) {
+	r.logger.Warnf("req: %s: %v", requestID, err)
+	writeHeaders(w)
+	w.WriteHeader(http.StatusNotFound)
+
+	if err := json.NewEncoder(w).Encode(&Response{Success: false, Message: fullUnwrap(err).Error()}); err != nil {
+		r.logger.Errorf("req: %s: %v", requestID, err)
+	}
+}
+
+// ErrorTooManyRequests tells the client to wait, Retry-After is in the whole seconds rounded up
+func (r *Respond) ErrorTooManyRequests(w http.ResponseWriter, err error, retryAfter time.Duration, requestID string) {
+	r.logger.Warnf("req: %s: %v", requestID, err)
+	writeHeaders(w)
+	seconds := max(1, int64((retryAfter+time.Second-1)/time.Second))
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	w.WriteHeader(http.StatusTooManyRequests)
+
+	if err := json.NewEncoder(w).Encode(&Response{Success: false, Message: fullUnwrap(err).Error()}); err != nil {
+		r.logger.Errorf("req: %s: %v", requestID, err)
+	}
+}
+
 func (r *Respond) ErrorInternal(w http.ResponseWriter, err error, requestID string) {
 	r.logger.Errorf("req: %s: %v", requestID, err)
 	writeHeaders(w)
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -76,6 +77,25 @@ func TestOutputBytes(t *testing.T) {
 	}
 }
 
+func TestOutputBytesType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n")
+	w := httptest.NewRecorder()
+	TestResponder.OutputBytes(w, png, uuid.New().String())
+	if got := w.Header().Get("Content-Type"); got != "image/png" {
+		t.Errorf("wrong content type, expected image/png, got %s", got)
+	}
+	if got := w.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Errorf("wrong content type options, expected nosniff, got %s", got)
+	}
+
+	w = httptest.NewRecorder()
+	w.Header().Set("Content-Type", "application/pdf")
+	TestResponder.OutputBytes(w, png, uuid.New().String())
+	if got := w.Header().Get("Content-Type"); got != "application/pdf" {
+		t.Errorf("wrong content type, expected application/pdf, got %s", got)
+	}
+}
+
 func TestOutputNoMoreContent(t *testing.T) {
 	tests := []TestRouter{
 		{
@@ -119,6 +139,72 @@ func TestErrorBadRequest(t *testing.T) {
 	}
 }
 
+func TestErrorNotFound(t *testing.T) {
+	tests := []TestRouter{
+		{
+			testResponse: httptest.NewRecorder(),
+			testErr:      TestError,
+			expectedCode: http.StatusNotFound,
+			expectedBody: TestDataBadRequest,
+			testReqID:    uuid.New().String(),
+		},
+	}
+
+	for caseNum, test := range tests {
+		TestResponder.ErrorNotFound(test.testResponse, test.testErr, test.testReqID)
+		if test.testResponse.Code != test.expectedCode {
+			t.Errorf("[%d} wrong status code, expected %d, got %d", caseNum, test.expectedCode, test.testResponse.Code)
+		}
+		if strings.Compare(test.expectedBody, strings.TrimSpace(test.testResponse.Body.String())) != 0 {
+			t.Errorf("[%d] wrong body, expected %s, got %s", caseNum, test.expectedBody, test.testResponse.Body.String())
+		}
+	}
+}
+
+func TestErrorTooManyRequests(t *testing.T) {
+	tests := []struct {
+		TestRouter
+		retryAfter    time.Duration
+		expectedRetry string
+	}{
+		{
+			TestRouter: TestRouter{
+				testResponse: httptest.NewRecorder(),
+				testErr:      TestError,
+				expectedCode: http.StatusTooManyRequests,
+				expectedBody: TestDataBadRequest,
+				testReqID:    uuid.New().String(),
+			},
+			retryAfter:    1500 * time.Millisecond,
+			expectedRetry: "2",
+		},
+		{
+			TestRouter: TestRouter{
+				testResponse: httptest.NewRecorder(),
+				testErr:      TestError,
+				expectedCode: http.StatusTooManyRequests,
+				expectedBody: TestDataBadRequest,
+				testReqID:    uuid.New().String(),
+			},
+			retryAfter:    0,
+			expectedRetry: "1",
+		},
+	}
+
+	for caseNum, test := range tests {
+		TestResponder.ErrorTooManyRequests(test.testResponse, test.testErr, test.retryAfter, test.testReqID)
+		if test.testResponse.Code != test.expectedCode {
+			t.Errorf("[%d} wrong status code, expected %d, got %d", caseNum, test.expectedCode, test.testResponse.Code)
+		}
+		if retry := test.testResponse.Header().Get("Retry-After"); retry != test.expectedRetry {
+			t.Errorf("[%d] wrong retry after, expected %s, got %s", caseNum, test.expectedRetry, retry)
+		}
+		if strings.Compare(test.expectedBody, strings.TrimSpace(test.testResponse.Body.String())) != 0 {
+			t.Errorf("[%d] wrong body, expected %s, got %s", caseNum, test.expectedBody, test.testResponse.Body.String())
+		}
+	}
+}
+
 func TestErrorInternal(t *testing.T) {
 	tests := []TestRouter{
 		{
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+const (
+	queryMinLen = 3
+	queryMaxLen = 100
+)
+
+//go:generate mockgen -destination=mock.go -source=$GOFILE -package=${GOPACKAGE}
+type SearchService interface {
+	Search(ctx context.Context, search *models.GlobalSearch) ([]*models.SearchSection, error)
+}
+
+type Responder interface {
+	OutputJSON(w http.ResponseWriter, data any, requestId string)
+	OutputNoMoreContentJSON(w http.ResponseWriter, requestId string)
+
+	ErrorInternal(w http.ResponseWriter, err error, requestId string)
+	ErrorBadRequest(w http.ResponseWriter, err error, requestId string)
+	LogError(err error, requestId string)
+}
+
+type SearchController struct {
+	service   SearchService
+	responder Responder
+}
+
+func NewSearchController(service SearchService, responder Responder) *SearchController {
+	return &SearchController{
+		service:   service,
+		responder: responder,
+	}
+}
+
+// Search finds profiles, communities and posts by q. With the type parameter only one section is searched,
+// its next page is asked with the cursor of the section
+func (sc *SearchController) Search(w http.ResponseWriter, r *http.Request) {
+	var (
+		reqID, ok = r.Context().Value("requestID").(string)
+		query     = strings.TrimSpace(r.URL.Query().Get("q"))
+		search    = &models.GlobalSearch{
+			Query:  query,
+			Type:   models.SearchSectionType(r.URL.Query().Get("type")),
+			Cursor: r.URL.Query().Get("cursor"),
+		}
+	)
+
+	if !ok {
+		sc.responder.LogError(my_err.ErrInvalidContext, "")
+	}
+
+	if length := utf8.RuneCountInString(query); length < queryMinLen || length > queryMaxLen {
+		sc.responder.ErrorBadRequest(w, my_err.ErrInvalidQuery, reqID)
+		return
+	}
+
+	if search.Type != "" && !search.Type.Valid() {
+		sc.responder.ErrorBadRequest(w, my_err.ErrInvalidQuery, reqID)
+		return
+	}
+
+	sess, err := models.SessionFromContext(r.Context())
+	if err != nil {
+		sc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+	search.UserID = sess.UserID
+
+	sections, err := sc.service.Search(r.Context(), search)
+	if errors.Is(err, my_err.ErrNoMoreContent) {
+		sc.responder.OutputNoMoreContentJSON(w, reqID)
+		return
+	}
+
+	if errors.Is(err, my_err.ErrWrongCursor) || errors.Is(err, my_err.ErrInvalidQuery) {
+		sc.responder.ErrorBadRequest(w, err, reqID)
+		return
+	}
+
+	if err != nil {
+		if len(sections) == 0 {
+			sc.responder.ErrorInternal(w, err, reqID)
+			return
+		}
+		// the failed sections are marked, the rest is still shown
+		sc.responder.LogError(err, reqID)
+	}
+
+	sc.responder.OutputJSON(w, sections, reqID)
+}
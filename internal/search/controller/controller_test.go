@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+type mocks struct {
+	service   *MockSearchService
+	responder *MockResponder
+}
+
+func getController(ctrl *gomock.Controller) (*SearchController, *mocks) {
+	m := &mocks{
+		service:   NewMockSearchService(ctrl),
+		responder: NewMockResponder(ctrl),
+	}
+
+	return NewSearchController(m.service, m.responder), m
+}
+
+type Request struct {
+	w *httptest.ResponseRecorder
+	r *http.Request
+}
+
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+type TableTest[T, In any] struct {
+	name           string
+	SetupInput     func() (*In, error)
+	Run            func(context.Context, *SearchController, In) (T, error)
+	ExpectedResult func() (T, error)
+	ExpectedErr    error
+	SetupMock      func(In, *mocks)
+}
+
+func TestSearch(t *testing.T) {
+	newRequest := func(query string) (*Request, error) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/search?"+query, nil)
+		w := httptest.NewRecorder()
+		req = req.WithContext(models.ContextWithSession(req.Context(), &models.Session{ID: "1", UserID: 1}))
+		return &Request{r: req, w: w}, nil
+	}
+	run := func(ctx context.Context, implementation *SearchController, request Request) (Response, error) {
+		implementation.Search(request.w, request.r)
+		res := Response{StatusCode: request.w.Code, Body: request.w.Body.String()}
+		return res, nil
+	}
+	badRequest := func() (Response, error) {
+		return Response{StatusCode: http.StatusBadRequest, Body: "bad request"}, nil
+	}
+	expectBadRequest := func(request Request, m *mocks) {
+		m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+		m.responder.EXPECT().ErrorBadRequest(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+			request.w.WriteHeader(http.StatusBadRequest)
+			request.w.Write([]byte("bad request"))
+		})
+	}
+	ok := func() (Response, error) {
+		return Response{StatusCode: http.StatusOK, Body: "OK"}, nil
+	}
+	expectOK := func(request Request, m *mocks) {
+		m.responder.EXPECT().OutputJSON(request.w, gomock.Any(), gomock.Any()).Do(func(w, data, req any) {
+			request.w.WriteHeader(http.StatusOK)
+			request.w.Write([]byte("OK"))
+		})
+	}
+	sections := []*models.SearchSection{{Type: models.SearchProfiles, Profiles: []*models.ShortProfile{{ID: 1}}}}
+
+	tests := []TableTest[Response, Request]{
+		{
+			name: "1",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=%20%20iv%20")
+			},
+			Run:            run,
+			ExpectedResult: badRequest,
+			SetupMock:      expectBadRequest,
+		},
+		{
+			name: "2",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=ivan&type=users")
+			},
+			Run:            run,
+			ExpectedResult: badRequest,
+			SetupMock:      expectBadRequest,
+		},
+		{
+			name: "3",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=ivan&cursor=10")
+			},
+			Run:            run,
+			ExpectedResult: badRequest,
+			SetupMock: func(request Request, m *mocks) {
+				m.service.EXPECT().Search(gomock.Any(), gomock.Any()).Return(nil, my_err.ErrWrongCursor)
+				expectBadRequest(request, m)
+			},
+		},
+		{
+			name: "4",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=ivan")
+			},
+			Run: run,
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusNoContent}, nil
+			},
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.service.EXPECT().Search(gomock.Any(), gomock.Any()).Return(nil, my_err.ErrNoMoreContent)
+				m.responder.EXPECT().OutputNoMoreContentJSON(request.w, gomock.Any()).Do(func(w, req any) {
+					request.w.WriteHeader(http.StatusNoContent)
+				})
+			},
+		},
+		{
+			name: "5",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=ivan")
+			},
+			Run: run,
+			ExpectedResult: func() (Response, error) {
+				return Response{StatusCode: http.StatusInternalServerError, Body: "error"}, nil
+			},
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any())
+				m.service.EXPECT().Search(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+				m.responder.EXPECT().ErrorInternal(request.w, gomock.Any(), gomock.Any()).Do(func(w, err, req any) {
+					request.w.WriteHeader(http.StatusInternalServerError)
+					request.w.Write([]byte("error"))
+				})
+			},
+		},
+		{
+			name: "6",
+			SetupInput: func() (*Request, error) {
+				return newRequest("q=ivan")
+			},
+			Run:            run,
+			ExpectedResult: ok,
+			SetupMock: func(request Request, m *mocks) {
+				m.responder.EXPECT().LogError(gomock.Any(), gomock.Any()).Times(2)
+				m.service.EXPECT().Search(gomock.Any(), gomock.Any()).
+					Return(append(sections, &models.SearchSection{Type: models.SearchPosts, Failed: true}), errors.New("error"))
+				expectOK(request, m)
+			},
+		},
+		{
+			name: "7",
+			SetupInput: func() (*Request, error) {
+				req, err := newRequest("q=ivan&type=profiles&cursor=10")
+				req.r = req.r.WithContext(context.WithValue(req.r.Context(), "requestID", "1"))
+				return req, err
+			},
+			Run:            run,
+			ExpectedResult: ok,
+			SetupMock: func(request Request, m *mocks) {
+				m.service.EXPECT().Search(gomock.Any(), &models.GlobalSearch{
+					Query: "ivan", UserID: 1, Type: models.SearchProfiles, Cursor: "10",
+				}).Return(sections, nil)
+				expectOK(request, m)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getController(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
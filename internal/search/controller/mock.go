@@ -0,0 +1,135 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: controller.go
+
+// Package controller is a generated GoMock package.
+package controller
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	models "github.com/2024_2_BetterCallFirewall/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSearchService is a mock of SearchService interface.
+type MockSearchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchServiceMockRecorder
+}
+
+// MockSearchServiceMockRecorder is the mock recorder for MockSearchService.
+type MockSearchServiceMockRecorder struct {
+	mock *MockSearchService
+}
+
+// NewMockSearchService creates a new mock instance.
+func NewMockSearchService(ctrl *gomock.Controller) *MockSearchService {
+	mock := &MockSearchService{ctrl: ctrl}
+	mock.recorder = &MockSearchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchService) EXPECT() *MockSearchServiceMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchService) Search(ctx context.Context, search *models.GlobalSearch) ([]*models.SearchSection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search)
+	ret0, _ := ret[0].([]*models.SearchSection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchServiceMockRecorder) Search(ctx, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchService)(nil).Search), ctx, search)
+}
+
+// MockResponder is a mock of Responder interface.
+type MockResponder struct {
+	ctrl     *gomock.Controller
+	recorder *MockResponderMockRecorder
+}
+
+// MockResponderMockRecorder is the mock recorder for MockResponder.
+type MockResponderMockRecorder struct {
+	mock *MockResponder
+}
+
+// NewMockResponder creates a new mock instance.
+func NewMockResponder(ctrl *gomock.Controller) *MockResponder {
+	mock := &MockResponder{ctrl: ctrl}
+	mock.recorder = &MockResponderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResponder) EXPECT() *MockResponderMockRecorder {
+	return m.recorder
+}
+
+// ErrorBadRequest mocks base method.
+func (m *MockResponder) ErrorBadRequest(w http.ResponseWriter, err error, requestId string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ErrorBadRequest", w, err, requestId)
+}
+
+// ErrorBadRequest indicates an expected call of ErrorBadRequest.
+func (mr *MockResponderMockRecorder) ErrorBadRequest(w, err, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErrorBadRequest", reflect.TypeOf((*MockResponder)(nil).ErrorBadRequest), w, err, requestId)
+}
+
+// ErrorInternal mocks base method.
+func (m *MockResponder) ErrorInternal(w http.ResponseWriter, err error, requestId string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ErrorInternal", w, err, requestId)
+}
+
+// ErrorInternal indicates an expected call of ErrorInternal.
+func (mr *MockResponderMockRecorder) ErrorInternal(w, err, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErrorInternal", reflect.TypeOf((*MockResponder)(nil).ErrorInternal), w, err, requestId)
+}
+
+// LogError mocks base method.
+func (m *MockResponder) LogError(err error, requestId string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogError", err, requestId)
+}
+
+// LogError indicates an expected call of LogError.
+func (mr *MockResponderMockRecorder) LogError(err, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogError", reflect.TypeOf((*MockResponder)(nil).LogError), err, requestId)
+}
+
+// OutputJSON mocks base method.
+func (m *MockResponder) OutputJSON(w http.ResponseWriter, data any, requestId string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OutputJSON", w, data, requestId)
+}
+
+// OutputJSON indicates an expected call of OutputJSON.
+func (mr *MockResponderMockRecorder) OutputJSON(w, data, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutputJSON", reflect.TypeOf((*MockResponder)(nil).OutputJSON), w, data, requestId)
+}
+
+// OutputNoMoreContentJSON mocks base method.
+func (m *MockResponder) OutputNoMoreContentJSON(w http.ResponseWriter, requestId string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OutputNoMoreContentJSON", w, requestId)
+}
+
+// OutputNoMoreContentJSON indicates an expected call of OutputNoMoreContentJSON.
+func (mr *MockResponderMockRecorder) OutputNoMoreContentJSON(w, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutputNoMoreContentJSON", reflect.TypeOf((*MockResponder)(nil).OutputNoMoreContentJSON), w, requestId)
+}
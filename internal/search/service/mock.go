@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/2024_2_BetterCallFirewall/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProfileSearcher is a mock of ProfileSearcher interface.
+type MockProfileSearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileSearcherMockRecorder
+}
+
+// MockProfileSearcherMockRecorder is the mock recorder for MockProfileSearcher.
+type MockProfileSearcherMockRecorder struct {
+	mock *MockProfileSearcher
+}
+
+// NewMockProfileSearcher creates a new mock instance.
+func NewMockProfileSearcher(ctrl *gomock.Controller) *MockProfileSearcher {
+	mock := &MockProfileSearcher{ctrl: ctrl}
+	mock.recorder = &MockProfileSearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileSearcher) EXPECT() *MockProfileSearcherMockRecorder {
+	return m.recorder
+}
+
+// SearchProfiles mocks base method.
+func (m *MockProfileSearcher) SearchProfiles(ctx context.Context, userID uint32, query, cursor string) (*models.ProfilePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchProfiles", ctx, userID, query, cursor)
+	ret0, _ := ret[0].(*models.ProfilePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchProfiles indicates an expected call of SearchProfiles.
+func (mr *MockProfileSearcherMockRecorder) SearchProfiles(ctx, userID, query, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProfiles", reflect.TypeOf((*MockProfileSearcher)(nil).SearchProfiles), ctx, userID, query, cursor)
+}
+
+// MockCommunitySearcher is a mock of CommunitySearcher interface.
+type MockCommunitySearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommunitySearcherMockRecorder
+}
+
+// MockCommunitySearcherMockRecorder is the mock recorder for MockCommunitySearcher.
+type MockCommunitySearcherMockRecorder struct {
+	mock *MockCommunitySearcher
+}
+
+// NewMockCommunitySearcher creates a new mock instance.
+func NewMockCommunitySearcher(ctrl *gomock.Controller) *MockCommunitySearcher {
+	mock := &MockCommunitySearcher{ctrl: ctrl}
+	mock.recorder = &MockCommunitySearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommunitySearcher) EXPECT() *MockCommunitySearcherMockRecorder {
+	return m.recorder
+}
+
+// SearchCommunities mocks base method.
+func (m *MockCommunitySearcher) SearchCommunities(ctx context.Context, userID uint32, query string, lastID uint32) ([]*models.CommunityCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCommunities", ctx, userID, query, lastID)
+	ret0, _ := ret[0].([]*models.CommunityCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCommunities indicates an expected call of SearchCommunities.
+func (mr *MockCommunitySearcherMockRecorder) SearchCommunities(ctx, userID, query, lastID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCommunities", reflect.TypeOf((*MockCommunitySearcher)(nil).SearchCommunities), ctx, userID, query, lastID)
+}
+
+// MockPostSearcher is a mock of PostSearcher interface.
+type MockPostSearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostSearcherMockRecorder
+}
+
+// MockPostSearcherMockRecorder is the mock recorder for MockPostSearcher.
+type MockPostSearcherMockRecorder struct {
+	mock *MockPostSearcher
+}
+
+// NewMockPostSearcher creates a new mock instance.
+func NewMockPostSearcher(ctrl *gomock.Controller) *MockPostSearcher {
+	mock := &MockPostSearcher{ctrl: ctrl}
+	mock.recorder = &MockPostSearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostSearcher) EXPECT() *MockPostSearcherMockRecorder {
+	return m.recorder
+}
+
+// SearchPosts mocks base method.
+func (m *MockPostSearcher) SearchPosts(ctx context.Context, userID uint32, query, cursor string) (*models.PostPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPosts", ctx, userID, query, cursor)
+	ret0, _ := ret[0].(*models.PostPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPosts indicates an expected call of SearchPosts.
+func (mr *MockPostSearcherMockRecorder) SearchPosts(ctx, userID, query, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPosts", reflect.TypeOf((*MockPostSearcher)(nil).SearchPosts), ctx, userID, query, cursor)
+}
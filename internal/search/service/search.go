@@ -0,0 +1,241 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+//go:generate mockgen -destination=mock.go -source=$GOFILE -package=${GOPACKAGE}
+type ProfileSearcher interface {
+	SearchProfiles(ctx context.Context, userID uint32, query string, cursor string) (*models.ProfilePage, error)
+}
+
+type CommunitySearcher interface {
+	SearchCommunities(ctx context.Context, userID uint32, query string, lastID uint32) ([]*models.CommunityCard, error)
+}
+
+type PostSearcher interface {
+	SearchPosts(ctx context.Context, userID uint32, query string, cursor string) (*models.PostPage, error)
+}
+
+// relevance of an item to the query
+const (
+	relevanceNone = iota
+	relevanceContains
+	relevancePrefix
+	relevanceExact
+)
+
+var sectionOrder = []models.SearchSectionType{models.SearchProfiles, models.SearchCommunities, models.SearchPosts}
+
+type SearchService struct {
+	profiles    ProfileSearcher
+	communities CommunitySearcher
+	posts       PostSearcher
+	timeout     time.Duration
+}
+
+func NewSearchService(
+	profiles ProfileSearcher, communities CommunitySearcher, posts PostSearcher, timeout time.Duration,
+) *SearchService {
+	return &SearchService{
+		profiles:    profiles,
+		communities: communities,
+		posts:       posts,
+		timeout:     timeout,
+	}
+}
+
+type foundSection struct {
+	section *models.SearchSection
+	best    int
+	err     error
+}
+
+// Search asks the services of all sections at once, each of them has its own timeout.
+// The sections which could not be searched are returned marked as failed, their errors are joined
+// and returned along with the found sections. The sections with the most relevant items go first
+func (s *SearchService) Search(ctx context.Context, search *models.GlobalSearch) ([]*models.SearchSection, error) {
+	types := sectionOrder
+	if search.Type != "" {
+		types = []models.SearchSectionType{search.Type}
+	}
+
+	lastID, err := parseCursor(search)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]foundSection, len(types))
+	var wg sync.WaitGroup
+	for i, sectionType := range types {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			callCtx, cancel := context.WithTimeout(ctx, s.timeout)
+			defer cancel()
+
+			found[i] = s.searchSection(callCtx, sectionType, search, lastID)
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for i := range found {
+		if found[i].err != nil {
+			found[i].section = &models.SearchSection{Type: types[i], Failed: true}
+			found[i].best = -1
+			errs = append(errs, fmt.Errorf("search %s: %w", types[i], found[i].err))
+		}
+	}
+
+	if len(errs) == len(found) {
+		return nil, errors.Join(errs...)
+	}
+
+	slices.SortStableFunc(found, func(a, b foundSection) int {
+		return cmp.Compare(b.best, a.best)
+	})
+
+	res := make([]*models.SearchSection, 0, len(found))
+	for _, f := range found {
+		if f.section.Failed || !isEmpty(f.section) {
+			res = append(res, f.section)
+		}
+	}
+
+	if len(errs) == 0 && len(res) == 0 {
+		return nil, my_err.ErrNoMoreContent
+	}
+
+	return res, errors.Join(errs...)
+}
+
+func (s *SearchService) searchSection(
+	ctx context.Context, sectionType models.SearchSectionType, search *models.GlobalSearch, lastID uint32,
+) foundSection {
+	section := &models.SearchSection{Type: sectionType}
+	best := relevanceNone
+
+	switch sectionType {
+	case models.SearchProfiles:
+		page, err := s.profiles.SearchProfiles(ctx, search.UserID, search.Query, search.Cursor)
+		if err != nil {
+			return foundSection{err: err}
+		}
+
+		// profiles are already ranked by the profile service
+		for _, p := range page.Profiles {
+			best = max(
+				best,
+				relevance(search.Query, p.FirstName+" "+p.LastName),
+				relevance(search.Query, p.LastName+" "+p.FirstName),
+			)
+		}
+		section.Profiles = page.Profiles
+		section.Cursor = page.Cursor
+
+	case models.SearchCommunities:
+		communities, err := s.communities.SearchCommunities(ctx, search.UserID, search.Query, lastID)
+		if err != nil {
+			return foundSection{err: err}
+		}
+		if len(communities) != 0 {
+			section.Cursor = strconv.FormatUint(uint64(communities[len(communities)-1].ID), 10)
+		}
+
+		score := func(c *models.CommunityCard) int {
+			return relevance(search.Query, c.Name)
+		}
+		slices.SortStableFunc(communities, func(a, b *models.CommunityCard) int {
+			return cmp.Compare(score(b), score(a))
+		})
+		if len(communities) != 0 {
+			best = score(communities[0])
+		}
+		section.Communities = communities
+
+	case models.SearchPosts:
+		page, err := s.posts.SearchPosts(ctx, search.UserID, search.Query, search.Cursor)
+		if err != nil {
+			return foundSection{err: err}
+		}
+
+		// posts are already ranked by the post service
+		for _, post := range page.Posts {
+			best = max(best, relevance(search.Query, post.PostContent.Text))
+		}
+		section.Posts = page.Posts
+		section.Cursor = page.Cursor
+	}
+
+	return foundSection{section: section, best: best}
+}
+
+// parseCursor returns the last id of the community section to continue from,
+// the cursors of the ranked sections are checked and passed to their services as they are
+func parseCursor(search *models.GlobalSearch) (uint32, error) {
+	if search.Type != "" && !search.Type.Valid() {
+		return 0, my_err.ErrInvalidQuery
+	}
+
+	if search.Cursor == "" {
+		return math.MaxInt32, nil
+	}
+
+	switch search.Type {
+	case models.SearchCommunities:
+		id, err := strconv.ParseUint(search.Cursor, 10, 32)
+		if err != nil || id == 0 {
+			return 0, my_err.ErrWrongCursor
+		}
+		return uint32(id), nil
+	case models.SearchProfiles, models.SearchPosts:
+		if _, err := models.ParseSearchCursor(search.Cursor); err != nil {
+			return 0, err
+		}
+		return 0, nil
+	}
+
+	// the cursor belongs to one section only
+	return 0, my_err.ErrWrongCursor
+}
+
+func relevance(query, text string) int {
+	query, text = strings.ToLower(query), strings.ToLower(text)
+
+	switch {
+	case text == query:
+		return relevanceExact
+	case strings.HasPrefix(text, query):
+		return relevancePrefix
+	}
+
+	for _, word := range strings.Fields(text) {
+		if strings.HasPrefix(word, query) {
+			return relevancePrefix
+		}
+	}
+
+	if strings.Contains(text, query) {
+		return relevanceContains
+	}
+
+	return relevanceNone
+}
+
+func isEmpty(section *models.SearchSection) bool {
+	return len(section.Profiles) == 0 && len(section.Communities) == 0 && len(section.Posts) == 0
+}
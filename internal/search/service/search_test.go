@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/2024_2_BetterCallFirewall/internal/models"
+	"github.com/2024_2_BetterCallFirewall/pkg/my_err"
+)
+
+type mocks struct {
+	profiles    *MockProfileSearcher
+	communities *MockCommunitySearcher
+	posts       *MockPostSearcher
+}
+
+const testTimeout = 50 * time.Millisecond
+
+func getService(ctrl *gomock.Controller) (*SearchService, *mocks) {
+	m := &mocks{
+		profiles:    NewMockProfileSearcher(ctrl),
+		communities: NewMockCommunitySearcher(ctrl),
+		posts:       NewMockPostSearcher(ctrl),
+	}
+
+	return NewSearchService(m.profiles, m.communities, m.posts, testTimeout), m
+}
+
+var errMock = errors.New("mock error")
+
+type TableTest[T, In any] struct {
+	name           string
+	SetupInput     func() (*In, error)
+	Run            func(context.Context, *SearchService, In) (T, error)
+	ExpectedResult func() (T, error)
+	ExpectedErr    error
+	SetupMock      func(In, *mocks)
+}
+
+func TestSearch(t *testing.T) {
+	run := func(ctx context.Context, implementation *SearchService, request models.GlobalSearch) (
+		[]*models.SearchSection, error,
+	) {
+		return implementation.Search(ctx, &request)
+	}
+	noResult := func() ([]*models.SearchSection, error) {
+		return nil, nil
+	}
+	noMock := func(request models.GlobalSearch, m *mocks) {}
+	// blocks until the search times out
+	slow := func(ctx context.Context, _, _, _ any) (*models.ProfilePage, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	tests := []TableTest[[]*models.SearchSection, models.GlobalSearch]{
+		{
+			name: "1",
+			SetupInput: func() (*models.GlobalSearch, error) {
+				return &models.GlobalSearch{Query: "ivan", UserID: 1, Type: "all"}, nil
+			},
+			Run:            run,
+			ExpectedResult: noResult,
+			ExpectedErr:    my_err.ErrInvalidQuery,
+			SetupMock:      noMock,
+		},
+		{
+			name: "2",
+			SetupInput: func() (*models.GlobalSearch, error) {
+				return &models.GlobalSearch{Query: "ivan", UserID: 1, Cursor: "10"}, nil
+			},
+			Run:            run,
+			ExpectedResult: noResult,
+			ExpectedErr:    my_err.ErrWrongCursor,
+			SetupMock:      noMock,
+		},
+		{
+			name: "3",
+			SetupInput: func() (*models.GlobalSearch, error) {
+				return &models.GlobalSearch{Query: "ivan", UserID: 1, Type: models.SearchProfiles, Cursor: "ten"}, nil
+			},
+			Run:            run,
+			ExpectedResult: noResult,
+			ExpectedErr:    my_err.ErrWrongCursor,
+			SetupMock:      noMock,
+		},
+		{
+			name: "4",
+			SetupInput: func() (*models.GlobalSearch, error) {
+				return &models.GlobalSearch{Query: "ivan", UserID: 1, Type: models.SearchPosts, Cursor: "wrong"}, nil
+			},
+			Run:            run,
+			ExpectedResult: noResult,
+			ExpectedErr:    my_err.ErrWrongCursor,
+			SetupMock:      noMock,
+		},
+		{
+			name: "5",
+			SetupInput: func() (*models.GlobalSearch, error) {
+				return &models.GlobalSearch{Query: "ivan", UserID: 1}, nil
+			},
+			Run:            run,
+			ExpectedResult: noResult,
+			ExpectedErr:    my_err.ErrNoMoreContent,
+			SetupMock: func(request models.GlobalSearch, m *mocks) {
+				m.profiles.EXPECT().SearchProfiles(gomock.Any(), uint32(1), "ivan", "").
+					Return(&models.ProfilePage{}, nil)
+				m.communities.EXPECT().SearchCommunities(gomock.Any(), uint32(1), "ivan", uint32(math.MaxInt32)).
+					Return(nil, nil)
+				m.posts.EXPECT().SearchPosts(gomock.Any(), uint32(1), "ivan", "").Return(&models.PostPage{}, nil)
+			},
+		},
+		{
+			name: "6",
+			SetupInput: func() (*models.GlobalSearch, error) {
+				return &models.GlobalSearch{Query: "ivan", UserID: 1}, nil
+			},
+			Run:            run,
+			ExpectedResult: noResult,
+			ExpectedErr:    errMock,
+			SetupMock: func(request models.GlobalSearch, m *mocks) {
+				m.profiles.EXPECT().SearchProfiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errMock)
+				m.communities.EXPECT().SearchCommunities(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errMock)
+				m.posts.EXPECT().SearchPosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errMock)
+			},
+		},
+		{
+			name: "7",
+			SetupInput: func() (*models.GlobalSearch, error) {
+				return &models.GlobalSearch{Query: "Ivan", UserID: 1}, nil
+			},
+			Run: run,
+			ExpectedResult: func() ([]*models.SearchSection, error) {
+				return []*models.SearchSection{
+					{
+						Type: models.SearchCommunities,
+						Communities: []*models.CommunityCard{
+							{ID: 4, Name: "Ivan"},
+							{ID: 7, Name: "about ivan"},
+						},
+						Cursor: "4",
+					},
+					{
+						Type: models.SearchProfiles,
+						Profiles: []*models.ShortProfile{
+							{ID: 3, FirstName: "Ivan", LastName: "Petrov"},
+							{ID: 8, FirstName: "Dmitry", LastName: "Ivanov"},
+							{ID: 9, FirstName: "Anna", LastName: "Bogoivanova"},
+						},
+						Cursor: "next",
+					},
+					{
+						Type:  models.SearchPosts,
+						Posts: []*models.Post{{ID: 2, PostContent: models.Content{Text: "Dear Bogoivan"}}},
+					},
+				}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request models.GlobalSearch, m *mocks) {
+				m.profiles.EXPECT().SearchProfiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&models.ProfilePage{
+						Profiles: []*models.ShortProfile{
+							{ID: 3, FirstName: "Ivan", LastName: "Petrov"},
+							{ID: 8, FirstName: "Dmitry", LastName: "Ivanov"},
+							{ID: 9, FirstName: "Anna", LastName: "Bogoivanova"},
+						},
+						Cursor: "next",
+					}, nil)
+				m.communities.EXPECT().SearchCommunities(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*models.CommunityCard{
+						{ID: 7, Name: "about ivan"},
+						{ID: 4, Name: "Ivan"},
+					}, nil)
+				m.posts.EXPECT().SearchPosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&models.PostPage{
+						Posts: []*models.Post{{ID: 2, PostContent: models.Content{Text: "Dear Bogoivan"}}},
+					}, nil)
+			},
+		},
+		{
+			name: "8",
+			SetupInput: func() (*models.GlobalSearch, error) {
+				return &models.GlobalSearch{Query: "ivan", UserID: 1}, nil
+			},
+			Run: run,
+			ExpectedResult: func() ([]*models.SearchSection, error) {
+				return []*models.SearchSection{
+					{
+						Type:   models.SearchPosts,
+						Posts:  []*models.Post{{ID: 2, PostContent: models.Content{Text: "ivan"}}},
+						Cursor: "next",
+					},
+					{Type: models.SearchProfiles, Failed: true},
+				}, nil
+			},
+			ExpectedErr: context.DeadlineExceeded,
+			SetupMock: func(request models.GlobalSearch, m *mocks) {
+				m.profiles.EXPECT().SearchProfiles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(slow)
+				m.communities.EXPECT().SearchCommunities(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				m.posts.EXPECT().SearchPosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&models.PostPage{
+						Posts:  []*models.Post{{ID: 2, PostContent: models.Content{Text: "ivan"}}},
+						Cursor: "next",
+					}, nil)
+			},
+		},
+		{
+			name: "9",
+			SetupInput: func() (*models.GlobalSearch, error) {
+				return &models.GlobalSearch{Query: "ivan", UserID: 1, Type: models.SearchCommunities, Cursor: "7"}, nil
+			},
+			Run: run,
+			ExpectedResult: func() ([]*models.SearchSection, error) {
+				return []*models.SearchSection{
+					{
+						Type:        models.SearchCommunities,
+						Communities: []*models.CommunityCard{{ID: 5, Name: "ivan"}},
+						Cursor:      "5",
+					},
+				}, nil
+			},
+			ExpectedErr: nil,
+			SetupMock: func(request models.GlobalSearch, m *mocks) {
+				m.communities.EXPECT().SearchCommunities(gomock.Any(), uint32(1), "ivan", uint32(7)).
+					Return([]*models.CommunityCard{{ID: 5, Name: "ivan"}}, nil)
+			},
+		},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serv, mock := getService(ctrl)
+			ctx := context.Background()
+
+			input, err := v.SetupInput()
+			if err != nil {
+				t.Error(err)
+			}
+
+			v.SetupMock(*input, mock)
+
+			res, err := v.ExpectedResult()
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual, err := v.Run(ctx, serv, *input)
+			assert.Equal(t, res, actual)
+			if !errors.Is(err, v.ExpectedErr) {
+				t.Errorf("expect %v, got %v", v.ExpectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRelevance(t *testing.T) {
+	assert.Equal(t, relevanceExact, relevance("Ivan", "ivan"))
+	assert.Equal(t, relevancePrefix, relevance("iva", "Ivanov Petr"))
+	assert.Equal(t, relevancePrefix, relevance("pet", "Ivanov Petr"))
+	assert.Equal(t, relevanceContains, relevance("van", "Ivanov Petr"))
+	assert.Equal(t, relevanceNone, relevance("anna", "Ivanov Petr"))
+}
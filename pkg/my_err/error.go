@@ -34,4 +34,27 @@ var (
 	ErrWrongCommunity       = errors.New("wrong community")
 	ErrWrongPost            = errors.New("wrong post")
 	ErrPostTooLong          = errors.New("post len is too big")
+	ErrCommentNotFound      = errors.New("comment not found")
+	ErrCommentTooLong       = errors.New("comment len is too big")
+	ErrWrongReaction        = errors.New("wrong reaction type")
+	ErrMessageNotFound      = errors.New("message not found")
+	ErrWrongEvent           = errors.New("wrong event")
+	ErrMessageTooLong       = errors.New("message len is too big")
+	ErrGroupNotFound        = errors.New("group not found")
+	ErrWrongGroupTitle      = errors.New("wrong group title")
+	ErrWrongClientID        = errors.New("wrong client id")
+	ErrMessageExists        = errors.New("message already exists")
+	ErrWrongCursor          = errors.New("wrong cursor")
+	ErrWrongPassword        = errors.New("wrong password")
+	ErrInvalidResetToken    = errors.New("invalid or expired reset token")
+	ErrInvalidVerifyToken   = errors.New("invalid or expired verification token")
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
+	ErrEmailNotVerified     = errors.New("email is not verified")
+	ErrResendCooldown       = errors.New("the letter was sent recently")
+	ErrVerifyNotSent        = errors.New("the letter about the email verification is not sent")
+	ErrTwoFactorNotFound    = errors.New("two factor authentication is not enabled")
+	ErrTwoFactorEnabled     = errors.New("two factor authentication is already enabled")
+	ErrWrongTwoFactorCode   = errors.New("wrong two factor code")
+	ErrInvalidLoginToken    = errors.New("invalid or expired login token")
+	ErrTooManyRequests      = errors.New("too many requests")
 )
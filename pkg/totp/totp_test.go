@@ -0,0 +1,82 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the secret of the test vectors of RFC 6238 for SHA1
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		code, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, code, tt.unix)
+	}
+
+	_, err := Code("not base32!", 1)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	step, ok := Validate(rfcSecret, "050471", now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	// the code of the previous period is taken with the skew only
+	_, ok = Validate(rfcSecret, "050471", now.Add(Period), 1)
+	assert.True(t, ok)
+	_, ok = Validate(rfcSecret, "050471", now.Add(Period), 0)
+	assert.False(t, ok)
+	_, ok = Validate(rfcSecret, "050471", now.Add(2*Period), 1)
+	assert.False(t, ok)
+
+	_, ok = Validate(rfcSecret, "50471", now, 1)
+	assert.False(t, ok)
+	_, ok = Validate(rfcSecret, "000000", now, 1)
+	assert.False(t, ok)
+}
+
+func TestSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	other, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	code, err := Code(secret, Step(time.Now()))
+	require.NoError(t, err)
+	_, ok := Validate(secret, code, time.Now(), 1)
+	assert.True(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(URI("Vilka", "user@mail.ru", "SECRET"))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Vilka:user@mail.ru", uri.Path)
+	assert.Equal(t, "SECRET", uri.Query().Get("secret"))
+	assert.Equal(t, "Vilka", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+}
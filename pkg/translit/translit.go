@@ -0,0 +1,78 @@
+package translit
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+var toLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// latin letter combinations go before the single letters, so the longest one is taken
+var toCyrillic = []struct {
+	latin    string
+	cyrillic string
+}{
+	{"shch", "щ"}, {"sch", "щ"},
+	{"zh", "ж"}, {"kh", "х"}, {"ts", "ц"}, {"ch", "ч"}, {"sh", "ш"}, {"yu", "ю"}, {"ya", "я"}, {"yo", "ё"},
+	{"a", "а"}, {"b", "б"}, {"c", "к"}, {"d", "д"}, {"e", "е"}, {"f", "ф"}, {"g", "г"}, {"h", "х"},
+	{"i", "и"}, {"j", "й"}, {"k", "к"}, {"l", "л"}, {"m", "м"}, {"n", "н"}, {"o", "о"}, {"p", "п"},
+	{"q", "к"}, {"r", "р"}, {"s", "с"}, {"t", "т"}, {"u", "у"}, {"v", "в"}, {"w", "в"}, {"x", "кс"},
+	{"y", "й"}, {"z", "з"},
+}
+
+// ToLatin writes the russian letters of s with latin ones, the rest is kept as it is
+func ToLatin(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if latin, ok := toLatin[r]; ok {
+			b.WriteString(latin)
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// ToCyrillic writes the latin letters of s with russian ones, the rest is kept as it is
+func ToCyrillic(s string) string {
+	var b strings.Builder
+	s = strings.ToLower(s)
+	for len(s) > 0 {
+		found := false
+		for _, l := range toCyrillic {
+			if strings.HasPrefix(s, l.latin) {
+				b.WriteString(l.cyrillic)
+				s = s[len(l.latin):]
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s)
+		b.WriteRune(r)
+		s = s[size:]
+	}
+
+	return b.String()
+}
+
+// Variants returns s in lower case with its latin and cyrillic spellings, without repeats
+func Variants(s string) []string {
+	res := []string{strings.ToLower(s)}
+	for _, v := range []string{ToLatin(s), ToCyrillic(s)} {
+		if v != res[0] && (len(res) == 1 || v != res[1]) {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
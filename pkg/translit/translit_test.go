@@ -0,0 +1,27 @@
+package translit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToLatin(t *testing.T) {
+	assert.Equal(t, "ivanov", ToLatin("Иванов"))
+	assert.Equal(t, "shchukin petr", ToLatin("Щукин Пётр"))
+	assert.Equal(t, "andrew 2", ToLatin("andrew 2"))
+}
+
+func TestToCyrillic(t *testing.T) {
+	assert.Equal(t, "иванов", ToCyrillic("Ivanov"))
+	assert.Equal(t, "щукин", ToCyrillic("Shchukin"))
+	assert.Equal(t, "жуков", ToCyrillic("Zhukov"))
+	assert.Equal(t, "иван 2", ToCyrillic("Иван 2"))
+}
+
+func TestVariants(t *testing.T) {
+	assert.Equal(t, []string{"ivanov", "иванов"}, Variants("Ivanov"))
+	assert.Equal(t, []string{"иванов", "ivanov"}, Variants("Иванов"))
+	assert.Equal(t, []string{"123"}, Variants("123"))
+	assert.Equal(t, []string{"ivan петров", "ivan petrov", "иван петров"}, Variants("Ivan Петров"))
+}